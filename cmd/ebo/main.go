@@ -1,34 +1,68 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/in/cli"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/in/mockserver"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/configfile"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/httpcache"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/plannerapi"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/plannerapimem"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/apispec"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/config"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/envelope"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/httpx"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/pager"
+	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
 )
 
 func main() {
 	env := cliopts.OSEnv{}
 	defaults := cliopts.DefaultGlobalOptions()
-	peek := cliopts.PeekGlobalOptions(os.Args[1:], env, defaults)
+	peekResolved := cliopts.PeekResolved(os.Args[1:], env, defaults)
+	peek := peekResolved.Options
 
 	store := configfile.Store{Env: configfile.OSEnv{}}
-	api := plannerapi.Adapter{
-		HTTPClient: &http.Client{},
-		Timeout:    peek.Timeout,
-		Verbose:    peek.Verbose,
-		LogSink:    os.Stderr,
-	}
-	cmd := cli.NewRootCmd(cli.RootDeps{Env: env, ConfigStore: store, PlannerAPI: api, Stdout: os.Stdout, Stderr: os.Stderr})
-	if err := cmd.Execute(); err != nil {
+
+	// One HTTP client (and transport) for the whole invocation: API and OIDC
+	// traffic both go through it, so --trace/--har capture everything.
+	redactor := httpx.Redactor{Fields: splitList(lookup(env, "EBO_REDACT_FIELDS"))}
+	var har *httpx.HARRecorder
+	if peek.HAR != "" {
+		har = httpx.NewHARRecorder(redactor)
+	}
+	cassette := cassetteLayer(env, redactor)
+	api, httpClient := newAPI(env, store, peekResolved, cassette, redactor, har, os.Stderr)
+	profileAPI := func(profile string) outplannerapi.Client {
+		a, _ := newAPI(env, store, withProfile(peekResolved, profile), cassette, redactor, har, os.Stderr)
+		return a
+	}
+
+	// Human output of list and detail commands may be paged; see cli.startPager.
+	stdout := &pager.Writer{Out: os.Stdout}
+	cmd := cli.NewRootCmd(cli.RootDeps{Env: env, ConfigStore: store, PlannerAPI: api, ProfileAPI: profileAPI, HTTPClient: httpClient, MockAPI: mockAPI, Stdout: stdout, Stderr: os.Stderr})
+	err := cmd.Execute()
+	// Errors are printed after the user quits the pager, so they stay visible.
+	_ = stdout.Close()
+	if har != nil {
+		// Written on failure too: that is when it is most useful.
+		if werr := har.WriteFile(peek.HAR); werr != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "warning: write HAR %s: %v\n", peek.HAR, werr)
+		}
+	}
+	if err != nil {
 		// Best-effort classify errors into the required exit code contract.
 		mapped := err
 		// Cobra/pflag parsing errors don't expose a stable exported type; use a best-effort heuristic.
@@ -38,9 +72,16 @@ func main() {
 
 		code := exitcode.Code(mapped)
 
-		if peek.Output == cliopts.OutputJSON {
+		switch {
+		case peek.Raw || peek.Format != "":
+			// stdout is reserved for the bare values a script captures.
+			_, _ = os.Stderr.WriteString(formatHumanError(peek, mapped))
+		case peek.Output == cliopts.OutputJSON:
 			_ = envelope.WriteJSON(os.Stdout, buildErrorEnvelope(peek, mapped))
-		} else {
+		case peek.Output == cliopts.OutputYAML:
+			_ = envelope.WriteYAML(os.Stdout, buildErrorEnvelope(peek, mapped))
+		default:
+			// Including csv/tsv/ics: there is nothing to write.
 			_, _ = os.Stderr.WriteString(formatHumanError(peek, mapped))
 		}
 
@@ -48,11 +89,226 @@ func main() {
 	}
 }
 
+// mockAPI serves the in-memory backend for `ebo dev mock-server`.
+func mockAPI(fixturesPath string) (http.Handler, map[string]string, error) {
+	fixtures := plannerapimem.DefaultFixtures()
+	if fixturesPath != "" {
+		f, err := plannerapimem.LoadFixtures(fixturesPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		fixtures = f
+	}
+	backend, err := plannerapimem.New(fixtures)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mockserver.Handler(backend), fixtures.Tokens(), nil
+}
+
+// newAPI builds the Planner API client for peek's effective profile: its
+// tls/proxy transport, its response cache scope and the optional contract
+// check. The returned HTTP client shares the transport without the API cache,
+// for OIDC. Every client built with the same cassette and HAR recorder records
+// into them.
+func newAPI(env cliopts.EnvProvider, store configfile.Store, peek cliopts.Resolved, cassette func(http.RoundTripper) http.RoundTripper, redactor httpx.Redactor, har *httpx.HARRecorder, stderr io.Writer) (*plannerapi.Adapter, *http.Client) {
+	httpClient := httpx.NewClient(&http.Client{Transport: cassette(profileTransport(store, peek, stderr))}, httpx.Options{
+		Trace:    peek.Options.Trace,
+		HAR:      har,
+		Redactor: redactor,
+		LogSink:  stderr,
+	})
+	api := &plannerapi.Adapter{
+		HTTPClient: contractClient(env, apiHTTPClient(env, httpClient, store, peek), stderr),
+		Timeout:    peek.Options.Timeout,
+		Verbose:    peek.Options.Verbose,
+		LogSink:    stderr,
+	}
+	api.RequestIDHeader = peek.Options.RequestIDHeader
+	if _, eff, ok := effectiveProfile(store, peek); ok {
+		api.RequestIDHeader = eff.RequestIDHeader
+	}
+	return api, httpClient
+}
+
+// withProfile returns peek with profile selected, as if by --profile.
+func withProfile(peek cliopts.Resolved, profile string) cliopts.Resolved {
+	peek.Options.Profile = profile
+	peek.Sources = maps.Clone(peek.Sources)
+	if peek.Sources == nil {
+		peek.Sources = map[string]string{}
+	}
+	peek.Sources["profile"] = "flag"
+	return peek
+}
+
+// profileTransport returns the transport for the effective profile's
+// tls/proxy settings, or the shared default transport when none are set.
+//
+// Configuration errors are deferred to the first request (ErrorTransport) so
+// commands that only touch the config file keep working.
+func profileTransport(store configfile.Store, peek cliopts.Resolved, stderr io.Writer) http.RoundTripper {
+	doc, eff, ok := effectiveProfile(store, peek)
+	profile := eff.Profile
+	if !ok {
+		// The command itself will report the unreadable config file.
+		return httpx.SharedTransport()
+	}
+
+	nc, err := config.NetworkOf(doc, profile)
+	if err != nil {
+		return httpx.ErrorTransport{Err: fmt.Errorf("profile %q: %w", profile, err)}
+	}
+	opts := httpx.TransportOptions{
+		CAFile:             nc.TLS.CAFile,
+		ClientCert:         nc.TLS.ClientCert,
+		ClientKey:          nc.TLS.ClientKey,
+		ServerName:         nc.TLS.ServerName,
+		InsecureSkipVerify: nc.TLS.InsecureSkipVerify,
+		Proxy:              nc.Proxy,
+	}
+	if opts.IsZero() {
+		return httpx.SharedTransport()
+	}
+	if opts.InsecureSkipVerify && peek.Options.Verbose {
+		warn := fmt.Sprintf("WARNING: TLS certificate verification is DISABLED for profile %q (profiles.%s.tls.insecureSkipVerify).\nWARNING: responses and your token can be intercepted. Do not use this outside local development.\n", profile, profile)
+		if !peek.Options.NoColor {
+			warn = "\x1b[1;31m" + warn + "\x1b[0m"
+		}
+		_, _ = io.WriteString(stderr, warn)
+	}
+	tr, err := httpx.NewTransportWith(opts)
+	if err != nil {
+		return httpx.ErrorTransport{Err: fmt.Errorf("profile %q transport: %w", profile, err)}
+	}
+	return tr
+}
+
+// cassetteLayer returns a wrapper applying EBO_REPLAY / EBO_RECORD to a base
+// transport:
+//
+//   - EBO_REPLAY=<dir> replaces the network with the recorded interactions in
+//     dir (matching per EBO_REPLAY_MATCH: strict (default) or lenient; strict
+//     replay resumes from the EBO_REPLAY_CURSOR file, if set)
+//   - EBO_RECORD=<dir> writes every exchange to dir as redacted cassette files
+//
+// Both sit at the bottom of the transport chain, so API and OIDC traffic are
+// covered and --trace/--har still see replayed exchanges. The replayer and
+// recorder are created once, so every transport wrapped shares one cassette.
+func cassetteLayer(env cliopts.EnvProvider, redactor httpx.Redactor) func(http.RoundTripper) http.RoundTripper {
+	var replay http.RoundTripper
+	if dir := lookup(env, "EBO_REPLAY"); dir != "" {
+		mode, err := httpx.ParseMatchMode(lookup(env, "EBO_REPLAY_MATCH"))
+		if err != nil {
+			replay = httpx.ErrorTransport{Err: fmt.Errorf("EBO_REPLAY_MATCH: %w", err)}
+		} else if rp, err := httpx.NewReplayer(dir, mode, redactor, lookup(env, "EBO_REPLAY_CURSOR")); err != nil {
+			replay = httpx.ErrorTransport{Err: fmt.Errorf("EBO_REPLAY: %w", err)}
+		} else {
+			replay = rp
+		}
+	}
+	var rec *httpx.CassetteRecorder
+	if dir := lookup(env, "EBO_RECORD"); dir != "" {
+		rec = httpx.NewCassetteRecorder(dir, redactor)
+	}
+	return func(base http.RoundTripper) http.RoundTripper {
+		if replay != nil {
+			base = replay
+		}
+		if rec != nil {
+			base = rec.RoundTripper(base)
+		}
+		return base
+	}
+}
+
+// apiHTTPClient wraps base with the on-disk response cache for Planner API
+// reads (and --offline). OIDC traffic keeps using base directly. When no cache
+// directory can be determined, base is returned unchanged.
+func apiHTTPClient(env cliopts.EnvProvider, base *http.Client, store configfile.Store, peek cliopts.Resolved) *http.Client {
+	dir, err := httpcache.DefaultDir(env)
+	if err != nil {
+		return base
+	}
+	profile := peek.Options.Profile
+	if _, eff, ok := effectiveProfile(store, peek); ok {
+		profile = eff.Profile
+	}
+	c := *base
+	c.Transport = &httpcache.Transport{
+		Base:    base.Transport,
+		Dir:     dir,
+		Scope:   profile,
+		Offline: peek.Options.Offline,
+	}
+	return &c
+}
+
+// contractClient checks Planner API traffic against the embedded OpenAPI
+// document when EBO_VALIDATE_REQUESTS is set, printing each mismatch to
+// stderr. Requests are sent unchanged either way.
+func contractClient(env cliopts.EnvProvider, base *http.Client, stderr io.Writer) *http.Client {
+	v := strings.TrimSpace(lookup(env, "EBO_VALIDATE_REQUESTS"))
+	if v == "" {
+		return base
+	}
+	c := *base
+	on, err := strconv.ParseBool(v)
+	switch {
+	case err != nil:
+		c.Transport = httpx.ErrorTransport{Err: fmt.Errorf("EBO_VALIDATE_REQUESTS: invalid boolean value %q", v)}
+	case on:
+		var mu sync.Mutex
+		c.Transport = &apispec.Transport{
+			Base: base.Transport,
+			Report: func(m apispec.Mismatch) {
+				mu.Lock()
+				defer mu.Unlock()
+				_, _ = fmt.Fprintf(stderr, "warning: contract mismatch: %s\n", m)
+			},
+		}
+	default:
+		return base
+	}
+	return &c
+}
+
+// effectiveProfile loads the config file and resolves the profile this
+// invocation will use, with its settings. ok is false when the config cannot
+// be read.
+func effectiveProfile(store configfile.Store, peek cliopts.Resolved) (config.Document, config.Effective, bool) {
+	doc, err := store.Load(context.Background())
+	if err != nil {
+		return config.Document{}, config.Effective{}, false
+	}
+	view, err := config.ViewOf(doc)
+	if err != nil {
+		return config.Document{}, config.Effective{}, false
+	}
+	return doc, config.ResolveEffective(peek, view), true
+}
+
 func formatHumanError(peek cliopts.GlobalOptions, err error) string {
 	if err == nil {
 		return ""
 	}
 	msg := err.Error()
+	var ae *plannerapi.APIError
+	if errors.As(err, &ae) && ae != nil {
+		for _, line := range ae.DetailLines() {
+			msg += "\n  - " + line
+		}
+	}
+	if hint := exitcode.HintOf(err); hint != "" {
+		msg += "\nTry:\n  " + hint
+	}
+	if c, ok := exitcode.ContextOf(err); ok && c.IdempotencyKey != "" {
+		// Reusing the key makes a retry safe.
+		msg += "\nIdempotency-Key: " + c.IdempotencyKey
+	}
+	if peek.Verbose && ae != nil && ae.RequestID != "" {
+		msg += "\nRequest-Id: " + ae.RequestID
+	}
 	if peek.NoColor {
 		return fmt.Sprintf("ERROR: %s\n", msg)
 	}
@@ -62,25 +318,43 @@ func formatHumanError(peek cliopts.GlobalOptions, err error) string {
 
 func buildErrorEnvelope(peek cliopts.GlobalOptions, mapped error) envelope.Envelope {
 	meta := envelope.Meta{APIURL: peek.APIURL, Profile: peek.Profile}
+	// Prefer what the command actually resolved (config profile, idempotency
+	// key) over the pre-parse peek.
+	if c, ok := exitcode.ContextOf(mapped); ok {
+		meta.Profile = c.Profile
+		meta.APIURL = c.APIURL
+		meta.IdempotencyKey = c.IdempotencyKey
+	}
+	body := &envelope.ErrorBody{
+		Code:    exitcode.ErrorCode(mapped),
+		Message: mapped.Error(),
+	}
 	var ae *plannerapi.APIError
-	if errors.As(mapped, &ae) && ae != nil && ae.RequestID != "" {
+	if errors.As(mapped, &ae) && ae != nil {
 		meta.RequestID = ae.RequestID
+		body.Details = ae.Details
 	}
 	return envelope.Envelope{
-		Meta: meta,
-		Error: &envelope.ErrorBody{
-			Code:    stringExitCodeKind(mapped),
-			Message: mapped.Error(),
-		},
+		Data:  exitcode.DataOf(mapped),
+		Meta:  meta,
+		Error: body,
 	}
 }
 
-func stringExitCodeKind(err error) string {
-	var e *exitcode.Error
-	if errors.As(err, &e) {
-		return string(e.Kind)
+func lookup(env cliopts.EnvProvider, key string) string {
+	v, _ := env.LookupEnv(key)
+	return v
+}
+
+// splitList splits a comma-separated list, dropping empty items.
+func splitList(s string) []string {
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
 	}
-	return string(exitcode.KindUnexpected)
+	return out
 }
 
 func looksLikeUsageError(err error) bool {
@@ -1,12 +1,24 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/configfile"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/httpcache"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/plannerapi"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/config"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/httpx"
 )
 
 func TestBuildErrorEnvelope_IncludesRequestIDWhenPresent(t *testing.T) {
@@ -18,6 +30,43 @@ func TestBuildErrorEnvelope_IncludesRequestIDWhenPresent(t *testing.T) {
 	}
 }
 
+func TestBuildErrorEnvelope_PrefersResolvedContext(t *testing.T) {
+	peek := cliopts.GlobalOptions{Profile: "default"}
+	err := exitcode.WithContext(exitcode.New(exitcode.KindServer, "boom", nil), exitcode.Context{Profile: "staging", APIURL: "https://staging", IdempotencyKey: "idem-1"})
+	meta := buildErrorEnvelope(peek, err).Meta
+	if meta.Profile != "staging" || meta.APIURL != "https://staging" || meta.IdempotencyKey != "idem-1" {
+		t.Fatalf("meta: %#v", meta)
+	}
+	if out := formatHumanError(cliopts.GlobalOptions{NoColor: true}, err); !strings.Contains(out, "Idempotency-Key: idem-1") {
+		t.Fatalf("expected idempotency key in human error, got %q", out)
+	}
+}
+
+func TestBuildErrorEnvelope_IncludesDetails(t *testing.T) {
+	details := map[string]any{"capacityRigs": "must be >= 1"}
+	err := exitcode.New(exitcode.KindValidation, "invalid", &plannerapi.APIError{StatusCode: 422, Details: details})
+	env := buildErrorEnvelope(cliopts.GlobalOptions{}, err)
+	if env.Error.Details["capacityRigs"] != "must be >= 1" {
+		t.Fatalf("details: %#v", env.Error.Details)
+	}
+}
+
+func TestFormatHumanError_RendersDetailsThenHint(t *testing.T) {
+	api := &plannerapi.APIError{StatusCode: 422, ErrorCode: "VALIDATION_FAILED", Message: "trip is not ready", Details: map[string]any{
+		"startDate":    "required",
+		"capacityRigs": "must be >= 1",
+	}}
+	err := exitcode.WithHint(exitcode.New(exitcode.KindValidation, api.Error(), api), "ebo trip update t1 --prompt")
+	got := formatHumanError(cliopts.GlobalOptions{NoColor: true}, err)
+	want := "trip is not ready\n" +
+		"  - capacityRigs: must be >= 1\n" +
+		"  - startDate: required\n" +
+		"Try:\n  ebo trip update t1 --prompt\n"
+	if !strings.HasSuffix(got, want) {
+		t.Fatalf("got:\n%s\nwant suffix:\n%s", got, want)
+	}
+}
+
 func TestFormatHumanError_NoColor_DisablesANSI(t *testing.T) {
 	err := exitcode.New(exitcode.KindUsage, "bad\nTry:\n  ebo x", nil)
 	out := formatHumanError(cliopts.GlobalOptions{NoColor: true}, err)
@@ -36,3 +85,191 @@ func TestFormatHumanError_ColorEnabled_UsesANSI(t *testing.T) {
 		t.Fatalf("expected ansi red, got %q", out)
 	}
 }
+
+func TestFormatHumanError_Verbose_IncludesRequestID(t *testing.T) {
+	err := exitcode.New(exitcode.KindServer, "boom", &plannerapi.APIError{Message: "boom", RequestID: "req-9"})
+	out := formatHumanError(cliopts.GlobalOptions{NoColor: true, Verbose: true}, err)
+	if !strings.Contains(out, "Request-Id: req-9") {
+		t.Fatalf("expected request id, got %q", out)
+	}
+	out = formatHumanError(cliopts.GlobalOptions{NoColor: true}, err)
+	if strings.Contains(out, "Request-Id") {
+		t.Fatalf("did not expect request id without verbose, got %q", out)
+	}
+}
+
+func newTestStore(t *testing.T, kv map[string]string) configfile.Store {
+	t.Helper()
+	store := configfile.Store{Env: cliopts.MapEnv{"EBO_CONFIG_DIR": t.TempDir()}}
+	doc := config.NewEmptyDocument()
+	for k, v := range kv {
+		var err error
+		if doc, err = config.SetString(doc, k, v); err != nil {
+			t.Fatalf("set %s: %v", k, err)
+		}
+	}
+	if err := store.Save(context.Background(), doc); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	return store
+}
+
+func TestProfileTransport_NoSettingsUsesSharedTransport(t *testing.T) {
+	store := newTestStore(t, map[string]string{"profiles.default.apiUrl": "http://x"})
+	peek := cliopts.PeekResolved(nil, cliopts.MapEnv{}, cliopts.DefaultGlobalOptions())
+	if got := profileTransport(store, peek, &bytes.Buffer{}); got != httpx.SharedTransport() {
+		t.Fatalf("expected shared transport, got %T", got)
+	}
+}
+
+func TestProfileTransport_UsesCurrentProfileProxy(t *testing.T) {
+	store := newTestStore(t, map[string]string{
+		"currentProfile":         "staging",
+		"profiles.staging.proxy": "http://proxy.internal:3128",
+	})
+	peek := cliopts.PeekResolved(nil, cliopts.MapEnv{}, cliopts.DefaultGlobalOptions())
+	tr, ok := profileTransport(store, peek, &bytes.Buffer{}).(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport")
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://api/x", nil)
+	if u, _ := tr.Proxy(req); u == nil || u.Host != "proxy.internal:3128" {
+		t.Fatalf("proxy: got %v", u)
+	}
+}
+
+func TestProfileTransport_InsecureSkipVerifyWarnsUnderVerbose(t *testing.T) {
+	store := newTestStore(t, map[string]string{"profiles.default.tls.insecureSkipVerify": "true"})
+
+	quiet := &bytes.Buffer{}
+	profileTransport(store, cliopts.PeekResolved(nil, cliopts.MapEnv{}, cliopts.DefaultGlobalOptions()), quiet)
+	if quiet.Len() != 0 {
+		t.Fatalf("expected no warning without --verbose, got %q", quiet.String())
+	}
+
+	loud := &bytes.Buffer{}
+	profileTransport(store, cliopts.PeekResolved([]string{"--verbose", "--no-color"}, cliopts.MapEnv{}, cliopts.DefaultGlobalOptions()), loud)
+	if !strings.Contains(loud.String(), "WARNING: TLS certificate verification is DISABLED") {
+		t.Fatalf("expected warning, got %q", loud.String())
+	}
+}
+
+func TestProfileTransport_BadConfigFailsOnRequest(t *testing.T) {
+	store := newTestStore(t, map[string]string{"profiles.default.tls.caFile": "/does/not/exist.pem"})
+	peek := cliopts.PeekResolved(nil, cliopts.MapEnv{}, cliopts.DefaultGlobalOptions())
+	rt := profileTransport(store, peek, &bytes.Buffer{})
+	_, err := (&http.Client{Transport: rt}).Get("https://api.example.invalid/")
+	if err == nil || !strings.Contains(err.Error(), "caFile") {
+		t.Fatalf("expected caFile error, got %v", err)
+	}
+}
+
+func TestAPIHTTPClient_CachesPerEffectiveProfile(t *testing.T) {
+	store := newTestStore(t, map[string]string{"currentProfile": "staging"})
+	env := cliopts.MapEnv{"EBO_CACHE_DIR": t.TempDir()}
+	base := &http.Client{Transport: httpx.SharedTransport()}
+	peek := cliopts.PeekResolved([]string{"--offline"}, env, cliopts.DefaultGlobalOptions())
+
+	c := apiHTTPClient(env, base, store, peek)
+	tr, ok := c.Transport.(*httpcache.Transport)
+	if !ok {
+		t.Fatalf("expected cache transport, got %T", c.Transport)
+	}
+	if tr.Scope != "staging" || !tr.Offline || tr.Base != base.Transport {
+		t.Fatalf("transport: %#v", tr)
+	}
+	if base.Transport != httpx.SharedTransport() {
+		t.Fatalf("base client must be left unchanged for OIDC")
+	}
+}
+
+func TestCassetteTransport_BadReplaySettingsFailOnRequest(t *testing.T) {
+	for name, env := range map[string]cliopts.MapEnv{
+		"match": {"EBO_REPLAY": t.TempDir(), "EBO_REPLAY_MATCH": "fuzzy"},
+		"empty": {"EBO_REPLAY": t.TempDir()},
+	} {
+		rt := cassetteLayer(env, httpx.Redactor{})(httpx.SharedTransport())
+		if _, err := (&http.Client{Transport: rt}).Get("https://api.example.invalid/"); err == nil || !strings.Contains(err.Error(), "EBO_REPLAY") {
+			t.Fatalf("%s: expected EBO_REPLAY error, got %v", name, err)
+		}
+	}
+	if got := cassetteLayer(cliopts.MapEnv{}, httpx.Redactor{})(httpx.SharedTransport()); got != httpx.SharedTransport() {
+		t.Fatalf("expected base transport unchanged, got %T", got)
+	}
+}
+
+func TestContractClient_ReportsMismatchesToStderr(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"members":[]}`))
+	}))
+	defer srv.Close()
+
+	base := &http.Client{Transport: httpx.SharedTransport()}
+	if got := contractClient(cliopts.MapEnv{}, base, io.Discard); got != base {
+		t.Fatalf("expected base client unchanged when unset")
+	}
+	if got := contractClient(cliopts.MapEnv{"EBO_VALIDATE_REQUESTS": "0"}, base, io.Discard); got != base {
+		t.Fatalf("expected base client unchanged when disabled")
+	}
+
+	var stderr bytes.Buffer
+	c := contractClient(cliopts.MapEnv{"EBO_VALIDATE_REQUESTS": "1"}, base, &stderr)
+	resp, err := c.Get(srv.URL + "/members/search?q=ab")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	_ = resp.Body.Close()
+	if got := stderr.String(); got != "warning: contract mismatch: searchMembers request: query q: length 2, want at least 3\n" {
+		t.Fatalf("stderr: %q", got)
+	}
+
+	bad := contractClient(cliopts.MapEnv{"EBO_VALIDATE_REQUESTS": "sometimes"}, base, io.Discard)
+	if _, err := bad.Get(srv.URL + "/members"); err == nil || !strings.Contains(err.Error(), "EBO_VALIDATE_REQUESTS") {
+		t.Fatalf("expected EBO_VALIDATE_REQUESTS error, got %v", err)
+	}
+}
+
+func TestNewAPI_PerProfileTLSAndCacheScope(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"member":{"memberId":"m1","displayName":"Alice","email":"a@example.com","isActive":true,"createdAt":"2026-01-01T00:00:00Z","updatedAt":"2026-01-01T00:00:00Z"}}`))
+	}))
+	defer srv.Close()
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The startup profile skips verification; the others must not inherit it.
+	store := newTestStore(t, map[string]string{
+		"currentProfile":                      "lab",
+		"profiles.lab.tls.insecureSkipVerify": "true",
+		"profiles.prod.tls.caFile":            caFile,
+		"profiles.plain.apiUrl":               srv.URL,
+	})
+	env := cliopts.MapEnv{"EBO_CACHE_DIR": t.TempDir()}
+	peek := cliopts.PeekResolved(nil, env, cliopts.DefaultGlobalOptions())
+	cassette := cassetteLayer(env, httpx.Redactor{})
+	build := func(profile string) *plannerapi.Adapter {
+		a, _ := newAPI(env, store, withProfile(peek, profile), cassette, httpx.Redactor{}, nil, io.Discard)
+		return a
+	}
+
+	for profile, wantOK := range map[string]bool{"lab": true, "prod": true, "plain": false} {
+		a := build(profile)
+		if tr, ok := a.HTTPClient.Transport.(*httpcache.Transport); !ok || tr.Scope != profile {
+			t.Fatalf("%s: cache transport %#v", profile, a.HTTPClient.Transport)
+		}
+		_, err := a.GetMyMemberProfile(context.Background(), srv.URL, "tok")
+		if wantOK && err != nil {
+			t.Errorf("%s: %v", profile, err)
+		}
+		if !wantOK && (err == nil || !strings.Contains(err.Error(), "certificate")) {
+			t.Errorf("%s: expected certificate error, got %v", profile, err)
+		}
+	}
+	if p := withProfile(peek, "prod"); peek.Options.Profile == "prod" || p.Sources["profile"] != "flag" {
+		t.Fatalf("withProfile must copy: %#v %#v", peek, p)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/http"
 	"net/http/httptest"
 	"os"
 	"os/exec"
@@ -15,6 +14,10 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/in/mockserver"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/plannerapimem"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
 )
 
 var eboPath string
@@ -55,7 +58,8 @@ func runEBO(t *testing.T, env map[string]string, args ...string) runResult {
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, eboPath, args...)
-	cmd.Env = os.Environ()
+	// Every exchange is checked against the pinned OpenAPI document.
+	cmd.Env = append(os.Environ(), "EBO_VALIDATE_REQUESTS=1")
 	for k, v := range env {
 		cmd.Env = append(cmd.Env, k+"="+v)
 	}
@@ -74,17 +78,45 @@ func runEBO(t *testing.T, env map[string]string, args ...string) runResult {
 			code = 1
 		}
 	}
+	for _, line := range strings.Split(stderr.String(), "\n") {
+		if strings.Contains(line, "contract mismatch:") {
+			t.Errorf("ebo %s: %s", strings.Join(args, " "), line)
+		}
+	}
 	return runResult{Stdout: stdout.String(), Stderr: stderr.String(), ExitCode: code}
 }
 
+// startMockAPI serves the in-memory Planner API (the same server as
+// `ebo dev mock-server`) seeded with fixtures.
+func startMockAPI(t *testing.T, fixtures plannerapimem.Fixtures) *httptest.Server {
+	t.Helper()
+	backend, err := plannerapimem.New(fixtures)
+	if err != nil {
+		t.Fatalf("mock api: %v", err)
+	}
+	api := httptest.NewServer(mockserver.Handler(backend))
+	t.Cleanup(api.Close)
+	return api
+}
+
+// loginAs returns an environment with its own config dir, authenticated with
+// token against apiURL.
+func loginAs(t *testing.T, apiURL, token string) map[string]string {
+	t.Helper()
+	env := map[string]string{
+		"EBO_CONFIG_DIR": t.TempDir(),
+		"EBO_NO_COLOR":   "1",
+		"EBO_API_URL":    apiURL,
+	}
+	if res := runEBO(t, env, "auth", "token", "set", "--token", token); res.ExitCode != 0 {
+		t.Fatalf("token set exit=%d stderr=%q", res.ExitCode, res.Stderr)
+	}
+	return env
+}
+
 func TestAuthTokenCommands_E2E(t *testing.T) {
 	cfgDir := t.TempDir()
-	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("content-type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(`{"ok":true}`))
-	}))
-	t.Cleanup(api.Close)
+	api := startMockAPI(t, plannerapimem.DefaultFixtures())
 
 	env := map[string]string{
 		"EBO_CONFIG_DIR": cfgDir,
@@ -167,3 +199,105 @@ func TestAuthTokenCommands_E2E(t *testing.T) {
 		t.Fatalf("token leaked when missing")
 	}
 }
+
+func TestRecordReplay_E2E(t *testing.T) {
+	name := "Recorded trip"
+	api := startMockAPI(t, plannerapimem.Fixtures{
+		Members: []plannerapimem.FixtureMember{{
+			Member:  plannerapi.Member{MemberID: "m-rec", DisplayName: "Recorder", Email: "rec@example.com"},
+			Subject: "a.b.c",
+		}},
+		Trips: []plannerapimem.FixtureTrip{{
+			TripID:       "t-rec",
+			Status:       plannerapi.TripStatusPublished,
+			Name:         &name,
+			OrganizerIDs: []string{"m-rec"},
+		}},
+	})
+
+	cassette := filepath.Join(t.TempDir(), "cassette")
+	env := loginAs(t, api.URL, "a.b.c")
+
+	env["EBO_RECORD"] = cassette
+	recorded := runEBO(t, env, "--output", "json", "trip", "list")
+	if recorded.ExitCode != 0 {
+		t.Fatalf("record exit=%d stderr=%q", recorded.ExitCode, recorded.Stderr)
+	}
+	api.Close()
+
+	delete(env, "EBO_RECORD")
+	env["EBO_REPLAY"] = cassette
+	env["EBO_CONFIG_DIR"] = t.TempDir()
+	if res := runEBO(t, env, "auth", "token", "set", "--token", "a.b.c"); res.ExitCode != 0 {
+		t.Fatalf("token set exit=%d stderr=%q", res.ExitCode, res.Stderr)
+	}
+	replayed := runEBO(t, env, "--output", "json", "trip", "list")
+	if replayed.ExitCode != 0 {
+		t.Fatalf("replay exit=%d stderr=%q", replayed.ExitCode, replayed.Stderr)
+	}
+	if replayed.Stdout != recorded.Stdout || !strings.Contains(replayed.Stdout, "t-rec") {
+		t.Fatalf("replay output differs:\nrecorded: %s\nreplayed: %s", recorded.Stdout, replayed.Stdout)
+	}
+
+	// A request missing from the cassette fails instead of reaching the network.
+	res := runEBO(t, env, "trip", "get", "t-other")
+	if res.ExitCode == 0 || !strings.Contains(res.Stderr, "no matching cassette interaction") {
+		t.Fatalf("expected replay miss, exit=%d stderr=%q", res.ExitCode, res.Stderr)
+	}
+}
+
+func TestTripFlow_MockAPI_E2E(t *testing.T) {
+	api := startMockAPI(t, plannerapimem.DefaultFixtures())
+	alice := loginAs(t, api.URL, plannerapimem.TokenFor("alice"))
+
+	// Retrying a create with the same key replays the original response.
+	tripID := func(res runResult) string {
+		t.Helper()
+		if res.ExitCode != 0 {
+			t.Fatalf("create exit=%d stderr=%q", res.ExitCode, res.Stderr)
+		}
+		var env struct {
+			Data struct {
+				Trip struct {
+					TripID string `json:"tripId"`
+				} `json:"trip"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(res.Stdout), &env); err != nil {
+			t.Fatalf("stdout not json: %v\n%s", err, res.Stdout)
+		}
+		return env.Data.Trip.TripID
+	}
+	first := tripID(runEBO(t, alice, "--output", "json", "trip", "create", "--name", "Moab", "--idempotency-key", "k-moab"))
+	again := tripID(runEBO(t, alice, "--output", "json", "trip", "create", "--name", "Moab", "--idempotency-key", "k-moab"))
+	if first == "" || first != again {
+		t.Fatalf("replay: first=%q again=%q", first, again)
+	}
+
+	// A private draft cannot be published (409 -> exit 5).
+	res := runEBO(t, alice, "trip", "publish", first)
+	if res.ExitCode != 5 || !strings.Contains(res.Stderr, "DRAFT_NOT_PUBLIC") {
+		t.Fatalf("publish exit=%d stderr=%q", res.ExitCode, res.Stderr)
+	}
+	// Other members cannot see it at all (404 -> exit 4).
+	bob := loginAs(t, api.URL, plannerapimem.TokenFor("bob"))
+	if res := runEBO(t, bob, "trip", "get", first); res.ExitCode != 4 {
+		t.Fatalf("get exit=%d stderr=%q", res.ExitCode, res.Stderr)
+	}
+
+	// t-rubicon has capacity 2 and alice is attending.
+	if res := runEBO(t, bob, "trip", "rsvp", "set", "t-rubicon", "--yes"); res.ExitCode != 0 {
+		t.Fatalf("bob rsvp exit=%d stderr=%q", res.ExitCode, res.Stderr)
+	}
+	carol := loginAs(t, api.URL, plannerapimem.TokenFor("carol"))
+	res = runEBO(t, carol, "trip", "rsvp", "set", "t-rubicon", "--yes")
+	if res.ExitCode != 5 || !strings.Contains(res.Stderr, "TRIP_AT_CAPACITY") {
+		t.Fatalf("carol rsvp exit=%d stderr=%q", res.ExitCode, res.Stderr)
+	}
+
+	// Unprovisioned callers are told to create a member profile.
+	dave := loginAs(t, api.URL, plannerapimem.TokenFor("dave"))
+	if res := runEBO(t, dave, "member", "me"); res.ExitCode != 4 {
+		t.Fatalf("member me exit=%d stderr=%q", res.ExitCode, res.Stderr)
+	}
+}
@@ -4,13 +4,16 @@ go 1.25.1
 
 require (
 	github.com/google/uuid v1.5.0
+	github.com/jmespath/go-jmespath v0.4.0
 	github.com/oapi-codegen/runtime v1.1.2
 	github.com/spf13/cobra v1.8.1
 	github.com/spf13/pflag v1.0.5
+	golang.org/x/term v0.45.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/apapsch/go-jsonmerge/v2 v2.0.0 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
 )
@@ -0,0 +1,112 @@
+// Package calendarfeed serves the calendarapp feeds over HTTP as iCalendar
+// files, for `ebo calendar serve`.
+//
+// Feeds live at /{token}/{profile}/{feed}.ics. The token is a secret path
+// segment: requests without it get 404, so only apps given the URL can read
+// the feeds.
+package calendarfeed
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/app/calendarapp"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/ical"
+)
+
+// ErrUnknownProfile is returned by a Handler's Source for a profile that is
+// not configured; the request gets 404.
+var ErrUnknownProfile = errors.New("unknown profile")
+
+// Handler serves the feeds.
+type Handler struct {
+	Token   string
+	Service *calendarapp.Service
+	// Source resolves a profile's API URL and bearer token, per request, so a
+	// fresh `ebo auth login` is picked up without a restart.
+	Source func(ctx context.Context, profile string) (calendarapp.Source, error)
+	// Log receives one line per failed or stale refresh; nil discards.
+	Log io.Writer
+	// Now stamps the files; nil means time.Now.
+	Now func() time.Time
+}
+
+// NewToken returns a random path token.
+func NewToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Path is the URL path of a profile's feed.
+func Path(token, profile, feed string) string {
+	return "/" + token + "/" + profile + "/" + feed + ".ics"
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if len(parts) != 3 || subtle.ConstantTimeCompare([]byte(parts[0]), []byte(h.Token)) != 1 {
+		http.NotFound(w, r)
+		return
+	}
+	profile := parts[1]
+	feed, ok := strings.CutSuffix(parts[2], ".ics")
+	if !ok || !slices.Contains(calendarapp.Feeds, feed) {
+		http.NotFound(w, r)
+		return
+	}
+
+	src, err := h.Source(r.Context(), profile)
+	if errors.Is(err, ErrUnknownProfile) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		h.logf("%s/%s: %v", profile, feed, err)
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	f, err := h.Service.Feed(r.Context(), src, feed)
+	if err != nil {
+		h.logf("%s/%s: %v", profile, feed, err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	if f.RefreshErr != nil {
+		h.logf("%s/%s: refresh failed, serving trips fetched %s: %v", profile, feed, f.FetchedAt.Format(time.RFC3339), f.RefreshErr)
+	}
+
+	events, _ := calendarapp.Events(f.Trips)
+	now := time.Now
+	if h.Now != nil {
+		now = h.Now
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Last-Modified", f.FetchedAt.UTC().Format(http.TimeFormat))
+	if r.Method == http.MethodHead {
+		return
+	}
+	_ = ical.Write(w, events, now())
+}
+
+func (h *Handler) logf(format string, args ...any) {
+	if h.Log != nil {
+		_, _ = fmt.Fprintf(h.Log, "calendar: "+format+"\n", args...)
+	}
+}
@@ -0,0 +1,125 @@
+package calendarfeed
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/app/calendarapp"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+// fakeAPI serves one published trip the member is attending; methods a test
+// does not use panic via the nil embedded Client.
+type fakeAPI struct {
+	plannerapi.Client
+
+	err error
+}
+
+func (f *fakeAPI) ListVisibleTripsForMember(ctx context.Context, baseURL, bearerToken string) (*plannerapi.TripList, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &plannerapi.TripList{Trips: []plannerapi.TripSummary{{TripID: "t1", StartDate: trip().StartDate}}}, nil
+}
+
+func (f *fakeAPI) GetTripDetails(ctx context.Context, baseURL, bearerToken, tripID string) (*plannerapi.TripResult, error) {
+	return &plannerapi.TripResult{Trip: trip()}, nil
+}
+
+func trip() plannerapi.Trip {
+	name := "Rubicon"
+	return plannerapi.Trip{
+		TripID:    "t1",
+		Status:    plannerapi.TripStatusPublished,
+		Name:      &name,
+		StartDate: &plannerapi.Date{Time: time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC)},
+		MyRSVP:    &plannerapi.RSVP{Response: plannerapi.RSVPYes},
+	}
+}
+
+func newHandler(api *fakeAPI, log *bytes.Buffer) *Handler {
+	return &Handler{
+		Token:   "secret",
+		Service: &calendarapp.Service{API: api},
+		Source: func(ctx context.Context, profile string) (calendarapp.Source, error) {
+			switch profile {
+			case "default":
+				return calendarapp.Source{Profile: profile, APIURL: "http://api"}, nil
+			case "logged-out":
+				return calendarapp.Source{}, errors.New("not logged in")
+			}
+			return calendarapp.Source{}, ErrUnknownProfile
+		},
+		Log: log,
+	}
+}
+
+func serve(h http.Handler, method, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+func TestHandler_ServesFeeds(t *testing.T) {
+	h := newHandler(&fakeAPI{}, &bytes.Buffer{})
+	for _, feed := range calendarapp.Feeds {
+		rec := serve(h, http.MethodGet, Path("secret", "default", feed))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: status %d: %s", feed, rec.Code, rec.Body)
+		}
+		if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/calendar") {
+			t.Fatalf("%s: content type %q", feed, ct)
+		}
+		if !strings.Contains(rec.Body.String(), "UID:ebo-trip-t1\r\n") {
+			t.Fatalf("%s: body:\n%s", feed, rec.Body)
+		}
+	}
+
+	rec := serve(h, http.MethodHead, Path("secret", "default", calendarapp.FeedAttending))
+	if rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+		t.Fatalf("HEAD: status %d, body %q", rec.Code, rec.Body)
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodGet, Path("wrong", "default", calendarapp.FeedAttending), http.StatusNotFound},
+		{http.MethodGet, "/secret/default", http.StatusNotFound},
+		{http.MethodGet, Path("secret", "default", "bogus"), http.StatusNotFound},
+		{http.MethodGet, "/secret/default/attending", http.StatusNotFound},
+		{http.MethodGet, Path("secret", "nobody", calendarapp.FeedAttending), http.StatusNotFound},
+		{http.MethodGet, Path("secret", "logged-out", calendarapp.FeedAttending), http.StatusServiceUnavailable},
+		{http.MethodPost, Path("secret", "default", calendarapp.FeedAttending), http.StatusMethodNotAllowed},
+	} {
+		if rec := serve(newHandler(&fakeAPI{}, &bytes.Buffer{}), tc.method, tc.path); rec.Code != tc.want {
+			t.Errorf("%s %s: status %d, want %d", tc.method, tc.path, rec.Code, tc.want)
+		}
+	}
+
+	log := &bytes.Buffer{}
+	rec := serve(newHandler(&fakeAPI{err: errors.New("offline")}, log), http.MethodGet, Path("secret", "default", calendarapp.FeedPublished))
+	if rec.Code != http.StatusBadGateway || !strings.Contains(log.String(), "offline") {
+		t.Fatalf("status %d, log %q", rec.Code, log)
+	}
+}
+
+func TestNewToken_IsRandom(t *testing.T) {
+	a, err := NewToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := NewToken()
+	if len(a) != 32 || a == b {
+		t.Fatalf("tokens %q, %q", a, b)
+	}
+}
@@ -5,10 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	plannerapiout "github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/plannerapi"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/config"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/envelope"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
 )
 
 type apiContext struct {
@@ -31,27 +35,100 @@ func resolveAPIContext(ctx context.Context, deps RootDeps, resolved cliopts.Reso
 	}
 
 	eff := config.ResolveEffective(resolved, view)
+	// Failures from here on still report the resolved profile/apiUrl.
+	errCtx := exitcode.Context{Profile: eff.Profile, APIURL: eff.APIURL}
 	if strings.TrimSpace(eff.APIURL) == "" {
-		return apiContext{}, exitcode.New(
+		return apiContext{}, exitcode.WithContext(exitcode.New(
 			exitcode.KindUsage,
 			fmt.Sprintf("missing api url\nTry:\n  ebo profile set %s --api-url <url>\nOr pass:\n  --api-url <url>", eff.Profile),
 			nil,
-		)
+		), errCtx)
 	}
 
 	tok, err := config.Get(doc, "profiles."+eff.Profile+".auth.accessToken")
 	if err != nil {
 		var nf config.ErrNotFound
 		if errors.As(err, &nf) {
-			return apiContext{}, exitcode.New(exitcode.KindAuth, "no token configured\nTry:\n  ebo auth login", nil)
+			return apiContext{}, exitcode.WithContext(exitcode.New(exitcode.KindAuth, "no token configured\nTry:\n  ebo auth login", nil), errCtx)
 		}
-		return apiContext{}, exitcode.New(exitcode.KindServer, "read token from config", err)
+		return apiContext{}, exitcode.WithContext(exitcode.New(exitcode.KindServer, "read token from config", err), errCtx)
 	}
 	if strings.TrimSpace(tok) == "" {
-		return apiContext{}, exitcode.New(exitcode.KindAuth, "no token configured\nTry:\n  ebo auth login", nil)
+		return apiContext{}, exitcode.WithContext(exitcode.New(exitcode.KindAuth, "no token configured\nTry:\n  ebo auth login", nil), errCtx)
 	}
 
 	return apiContext{Profile: eff.Profile, APIURL: eff.APIURL, BearerToken: tok}, nil
 }
 
+// envelopeMeta builds the JSON envelope meta for an API-backed command.
+// idempotencyKey may be empty when none was sent.
+func (c apiContext) envelopeMeta(rm *outplannerapi.ResponseMeta, idempotencyKey string) envelope.Meta {
+	meta := envelope.Meta{APIURL: c.APIURL, Profile: c.Profile, IdempotencyKey: idempotencyKey}
+	if rm != nil {
+		meta.RequestID = rm.RequestID
+		if rm.Cached {
+			meta.Cached = true
+			if !rm.FetchedAt.IsZero() {
+				meta.FetchedAt = rm.FetchedAt.UTC().Format(time.RFC3339)
+			}
+		}
+	}
+	return meta
+}
+
+// writeResponseMeta prints response metadata to stderr in table mode (JSON mode
+// reports it in meta instead):
+//   - the fetch age when data was served from the offline cache
+//   - the API request ID under --verbose, so it can be handed to the backend team
+func writeResponseMeta(deps RootDeps, resolved cliopts.Resolved, rm *outplannerapi.ResponseMeta) {
+	if rm == nil || resolved.Options.Output != cliopts.OutputTable {
+		return
+	}
+	if rm.Cached {
+		age := "at an unknown time"
+		if !rm.FetchedAt.IsZero() {
+			age = formatAge(time.Since(rm.FetchedAt)) + " ago"
+		}
+		_, _ = fmt.Fprintf(deps.Stderr, "Offline: showing cached data fetched %s\n", age)
+	}
+	if resolved.Options.Verbose && rm.RequestID != "" {
+		_, _ = fmt.Fprintf(deps.Stderr, "Request-Id: %s\n", rm.RequestID)
+	}
+}
+
+// formatAge renders d coarsely for humans (e.g. "3h12m", "2d4h").
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "<1m"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh%dm", int(d/time.Hour), int(d%time.Hour/time.Minute))
+	default:
+		return fmt.Sprintf("%dd%dh", int(d/(24*time.Hour)), int(d%(24*time.Hour)/time.Hour))
+	}
+}
+
+// apiErrorHints maps Planner API error codes to "Try:" guidance.
+var apiErrorHints = map[string]string{
+	"MEMBER_NOT_PROVISIONED": "ebo member create --display-name <name> --email <email>",
+	"MEMBER_ALREADY_EXISTS":  "ebo member me\n  ebo member update --prompt",
+}
+
+// apiError decorates an error returned by the Planner API with a "Try:" hint
+// when the error code has a known fix, and with the resolved profile, API URL
+// and idempotency key so the error envelope is as complete as a success one.
+// idempotencyKey may be empty when none was sent.
+func (c apiContext) apiError(err error, idempotencyKey string) error {
+	if err == nil {
+		return nil
+	}
+	var ae *plannerapiout.APIError
+	if errors.As(err, &ae) && ae != nil && exitcode.HintOf(err) == "" {
+		err = exitcode.WithHint(err, apiErrorHints[ae.ErrorCode])
+	}
+	return exitcode.WithContext(err, exitcode.Context{Profile: c.Profile, APIURL: c.APIURL, IdempotencyKey: idempotencyKey})
+}
+
 // NOTE: additional shared API helpers belong here as the command surface grows.
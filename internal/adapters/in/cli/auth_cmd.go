@@ -10,6 +10,7 @@ import (
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/envelope"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 func addAuthCommands(root *cobra.Command, deps RootDeps) {
@@ -42,8 +43,8 @@ func newAuthStatusCmd(deps RootDeps, svc authapp.Service) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
 					Data: map[string]any{
 						"profile":         st.Profile,
 						"tokenConfigured": st.TokenConfigured,
@@ -77,8 +78,8 @@ func newAuthLogoutCmd(deps RootDeps, svc authapp.Service) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
 					Data: map[string]any{"ok": true},
 					Meta: envelope.Meta{APIURL: resolved.Options.APIURL, Profile: resolved.Options.Profile},
 				})
@@ -114,8 +115,8 @@ func newAuthTokenSetCmd(deps RootDeps, svc authapp.Service) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
 					Data: map[string]any{"ok": true},
 					Meta: envelope.Meta{APIURL: resolved.Options.APIURL, Profile: resolved.Options.Profile},
 				})
@@ -144,11 +145,22 @@ func newAuthTokenPrintCmd(deps RootDeps, svc authapp.Service) *cobra.Command {
 				return err
 			}
 
-			// Special-case: must be stdout-only (no extra noise). In JSON mode we still output a single JSON object.
-			if resolved.Options.Output == cliopts.OutputJSON {
+			// Special-case: must be stdout-only (no extra noise). In JSON/YAML mode we still output a single object.
+			if resolved.Options.Query != "" || resolved.Options.Raw || resolved.Options.Format != "" {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: map[string]any{"token": tok},
+					Meta: envelope.Meta{APIURL: resolved.Options.APIURL, Profile: resolved.Options.Profile},
+				})
+			}
+			switch resolved.Options.Output {
+			case cliopts.OutputJSON:
 				b, _ := json.Marshal(map[string]any{"token": tok})
 				_, _ = deps.Stdout.Write(append(b, '\n'))
 				return nil
+			case cliopts.OutputYAML:
+				b, _ := yaml.Marshal(map[string]any{"token": tok})
+				_, _ = deps.Stdout.Write(b)
+				return nil
 			}
 			_, _ = io.WriteString(deps.Stdout, tok+"\n")
 			return nil
@@ -9,7 +9,6 @@ import (
 
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/app/authloginapp"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/browseropen"
-	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/config"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/envelope"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
@@ -50,7 +49,11 @@ func newAuthLoginCmd(deps RootDeps) *cobra.Command {
 			loginCtx, cancel := context.WithTimeout(ctx, totalTimeout)
 			defer cancel()
 
-			client := oidcdevice.Client{HTTP: &http.Client{}}
+			hc := deps.HTTPClient
+			if hc == nil {
+				hc = &http.Client{}
+			}
+			client := oidcdevice.Client{HTTP: hc}
 			opener := deps.BrowserOpener
 			if opener == nil {
 				opener = browseropen.DefaultOpener{}
@@ -69,8 +72,8 @@ func newAuthLoginCmd(deps RootDeps) *cobra.Command {
 			// Print guidance to stderr.
 			_, _ = fmt.Fprintf(deps.Stderr, "Open: %s\nCode: %s\n", verify, res.UserCode)
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
 					Data: map[string]any{"ok": true, "profile": res.Profile, "expiresAt": res.ExpiresAtRFC3339},
 					Meta: envelope.Meta{APIURL: eff.APIURL, Profile: eff.Profile},
 				})
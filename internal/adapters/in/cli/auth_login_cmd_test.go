@@ -5,11 +5,16 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/in/mockserver"
+	plannerapiout "github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/plannerapi"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/plannerapimem"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/config"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/oidcfake"
 )
 
 type noopOpener struct{}
@@ -43,27 +48,23 @@ func TestAuthLogin_MissingOIDCIsUsageExit2(t *testing.T) {
 	}
 }
 
+func newFakeIssuer(t *testing.T) (*oidcfake.Issuer, *httptest.Server) {
+	t.Helper()
+	iss, err := oidcfake.New(oidcfake.Options{Subjects: []string{"alice", "bob"}, ClientID: "cid", AutoApprove: true})
+	if err != nil {
+		t.Fatalf("issuer: %v", err)
+	}
+	srv := httptest.NewServer(iss.Handler())
+	t.Cleanup(srv.Close)
+	return iss, srv
+}
+
 func TestAuthLogin_SuccessPersistsAndPrintsGuidanceToStderr(t *testing.T) {
-	var base string
-	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("content-type", "application/json")
-		switch r.URL.Path {
-		case "/.well-known/openid-configuration":
-			_, _ = w.Write([]byte(`{"device_authorization_endpoint":"` + base + `/device","token_endpoint":"` + base + `/token"}`))
-		case "/device":
-			_, _ = w.Write([]byte(`{"device_code":"dc","user_code":"UC","verification_uri":"` + base + `/verify","expires_in":600,"interval":0}`))
-		case "/token":
-			_, _ = w.Write([]byte(`{"access_token":"a.b.c","token_type":"Bearer","expires_in":60}`))
-		default:
-			w.WriteHeader(404)
-		}
-	}))
-	defer srv.Close()
-	base = srv.URL
+	iss, srv := newFakeIssuer(t)
 
 	doc := config.NewEmptyDocument()
 	doc, _ = config.WithProfileAPIURL(doc, "default", "http://x")
-	doc, _ = config.WithProfileOIDC(doc, "default", base, "cid", []string{"openid"})
+	doc, _ = config.WithProfileOIDC(doc, "default", srv.URL, "cid", []string{"openid"})
 	store := &memStore2{path: "/x", doc: doc}
 
 	stdout := &bytes.Buffer{}
@@ -76,12 +77,55 @@ func TestAuthLogin_SuccessPersistsAndPrintsGuidanceToStderr(t *testing.T) {
 	if !bytes.Contains(stderr.Bytes(), []byte("Open:")) || !bytes.Contains(stderr.Bytes(), []byte("Code:")) {
 		t.Fatalf("stderr=%q", stderr.String())
 	}
-	if bytes.Contains(stderr.Bytes(), []byte("a.b.c")) || bytes.Contains(stdout.Bytes(), []byte("a.b.c")) {
-		t.Fatalf("token leaked")
-	}
 	// persisted
 	got, _ := config.Get(store.doc, "profiles.default.auth.accessToken")
-	if got != "a.b.c" {
-		t.Fatalf("token %q", got)
+	if _, err := iss.Verify(got); err != nil {
+		t.Fatalf("token %q: %v", got, err)
+	}
+	if bytes.Contains(stderr.Bytes(), []byte(got)) || bytes.Contains(stdout.Bytes(), []byte(got)) {
+		t.Fatalf("token leaked")
+	}
+}
+
+// TestAuthLogin_ThenAPICall_Offline signs in against the fake issuer and
+// calls an in-memory Planner API that only accepts the issuer's tokens.
+func TestAuthLogin_ThenAPICall_Offline(t *testing.T) {
+	iss, oidcSrv := newFakeIssuer(t)
+	backend, err := plannerapimem.New(plannerapimem.DefaultFixtures())
+	if err != nil {
+		t.Fatalf("backend: %v", err)
+	}
+	api := mockserver.Handler(backend)
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := iss.Verify(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")); err != nil {
+			http.Error(w, `{"error":{"code":"UNAUTHORIZED","message":"`+err.Error()+`"}}`, http.StatusUnauthorized)
+			return
+		}
+		api.ServeHTTP(w, r)
+	}))
+	defer apiSrv.Close()
+
+	doc := config.NewEmptyDocument()
+	doc, _ = config.WithProfileAPIURL(doc, "default", apiSrv.URL)
+	doc, _ = config.WithProfileOIDC(doc, "default", oidcSrv.URL, "cid", []string{"openid"})
+	store := &memStore2{path: "/x", doc: doc}
+	run := func(args ...string) *bytes.Buffer {
+		t.Helper()
+		stdout := &bytes.Buffer{}
+		cmd := NewRootCmd(RootDeps{
+			Env: cliopts.MapEnv{}, ConfigStore: store, PlannerAPI: &plannerapiout.Adapter{},
+			Stdout: stdout, Stderr: &bytes.Buffer{}, BrowserOpener: noopOpener{},
+		})
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		return stdout
+	}
+
+	run("--timeout", "2s", "auth", "login")
+	out := run("--output", "json", "member", "me")
+	if !bytes.Contains(out.Bytes(), []byte(`"memberId":"m-alice"`)) {
+		t.Fatalf("member me: %s", out)
 	}
 }
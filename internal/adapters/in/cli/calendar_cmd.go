@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/in/calendarfeed"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/app/calendarapp"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/config"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/envelope"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+	"github.com/spf13/cobra"
+)
+
+func addCalendarCommands(root *cobra.Command, deps RootDeps) {
+	calendarCmd := &cobra.Command{
+		Use:   "calendar",
+		Short: "Calendar feeds of trips",
+	}
+	calendarCmd.AddCommand(newCalendarServeCmd(deps))
+	root.AddCommand(calendarCmd)
+}
+
+// calendarFeed is one feed URL, as printed on start.
+type calendarFeed struct {
+	Profile string `json:"profile"`
+	Feed    string `json:"feed"`
+	URL     string `json:"url"`
+}
+
+func newCalendarServeCmd(deps RootDeps) *cobra.Command {
+	var (
+		listen  string
+		refresh time.Duration
+		token   string
+	)
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve iCalendar feeds of trips for calendar apps to subscribe to",
+		Long: `Serve iCalendar feeds of trips for calendar apps to subscribe to.
+
+Every configured profile gets two feeds, built from the trips visible to its
+member (with each trip's details, for myRsvp) at the profile's own apiUrl:
+
+  attending.ics   trips the member answered YES to
+  published.ics   every published trip
+
+Canceled trips stay in the feeds as CANCELLED events. Event UIDs derive from
+the trip ID, so calendar apps update events instead of duplicating them.
+Trips are fetched at most once per --refresh and cached in memory; if a
+refresh fails, the last trips fetched are served, and the API is retried
+after a minute (or --refresh, if shorter).
+
+Feed URLs start with a random token (or --token, to keep URLs stable across
+restarts); requests without it get 404. On start the feed URLs are printed.
+The server runs until interrupted. --api-url (or EBO_API_URL) is rejected
+when more than one profile is configured.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.PlannerAPI == nil {
+				return exitcode.New(exitcode.KindUnexpected, "planner api", fmt.Errorf("nil planner api client"))
+			}
+			resolved, err := resolvedFromRoot(cmd, deps)
+			if err != nil {
+				return err
+			}
+			if refresh <= 0 {
+				return exitcode.New(exitcode.KindUsage, "--refresh must be positive", nil)
+			}
+			profiles, err := calendarProfiles(cmd.Context(), deps, resolved)
+			if err != nil {
+				return err
+			}
+			// A global API URL would override every profile's, sending all
+			// their tokens to one server.
+			if len(profiles) > 1 && resolved.Sources["api-url"] != "default" {
+				return exitcode.New(exitcode.KindUsage, "--api-url (or EBO_API_URL) cannot be used when serving several profiles; each profile uses its own apiUrl\nTry:\n  ebo profile set <name> --api-url <url>", nil)
+			}
+			if token == "" {
+				if token, err = calendarfeed.NewToken(); err != nil {
+					return exitcode.New(exitcode.KindUnexpected, "generate token", err)
+				}
+			}
+
+			ln, err := net.Listen("tcp", listen)
+			if err != nil {
+				return exitcode.New(exitcode.KindUsage, "listen on "+listen, err)
+			}
+			url := "http://" + ln.Addr().String()
+			var feeds []calendarFeed
+			for _, p := range profiles {
+				for _, f := range calendarapp.Feeds {
+					feeds = append(feeds, calendarFeed{Profile: p, Feed: f, URL: url + calendarfeed.Path(token, p, f)})
+				}
+			}
+
+			// Each profile gets its own client, so its tls/proxy settings (and
+			// not the startup profile's) carry its token.
+			var clientsMu sync.Mutex
+			clients := map[string]outplannerapi.Client{}
+			clientFor := func(profile string) outplannerapi.Client {
+				if deps.ProfileAPI == nil {
+					return deps.PlannerAPI
+				}
+				clientsMu.Lock()
+				defer clientsMu.Unlock()
+				c, ok := clients[profile]
+				if !ok {
+					c = deps.ProfileAPI(profile)
+					clients[profile] = c
+				}
+				return c
+			}
+
+			handler := &calendarfeed.Handler{
+				Token:   token,
+				Service: &calendarapp.Service{API: deps.PlannerAPI, Refresh: refresh},
+				Source: func(ctx context.Context, profile string) (calendarapp.Source, error) {
+					if !slices.Contains(profiles, profile) {
+						return calendarapp.Source{}, calendarfeed.ErrUnknownProfile
+					}
+					r := resolved
+					r.Options.Profile = profile
+					r.Sources = maps.Clone(resolved.Sources)
+					r.Sources["profile"] = "flag"
+					apiCtx, err := resolveAPIContext(ctx, deps, r)
+					if err != nil {
+						return calendarapp.Source{}, err
+					}
+					return calendarapp.Source{Profile: profile, APIURL: apiCtx.APIURL, BearerToken: apiCtx.BearerToken, API: clientFor(profile)}, nil
+				},
+				Log: deps.Stderr,
+			}
+
+			if resolved.Options.Output.Envelope() {
+				if err := writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: map[string]any{"url": url, "token": token, "feeds": feeds},
+					Meta: envelope.Meta{APIURL: resolved.Options.APIURL, Profile: resolved.Options.Profile},
+				}); err != nil {
+					_ = ln.Close()
+					return err
+				}
+			} else {
+				for _, f := range feeds {
+					_, _ = fmt.Fprintln(deps.Stdout, f.URL)
+				}
+				_, _ = fmt.Fprintf(deps.Stderr, "Calendar feeds listening on %s, refreshed every %s (Ctrl-C to stop)\n", url, refresh)
+			}
+			if host, _, err := net.SplitHostPort(ln.Addr().String()); err == nil {
+				if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+					_, _ = fmt.Fprintf(deps.Stderr, "warning: %s is reachable from other machines; anyone with a feed URL can read it\n", url)
+				}
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			return serveUntilDone(ctx, "calendar server", ln, handler, deps.Stderr)
+		},
+	}
+	cmd.Flags().StringVar(&listen, "listen", "127.0.0.1:8765", "Address to listen on (host:port; port 0 picks a free port)")
+	cmd.Flags().DurationVar(&refresh, "refresh", calendarapp.DefaultRefresh, "How long fetched trips are served before refetching")
+	cmd.Flags().StringVar(&token, "token", "", "Path token for the feed URLs (default: random)")
+	return cmd
+}
+
+// calendarProfiles lists the configured profiles, sorted; with none
+// configured, the effective profile.
+func calendarProfiles(ctx context.Context, deps RootDeps, resolved cliopts.Resolved) ([]string, error) {
+	if deps.ConfigStore == nil {
+		return nil, exitcode.New(exitcode.KindUnexpected, "config store", fmt.Errorf("nil store"))
+	}
+	doc, err := deps.ConfigStore.Load(ctx)
+	if err != nil {
+		return nil, exitcode.New(exitcode.KindServer, "load config", err)
+	}
+	view, err := config.ViewOf(doc)
+	if err != nil {
+		return nil, exitcode.New(exitcode.KindServer, "parse config", err)
+	}
+	if len(view.Profiles) == 0 {
+		return []string{config.ResolveEffective(resolved, view).Profile}, nil
+	}
+	return slices.Sorted(maps.Keys(view.Profiles)), nil
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/config"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+func TestCalendarServe_ServesFeedsUntilCanceled(t *testing.T) {
+	api := newCalendarAPI()
+	stdoutR, stdoutW := io.Pipe()
+	cmd := NewRootCmd(RootDeps{Env: cliopts.MapEnv{}, ConfigStore: &memStore{path: "/x", doc: baseDoc(t)}, PlannerAPI: api, Stdout: stdoutW, Stderr: &bytes.Buffer{}})
+	cmd.SetArgs([]string{"calendar", "serve", "--listen", "127.0.0.1:0", "--token", "secret"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- cmd.ExecuteContext(ctx) }()
+
+	out := bufio.NewReader(stdoutR)
+	var urls []string
+	for range 2 {
+		line, err := out.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read url: %v", err)
+		}
+		urls = append(urls, strings.TrimSpace(line))
+	}
+	if !strings.HasSuffix(urls[0], "/secret/default/attending.ics") || !strings.HasSuffix(urls[1], "/secret/default/published.ics") {
+		t.Fatalf("urls=%v", urls)
+	}
+
+	resp, err := http.Get(urls[1])
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "UID:ebo-trip-t1\r\n") || !strings.Contains(string(body), "UID:ebo-trip-t3\r\n") {
+		t.Fatalf("status=%d body:\n%s", resp.StatusCode, body)
+	}
+	if strings.Join(api.fetched, ",") != "t1,t3" {
+		t.Fatalf("fetched %v", api.fetched)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("server did not stop")
+	}
+}
+
+func TestCalendarServe_UsesEachProfilesClient(t *testing.T) {
+	doc := baseDoc(t)
+	for k, v := range map[string]string{"profiles.other.apiUrl": "http://other", "profiles.other.auth.accessToken": "tok2"} {
+		var err error
+		if doc, err = config.SetString(doc, k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	apis := map[string]*calendarAPI{"default": newCalendarAPI(), "other": newCalendarAPI()}
+	startup := newCalendarAPI()
+	stdoutR, stdoutW := io.Pipe()
+	cmd := NewRootCmd(RootDeps{
+		Env:         cliopts.MapEnv{},
+		ConfigStore: &memStore{path: "/x", doc: doc},
+		PlannerAPI:  startup,
+		ProfileAPI:  func(profile string) outplannerapi.Client { return apis[profile] },
+		Stdout:      stdoutW,
+		Stderr:      &bytes.Buffer{},
+	})
+	cmd.SetArgs([]string{"calendar", "serve", "--listen", "127.0.0.1:0", "--token", "secret"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- cmd.ExecuteContext(ctx) }()
+
+	out := bufio.NewReader(stdoutR)
+	urls := map[string]string{}
+	for range 4 {
+		line, err := out.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read url: %v", err)
+		}
+		if u := strings.TrimSpace(line); strings.HasSuffix(u, "/published.ics") {
+			urls[strings.Split(u, "/")[4]] = u
+		}
+	}
+	for profile, api := range apis {
+		resp, err := http.Get(urls[profile])
+		if err != nil {
+			t.Fatalf("%s: get: %v", profile, err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || api.listCalls != 1 {
+			t.Fatalf("%s: status=%d listCalls=%d", profile, resp.StatusCode, api.listCalls)
+		}
+	}
+	if startup.listCalls+len(startup.fetched) != 0 {
+		t.Fatalf("startup profile's client was used for other profiles")
+	}
+	if strings.Join(apis["other"].fetched, ",") != "t1,t3" || strings.Join(apis["default"].fetched, ",") != "t1,t3" {
+		t.Fatalf("fetched default=%v other=%v", apis["default"].fetched, apis["other"].fetched)
+	}
+	cancel()
+	<-done
+}
+
+func TestCalendarServe_RejectsGlobalAPIURLWithSeveralProfiles(t *testing.T) {
+	doc := baseDoc(t)
+	for k, v := range map[string]string{"profiles.other.apiUrl": "http://other", "profiles.other.auth.accessToken": "tok2"} {
+		var err error
+		if doc, err = config.SetString(doc, k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, tc := range []struct {
+		env  cliopts.MapEnv
+		args []string
+	}{
+		{cliopts.MapEnv{}, []string{"--api-url", "http://evil", "calendar", "serve", "--listen", "127.0.0.1:0"}},
+		{cliopts.MapEnv{"EBO_API_URL": "http://evil"}, []string{"calendar", "serve", "--listen", "127.0.0.1:0"}},
+	} {
+		api := newCalendarAPI()
+		stderr := &bytes.Buffer{}
+		cmd := NewRootCmd(RootDeps{Env: tc.env, ConfigStore: &memStore{path: "/x", doc: doc}, PlannerAPI: api, Stdout: &bytes.Buffer{}, Stderr: stderr})
+		cmd.SetArgs(tc.args)
+		err := cmd.ExecuteContext(context.Background())
+		if exitcode.Code(err) != exitcode.Usage || !strings.Contains(err.Error(), "several profiles") {
+			t.Errorf("%v %v: expected usage error, got %v", tc.env, tc.args, err)
+		}
+		if api.listCalls != 0 {
+			t.Errorf("%v %v: api called", tc.env, tc.args)
+		}
+	}
+}
+
+func TestCalendarServe_InvalidFlagsAreUsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"calendar", "serve", "--refresh", "0s"},
+		{"calendar", "serve", "--listen", "127.0.0.1:70000"},
+	} {
+		_, _, err := runCalendar(t, newCalendarAPI(), args...)
+		if exitcode.Code(err) != exitcode.Usage {
+			t.Errorf("%v: expected usage error, got %v", args, err)
+		}
+	}
+}
@@ -53,8 +53,8 @@ func newConfigPathCmd(deps RootDeps, svc configapp.Service) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
 					Data: map[string]any{"path": p},
 					Meta: envelope.Meta{APIURL: resolved.Options.APIURL, Profile: resolved.Options.Profile},
 				})
@@ -82,8 +82,8 @@ func newConfigGetCmd(deps RootDeps, svc configapp.Service) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
 					Data: map[string]any{"key": key, "value": val},
 					Meta: envelope.Meta{APIURL: resolved.Options.APIURL, Profile: resolved.Options.Profile},
 				})
@@ -110,12 +110,12 @@ func newConfigSetCmd(deps RootDeps, svc configapp.Service) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if resolved.Options.Output == cliopts.OutputJSON {
+			if resolved.Options.Output.Envelope() {
 				outVal := val
 				if strings.Contains(key, "accessToken") {
 					outVal = "REDACTED"
 				}
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
+				return writeEnvelope(deps, resolved, envelope.Envelope{
 					Data: map[string]any{"key": key, "value": outVal},
 					Meta: envelope.Meta{APIURL: resolved.Options.APIURL, Profile: resolved.Options.Profile},
 				})
@@ -142,8 +142,8 @@ func newConfigUnsetCmd(deps RootDeps, svc configapp.Service) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
 					Data: map[string]any{"key": key},
 					Meta: envelope.Meta{APIURL: resolved.Options.APIURL, Profile: resolved.Options.Profile},
 				})
@@ -167,12 +167,12 @@ func newConfigListCmd(deps RootDeps, svc configapp.Service) *cobra.Command {
 				return err
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
+			if resolved.Options.Output.Envelope() {
 				data, err := svc.ListJSON(ctx, includeSecrets)
 				if err != nil {
 					return err
 				}
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
+				return writeEnvelope(deps, resolved, envelope.Envelope{
 					Data: data,
 					Meta: envelope.Meta{APIURL: resolved.Options.APIURL, Profile: resolved.Options.Profile},
 				})
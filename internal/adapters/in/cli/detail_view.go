@@ -0,0 +1,234 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/app/tripapp"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/table"
+	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+// Human detail views for `trip get` and `member me`: aligned "Label: value"
+// fields, then sections. Labels are the JSON field names, capitalized (as in
+// --format); section headings that summarize (RSVPs) read as prose. Free text
+// (description, requirements, vehicle notes) is printed verbatim under its
+// label, with no wrapping or indentation, so multi-line values read exactly
+// as entered.
+
+// missingMarker follows an empty field a draft must set before publishing.
+const missingMarker = "(missing for publish)"
+
+// detailFields collects aligned "Label: value" lines.
+type detailFields struct {
+	labels []string
+	values []string
+}
+
+// add appends a field; an empty value prints as "-".
+func (f *detailFields) add(label, value string) {
+	if value == "" {
+		value = "-"
+	}
+	f.labels = append(f.labels, label)
+	f.values = append(f.values, value)
+}
+
+func (f *detailFields) write(b *strings.Builder, indent string) {
+	width := 0
+	for _, l := range f.labels {
+		width = max(width, len(l)+1)
+	}
+	for i, l := range f.labels {
+		fmt.Fprintf(b, "%s%-*s %s\n", indent, width, l+":", f.values[i])
+	}
+}
+
+// writeText writes a free-text section: the label, then the text verbatim.
+// An empty value is a one-line "Label: -" (plus note, if any).
+func writeText(b *strings.Builder, label string, text *string, note string) {
+	b.WriteString("\n")
+	if text == nil || *text == "" {
+		b.WriteString(strings.TrimSpace(fmt.Sprintf("%s: - %s", label, note)) + "\n")
+		return
+	}
+	b.WriteString(label + ":\n")
+	b.WriteString(*text)
+	if !strings.HasSuffix(*text, "\n") {
+		b.WriteString("\n")
+	}
+}
+
+// writeSubtable writes t indented under a section label.
+func writeSubtable(b *strings.Builder, label string, t *table.Table, opts table.Options) {
+	b.WriteString("\n" + label + ":\n")
+	if opts.Width > 2 {
+		opts.Width -= 2
+	}
+	var sb strings.Builder
+	_ = t.Render(&sb, opts)
+	for _, l := range strings.SplitAfter(sb.String(), "\n") {
+		if l != "" {
+			b.WriteString("  " + l)
+		}
+	}
+}
+
+// tripDetailView renders the full trip. Drafts mark the fields still
+// missing for publish.
+func tripDetailView(t outplannerapi.Trip, opts table.Options) string {
+	var missing []string
+	if t.Status == outplannerapi.TripStatusDraft {
+		missing = tripapp.MissingForPublish(t)
+	}
+	mark := func(field, value string) string {
+		if value == "" && slices.Contains(missing, field) {
+			return "- " + missingMarker
+		}
+		return value
+	}
+	note := func(field string) string {
+		if slices.Contains(missing, field) {
+			return missingMarker
+		}
+		return ""
+	}
+
+	var b strings.Builder
+	var f detailFields
+	f.add("TripId", t.TripID)
+	f.add("Status", string(t.Status))
+	if t.DraftVisibility != nil {
+		f.add("DraftVisibility", string(*t.DraftVisibility))
+	}
+	f.add("Name", mark("name", stringCell(t.Name)))
+	f.add("StartDate", mark("startDate", dateCell(t.StartDate)))
+	f.add("EndDate", mark("endDate", dateCell(t.EndDate)))
+	f.add("CapacityRigs", mark("capacityRigs", intCell(t.CapacityRigs)))
+	if t.RSVPSummary != nil {
+		f.add("AttendingRigs", strconv.Itoa(t.RSVPSummary.AttendingRigs))
+	}
+	myRSVP := ""
+	if t.MyRSVP != nil {
+		myRSVP = fmt.Sprintf("%s (updated %s)", t.MyRSVP.Response, t.MyRSVP.UpdatedAt.UTC().Format(time.RFC3339))
+	}
+	f.add("MyRsvp", myRSVP)
+	f.add("RsvpActionsEnabled", strconv.FormatBool(t.RSVPActionsEnabled))
+	f.write(&b, "")
+
+	b.WriteString("\n")
+	if loc := t.MeetingLocation; loc != nil {
+		b.WriteString("MeetingLocation:\n")
+		var lf detailFields
+		lf.add("Label", loc.Label)
+		lf.add("Address", stringCell(loc.Address))
+		latLng := ""
+		if ll := loc.LatitudeLongitude; ll != nil && ll.Latitude != nil && ll.Longitude != nil {
+			latLng = strconv.FormatFloat(*ll.Latitude, 'f', -1, 64) + ", " + strconv.FormatFloat(*ll.Longitude, 'f', -1, 64)
+		}
+		lf.add("LatitudeLongitude", latLng)
+		lf.write(&b, "  ")
+	} else {
+		b.WriteString(strings.TrimSpace("MeetingLocation: - "+note("meetingLocation")) + "\n")
+	}
+
+	writeText(&b, "Description", t.Description, note("description"))
+	writeText(&b, "DifficultyText", t.DifficultyText, "")
+	writeText(&b, "CommsRequirementsText", t.CommsRequirementsText, "")
+	writeText(&b, "RecommendedRequirementsText", t.RecommendedRequirementsText, "")
+
+	if len(t.Organizers) > 0 {
+		writeSubtable(&b, "Organizers", memberSummaryTable(t.Organizers), opts)
+	}
+	if len(t.Artifacts) > 0 {
+		at := table.New(
+			table.Column{Header: "TITLE", Flex: true},
+			table.Column{Header: "TYPE"},
+			table.Column{Header: "URL"},
+		)
+		for _, a := range t.Artifacts {
+			at.Row(a.Title, string(a.Type), a.URL)
+		}
+		writeSubtable(&b, "Artifacts", at, opts)
+	}
+	if s := t.RSVPSummary; s != nil {
+		label := fmt.Sprintf("RSVPs (%d attending %s", s.AttendingRigs, plural(s.AttendingRigs, "rig", "rigs"))
+		if s.CapacityRigs != nil {
+			label += fmt.Sprintf(" of %d", *s.CapacityRigs)
+		}
+		label += ")"
+		rt := table.New(
+			table.Column{Header: "RESPONSE", Style: table.ByValue(map[string]table.Style{"YES": table.Green, "NO": table.Dim})},
+			table.Column{Header: "MEMBER_ID"},
+			table.Column{Header: "DISPLAY_NAME", Flex: true},
+		)
+		for _, m := range s.AttendingMembers {
+			rt.Row(string(outplannerapi.RSVPYes), m.MemberID, m.DisplayName)
+		}
+		for _, m := range s.NotAttendingMembers {
+			rt.Row(string(outplannerapi.RSVPNo), m.MemberID, m.DisplayName)
+		}
+		if len(s.AttendingMembers)+len(s.NotAttendingMembers) == 0 {
+			b.WriteString("\n" + label + ": no responses\n")
+		} else {
+			writeSubtable(&b, label, rt, opts)
+		}
+	}
+
+	if len(missing) > 0 {
+		fmt.Fprintf(&b, "\nMissing for publish: %s\n", strings.Join(missing, ", "))
+	}
+	return b.String()
+}
+
+func memberSummaryTable(members []outplannerapi.MemberSummary) *table.Table {
+	t := table.New(
+		table.Column{Header: "MEMBER_ID"},
+		table.Column{Header: "DISPLAY_NAME", Flex: true},
+		table.Column{Header: "EMAIL"},
+	)
+	for _, m := range members {
+		t.Row(m.MemberID, m.DisplayName, m.Email)
+	}
+	return t
+}
+
+// memberDetailView renders the caller's profile, vehicle included.
+func memberDetailView(m outplannerapi.Member) string {
+	var b strings.Builder
+	var f detailFields
+	f.add("MemberId", m.MemberID)
+	f.add("DisplayName", m.DisplayName)
+	f.add("Email", m.Email)
+	f.add("GroupAliasEmail", stringCell(m.GroupAliasEmail))
+	f.write(&b, "")
+
+	b.WriteString("\n")
+	v := m.VehicleProfile
+	if v == nil {
+		b.WriteString("VehicleProfile: -\n")
+		return b.String()
+	}
+	b.WriteString("VehicleProfile:\n")
+	var vf detailFields
+	vf.add("Make", stringCell(v.Make))
+	vf.add("Model", stringCell(v.Model))
+	vf.add("TireSize", stringCell(v.TireSize))
+	vf.add("LiftLockers", stringCell(v.LiftLockers))
+	vf.add("FuelRange", stringCell(v.FuelRange))
+	vf.add("RecoveryGear", stringCell(v.RecoveryGear))
+	vf.add("HamRadioCallSign", stringCell(v.HamRadioCallSign))
+	vf.write(&b, "  ")
+	writeText(&b, "VehicleProfile.Notes", v.Notes, "")
+	return b.String()
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
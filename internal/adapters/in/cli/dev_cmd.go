@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/envelope"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/spf13/cobra"
+)
+
+func addDevCommands(root *cobra.Command, deps RootDeps) {
+	devCmd := &cobra.Command{
+		Use:   "dev",
+		Short: "Local development tools",
+	}
+	devCmd.AddCommand(newDevMockServerCmd(deps))
+	devCmd.AddCommand(newDevOIDCServerCmd(deps))
+	root.AddCommand(devCmd)
+}
+
+func newDevMockServerCmd(deps RootDeps) *cobra.Command {
+	var (
+		addr     string
+		port     int
+		fixtures string
+	)
+	cmd := &cobra.Command{
+		Use:   "mock-server",
+		Short: "Serve an in-memory Planner API for local development",
+		Long: `Serve an in-memory Planner API for local development.
+
+The server implements every endpoint the CLI uses, with the API's status
+codes, authorization rules and idempotency-key replay. State lives in memory
+and is seeded from --fixtures (JSON or YAML), or from built-in fixtures with
+the members alice, bob and carol.
+
+On start it prints the URL, then (on stderr) a bearer token per fixture member.
+Point the CLI at it with EBO_API_URL (or --api-url) and sign in with
+` + "`ebo auth token set --token TOKEN`" + `. The server runs until interrupted.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.MockAPI == nil {
+				return exitcode.New(exitcode.KindUnexpected, "mock server not configured", nil)
+			}
+			resolved, err := resolvedFromRoot(cmd, deps)
+			if err != nil {
+				return err
+			}
+			if port < 0 || port > 65535 {
+				return exitcode.New(exitcode.KindUsage, "--port must be between 0 and 65535", nil)
+			}
+			handler, tokens, err := deps.MockAPI(fixtures)
+			if err != nil {
+				return err
+			}
+
+			ln, err := net.Listen("tcp", net.JoinHostPort(addr, strconv.Itoa(port)))
+			if err != nil {
+				return exitcode.New(exitcode.KindUsage, fmt.Sprintf("listen on %s:%d", addr, port), err)
+			}
+			url := "http://" + ln.Addr().String()
+
+			if resolved.Options.Output.Envelope() {
+				if err := writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: map[string]any{"url": url, "fixtures": fixtures, "tokens": tokens},
+					Meta: envelope.Meta{APIURL: url, Profile: resolved.Options.Profile},
+				}); err != nil {
+					_ = ln.Close()
+					return err
+				}
+			} else {
+				_, _ = fmt.Fprintln(deps.Stdout, url)
+				_, _ = fmt.Fprintf(deps.Stderr, "Mock Planner API listening on %s (Ctrl-C to stop)\n", url)
+				subjects := make([]string, 0, len(tokens))
+				for s := range tokens {
+					subjects = append(subjects, s)
+				}
+				sort.Strings(subjects)
+				for _, s := range subjects {
+					_, _ = fmt.Fprintf(deps.Stderr, "  token for %s: %s\n", s, tokens[s])
+				}
+				_, _ = fmt.Fprintf(deps.Stderr, "Try: export EBO_API_URL=%s && ebo auth token set --token TOKEN\n", url)
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			return serveUntilDone(ctx, "mock server", ln, handler, deps.Stderr)
+		},
+	}
+	cmd.Flags().StringVar(&addr, "addr", "127.0.0.1", "Address to listen on")
+	cmd.Flags().IntVar(&port, "port", 8080, "Port to listen on (0 picks a free port)")
+	cmd.Flags().StringVar(&fixtures, "fixtures", "", "Seed state from a JSON/YAML fixtures file (default: built-in fixtures)")
+	return cmd
+}
+
+// serveUntilDone serves handler on ln until ctx is done, then shuts down
+// gracefully. name labels errors and warnings.
+func serveUntilDone(ctx context.Context, name string, ln net.Listener, handler http.Handler, stderr io.Writer) error {
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errc:
+		return exitcode.New(exitcode.KindUnexpected, name+" stopped", err)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		_, _ = fmt.Fprintf(stderr, "warning: %s shutdown: %v\n", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/config"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+)
+
+func TestDevMockServer_ServesUntilCanceled(t *testing.T) {
+	var gotFixtures string
+	mock := func(path string) (http.Handler, map[string]string, error) {
+		gotFixtures = path
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, "mock "+r.URL.Path)
+		}), map[string]string{"alice": "x.y.z"}, nil
+	}
+	stdoutR, stdoutW := io.Pipe()
+	store := &memStore{path: "/x", doc: config.NewEmptyDocument()}
+	cmd := NewRootCmd(RootDeps{ConfigStore: store, MockAPI: mock, Stdout: stdoutW, Stderr: &bytes.Buffer{}})
+	cmd.SetArgs([]string{"dev", "mock-server", "--port", "0", "--fixtures", "f.yaml"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- cmd.ExecuteContext(ctx) }()
+
+	url, err := bufio.NewReader(stdoutR).ReadString('\n')
+	if err != nil {
+		t.Fatalf("read url: %v", err)
+	}
+	url = strings.TrimSpace(url)
+	if !strings.HasPrefix(url, "http://127.0.0.1:") || gotFixtures != "f.yaml" {
+		t.Fatalf("url=%q fixtures=%q", url, gotFixtures)
+	}
+
+	resp, err := http.Get(url + "/trips")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if string(body) != "mock /trips" {
+		t.Fatalf("body=%q", body)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("server did not stop")
+	}
+}
+
+func TestDevMockServer_InvalidPortIsUsageError(t *testing.T) {
+	mock := func(string) (http.Handler, map[string]string, error) { return http.NotFoundHandler(), nil, nil }
+	store := &memStore{path: "/x", doc: config.NewEmptyDocument()}
+	cmd := NewRootCmd(RootDeps{ConfigStore: store, MockAPI: mock, Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}})
+	cmd.SetArgs([]string{"dev", "mock-server", "--port", "70000"})
+	if err := cmd.Execute(); exitcode.Code(err) != exitcode.Usage {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
+
+func TestDevOIDCServer_ApproveDeviceCode(t *testing.T) {
+	stdoutR, stdoutW := io.Pipe()
+	store := &memStore{path: "/x", doc: config.NewEmptyDocument()}
+	cmd := NewRootCmd(RootDeps{ConfigStore: store, Stdout: stdoutW, Stderr: &bytes.Buffer{}})
+	cmd.SetArgs([]string{"dev", "oidc-server", "--port", "0", "--subject", "alice", "--subject", "bob"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- cmd.ExecuteContext(ctx) }()
+
+	issuer, err := bufio.NewReader(stdoutR).ReadString('\n')
+	if err != nil {
+		t.Fatalf("read url: %v", err)
+	}
+	issuer = strings.TrimSpace(issuer)
+
+	resp, err := http.PostForm(issuer+"/device", url.Values{"client_id": {"cid"}})
+	if err != nil {
+		t.Fatalf("device: %v", err)
+	}
+	var dc struct {
+		DeviceCode string `json:"device_code"`
+		UserCode   string `json:"user_code"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&dc)
+	_ = resp.Body.Close()
+
+	approve := func(args ...string) (string, error) {
+		stderr := &bytes.Buffer{}
+		c := NewRootCmd(RootDeps{ConfigStore: store, Stdout: &bytes.Buffer{}, Stderr: stderr})
+		c.SetArgs(append([]string{"dev", "oidc-server", "--issuer", issuer}, args...))
+		err := c.Execute()
+		return stderr.String(), err
+	}
+	if out, err := approve("--approve", dc.UserCode, "--subject", "bob"); err != nil || !strings.Contains(out, "Approved "+dc.UserCode+" as bob") {
+		t.Fatalf("approve: %q %v", out, err)
+	}
+	if _, err := approve("--approve", dc.UserCode); exitcode.Code(err) != exitcode.NotFound {
+		t.Fatalf("second approve: %v", err)
+	}
+	if _, err := approve("--approve", "ZZZZ-ZZZZ", "--subject", "mallory"); exitcode.Code(err) != exitcode.Usage {
+		t.Fatalf("unknown subject: %v", err)
+	}
+
+	resp, err = http.PostForm(issuer+"/token", url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:device_code"}, "client_id": {"cid"}, "device_code": {dc.DeviceCode},
+	})
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("token: %v %v", resp, err)
+	}
+	_ = resp.Body.Close()
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("server did not stop")
+	}
+}
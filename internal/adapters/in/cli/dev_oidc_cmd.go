@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/envelope"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/oidcfake"
+	"github.com/spf13/cobra"
+)
+
+const defaultOIDCServerPort = 8090
+
+func newDevOIDCServerCmd(deps RootDeps) *cobra.Command {
+	var (
+		addr        string
+		port        int
+		subjects    []string
+		clientID    string
+		autoApprove bool
+		tokenTTL    time.Duration
+		approve     string
+		deny        bool
+		issuer      string
+	)
+	cmd := &cobra.Command{
+		Use:   "oidc-server",
+		Short: "Serve a fake OIDC issuer for local development",
+		Long: `Serve a fake OIDC issuer for local development.
+
+The issuer implements discovery, the device authorization grant used by
+` + "`ebo auth login`" + `, the authorization code grant with PKCE, refresh tokens,
+revocation and a JWKS. Tokens are signed JWTs whose "sub" is one of --subject
+(default: alice, bob and carol, the members of ` + "`ebo dev mock-server`" + `).
+
+Device codes wait for approval: open the printed verification page, or run
+` + "`ebo dev oidc-server --approve USER_CODE [--subject NAME]`" + ` from another
+shell. With --auto-approve they are approved immediately as the first subject.
+The server runs until interrupted.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resolved, err := resolvedFromRoot(cmd, deps)
+			if err != nil {
+				return err
+			}
+			if approve != "" {
+				return approveDeviceCode(deps, resolved, issuer, approve, subjects, deny)
+			}
+			if port < 0 || port > 65535 {
+				return exitcode.New(exitcode.KindUsage, "--port must be between 0 and 65535", nil)
+			}
+			if tokenTTL <= 0 {
+				return exitcode.New(exitcode.KindUsage, "--token-ttl must be positive", nil)
+			}
+			iss, err := oidcfake.New(oidcfake.Options{
+				Subjects:    subjects,
+				ClientID:    clientID,
+				AutoApprove: autoApprove,
+				TokenTTL:    tokenTTL,
+				Interval:    1,
+			})
+			if err != nil {
+				return exitcode.New(exitcode.KindUnexpected, "start issuer", err)
+			}
+
+			ln, err := net.Listen("tcp", net.JoinHostPort(addr, strconv.Itoa(port)))
+			if err != nil {
+				return exitcode.New(exitcode.KindUsage, fmt.Sprintf("listen on %s:%d", addr, port), err)
+			}
+			url := "http://" + ln.Addr().String()
+
+			if resolved.Options.Output.Envelope() {
+				if err := writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: map[string]any{"issuerUrl": url, "subjects": subjects, "autoApprove": autoApprove},
+					Meta: envelope.Meta{Profile: resolved.Options.Profile},
+				}); err != nil {
+					_ = ln.Close()
+					return err
+				}
+			} else {
+				_, _ = fmt.Fprintln(deps.Stdout, url)
+				_, _ = fmt.Fprintf(deps.Stderr, "Fake OIDC issuer listening on %s (Ctrl-C to stop)\n", url)
+				_, _ = fmt.Fprintf(deps.Stderr, "  subjects: %s\n", strings.Join(subjects, ", "))
+				if autoApprove {
+					_, _ = fmt.Fprintf(deps.Stderr, "  device codes are approved automatically as %s\n", iss.DefaultSubject())
+				} else {
+					_, _ = fmt.Fprintf(deps.Stderr, "  approve device codes at %s/device or with: ebo dev oidc-server --approve USER_CODE --issuer %s\n", url, url)
+				}
+				id := clientID
+				if id == "" {
+					id = "ebo-cli"
+				}
+				_, _ = fmt.Fprintf(deps.Stderr, "Try: ebo config set profiles.%s.oidc.issuerUrl %s\n", resolved.Options.Profile, url)
+				_, _ = fmt.Fprintf(deps.Stderr, "     ebo config set profiles.%s.oidc.clientId %s\n", resolved.Options.Profile, id)
+				_, _ = fmt.Fprintf(deps.Stderr, "     ebo config set profiles.%s.oidc.scopes '[\"openid\"]'\n", resolved.Options.Profile)
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			return serveUntilDone(ctx, "oidc server", ln, iss.Handler(), deps.Stderr)
+		},
+	}
+	cmd.Flags().StringVar(&addr, "addr", "127.0.0.1", "Address to listen on")
+	cmd.Flags().IntVar(&port, "port", defaultOIDCServerPort, "Port to listen on (0 picks a free port)")
+	cmd.Flags().StringSliceVar(&subjects, "subject", []string{"alice", "bob", "carol"}, "Subject that may sign in (repeatable; the first is the default). With --approve: the subject to sign in as")
+	cmd.Flags().StringVar(&clientID, "client-id", "", "Only accept this client ID (default: any)")
+	cmd.Flags().BoolVar(&autoApprove, "auto-approve", false, "Approve device codes as soon as they are issued")
+	cmd.Flags().DurationVar(&tokenTTL, "token-ttl", time.Hour, "Access and ID token lifetime")
+	cmd.Flags().StringVar(&approve, "approve", "", "Approve USER_CODE on a running issuer, then exit")
+	cmd.Flags().BoolVar(&deny, "deny", false, "With --approve: deny the code instead")
+	cmd.Flags().StringVar(&issuer, "issuer", "http://127.0.0.1:"+strconv.Itoa(defaultOIDCServerPort), "With --approve: URL of the running issuer")
+	return cmd
+}
+
+// approveDeviceCode asks a running fake issuer to approve (or deny) a
+// pending device code.
+func approveDeviceCode(deps RootDeps, resolved cliopts.Resolved, issuer, userCode string, subjects []string, deny bool) error {
+	form := url.Values{"user_code": {userCode}}
+	subject := ""
+	if deny {
+		form.Set("deny", "1")
+	} else if len(subjects) > 0 {
+		subject = subjects[0]
+		form.Set("subject", subject)
+	}
+	hc := deps.HTTPClient
+	if hc == nil {
+		hc = &http.Client{Timeout: resolved.Options.Timeout}
+	}
+	endpoint := strings.TrimRight(issuer, "/") + "/device/approve"
+	resp, err := hc.PostForm(endpoint, form)
+	if err != nil {
+		return exitcode.New(exitcode.KindNetwork, "contact issuer at "+issuer, err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+
+	var body struct {
+		Error       string `json:"error"`
+		Description string `json:"error_description"`
+		UserCode    string `json:"user_code"`
+		Subject     string `json:"subject"`
+	}
+	_ = json.Unmarshal(b, &body)
+	switch {
+	case resp.StatusCode == http.StatusNotFound && body.Error != "":
+		return exitcode.New(exitcode.KindNotFound, body.Description, nil)
+	case resp.StatusCode == http.StatusBadRequest && body.Error != "":
+		return exitcode.New(exitcode.KindUsage, body.Description, nil)
+	case resp.StatusCode != http.StatusOK:
+		return exitcode.New(exitcode.KindServer, fmt.Sprintf("approve: http %d from %s", resp.StatusCode, endpoint), nil)
+	}
+
+	action := "approved"
+	if deny {
+		action = "denied"
+	}
+	if resolved.Options.Output.Envelope() {
+		return writeEnvelope(deps, resolved, envelope.Envelope{
+			Data: map[string]any{"userCode": body.UserCode, "subject": body.Subject, "result": action},
+			Meta: envelope.Meta{Profile: resolved.Options.Profile},
+		})
+	}
+	if deny {
+		_, _ = fmt.Fprintf(deps.Stderr, "Denied %s\n", body.UserCode)
+	} else {
+		_, _ = fmt.Fprintf(deps.Stderr, "Approved %s as %s\n", body.UserCode, body.Subject)
+	}
+	return nil
+}
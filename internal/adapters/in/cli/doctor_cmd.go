@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/app/doctorapp"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/apispec"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/envelope"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/table"
+	"github.com/spf13/cobra"
+)
+
+func addDoctorCommand(root *cobra.Command, deps RootDeps) {
+	root.AddCommand(newDoctorCmd(deps))
+}
+
+func newDoctorCmd(deps RootDeps) *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Check config, connectivity, login and membership end to end",
+		Long: `Check config, connectivity, login and membership end to end.
+
+Runs, in order: config file parse and schema, config file permissions (0600),
+effective profile and apiUrl, API reachability and latency, OIDC discovery and
+device-grant support, token presence and expiry, clock skew against the API's
+Date header, member provisioning, and the spec version this binary targets.
+
+Each check reports pass, warn or fail. Exits non-zero (with the exit code of
+the first failed check) when any check fails.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resolved, err := resolvedFromRoot(cmd, deps)
+			if err != nil {
+				return err
+			}
+			svc := doctorapp.Service{
+				Store:    deps.ConfigStore,
+				API:      deps.PlannerAPI,
+				HTTP:     doctorHTTPClient(deps, resolved),
+				FileMode: fileMode,
+				SpecRef:  apispec.PinnedRef(),
+			}
+			if runtime.GOOS == "windows" {
+				svc.FileMode = nil
+			}
+			report := svc.Run(cmd.Context(), resolved)
+
+			if resolved.Options.Output.Envelope() {
+				if err := report.Err(); err != nil {
+					// The envelope (checks included) is written by main.
+					return err
+				}
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: report,
+					Meta: envelope.Meta{APIURL: report.APIURL, Profile: report.Profile},
+				})
+			}
+
+			t := table.New(
+				table.Column{Header: "CHECK"},
+				table.Column{Header: "STATUS", Style: table.ByValue(map[string]table.Style{
+					string(doctorapp.Pass): table.Green,
+					string(doctorapp.Warn): table.Yellow,
+					string(doctorapp.Fail): table.Red,
+				})},
+				table.Column{Header: "DETAIL", Flex: true},
+			)
+			for _, c := range report.Checks {
+				t.Row(c.Name, string(c.Status), c.Message)
+			}
+			if err := writeTable(deps, resolved, t); err != nil {
+				return err
+			}
+			var hints []string
+			for _, c := range report.Checks {
+				if c.Status != doctorapp.Pass && c.Hint != "" {
+					hints = append(hints, fmt.Sprintf("  %s: %s", c.Name, c.Hint))
+				}
+			}
+			if len(hints) > 0 {
+				_, _ = fmt.Fprintf(deps.Stderr, "Suggestions:\n%s\n", strings.Join(hints, "\n"))
+			}
+			return report.Err()
+		},
+	}
+}
+
+// doctorHTTPClient is the invocation's client (so --trace/--har see the
+// checks), bounded by --timeout.
+func doctorHTTPClient(deps RootDeps, resolved cliopts.Resolved) *http.Client {
+	c := http.Client{}
+	if deps.HTTPClient != nil {
+		c = *deps.HTTPClient
+	}
+	if c.Timeout == 0 {
+		c.Timeout = resolved.Options.Timeout
+	}
+	return &c
+}
+
+func fileMode(path string) (fs.FileMode, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	return fi.Mode(), nil
+}
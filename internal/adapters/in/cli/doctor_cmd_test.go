@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/apispec"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/config"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+type doctorAPI struct {
+	outplannerapi.Client
+	err error
+}
+
+func (d doctorAPI) GetMyMemberProfile(ctx context.Context, baseURL, bearerToken string) (*outplannerapi.MemberResult, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	return &outplannerapi.MemberResult{Member: outplannerapi.Member{MemberID: "m1", DisplayName: "Alice"}}, nil
+}
+
+// doctorStore is a healthy config backed by a real 0600 file so the
+// permissions check has something to stat.
+func doctorStore(t *testing.T, apiURL string) *memStore {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("version: 1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	enc := base64.RawURLEncoding
+	token := enc.EncodeToString([]byte(`{"alg":"none"}`)) + "." +
+		enc.EncodeToString([]byte(fmt.Sprintf(`{"sub":"alice","exp":%d}`, time.Now().Add(time.Hour).Unix()))) + ".sig"
+
+	doc := config.NewEmptyDocument()
+	doc, _ = config.WithProfileAPIURL(doc, "default", apiURL)
+	doc, _ = config.WithProfileOIDC(doc, "default", apiURL, "cid", []string{"openid"})
+	doc, _ = config.SetString(doc, "profiles.default.auth.accessToken", token)
+	return &memStore{path: path, doc: doc}
+}
+
+func TestDoctor_HealthyPrintsTable(t *testing.T) {
+	_, srv := newFakeIssuer(t)
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd := NewRootCmd(RootDeps{Env: cliopts.MapEnv{}, ConfigStore: doctorStore(t, srv.URL), PlannerAPI: doctorAPI{}, Stdout: stdout, Stderr: stderr})
+	cmd.SetArgs([]string{"doctor"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v\nstdout=%s\nstderr=%s", err, stdout, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if strings.Fields(lines[0])[0] != "CHECK" || len(lines) != 10 {
+		t.Fatalf("stdout=%q", stdout.String())
+	}
+	for _, l := range lines[1:] {
+		f := strings.Fields(l)
+		// Until make gen embeds the pinned spec, the spec check warns.
+		if f[0] == "spec" && apispec.PinnedRef() == "" && f[1] == "warn" {
+			continue
+		}
+		if f[1] != "pass" {
+			t.Errorf("not passing: %q", l)
+		}
+	}
+}
+
+func TestDoctor_FailureExitsNonZeroWithChecksInJSON(t *testing.T) {
+	_, srv := newFakeIssuer(t)
+	stdout := &bytes.Buffer{}
+	api := doctorAPI{err: exitcode.New(exitcode.KindNotFound, "MEMBER_NOT_PROVISIONED", nil)}
+	cmd := NewRootCmd(RootDeps{Env: cliopts.MapEnv{}, ConfigStore: doctorStore(t, srv.URL), PlannerAPI: api, Stdout: stdout, Stderr: &bytes.Buffer{}})
+	cmd.SetArgs([]string{"--output", "json", "doctor"})
+	err := cmd.Execute()
+	if exitcode.Code(err) != exitcode.NotFound {
+		t.Fatalf("err=%v code=%d", err, exitcode.Code(err))
+	}
+	if stdout.Len() != 0 {
+		t.Fatalf("the error envelope is main's to write; stdout=%q", stdout.String())
+	}
+	b, _ := json.Marshal(exitcode.DataOf(err))
+	var data struct {
+		Checks []struct{ Name, Status string }
+	}
+	if err := json.Unmarshal(b, &data); err != nil || len(data.Checks) != 9 {
+		t.Fatalf("data=%s", b)
+	}
+	if m := data.Checks[7]; m.Name != "member" || m.Status != "fail" {
+		t.Fatalf("member: %+v", m)
+	}
+}
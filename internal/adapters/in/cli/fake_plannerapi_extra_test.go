@@ -3,26 +3,26 @@ package cli
 import (
 	"context"
 
-	gen "github.com/Overland-East-Bay/trip-planner-cli/internal/gen/plannerapi"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
 )
 
 // Satisfy newly-added plannerapi.Client methods for existing tests that use fakePlannerAPI.
-func (f *fakePlannerAPI) ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*gen.ListVisibleTripsForMemberClientResponse, error) {
+func (f *fakePlannerAPI) ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.TripList, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in this test fake", nil)
 }
 
-func (f *fakePlannerAPI) ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*gen.ListMyDraftTripsClientResponse, error) {
+func (f *fakePlannerAPI) ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.TripList, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in this test fake", nil)
 }
 
-func (f *fakePlannerAPI) GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripDetailsClientResponse, error) {
+func (f *fakePlannerAPI) GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.TripResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -30,7 +30,7 @@ func (f *fakePlannerAPI) GetTripDetails(ctx context.Context, baseURL string, bea
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in this test fake", nil)
 }
 
-func (f *fakePlannerAPI) SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetTripDraftVisibilityJSONRequestBody) (*gen.SetTripDraftVisibilityClientResponse, error) {
+func (f *fakePlannerAPI) SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.SetDraftVisibilityRequest) (*outplannerapi.TripResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -40,7 +40,7 @@ func (f *fakePlannerAPI) SetTripDraftVisibility(ctx context.Context, baseURL str
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in this test fake", nil)
 }
 
-func (f *fakePlannerAPI) PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.PublishTripClientResponse, error) {
+func (f *fakePlannerAPI) PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.PublishResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -48,7 +48,7 @@ func (f *fakePlannerAPI) PublishTrip(ctx context.Context, baseURL string, bearer
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in this test fake", nil)
 }
 
-func (f *fakePlannerAPI) AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.AddTripOrganizerJSONRequestBody) (*gen.AddTripOrganizerClientResponse, error) {
+func (f *fakePlannerAPI) AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.AddOrganizerRequest) (*outplannerapi.TripResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -58,7 +58,7 @@ func (f *fakePlannerAPI) AddTripOrganizer(ctx context.Context, baseURL string, b
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in this test fake", nil)
 }
 
-func (f *fakePlannerAPI) RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, memberID gen.MemberId, idempotencyKey string) (*gen.RemoveTripOrganizerClientResponse, error) {
+func (f *fakePlannerAPI) RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, memberID string, idempotencyKey string) (*outplannerapi.TripResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -68,7 +68,7 @@ func (f *fakePlannerAPI) RemoveTripOrganizer(ctx context.Context, baseURL string
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in this test fake", nil)
 }
 
-func (f *fakePlannerAPI) SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetMyRSVPJSONRequestBody) (*gen.SetMyRSVPClientResponse, error) {
+func (f *fakePlannerAPI) SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.SetMyRSVPRequest) (*outplannerapi.RSVPResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -78,7 +78,7 @@ func (f *fakePlannerAPI) SetMyRSVP(ctx context.Context, baseURL string, bearerTo
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in this test fake", nil)
 }
 
-func (f *fakePlannerAPI) GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetMyRSVPForTripClientResponse, error) {
+func (f *fakePlannerAPI) GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.RSVPResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -86,7 +86,7 @@ func (f *fakePlannerAPI) GetMyRSVPForTrip(ctx context.Context, baseURL string, b
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in this test fake", nil)
 }
 
-func (f *fakePlannerAPI) GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripRSVPSummaryClientResponse, error) {
+func (f *fakePlannerAPI) GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.RSVPSummaryResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -94,22 +94,22 @@ func (f *fakePlannerAPI) GetTripRSVPSummary(ctx context.Context, baseURL string,
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in this test fake", nil)
 }
 
-func (f *fakePlannerAPI) SearchMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.SearchMembersParams) (*gen.SearchMembersClientResponse, error) {
+func (f *fakePlannerAPI) SearchMembers(ctx context.Context, baseURL string, bearerToken string, query string) (*outplannerapi.MemberList, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
-	_ = params
+	_ = query
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in this test fake", nil)
 }
 
-func (f *fakePlannerAPI) GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*gen.GetMyMemberProfileClientResponse, error) {
+func (f *fakePlannerAPI) GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.MemberResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in this test fake", nil)
 }
 
-func (f *fakePlannerAPI) DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.DeleteMyMemberAccountJSONRequestBody) (*gen.DeleteMyMemberAccountClientResponse, error) {
+func (f *fakePlannerAPI) DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.DeleteMemberRequest) (*outplannerapi.MemberDeleted, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -118,7 +118,7 @@ func (f *fakePlannerAPI) DeleteMyMemberAccount(ctx context.Context, baseURL stri
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in this test fake", nil)
 }
 
-func (f *fakePlannerAPI) CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req gen.CreateMyMemberJSONRequestBody) (*gen.CreateMyMemberClientResponse, error) {
+func (f *fakePlannerAPI) CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req outplannerapi.CreateMemberRequest) (*outplannerapi.MemberResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/table"
+	"github.com/spf13/cobra"
+)
+
+// listFlags are --columns, --sort-by and --no-headers for the list commands.
+// Column names are the table's keys: the JSON field names of the listed type.
+type listFlags struct {
+	columns   []string
+	sortBy    string
+	noHeaders bool
+}
+
+// jsonKey returns the JSON name of row's Go field, for a list column's key.
+// It panics if row has no such field, so a renamed field fails every list
+// command's tests instead of drifting.
+func jsonKey(row any, field string) string {
+	f, ok := reflect.TypeOf(row).FieldByName(field)
+	if !ok {
+		panic(fmt.Sprintf("%T has no field %s", row, field))
+	}
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	return name
+}
+
+func addListFlags(cmd *cobra.Command, f *listFlags) {
+	cmd.Flags().StringSliceVar(&f.columns, "columns", nil, "Comma-separated columns to show, in order (e.g. tripId,name,startDate)")
+	cmd.Flags().StringVar(&f.sortBy, "sort-by", "", "Sort rows by a column, optionally descending (e.g. startDate or startDate,desc)")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Omit the header row (table, csv and tsv)")
+}
+
+func (f listFlags) set() bool {
+	return len(f.columns) > 0 || f.sortBy != "" || f.noHeaders
+}
+
+// check validates the flags against an empty table of the command's columns,
+// so a bad column fails before any request.
+func (f listFlags) check(resolved cliopts.Resolved, empty *table.Table) error {
+	if f.set() && resolved.Options.Output.Envelope() {
+		return exitcode.WithHint(
+			exitcode.New(exitcode.KindUsage, fmt.Sprintf("--columns, --sort-by and --no-headers apply to table, csv and tsv output, not --output %s", resolved.Options.Output), nil),
+			"for json, select and sort with --query, e.g. --query 'sort_by(trips, &startDate)[].tripId'")
+	}
+	if f.set() && resolved.Options.Output == cliopts.OutputICS {
+		return exitcode.New(exitcode.KindUsage, "--columns, --sort-by and --no-headers apply to table, csv and tsv output, not --output ics", nil)
+	}
+	return f.apply(empty)
+}
+
+// apply sorts, selects and (with --no-headers) hides the header of t.
+func (f listFlags) apply(t *table.Table) error {
+	if f.sortBy != "" {
+		key, order, _ := strings.Cut(f.sortBy, ",")
+		if order != "" && order != "asc" && order != "desc" {
+			return exitcode.New(exitcode.KindUsage, fmt.Sprintf("invalid --sort-by %q: order must be asc or desc", f.sortBy), nil)
+		}
+		if err := t.SortBy(key, order == "desc"); err != nil {
+			return unknownColumn("--sort-by", t, err)
+		}
+	}
+	if len(f.columns) > 0 {
+		if err := t.Select(f.columns...); err != nil {
+			return unknownColumn("--columns", t, err)
+		}
+	}
+	if f.noHeaders {
+		t.HideHeader()
+	}
+	return nil
+}
+
+func unknownColumn(flag string, t *table.Table, err error) error {
+	return exitcode.WithHint(exitcode.New(exitcode.KindUsage, "invalid "+flag, err),
+		"valid columns: "+strings.Join(t.Keys(), ", "))
+}
+
+// writeList writes a list command's table as CSV/TSV or human output, after
+// applying its list flags.
+func writeList(deps RootDeps, resolved cliopts.Resolved, f listFlags, t *table.Table) error {
+	if err := f.apply(t); err != nil {
+		return err
+	}
+	if resolved.Options.Output.Delimited() {
+		return writeDelimited(deps, resolved, t)
+	}
+	return writeTable(deps, resolved, t)
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+// jsonFields returns the JSON field names of a response type, sorted.
+func jsonFields(v any) []string {
+	var names []string
+	rt := reflect.TypeOf(v)
+	for i := 0; i < rt.NumField(); i++ {
+		name, _, _ := strings.Cut(rt.Field(i).Tag.Get("json"), ",")
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestListColumns_AreTheResponseFieldNames(t *testing.T) {
+	cases := []struct {
+		keys []string
+		typ  any
+	}{
+		{tripSummaryTable(nil).Keys(), outplannerapi.TripSummary{}},
+		{memberDirectoryTable(nil).Keys(), outplannerapi.MemberDirectoryEntry{}},
+	}
+	for _, tc := range cases {
+		keys := slices.Sorted(slices.Values(tc.keys))
+		if want := jsonFields(tc.typ); !slices.Equal(keys, want) {
+			t.Errorf("%T: columns %v, want %v", tc.typ, keys, want)
+		}
+	}
+}
+
+func TestListFlags_SelectSortAndHideHeaders(t *testing.T) {
+	out, err := runOutput(t, listAPI(), cliopts.MapEnv{}, "trip", "list", "--columns", "capacityRigs,tripId", "--sort-by", "capacityRigs,desc")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	want := "" +
+		"CAPACITY_RIGS  TRIP_ID\n" +
+		"12             t1\n" +
+		"-              t2\n"
+	if out != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", out, want)
+	}
+
+	out, err = runOutput(t, listAPI(), cliopts.MapEnv{}, "--output", "csv", "trip", "list", "--columns", "tripId,status", "--sort-by", "status", "--no-headers")
+	if err != nil || out != "t2,CANCELED\nt1,PUBLISHED\n" {
+		t.Fatalf("csv: %q %v", out, err)
+	}
+}
+
+func TestListFlags_ErrorsAreUsageAndMakeNoRequest(t *testing.T) {
+	cases := []struct {
+		args []string
+		hint string
+	}{
+		{[]string{"trip", "list", "--columns", "tripId,bogus"}, "valid columns: tripId, status, startDate"},
+		{[]string{"trip", "list", "--sort-by", "bogus"}, "valid columns: tripId"},
+		{[]string{"trip", "list", "--sort-by", "startDate,sideways"}, ""},
+		{[]string{"--output", "json", "trip", "list", "--sort-by", "startDate"}, "--query"},
+	}
+	for _, tc := range cases {
+		api := listAPI()
+		_, err := runOutput(t, api, cliopts.MapEnv{}, tc.args...)
+		if exitcode.Code(err) != exitcode.Usage {
+			t.Errorf("%v: err=%v code=%d", tc.args, err, exitcode.Code(err))
+		}
+		if !strings.Contains(exitcode.HintOf(err), tc.hint) {
+			t.Errorf("%v: hint %q, want %q", tc.args, exitcode.HintOf(err), tc.hint)
+		}
+		if api.listCalls != 0 {
+			t.Errorf("%v: expected no request", tc.args)
+		}
+	}
+}
@@ -1,41 +1,39 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"net/mail"
-	"os"
 	"strings"
 
 	plannerapiout "github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/plannerapi"
-	gen "github.com/Overland-East-Bay/trip-planner-cli/internal/gen/plannerapi"
-	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/app/memberapp"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/editmode"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/envelope"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
-	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/idempotency"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/prompt"
-	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/requestfile"
-	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/table"
+	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
 	"github.com/spf13/cobra"
 )
 
 func addMemberCommands(root *cobra.Command, deps RootDeps) {
+	svc := memberapp.Service{API: deps.PlannerAPI}
 	memberCmd := &cobra.Command{
 		Use:   "member",
 		Short: "Member operations",
 	}
-	memberCmd.AddCommand(newMemberListCmd(deps))
-	memberCmd.AddCommand(newMemberSearchCmd(deps))
-	memberCmd.AddCommand(newMemberMeCmd(deps))
-	memberCmd.AddCommand(newMemberDeleteCmd(deps))
-	memberCmd.AddCommand(newMemberCreateCmd(deps))
-	memberCmd.AddCommand(newMemberUpdateCmd(deps))
+	memberCmd.AddCommand(newMemberListCmd(deps, svc))
+	memberCmd.AddCommand(newMemberSearchCmd(deps, svc))
+	memberCmd.AddCommand(newMemberMeCmd(deps, svc))
+	memberCmd.AddCommand(newMemberDeleteCmd(deps, svc))
+	memberCmd.AddCommand(newMemberCreateCmd(deps, svc))
+	memberCmd.AddCommand(newMemberUpdateCmd(deps, svc))
 	root.AddCommand(memberCmd)
 }
 
-func newMemberDeleteCmd(deps RootDeps) *cobra.Command {
+func newMemberDeleteCmd(deps RootDeps, svc memberapp.Service) *cobra.Command {
 	var (
 		force          bool
 		idempotencyKey string
@@ -58,35 +56,18 @@ func newMemberDeleteCmd(deps RootDeps) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			ctx, respMeta := outplannerapi.WithResponseMeta(ctx)
 
-			if !force {
-				return exitcode.New(exitcode.KindUsage, "refusing to delete without --force", nil)
-			}
-			if err := validateSingleLineFlag(reason, "--reason"); err != nil {
-				return exitcode.New(exitcode.KindUsage, "invalid --reason", err)
-			}
-
-			if strings.TrimSpace(idempotencyKey) == "" {
-				idempotencyKey = idempotency.NewKey()
-			}
-
-			req := gen.DeleteMyMemberRequest{
-				Confirm: true,
-			}
-			if strings.TrimSpace(reason) != "" {
-				r := strings.TrimSpace(reason)
-				req.Reason = &r
-			}
-
-			resp, err := deps.PlannerAPI.DeleteMyMemberAccount(ctx, apiCtx.APIURL, apiCtx.BearerToken, idempotencyKey, req)
+			resp, idempotencyKey, err := svc.Delete(ctx, apiCtx.APIURL, apiCtx.BearerToken, idempotencyKey, reason, force)
 			if err != nil {
-				return err
+				return apiCtx.apiError(err, idempotencyKey)
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
-					Data: resp.JSON200,
-					Meta: envelope.Meta{APIURL: apiCtx.APIURL, Profile: apiCtx.Profile, IdempotencyKey: idempotencyKey},
+			writeResponseMeta(deps, resolved, respMeta)
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: resp,
+					Meta: apiCtx.envelopeMeta(respMeta, idempotencyKey),
 				})
 			}
 
@@ -101,7 +82,7 @@ func newMemberDeleteCmd(deps RootDeps) *cobra.Command {
 	return cmd
 }
 
-func newMemberCreateCmd(deps RootDeps) *cobra.Command {
+func newMemberCreateCmd(deps RootDeps, svc memberapp.Service) *cobra.Command {
 	var (
 		displayName     string
 		email           string
@@ -134,132 +115,42 @@ func newMemberCreateCmd(deps RootDeps) *cobra.Command {
 			if err != nil {
 				return err
 			}
-
-			rejectMultiline := func(label, v string) error {
-				if strings.ContainsAny(v, "\r\n") {
-					return exitcode.New(exitcode.KindUsage, fmt.Sprintf("%s must not be multi-line; use --from-file/--edit/--prompt instead", label), nil)
-				}
-				return nil
-			}
-			if err := rejectMultiline("--display-name", displayName); err != nil {
-				return err
-			}
-			if err := rejectMultiline("--email", email); err != nil {
-				return err
-			}
-			if err := rejectMultiline("--group-alias-email", groupAliasEmail); err != nil {
-				return err
-			}
-			if err := rejectMultiline("--vehicle-make", vehicleMake); err != nil {
-				return err
-			}
-			if err := rejectMultiline("--vehicle-model", vehicleModel); err != nil {
-				return err
-			}
-			if err := rejectMultiline("--vehicle-tire-size", vehicleTireSize); err != nil {
-				return err
-			}
-			if err := rejectMultiline("--vehicle-lift-lockers", vehicleLiftLockers); err != nil {
-				return err
-			}
-			if err := rejectMultiline("--vehicle-fuel-range", vehicleFuelRange); err != nil {
-				return err
-			}
-			if err := rejectMultiline("--vehicle-recovery-gear", vehicleRecoveryGear); err != nil {
-				return err
-			}
-			if err := rejectMultiline("--vehicle-ham-radio-call-sign", vehicleHamRadioCallSign); err != nil {
-				return err
-			}
-			if err := rejectMultiline("--vehicle-notes", vehicleNotes); err != nil {
+			ctx, respMeta := outplannerapi.WithResponseMeta(ctx)
+
+			req, err := memberapp.BuildCreate(memberapp.Profile{
+				DisplayName:     displayName,
+				Email:           email,
+				GroupAliasEmail: groupAliasEmail,
+				Vehicle: memberapp.Vehicle{
+					Make:             vehicleMake,
+					Model:            vehicleModel,
+					TireSize:         vehicleTireSize,
+					LiftLockers:      vehicleLiftLockers,
+					FuelRange:        vehicleFuelRange,
+					RecoveryGear:     vehicleRecoveryGear,
+					HamRadioCallSign: vehicleHamRadioCallSign,
+					Notes:            vehicleNotes,
+				},
+			})
+			if err != nil {
 				return err
 			}
 
-			if strings.TrimSpace(displayName) == "" {
-				return exitcode.New(exitcode.KindUsage, "missing --display-name", nil)
-			}
-			if strings.TrimSpace(email) == "" {
-				return exitcode.New(exitcode.KindUsage, "missing --email", nil)
-			}
-			if _, err := mail.ParseAddress(email); err != nil {
-				return exitcode.New(exitcode.KindUsage, "invalid --email", err)
-			}
-			if strings.TrimSpace(groupAliasEmail) != "" {
-				if _, err := mail.ParseAddress(groupAliasEmail); err != nil {
-					return exitcode.New(exitcode.KindUsage, "invalid --group-alias-email", err)
-				}
-			}
-
-			req := gen.CreateMemberRequest{
-				DisplayName: strings.TrimSpace(displayName),
-				Email:       openapi_types.Email(strings.TrimSpace(email)),
-			}
-			if strings.TrimSpace(groupAliasEmail) != "" {
-				v := openapi_types.Email(strings.TrimSpace(groupAliasEmail))
-				req.GroupAliasEmail = &v
-			}
-
-			// Vehicle profile is optional; include if any fields are set.
-			var vp gen.VehicleProfile
-			setAny := false
-			if strings.TrimSpace(vehicleMake) != "" {
-				v := strings.TrimSpace(vehicleMake)
-				vp.Make = &v
-				setAny = true
-			}
-			if strings.TrimSpace(vehicleModel) != "" {
-				v := strings.TrimSpace(vehicleModel)
-				vp.Model = &v
-				setAny = true
-			}
-			if strings.TrimSpace(vehicleTireSize) != "" {
-				v := strings.TrimSpace(vehicleTireSize)
-				vp.TireSize = &v
-				setAny = true
-			}
-			if strings.TrimSpace(vehicleLiftLockers) != "" {
-				v := strings.TrimSpace(vehicleLiftLockers)
-				vp.LiftLockers = &v
-				setAny = true
-			}
-			if strings.TrimSpace(vehicleFuelRange) != "" {
-				v := strings.TrimSpace(vehicleFuelRange)
-				vp.FuelRange = &v
-				setAny = true
-			}
-			if strings.TrimSpace(vehicleRecoveryGear) != "" {
-				v := strings.TrimSpace(vehicleRecoveryGear)
-				vp.RecoveryGear = &v
-				setAny = true
-			}
-			if strings.TrimSpace(vehicleHamRadioCallSign) != "" {
-				v := strings.TrimSpace(vehicleHamRadioCallSign)
-				vp.HamRadioCallSign = &v
-				setAny = true
-			}
-			if strings.TrimSpace(vehicleNotes) != "" {
-				v := strings.TrimSpace(vehicleNotes)
-				vp.Notes = &v
-				setAny = true
-			}
-			if setAny {
-				req.VehicleProfile = &vp
-			}
-
-			resp, err := deps.PlannerAPI.CreateMyMember(ctx, apiCtx.APIURL, apiCtx.BearerToken, req)
+			resp, err := svc.Create(ctx, apiCtx.APIURL, apiCtx.BearerToken, req)
 			if err != nil {
-				return err
+				return apiCtx.apiError(err, "")
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
-					Data: resp.JSON201,
-					Meta: envelope.Meta{APIURL: apiCtx.APIURL, Profile: apiCtx.Profile},
+			writeResponseMeta(deps, resolved, respMeta)
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: resp,
+					Meta: apiCtx.envelopeMeta(respMeta, ""),
 				})
 			}
 
-			if resp.JSON201 != nil {
-				_, _ = fmt.Fprintf(deps.Stdout, "memberId=%s\n", resp.JSON201.Member.MemberId)
+			if resp != nil {
+				_, _ = fmt.Fprintf(deps.Stdout, "memberId=%s\n", resp.Member.MemberID)
 				return nil
 			}
 			_, _ = io.WriteString(deps.Stdout, "OK\n")
@@ -283,11 +174,27 @@ func newMemberCreateCmd(deps RootDeps) *cobra.Command {
 	return cmd
 }
 
-func newMemberListCmd(deps RootDeps) *cobra.Command {
+// memberDirectoryTable is the table and CSV/TSV output of `member list` and
+// `member search`.
+func memberDirectoryTable(entries []outplannerapi.MemberDirectoryEntry) *table.Table {
+	key := func(field string) string { return jsonKey(outplannerapi.MemberDirectoryEntry{}, field) }
+	t := table.New(
+		table.Column{Header: "MEMBER_ID", Key: key("MemberID")},
+		table.Column{Header: "DISPLAY_NAME", Key: key("DisplayName"), Flex: true},
+	)
+	for _, m := range entries {
+		t.Row(m.MemberID, m.DisplayName)
+	}
+	return t
+}
+
+func newMemberListCmd(deps RootDeps, svc memberapp.Service) *cobra.Command {
 	var includeInactive bool
+	var list listFlags
 	cmd := &cobra.Command{
-		Use:   "list",
-		Short: "List members",
+		Use:         "list",
+		Short:       "List members",
+		Annotations: tabularAnnotations,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			_ = args
 			ctx := cmd.Context()
@@ -298,50 +205,48 @@ func newMemberListCmd(deps RootDeps) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if err := list.check(resolved, memberDirectoryTable(nil)); err != nil {
+				return err
+			}
 			apiCtx, err := resolveAPIContext(ctx, deps, resolved)
 			if err != nil {
 				return err
 			}
+			ctx, respMeta := outplannerapi.WithResponseMeta(ctx)
 
-			var params *gen.ListMembersParams
-			if includeInactive {
-				v := gen.IncludeInactive(true)
-				params = &gen.ListMembersParams{IncludeInactive: &v}
-			}
-
-			resp, err := deps.PlannerAPI.ListMembers(ctx, apiCtx.APIURL, apiCtx.BearerToken, params)
+			resp, err := svc.List(ctx, apiCtx.APIURL, apiCtx.BearerToken, includeInactive)
 			if err != nil {
-				return err
+				return apiCtx.apiError(err, "")
 			}
 
-			entries := []gen.MemberDirectoryEntry{}
-			if resp.JSON200 != nil {
-				entries = resp.JSON200.Members
+			entries := []outplannerapi.MemberDirectoryEntry{}
+			if resp != nil {
+				entries = resp.Members
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
-					Data: resp.JSON200,
-					Meta: envelope.Meta{APIURL: apiCtx.APIURL, Profile: apiCtx.Profile},
+			writeResponseMeta(deps, resolved, respMeta)
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: resp,
+					Meta: apiCtx.envelopeMeta(respMeta, ""),
 				})
 			}
 
-			_, _ = io.WriteString(deps.Stdout, "MEMBER_ID\tDISPLAY_NAME\n")
-			for _, m := range entries {
-				_, _ = fmt.Fprintf(deps.Stdout, "%s\t%s\n", m.MemberId, m.DisplayName)
-			}
-			return nil
+			return writeList(deps, resolved, list, memberDirectoryTable(entries))
 		},
 	}
 	cmd.Flags().BoolVar(&includeInactive, "include-inactive", false, "Include inactive members")
+	addListFlags(cmd, &list)
 	return cmd
 }
 
-func newMemberSearchCmd(deps RootDeps) *cobra.Command {
+func newMemberSearchCmd(deps RootDeps, svc memberapp.Service) *cobra.Command {
+	var list listFlags
 	cmd := &cobra.Command{
-		Use:   "search <query>",
-		Short: "Search members by display name",
-		Args:  cobra.ExactArgs(1),
+		Use:         "search <query>",
+		Short:       "Search members by display name",
+		Args:        cobra.ExactArgs(1),
+		Annotations: tabularAnnotations,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			if deps.PlannerAPI == nil {
@@ -351,47 +256,45 @@ func newMemberSearchCmd(deps RootDeps) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if err := list.check(resolved, memberDirectoryTable(nil)); err != nil {
+				return err
+			}
 			apiCtx, err := resolveAPIContext(ctx, deps, resolved)
 			if err != nil {
 				return err
 			}
+			ctx, respMeta := outplannerapi.WithResponseMeta(ctx)
 
-			q := strings.TrimSpace(args[0])
-			if len(q) < 3 {
-				return exitcode.New(exitcode.KindUsage, "query must be at least 3 characters", nil)
-			}
-			params := &gen.SearchMembersParams{Q: gen.SearchQuery(q)}
-			resp, err := deps.PlannerAPI.SearchMembers(ctx, apiCtx.APIURL, apiCtx.BearerToken, params)
+			resp, err := svc.Search(ctx, apiCtx.APIURL, apiCtx.BearerToken, args[0])
 			if err != nil {
-				return err
+				return apiCtx.apiError(err, "")
 			}
 
-			entries := []gen.MemberDirectoryEntry{}
-			if resp.JSON200 != nil {
-				entries = resp.JSON200.Members
+			entries := []outplannerapi.MemberDirectoryEntry{}
+			if resp != nil {
+				entries = resp.Members
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
-					Data: resp.JSON200,
-					Meta: envelope.Meta{APIURL: apiCtx.APIURL, Profile: apiCtx.Profile},
+			writeResponseMeta(deps, resolved, respMeta)
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: resp,
+					Meta: apiCtx.envelopeMeta(respMeta, ""),
 				})
 			}
 
-			_, _ = io.WriteString(deps.Stdout, "MEMBER_ID\tDISPLAY_NAME\n")
-			for _, m := range entries {
-				_, _ = fmt.Fprintf(deps.Stdout, "%s\t%s\n", m.MemberId, m.DisplayName)
-			}
-			return nil
+			return writeList(deps, resolved, list, memberDirectoryTable(entries))
 		},
 	}
+	addListFlags(cmd, &list)
 	return cmd
 }
 
-func newMemberMeCmd(deps RootDeps) *cobra.Command {
+func newMemberMeCmd(deps RootDeps, svc memberapp.Service) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "me",
-		Short: "Get my member profile",
+		Use:         "me",
+		Short:       "Get my member profile",
+		Annotations: pagedAnnotations,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			_ = args
 			ctx := cmd.Context()
@@ -406,43 +309,40 @@ func newMemberMeCmd(deps RootDeps) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			ctx, respMeta := outplannerapi.WithResponseMeta(ctx)
 
-			resp, err := deps.PlannerAPI.GetMyMemberProfile(ctx, apiCtx.APIURL, apiCtx.BearerToken)
+			resp, err := svc.Me(ctx, apiCtx.APIURL, apiCtx.BearerToken)
 			if err != nil {
 				var ae *plannerapiout.APIError
 				if errors.As(err, &ae) && ae != nil && ae.ErrorCode == "MEMBER_NOT_PROVISIONED" {
-					return exitcode.New(exitcode.KindNotFound,
+					return apiCtx.apiError(exitcode.New(exitcode.KindNotFound,
 						"member not provisioned\nTry:\n  ebo member create --display-name <name> --email <email>",
 						err,
-					)
+					), "")
 				}
-				return err
+				return apiCtx.apiError(err, "")
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
-					Data: resp.JSON200,
-					Meta: envelope.Meta{APIURL: apiCtx.APIURL, Profile: apiCtx.Profile},
+			writeResponseMeta(deps, resolved, respMeta)
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: resp,
+					Meta: apiCtx.envelopeMeta(respMeta, ""),
 				})
 			}
 
-			if resp.JSON200 == nil {
+			if resp == nil {
 				_, _ = io.WriteString(deps.Stdout, "OK\n")
 				return nil
 			}
-			m := resp.JSON200.Member
-			groupAlias := ""
-			if m.GroupAliasEmail != nil {
-				groupAlias = string(*m.GroupAliasEmail)
-			}
-			_, _ = fmt.Fprintf(deps.Stdout, "MemberId: %s\nDisplayName: %s\nEmail: %s\nGroupAliasEmail: %s\n", m.MemberId, m.DisplayName, string(m.Email), groupAlias)
+			_, _ = io.WriteString(deps.Stdout, memberDetailView(resp.Member))
 			return nil
 		},
 	}
 	return cmd
 }
 
-func newMemberUpdateCmd(deps RootDeps) *cobra.Command {
+func newMemberUpdateCmd(deps RootDeps, svc memberapp.Service) *cobra.Command {
 	var (
 		fromFile       string
 		edit           bool
@@ -494,34 +394,44 @@ func newMemberUpdateCmd(deps RootDeps) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			ctx, respMeta := outplannerapi.WithResponseMeta(ctx)
 
-			flagsMode := false
-			for _, name := range []string{
-				"display-name",
-				"email",
-				"group-alias-email",
-				"vehicle-make",
-				"vehicle-model",
-				"vehicle-tire-size",
-				"vehicle-lift-lockers",
-				"vehicle-fuel-range",
-				"vehicle-recovery-gear",
-				"vehicle-ham-radio-call-sign",
-				"vehicle-notes",
-			} {
-				if cmd.Flags().Changed(name) {
-					flagsMode = true
+			flags := cmd.Flags()
+			optional := func(name string, v *string) *string {
+				if flags.Changed(name) {
+					return v
 				}
+				return nil
 			}
-			if clearDisplayName || clearGroupAlias || clearVehicle ||
-				clearVehicleMake || clearVehicleModel || clearVehicleTireSize ||
-				clearVehicleLiftLockers || clearVehicleFuelRange || clearVehicleRecoveryGear ||
-				clearVehicleHamRadio || clearVehicleNotes {
-				flagsMode = true
+			patch := memberapp.Patch{
+				DisplayName:          optional("display-name", &displayName),
+				ClearDisplayName:     clearDisplayName,
+				Email:                optional("email", &email),
+				GroupAliasEmail:      optional("group-alias-email", &groupAliasEmail),
+				ClearGroupAliasEmail: clearGroupAlias,
+				Vehicle: memberapp.VehiclePatch{
+					Make:                  optional("vehicle-make", &vehicleMake),
+					Model:                 optional("vehicle-model", &vehicleModel),
+					TireSize:              optional("vehicle-tire-size", &vehicleTireSize),
+					LiftLockers:           optional("vehicle-lift-lockers", &vehicleLiftLockers),
+					FuelRange:             optional("vehicle-fuel-range", &vehicleFuelRange),
+					RecoveryGear:          optional("vehicle-recovery-gear", &vehicleRecoveryGear),
+					HamRadioCallSign:      optional("vehicle-ham-radio-call-sign", &vehicleHamRadioCallSign),
+					Notes:                 optional("vehicle-notes", &vehicleNotes),
+					ClearMake:             clearVehicleMake,
+					ClearModel:            clearVehicleModel,
+					ClearTireSize:         clearVehicleTireSize,
+					ClearLiftLockers:      clearVehicleLiftLockers,
+					ClearFuelRange:        clearVehicleFuelRange,
+					ClearRecoveryGear:     clearVehicleRecoveryGear,
+					ClearHamRadioCallSign: clearVehicleHamRadio,
+					ClearNotes:            clearVehicleNotes,
+					Clear:                 clearVehicle,
+				},
 			}
 
 			modeCount := 0
-			if flagsMode {
+			if !patch.IsEmpty() {
 				modeCount++
 			}
 			if strings.TrimSpace(fromFile) != "" {
@@ -540,266 +450,31 @@ func newMemberUpdateCmd(deps RootDeps) *cobra.Command {
 				return exitcode.New(exitcode.KindUsage, "choose exactly one patch mode: flags, --from-file, --edit, or --prompt", nil)
 			}
 
-			var req gen.UpdateMyMemberProfileRequest
+			var req outplannerapi.UpdateMemberRequest
 			switch {
-			case flagsMode:
-				rejectMultiline := func(label, v string) error {
-					if strings.ContainsAny(v, "\r\n") {
-						return exitcode.New(exitcode.KindUsage, fmt.Sprintf("%s must not be multi-line; use --from-file/--edit/--prompt instead", label), nil)
-					}
-					return nil
-				}
-
-				if cmd.Flags().Changed("display-name") {
-					if err := rejectMultiline("--display-name", displayName); err != nil {
-						return err
-					}
-					v := strings.TrimSpace(displayName)
-					if v == "" {
-						return exitcode.New(exitcode.KindUsage, "--display-name must be non-empty (use --clear-display-name to clear)", nil)
-					}
-					req.DisplayName = &v
-				}
-				if clearDisplayName {
-					if cmd.Flags().Changed("display-name") {
-						return exitcode.New(exitcode.KindUsage, "cannot use --display-name with --clear-display-name", nil)
-					}
-					empty := ""
-					req.DisplayName = &empty
-				}
-
-				if cmd.Flags().Changed("email") {
-					if err := rejectMultiline("--email", email); err != nil {
-						return err
-					}
-					v := strings.TrimSpace(email)
-					if v == "" {
-						return exitcode.New(exitcode.KindUsage, "--email must be non-empty", nil)
-					}
-					if _, err := mail.ParseAddress(v); err != nil {
-						return exitcode.New(exitcode.KindUsage, "invalid --email", err)
-					}
-					ev := openapi_types.Email(v)
-					req.Email = &ev
-				}
-
-				if cmd.Flags().Changed("group-alias-email") {
-					if err := rejectMultiline("--group-alias-email", groupAliasEmail); err != nil {
-						return err
-					}
-					v := strings.TrimSpace(groupAliasEmail)
-					if v == "" {
-						return exitcode.New(exitcode.KindUsage, "--group-alias-email must be non-empty (use --clear-group-alias-email to clear)", nil)
-					}
-					if _, err := mail.ParseAddress(v); err != nil {
-						return exitcode.New(exitcode.KindUsage, "invalid --group-alias-email", err)
-					}
-					ev := openapi_types.Email(v)
-					req.GroupAliasEmail = &ev
-				}
-				if clearGroupAlias {
-					if cmd.Flags().Changed("group-alias-email") {
-						return exitcode.New(exitcode.KindUsage, "cannot use --group-alias-email with --clear-group-alias-email", nil)
-					}
-					empty := openapi_types.Email("")
-					req.GroupAliasEmail = &empty
-				}
-
-				vehicleAnySetOrClear := false
-				if cmd.Flags().Changed("vehicle-make") || cmd.Flags().Changed("vehicle-model") || cmd.Flags().Changed("vehicle-tire-size") ||
-					cmd.Flags().Changed("vehicle-lift-lockers") || cmd.Flags().Changed("vehicle-fuel-range") || cmd.Flags().Changed("vehicle-recovery-gear") ||
-					cmd.Flags().Changed("vehicle-ham-radio-call-sign") || cmd.Flags().Changed("vehicle-notes") ||
-					clearVehicleMake || clearVehicleModel || clearVehicleTireSize || clearVehicleLiftLockers || clearVehicleFuelRange ||
-					clearVehicleRecoveryGear || clearVehicleHamRadio || clearVehicleNotes || clearVehicle {
-					vehicleAnySetOrClear = true
-				}
-
-				if clearVehicle && (cmd.Flags().Changed("vehicle-make") || cmd.Flags().Changed("vehicle-model") || cmd.Flags().Changed("vehicle-tire-size") ||
-					cmd.Flags().Changed("vehicle-lift-lockers") || cmd.Flags().Changed("vehicle-fuel-range") || cmd.Flags().Changed("vehicle-recovery-gear") ||
-					cmd.Flags().Changed("vehicle-ham-radio-call-sign") || cmd.Flags().Changed("vehicle-notes") ||
-					clearVehicleMake || clearVehicleModel || clearVehicleTireSize || clearVehicleLiftLockers || clearVehicleFuelRange ||
-					clearVehicleRecoveryGear || clearVehicleHamRadio || clearVehicleNotes) {
-					return exitcode.New(exitcode.KindUsage, "--clear-vehicle cannot be combined with other vehicle flags", nil)
-				}
-
-				if vehicleAnySetOrClear {
-					var vp gen.VehicleProfile
-					empty := ""
-					if clearVehicle {
-						vp = gen.VehicleProfile{
-							Make:             &empty,
-							Model:            &empty,
-							TireSize:         &empty,
-							LiftLockers:      &empty,
-							FuelRange:        &empty,
-							RecoveryGear:     &empty,
-							HamRadioCallSign: &empty,
-							Notes:            &empty,
-						}
-						req.VehicleProfile = &vp
-					} else {
-						setField := func(flag string, label string, raw string, dst **string) error {
-							if !cmd.Flags().Changed(flag) {
-								return nil
-							}
-							if err := rejectMultiline(label, raw); err != nil {
-								return err
-							}
-							v := strings.TrimSpace(raw)
-							if v == "" {
-								return exitcode.New(exitcode.KindUsage, fmt.Sprintf("%s must be non-empty (use the corresponding --clear-* flag to clear)", label), nil)
-							}
-							*dst = &v
-							return nil
-						}
-						if err := setField("vehicle-make", "--vehicle-make", vehicleMake, &vp.Make); err != nil {
-							return err
-						}
-						if err := setField("vehicle-model", "--vehicle-model", vehicleModel, &vp.Model); err != nil {
-							return err
-						}
-						if err := setField("vehicle-tire-size", "--vehicle-tire-size", vehicleTireSize, &vp.TireSize); err != nil {
-							return err
-						}
-						if err := setField("vehicle-lift-lockers", "--vehicle-lift-lockers", vehicleLiftLockers, &vp.LiftLockers); err != nil {
-							return err
-						}
-						if err := setField("vehicle-fuel-range", "--vehicle-fuel-range", vehicleFuelRange, &vp.FuelRange); err != nil {
-							return err
-						}
-						if err := setField("vehicle-recovery-gear", "--vehicle-recovery-gear", vehicleRecoveryGear, &vp.RecoveryGear); err != nil {
-							return err
-						}
-						if err := setField("vehicle-ham-radio-call-sign", "--vehicle-ham-radio-call-sign", vehicleHamRadioCallSign, &vp.HamRadioCallSign); err != nil {
-							return err
-						}
-						if err := setField("vehicle-notes", "--vehicle-notes", vehicleNotes, &vp.Notes); err != nil {
-							return err
-						}
-
-						if clearVehicleMake {
-							vp.Make = &empty
-						}
-						if clearVehicleModel {
-							vp.Model = &empty
-						}
-						if clearVehicleTireSize {
-							vp.TireSize = &empty
-						}
-						if clearVehicleLiftLockers {
-							vp.LiftLockers = &empty
-						}
-						if clearVehicleFuelRange {
-							vp.FuelRange = &empty
-						}
-						if clearVehicleRecoveryGear {
-							vp.RecoveryGear = &empty
-						}
-						if clearVehicleHamRadio {
-							vp.HamRadioCallSign = &empty
-						}
-						if clearVehicleNotes {
-							vp.Notes = &empty
-						}
-
-						req.VehicleProfile = &vp
-					}
-				}
-
-				// Must change at least one field.
-				if req.DisplayName == nil && req.Email == nil && req.GroupAliasEmail == nil && req.VehicleProfile == nil {
-					return exitcode.New(exitcode.KindUsage, "no changes specified", nil)
-				}
 			case edit:
-				edited, err := editmode.EditTemp(updateMemberTemplateYAML)
-				if err != nil {
-					return exitcode.New(exitcode.KindServer, "open editor", err)
-				}
-				tmp, err := os.CreateTemp("", "ebo-editbuf-*.req")
-				if err != nil {
-					return exitcode.New(exitcode.KindServer, "temp file", err)
-				}
-				tmpName := tmp.Name()
-				_ = tmp.Close()
-				defer func() { _ = os.Remove(tmpName) }()
-				if err := os.WriteFile(tmpName, edited, 0o600); err != nil {
-					return exitcode.New(exitcode.KindServer, "write edited buffer", err)
-				}
-				if err := requestfile.LoadStrict(tmpName, &req); err != nil {
-					return exitcode.New(exitcode.KindUsage, "parse edited buffer", err)
-				}
+				req, err = svc.EditPatch()
 			case promptMode:
-				p := prompt.New(cmd.InOrStdin(), deps.Stderr, func(template string) ([]byte, error) { return editmode.EditTemp(template) })
-
-				name, err := p.PromptOptionalString(ctx, "Display name (optional)")
-				if err != nil {
-					if err == prompt.ErrAborted {
-						return exitcode.New(exitcode.KindInterrupted, "interrupted", err)
-					}
-					return exitcode.New(exitcode.KindServer, "prompt", err)
-				}
-				if strings.TrimSpace(name) != "" {
-					req.DisplayName = &name
-				}
-
-				wantVehicle, err := p.PromptYesNo(ctx, "Configure vehicle profile?", true)
-				if err != nil {
-					if err == prompt.ErrAborted {
-						return exitcode.New(exitcode.KindInterrupted, "interrupted", err)
-					}
-					return exitcode.New(exitcode.KindServer, "prompt", err)
-				}
-				if wantVehicle {
-					notes, usedEditor, err := p.PromptMultilineOrInline(ctx, "vehicle notes (optional)", editTextTemplateYAML("text", "Vehicle notes"))
-					if err != nil {
-						if err == prompt.ErrAborted {
-							return exitcode.New(exitcode.KindInterrupted, "interrupted", err)
-						}
-						return exitcode.New(exitcode.KindServer, "prompt", err)
-					}
-					if usedEditor {
-						type textDoc struct {
-							Text string `json:"text"`
-						}
-						tmp, err := os.CreateTemp("", "ebo-edittext-*.req")
-						if err != nil {
-							return exitcode.New(exitcode.KindServer, "temp file", err)
-						}
-						tmpName := tmp.Name()
-						_ = tmp.Close()
-						defer func() { _ = os.Remove(tmpName) }()
-						if err := os.WriteFile(tmpName, []byte(notes), 0o600); err != nil {
-							return exitcode.New(exitcode.KindServer, "write edited buffer", err)
-						}
-						var td textDoc
-						if err := requestfile.LoadStrict(tmpName, &td); err != nil {
-							return exitcode.New(exitcode.KindUsage, "parse edited buffer", err)
-						}
-						notes = td.Text
-					}
-					if strings.TrimSpace(notes) != "" {
-						req.VehicleProfile = &gen.VehicleProfile{Notes: &notes}
-					}
-				}
+				req, err = promptMemberPatch(ctx, cmd, deps)
+			case strings.TrimSpace(fromFile) != "":
+				req, err = memberapp.LoadPatch(fromFile)
 			default:
-				if err := requestfile.LoadStrict(fromFile, &req); err != nil {
-					return exitcode.New(exitcode.KindUsage, "parse request file", err)
-				}
+				req, err = memberapp.BuildPatch(patch)
 			}
-
-			if strings.TrimSpace(idempotencyKey) == "" {
-				idempotencyKey = idempotency.NewKey()
+			if err != nil {
+				return err
 			}
 
-			resp, err := deps.PlannerAPI.UpdateMyMemberProfile(ctx, apiCtx.APIURL, apiCtx.BearerToken, idempotencyKey, req)
+			resp, idempotencyKey, err := svc.Update(ctx, apiCtx.APIURL, apiCtx.BearerToken, idempotencyKey, req)
 			if err != nil {
-				return err
+				return apiCtx.apiError(err, idempotencyKey)
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
-					Data: resp.JSON200,
-					Meta: envelope.Meta{APIURL: apiCtx.APIURL, Profile: apiCtx.Profile, IdempotencyKey: idempotencyKey},
+			writeResponseMeta(deps, resolved, respMeta)
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: resp,
+					Meta: apiCtx.envelopeMeta(respMeta, idempotencyKey),
 				})
 			}
 
@@ -842,22 +517,37 @@ func newMemberUpdateCmd(deps RootDeps) *cobra.Command {
 	return cmd
 }
 
-const updateMemberTemplateYAML = `# UpdateMyMemberProfileRequest (patch)
-#
-# - Omitted fields are unchanged.
-# - Set fields to update.
-#
-# displayName:
-# email:
-# groupAliasEmail:
-# vehicleProfile:
-#   make:
-#   model:
-#   tireSize:
-#   liftLockers:
-#   fuelRange:
-#   recoveryGear:
-#   hamRadioCallSign:
-#   notes:
-{}
-`
+// promptMemberPatch collects a profile patch interactively (`member update --prompt`).
+func promptMemberPatch(ctx context.Context, cmd *cobra.Command, deps RootDeps) (outplannerapi.UpdateMemberRequest, error) {
+	var req outplannerapi.UpdateMemberRequest
+	p := prompt.New(cmd.InOrStdin(), deps.Stderr, func(template string) ([]byte, error) { return editmode.EditTemp(template) })
+
+	name, err := p.PromptOptionalString(ctx, "Display name (optional)")
+	if err != nil {
+		return req, promptError(err)
+	}
+	if strings.TrimSpace(name) != "" {
+		req.DisplayName = &name
+	}
+
+	wantVehicle, err := p.PromptYesNo(ctx, "Configure vehicle profile?", true)
+	if err != nil {
+		return req, promptError(err)
+	}
+	if !wantVehicle {
+		return req, nil
+	}
+	notes, usedEditor, err := p.PromptMultilineOrInline(ctx, "vehicle notes (optional)", editTextTemplateYAML("text", "Vehicle notes"))
+	if err != nil {
+		return req, promptError(err)
+	}
+	if usedEditor {
+		if notes, err = parseEditedText(notes); err != nil {
+			return req, err
+		}
+	}
+	if strings.TrimSpace(notes) != "" {
+		req.VehicleProfile = &outplannerapi.VehicleProfile{Notes: &notes}
+	}
+	return req, nil
+}
@@ -6,89 +6,94 @@ import (
 	"encoding/json"
 	"testing"
 
-	gen "github.com/Overland-East-Bay/trip-planner-cli/internal/gen/plannerapi"
+	plannerapiout "github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/plannerapi"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
 	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
 )
 
 type fakeMemberCreateAPI struct {
 	createCalls int
-	lastReq     gen.CreateMyMemberJSONRequestBody
+	lastReq     outplannerapi.CreateMemberRequest
 
-	resp *gen.CreateMyMemberClientResponse
+	// noBody makes CreateMyMember succeed without a response body.
+	noBody bool
+	err    error
 }
 
 var _ outplannerapi.Client = (*fakeMemberCreateAPI)(nil)
 
 // Trips (unused)
-func (f *fakeMemberCreateAPI) ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*gen.ListVisibleTripsForMemberClientResponse, error) {
+func (f *fakeMemberCreateAPI) ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.TripList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberCreateAPI) ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*gen.ListMyDraftTripsClientResponse, error) {
+func (f *fakeMemberCreateAPI) ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.TripList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberCreateAPI) GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripDetailsClientResponse, error) {
+func (f *fakeMemberCreateAPI) GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberCreateAPI) CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.CreateTripDraftJSONRequestBody) (*gen.CreateTripDraftClientResponse, error) {
+func (f *fakeMemberCreateAPI) CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.CreateTripDraftRequest) (*outplannerapi.TripCreatedResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberCreateAPI) UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.UpdateTripJSONRequestBody) (*gen.UpdateTripClientResponse, error) {
+func (f *fakeMemberCreateAPI) UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.UpdateTripRequest) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberCreateAPI) SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetTripDraftVisibilityJSONRequestBody) (*gen.SetTripDraftVisibilityClientResponse, error) {
+func (f *fakeMemberCreateAPI) SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.SetDraftVisibilityRequest) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberCreateAPI) PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.PublishTripClientResponse, error) {
+func (f *fakeMemberCreateAPI) PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.PublishResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberCreateAPI) AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.AddTripOrganizerJSONRequestBody) (*gen.AddTripOrganizerClientResponse, error) {
+func (f *fakeMemberCreateAPI) AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.AddOrganizerRequest) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberCreateAPI) RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, memberID gen.MemberId, idempotencyKey string) (*gen.RemoveTripOrganizerClientResponse, error) {
+func (f *fakeMemberCreateAPI) RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, memberID string, idempotencyKey string) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberCreateAPI) CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey *string) (*gen.CancelTripClientResponse, error) {
+func (f *fakeMemberCreateAPI) CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey *string) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberCreateAPI) SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetMyRSVPJSONRequestBody) (*gen.SetMyRSVPClientResponse, error) {
+func (f *fakeMemberCreateAPI) SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.SetMyRSVPRequest) (*outplannerapi.RSVPResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberCreateAPI) GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetMyRSVPForTripClientResponse, error) {
+func (f *fakeMemberCreateAPI) GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.RSVPResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberCreateAPI) GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripRSVPSummaryClientResponse, error) {
+func (f *fakeMemberCreateAPI) GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.RSVPSummaryResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
 // Members (unused except create)
-func (f *fakeMemberCreateAPI) ListMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.ListMembersParams) (*gen.ListMembersClientResponse, error) {
+func (f *fakeMemberCreateAPI) ListMembers(ctx context.Context, baseURL string, bearerToken string, includeInactive bool) (*outplannerapi.MemberList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberCreateAPI) SearchMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.SearchMembersParams) (*gen.SearchMembersClientResponse, error) {
+func (f *fakeMemberCreateAPI) SearchMembers(ctx context.Context, baseURL string, bearerToken string, query string) (*outplannerapi.MemberList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberCreateAPI) GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*gen.GetMyMemberProfileClientResponse, error) {
+func (f *fakeMemberCreateAPI) GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.MemberResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeMemberCreateAPI) DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.DeleteMyMemberAccountJSONRequestBody) (*gen.DeleteMyMemberAccountClientResponse, error) {
+func (f *fakeMemberCreateAPI) DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.DeleteMemberRequest) (*outplannerapi.MemberDeleted, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeMemberCreateAPI) CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req gen.CreateMyMemberJSONRequestBody) (*gen.CreateMyMemberClientResponse, error) {
+func (f *fakeMemberCreateAPI) CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req outplannerapi.CreateMemberRequest) (*outplannerapi.MemberResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
 	f.createCalls++
 	f.lastReq = req
-	if f.resp != nil {
-		return f.resp, nil
+	if f.err != nil {
+		return nil, f.err
 	}
-	return &gen.CreateMyMemberClientResponse{JSON201: &gen.CreateMemberResponse{Member: gen.MemberProfile{MemberId: "m1", DisplayName: req.DisplayName, Email: req.Email}}}, nil
+	if f.noBody {
+		return nil, nil
+	}
+	return &outplannerapi.MemberResult{Member: outplannerapi.Member{MemberID: "m1", DisplayName: req.DisplayName, Email: req.Email}}, nil
 }
 
-func (f *fakeMemberCreateAPI) UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.UpdateMyMemberProfileJSONRequestBody) (*gen.UpdateMyMemberProfileClientResponse, error) {
+func (f *fakeMemberCreateAPI) UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.UpdateMemberRequest) (*outplannerapi.MemberResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
@@ -358,7 +363,7 @@ func TestMemberCreate_TableOutput_JSON201Nil_PrintsOK(t *testing.T) {
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
 	store := &memStore{path: "/x", doc: baseDoc(t)}
-	api := &fakeMemberCreateAPI{resp: &gen.CreateMyMemberClientResponse{}}
+	api := &fakeMemberCreateAPI{noBody: true}
 
 	cmd := NewRootCmd(RootDeps{ConfigStore: store, PlannerAPI: api, Stdout: stdout, Stderr: stderr})
 	cmd.SetArgs([]string{"member", "create", "--display-name", "A", "--email", "a@example.com"})
@@ -410,3 +415,19 @@ func TestMemberCreate_RejectsMultilineForAllStringFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestMemberCreate_AlreadyExists_HintsMemberMe(t *testing.T) {
+	store := &memStore{path: "/x", doc: baseDoc(t)}
+	ae := &plannerapiout.APIError{StatusCode: 409, ErrorCode: "MEMBER_ALREADY_EXISTS", Message: "exists"}
+	api := &fakeMemberCreateAPI{err: exitcode.New(exitcode.KindConflict, ae.Error(), ae)}
+
+	cmd := NewRootCmd(RootDeps{ConfigStore: store, PlannerAPI: api, Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}})
+	cmd.SetArgs([]string{"member", "create", "--display-name", "A", "--email", "a@example.com"})
+	err := cmd.Execute()
+	if exitcode.Code(err) != exitcode.Conflict {
+		t.Fatalf("expected exit 5, got %d (%v)", exitcode.Code(err), err)
+	}
+	if got := exitcode.HintOf(err); got != "ebo member me\n  ebo member update --prompt" {
+		t.Fatalf("hint: %q", got)
+	}
+}
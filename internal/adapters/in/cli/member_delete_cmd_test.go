@@ -7,7 +7,6 @@ import (
 	"strings"
 	"testing"
 
-	gen "github.com/Overland-East-Bay/trip-planner-cli/internal/gen/plannerapi"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
 	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
 )
@@ -16,72 +15,72 @@ type fakeMemberDeleteAPI struct {
 	deleteCalls int
 
 	lastIdem string
-	lastReq  gen.DeleteMyMemberAccountJSONRequestBody
+	lastReq  outplannerapi.DeleteMemberRequest
 
-	resp *gen.DeleteMyMemberAccountClientResponse
+	resp *outplannerapi.MemberDeleted
 }
 
 var _ outplannerapi.Client = (*fakeMemberDeleteAPI)(nil)
 
 // Trips (unused)
-func (f *fakeMemberDeleteAPI) ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*gen.ListVisibleTripsForMemberClientResponse, error) {
+func (f *fakeMemberDeleteAPI) ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.TripList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberDeleteAPI) ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*gen.ListMyDraftTripsClientResponse, error) {
+func (f *fakeMemberDeleteAPI) ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.TripList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberDeleteAPI) GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripDetailsClientResponse, error) {
+func (f *fakeMemberDeleteAPI) GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberDeleteAPI) CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.CreateTripDraftJSONRequestBody) (*gen.CreateTripDraftClientResponse, error) {
+func (f *fakeMemberDeleteAPI) CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.CreateTripDraftRequest) (*outplannerapi.TripCreatedResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberDeleteAPI) UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.UpdateTripJSONRequestBody) (*gen.UpdateTripClientResponse, error) {
+func (f *fakeMemberDeleteAPI) UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.UpdateTripRequest) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberDeleteAPI) SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetTripDraftVisibilityJSONRequestBody) (*gen.SetTripDraftVisibilityClientResponse, error) {
+func (f *fakeMemberDeleteAPI) SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.SetDraftVisibilityRequest) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberDeleteAPI) PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.PublishTripClientResponse, error) {
+func (f *fakeMemberDeleteAPI) PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.PublishResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberDeleteAPI) AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.AddTripOrganizerJSONRequestBody) (*gen.AddTripOrganizerClientResponse, error) {
+func (f *fakeMemberDeleteAPI) AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.AddOrganizerRequest) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberDeleteAPI) RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, memberID gen.MemberId, idempotencyKey string) (*gen.RemoveTripOrganizerClientResponse, error) {
+func (f *fakeMemberDeleteAPI) RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, memberID string, idempotencyKey string) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberDeleteAPI) CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey *string) (*gen.CancelTripClientResponse, error) {
+func (f *fakeMemberDeleteAPI) CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey *string) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberDeleteAPI) SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetMyRSVPJSONRequestBody) (*gen.SetMyRSVPClientResponse, error) {
+func (f *fakeMemberDeleteAPI) SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.SetMyRSVPRequest) (*outplannerapi.RSVPResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberDeleteAPI) GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetMyRSVPForTripClientResponse, error) {
+func (f *fakeMemberDeleteAPI) GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.RSVPResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberDeleteAPI) GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripRSVPSummaryClientResponse, error) {
+func (f *fakeMemberDeleteAPI) GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.RSVPSummaryResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
 // Members (unused except delete)
-func (f *fakeMemberDeleteAPI) ListMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.ListMembersParams) (*gen.ListMembersClientResponse, error) {
+func (f *fakeMemberDeleteAPI) ListMembers(ctx context.Context, baseURL string, bearerToken string, includeInactive bool) (*outplannerapi.MemberList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberDeleteAPI) SearchMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.SearchMembersParams) (*gen.SearchMembersClientResponse, error) {
+func (f *fakeMemberDeleteAPI) SearchMembers(ctx context.Context, baseURL string, bearerToken string, query string) (*outplannerapi.MemberList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberDeleteAPI) GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*gen.GetMyMemberProfileClientResponse, error) {
+func (f *fakeMemberDeleteAPI) GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.MemberResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberDeleteAPI) CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req gen.CreateMyMemberJSONRequestBody) (*gen.CreateMyMemberClientResponse, error) {
+func (f *fakeMemberDeleteAPI) CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req outplannerapi.CreateMemberRequest) (*outplannerapi.MemberResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberDeleteAPI) UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.UpdateMyMemberProfileJSONRequestBody) (*gen.UpdateMyMemberProfileClientResponse, error) {
+func (f *fakeMemberDeleteAPI) UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.UpdateMemberRequest) (*outplannerapi.MemberResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeMemberDeleteAPI) DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.DeleteMyMemberAccountJSONRequestBody) (*gen.DeleteMyMemberAccountClientResponse, error) {
+func (f *fakeMemberDeleteAPI) DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.DeleteMemberRequest) (*outplannerapi.MemberDeleted, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -91,7 +90,7 @@ func (f *fakeMemberDeleteAPI) DeleteMyMemberAccount(ctx context.Context, baseURL
 	if f.resp != nil {
 		return f.resp, nil
 	}
-	return &gen.DeleteMyMemberAccountClientResponse{JSON200: &gen.DeleteMyMemberResponse{Deleted: true}}, nil
+	return &outplannerapi.MemberDeleted{Deleted: true}, nil
 }
 
 func TestMemberDelete_RequiresForce_Exit2_NoAPICall(t *testing.T) {
@@ -8,7 +8,6 @@ import (
 	"testing"
 
 	plannerapiout "github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/plannerapi"
-	gen "github.com/Overland-East-Bay/trip-planner-cli/internal/gen/plannerapi"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
 	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
 )
@@ -18,80 +17,77 @@ type fakeMemberReadAPI struct {
 	searchCalls int
 	meCalls     int
 
-	lastListParams   *gen.ListMembersParams
-	lastSearchParams *gen.SearchMembersParams
+	lastIncludeInactive bool
+	lastQuery           string
 
-	meResp *gen.GetMyMemberProfileClientResponse
-	meErr  error
+	meResp   *outplannerapi.MemberResult
+	meNoBody bool
+	meErr    error
 }
 
 var _ outplannerapi.Client = (*fakeMemberReadAPI)(nil)
 
 // Trips (unused)
-func (f *fakeMemberReadAPI) ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*gen.ListVisibleTripsForMemberClientResponse, error) {
+func (f *fakeMemberReadAPI) ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.TripList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberReadAPI) ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*gen.ListMyDraftTripsClientResponse, error) {
+func (f *fakeMemberReadAPI) ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.TripList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberReadAPI) GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripDetailsClientResponse, error) {
+func (f *fakeMemberReadAPI) GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberReadAPI) CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.CreateTripDraftJSONRequestBody) (*gen.CreateTripDraftClientResponse, error) {
+func (f *fakeMemberReadAPI) CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.CreateTripDraftRequest) (*outplannerapi.TripCreatedResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberReadAPI) UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.UpdateTripJSONRequestBody) (*gen.UpdateTripClientResponse, error) {
+func (f *fakeMemberReadAPI) UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.UpdateTripRequest) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberReadAPI) SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetTripDraftVisibilityJSONRequestBody) (*gen.SetTripDraftVisibilityClientResponse, error) {
+func (f *fakeMemberReadAPI) SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.SetDraftVisibilityRequest) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberReadAPI) PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.PublishTripClientResponse, error) {
+func (f *fakeMemberReadAPI) PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.PublishResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberReadAPI) AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.AddTripOrganizerJSONRequestBody) (*gen.AddTripOrganizerClientResponse, error) {
+func (f *fakeMemberReadAPI) AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.AddOrganizerRequest) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberReadAPI) RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, memberID gen.MemberId, idempotencyKey string) (*gen.RemoveTripOrganizerClientResponse, error) {
+func (f *fakeMemberReadAPI) RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, memberID string, idempotencyKey string) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberReadAPI) CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey *string) (*gen.CancelTripClientResponse, error) {
+func (f *fakeMemberReadAPI) CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey *string) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberReadAPI) SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetMyRSVPJSONRequestBody) (*gen.SetMyRSVPClientResponse, error) {
+func (f *fakeMemberReadAPI) SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.SetMyRSVPRequest) (*outplannerapi.RSVPResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberReadAPI) GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetMyRSVPForTripClientResponse, error) {
+func (f *fakeMemberReadAPI) GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.RSVPResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeMemberReadAPI) GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripRSVPSummaryClientResponse, error) {
+func (f *fakeMemberReadAPI) GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.RSVPSummaryResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
 // Members
-func (f *fakeMemberReadAPI) ListMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.ListMembersParams) (*gen.ListMembersClientResponse, error) {
+func (f *fakeMemberReadAPI) ListMembers(ctx context.Context, baseURL string, bearerToken string, includeInactive bool) (*outplannerapi.MemberList, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
 	f.listCalls++
-	f.lastListParams = params
-	return &gen.ListMembersClientResponse{JSON200: &struct {
-		Members []gen.MemberDirectoryEntry `json:"members"`
-	}{Members: []gen.MemberDirectoryEntry{{MemberId: "m1", DisplayName: "Alice"}}}}, nil
+	f.lastIncludeInactive = includeInactive
+	return &outplannerapi.MemberList{Members: []outplannerapi.MemberDirectoryEntry{{MemberID: "m1", DisplayName: "Alice"}}}, nil
 }
 
-func (f *fakeMemberReadAPI) SearchMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.SearchMembersParams) (*gen.SearchMembersClientResponse, error) {
+func (f *fakeMemberReadAPI) SearchMembers(ctx context.Context, baseURL string, bearerToken string, query string) (*outplannerapi.MemberList, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
 	f.searchCalls++
-	f.lastSearchParams = params
-	return &gen.SearchMembersClientResponse{JSON200: &struct {
-		Members []gen.MemberDirectoryEntry `json:"members"`
-	}{Members: []gen.MemberDirectoryEntry{{MemberId: "m2", DisplayName: "Bob"}}}}, nil
+	f.lastQuery = query
+	return &outplannerapi.MemberList{Members: []outplannerapi.MemberDirectoryEntry{{MemberID: "m2", DisplayName: "Bob"}}}, nil
 }
 
-func (f *fakeMemberReadAPI) GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*gen.GetMyMemberProfileClientResponse, error) {
+func (f *fakeMemberReadAPI) GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.MemberResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -99,23 +95,24 @@ func (f *fakeMemberReadAPI) GetMyMemberProfile(ctx context.Context, baseURL stri
 	if f.meErr != nil {
 		return nil, f.meErr
 	}
+	if f.meNoBody {
+		return nil, nil
+	}
 	if f.meResp != nil {
 		return f.meResp, nil
 	}
-	return &gen.GetMyMemberProfileClientResponse{JSON200: &struct {
-		Member gen.MemberProfile `json:"member"`
-	}{Member: gen.MemberProfile{MemberId: "m1", DisplayName: "Me", Email: "me@example.com"}}}, nil
+	return &outplannerapi.MemberResult{Member: outplannerapi.Member{MemberID: "m1", DisplayName: "Me", Email: "me@example.com"}}, nil
 }
 
-func (f *fakeMemberReadAPI) DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.DeleteMyMemberAccountJSONRequestBody) (*gen.DeleteMyMemberAccountClientResponse, error) {
+func (f *fakeMemberReadAPI) DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.DeleteMemberRequest) (*outplannerapi.MemberDeleted, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeMemberReadAPI) CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req gen.CreateMyMemberJSONRequestBody) (*gen.CreateMyMemberClientResponse, error) {
+func (f *fakeMemberReadAPI) CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req outplannerapi.CreateMemberRequest) (*outplannerapi.MemberResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeMemberReadAPI) UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.UpdateMyMemberProfileJSONRequestBody) (*gen.UpdateMyMemberProfileClientResponse, error) {
+func (f *fakeMemberReadAPI) UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.UpdateMemberRequest) (*outplannerapi.MemberResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
@@ -195,8 +192,8 @@ func TestMemberList_IncludeInactive_SetsParam(t *testing.T) {
 	if err := cmd.Execute(); err != nil {
 		t.Fatalf("execute: %v", err)
 	}
-	if api.lastListParams == nil || api.lastListParams.IncludeInactive == nil || bool(*api.lastListParams.IncludeInactive) != true {
-		t.Fatalf("params: %#v", api.lastListParams)
+	if !api.lastIncludeInactive {
+		t.Fatalf("includeInactive not passed")
 	}
 }
 
@@ -214,8 +211,8 @@ func TestMemberSearch_JSONEnvelope(t *testing.T) {
 	if api.searchCalls != 1 {
 		t.Fatalf("expected 1 call, got %d", api.searchCalls)
 	}
-	if api.lastSearchParams == nil || api.lastSearchParams.Q != "bob" {
-		t.Fatalf("params: %#v", api.lastSearchParams)
+	if api.lastQuery != "bob" {
+		t.Fatalf("query: %q", api.lastQuery)
 	}
 	var env map[string]any
 	if err := json.Unmarshal(stdout.Bytes(), &env); err != nil {
@@ -278,11 +275,48 @@ func TestMemberMe_TableOutput(t *testing.T) {
 	}
 }
 
+func TestMemberMe_TableOutputShowsVehicleProfile(t *testing.T) {
+	vehicleMake, notes := "Toyota", "Winch: 10k lb\nMaxtrax x2"
+	stdout := &bytes.Buffer{}
+	store := &memStore{path: "/x", doc: baseDoc(t)}
+	api := &fakeMemberReadAPI{meResp: &outplannerapi.MemberResult{Member: outplannerapi.Member{
+		MemberID: "m1", DisplayName: "Alice", Email: "alice@example.com",
+		VehicleProfile: &outplannerapi.VehicleProfile{Make: &vehicleMake, Notes: &notes},
+	}}}
+
+	cmd := NewRootCmd(RootDeps{ConfigStore: store, PlannerAPI: api, Stdout: stdout, Stderr: &bytes.Buffer{}})
+	cmd.SetArgs([]string{"member", "me"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	want := "" +
+		"MemberId:        m1\n" +
+		"DisplayName:     Alice\n" +
+		"Email:           alice@example.com\n" +
+		"GroupAliasEmail: -\n" +
+		"\n" +
+		"VehicleProfile:\n" +
+		"  Make:             Toyota\n" +
+		"  Model:            -\n" +
+		"  TireSize:         -\n" +
+		"  LiftLockers:      -\n" +
+		"  FuelRange:        -\n" +
+		"  RecoveryGear:     -\n" +
+		"  HamRadioCallSign: -\n" +
+		"\n" +
+		"VehicleProfile.Notes:\n" +
+		"Winch: 10k lb\n" +
+		"Maxtrax x2\n"
+	if got := stdout.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
 func TestMemberMe_TableOutput_JSON200Nil_PrintsOK(t *testing.T) {
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
 	store := &memStore{path: "/x", doc: baseDoc(t)}
-	api := &fakeMemberReadAPI{meResp: &gen.GetMyMemberProfileClientResponse{}}
+	api := &fakeMemberReadAPI{meNoBody: true}
 
 	cmd := NewRootCmd(RootDeps{ConfigStore: store, PlannerAPI: api, Stdout: stdout, Stderr: stderr})
 	cmd.SetArgs([]string{"member", "me"})
@@ -2,8 +2,16 @@ package cli
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+	"gopkg.in/yaml.v3"
 )
 
 func TestJSONOutput_HasNoANSIEscapeCodes(t *testing.T) {
@@ -21,3 +29,195 @@ func TestJSONOutput_HasNoANSIEscapeCodes(t *testing.T) {
 		t.Fatalf("stdout contains ansi: %q", stdout.String())
 	}
 }
+
+func listAPI() *fakeTripReadAPI {
+	name := `Rubicon, "Spring"`
+	capacity := 12
+	return &fakeTripReadAPI{
+		listTrips: []outplannerapi.TripSummary{
+			{TripID: "t1", Status: "PUBLISHED", Name: &name, CapacityRigs: &capacity},
+			{TripID: "t2", Status: "CANCELED"},
+		},
+	}
+}
+
+func runOutput(t *testing.T, api outplannerapi.Client, env cliopts.MapEnv, args ...string) (string, error) {
+	t.Helper()
+	stdout := &bytes.Buffer{}
+	cmd := NewRootCmd(RootDeps{Env: env, ConfigStore: &memStore{path: "/x", doc: baseDoc(t)}, PlannerAPI: api, Stdout: stdout, Stderr: &bytes.Buffer{}})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func TestYAMLOutput_MirrorsJSONEnvelope(t *testing.T) {
+	jsonOut, err := runOutput(t, listAPI(), cliopts.MapEnv{}, "--output", "json", "trip", "list")
+	if err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	yamlOut, err := runOutput(t, listAPI(), cliopts.MapEnv{"EBO_OUTPUT": "yaml"}, "trip", "list")
+	if err != nil {
+		t.Fatalf("yaml: %v", err)
+	}
+	if !strings.HasPrefix(yamlOut, "data:\n  trips:\n") {
+		t.Fatalf("yaml:\n%s", yamlOut)
+	}
+
+	var fromJSON, fromYAML any
+	if err := json.Unmarshal([]byte(jsonOut), &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal([]byte(yamlOut), &fromYAML); err != nil {
+		t.Fatalf("yaml does not parse: %v\n%s", err, yamlOut)
+	}
+	a, _ := json.Marshal(fromJSON)
+	b, _ := json.Marshal(fromYAML)
+	if string(a) != string(b) {
+		t.Fatalf("yaml differs from json:\n%s\n%s", a, b)
+	}
+}
+
+func TestCSVOutput_TripListHasStableColumns(t *testing.T) {
+	out, err := runOutput(t, listAPI(), cliopts.MapEnv{}, "--output", "csv", "trip", "list")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	want := "" +
+		"tripId,status,startDate,endDate,attendingRigs,capacityRigs,draftVisibility,name\n" +
+		"t1,PUBLISHED,,,,12,,\"Rubicon, \"\"Spring\"\"\"\n" +
+		"t2,CANCELED,,,,,,\n"
+	if out != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestTSVOutput_RSVPSummaryIsARoster(t *testing.T) {
+	api := &fakeRosterAPI{summary: outplannerapi.RSVPSummary{
+		AttendingRigs:       1,
+		AttendingMembers:    []outplannerapi.MemberSummary{{MemberID: "m1", DisplayName: "Alice", Email: "a@example.com"}},
+		NotAttendingMembers: []outplannerapi.MemberSummary{{MemberID: "m2", DisplayName: "Bob", Email: "b@example.com"}},
+	}}
+	out, err := runOutput(t, api, cliopts.MapEnv{}, "--output", "tsv", "trip", "rsvp", "summary", "t1")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	want := "" +
+		"tripId\tresponse\tmemberId\tdisplayName\temail\n" +
+		"t1\tYES\tm1\tAlice\ta@example.com\n" +
+		"t1\tNO\tm2\tBob\tb@example.com\n"
+	if out != want {
+		t.Fatalf("got:\n%q\nwant:\n%q", out, want)
+	}
+}
+
+func TestDelimitedOutput_NonListCommandIsUsageErrorBeforeAPICall(t *testing.T) {
+	api := &fakeTripReadAPI{}
+	_, err := runOutput(t, api, cliopts.MapEnv{}, "--output", "csv", "trip", "get", "t1")
+	if exitcode.Code(err) != exitcode.Usage || !strings.Contains(err.Error(), `--output csv is not supported by "ebo trip get"`) {
+		t.Fatalf("err=%v code=%d", err, exitcode.Code(err))
+	}
+	if exitcode.HintOf(err) == "" {
+		t.Fatalf("expected a hint listing the list commands")
+	}
+	if api.getCalls != 0 {
+		t.Fatalf("expected no API call, got %d", api.getCalls)
+	}
+	if _, err := runOutput(t, api, cliopts.MapEnv{"EBO_OUTPUT": "tsv"}, "config", "path"); exitcode.Code(err) != exitcode.Usage {
+		t.Fatalf("EBO_OUTPUT=tsv config path: %v", err)
+	}
+}
+
+// fakeRosterAPI answers GetTripRSVPSummary only.
+type fakeRosterAPI struct {
+	outplannerapi.Client
+	summary outplannerapi.RSVPSummary
+}
+
+func (f *fakeRosterAPI) GetTripRSVPSummary(ctx context.Context, baseURL, bearerToken, tripID string) (*outplannerapi.RSVPSummaryResult, error) {
+	return &outplannerapi.RSVPSummaryResult{RSVPSummary: f.summary}, nil
+}
+
+func TestQuery_RawPrintsJustTheValue(t *testing.T) {
+	api := &fakePlannerAPI{}
+	out, err := runOutput(t, api, cliopts.MapEnv{}, "trip", "create", "--name", "X", "--query", "trip.tripId", "--raw")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if out != "t1\n" {
+		t.Fatalf("stdout: %q", out)
+	}
+}
+
+func TestQuery_ReplacesEnvelopeData(t *testing.T) {
+	out, err := runOutput(t, listAPI(), cliopts.MapEnv{}, "trip", "list", "--query", "trips[?capacityRigs != null].tripId")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	var env struct {
+		Data []string       `json:"data"`
+		Meta map[string]any `json:"meta"`
+	}
+	if err := json.Unmarshal([]byte(out), &env); err != nil {
+		t.Fatalf("not a json envelope: %v\n%s", err, out)
+	}
+	if len(env.Data) != 1 || env.Data[0] != "t1" || env.Meta["profile"] == nil {
+		t.Fatalf("stdout: %s", out)
+	}
+
+	out, err = runOutput(t, listAPI(), cliopts.MapEnv{}, "--output", "yaml", "trip", "list", "--query", "length(trips)")
+	if err != nil || !strings.HasPrefix(out, "data: 2\n") {
+		t.Fatalf("yaml: %q %v", out, err)
+	}
+}
+
+func TestQuery_ErrorsAreUsageAndMakeNoRequest(t *testing.T) {
+	cases := [][]string{
+		{"trip", "create", "--name", "X", "--query", "trip.[tripId"},
+		{"--output", "table", "trip", "create", "--name", "X", "--query", "trip.tripId"},
+		{"--output", "csv", "trip", "list", "--raw"},
+	}
+	for _, args := range cases {
+		api := &fakePlannerAPI{}
+		_, err := runOutput(t, api, cliopts.MapEnv{}, args...)
+		if exitcode.Code(err) != exitcode.Usage {
+			t.Errorf("%v: err=%v code=%d", args, err, exitcode.Code(err))
+		}
+		if api.createCalls != 0 {
+			t.Errorf("%v: expected no request", args)
+		}
+	}
+}
+
+func TestFormat_RendersEachListItem(t *testing.T) {
+	out, err := runOutput(t, listAPI(), cliopts.MapEnv{}, "trip", "list", "--format", "{{.TripId}} {{.CapacityRigs | default \"-\"}}")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if out != "t1 12\nt2 -\n" {
+		t.Fatalf("stdout: %q", out)
+	}
+
+	out, err = runOutput(t, &fakePlannerAPI{}, cliopts.MapEnv{}, "trip", "create", "--name", "X", "--format", "created {{.TripId}}")
+	if err != nil || out != "created t1\n" {
+		t.Fatalf("single result: %q %v", out, err)
+	}
+}
+
+func TestFormat_ErrorsAreUsageAndMakeNoRequest(t *testing.T) {
+	cases := [][]string{
+		{"trip", "create", "--name", "X", "--format", "{{.TripId"},
+		{"trip", "create", "--name", "X", "--format", "@" + filepath.Join(t.TempDir(), "missing.tmpl")},
+		{"trip", "create", "--name", "X", "--format", "{{.TripId}}", "--raw"},
+		{"--output", "table", "trip", "create", "--name", "X", "--format", "{{.TripId}}"},
+	}
+	for _, args := range cases {
+		api := &fakePlannerAPI{}
+		_, err := runOutput(t, api, cliopts.MapEnv{}, args...)
+		if exitcode.Code(err) != exitcode.Usage {
+			t.Errorf("%v: err=%v code=%d", args, err, exitcode.Code(err))
+		}
+		if api.createCalls != 0 {
+			t.Errorf("%v: expected no request", args)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/config"
+)
+
+// fakeTerminal is a Stdout that claims to be a terminal and records pager
+// requests instead of starting one.
+type fakeTerminal struct {
+	bytes.Buffer
+	paged []string
+}
+
+func (f *fakeTerminal) IsTerminal() bool    { return true }
+func (f *fakeTerminal) Page(command string) { f.paged = append(f.paged, command) }
+
+func runPaged(t *testing.T, env cliopts.MapEnv, doc config.Document, args ...string) *fakeTerminal {
+	t.Helper()
+	stdout := &fakeTerminal{}
+	cmd := NewRootCmd(RootDeps{Env: env, ConfigStore: &memStore{path: "/x", doc: doc}, PlannerAPI: listAPI(), Stdout: stdout, Stderr: &bytes.Buffer{}})
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("%v: %v", args, err)
+	}
+	return stdout
+}
+
+func TestPager_PagesTableOutputOfListAndDetailCommands(t *testing.T) {
+	for _, args := range [][]string{{"trip", "list"}, {"trip", "get", "t1"}} {
+		if got := runPaged(t, cliopts.MapEnv{}, baseDoc(t), args...).paged; len(got) != 1 || got[0] != "less -FRX" {
+			t.Errorf("%v: paged %v", args, got)
+		}
+	}
+	if got := runPaged(t, cliopts.MapEnv{"PAGER": "more"}, baseDoc(t), "trip", "list").paged; len(got) != 1 || got[0] != "more" {
+		t.Errorf("PAGER: paged %v", got)
+	}
+}
+
+func TestPager_ConfigDefault(t *testing.T) {
+	doc, err := config.SetString(baseDoc(t), "pager", "less -R")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := runPaged(t, cliopts.MapEnv{"PAGER": "more"}, doc, "trip", "list").paged; len(got) != 1 || got[0] != "less -R" {
+		t.Errorf("config pager: paged %v", got)
+	}
+	if got := runPaged(t, cliopts.MapEnv{"EBO_PAGER": "most"}, doc, "trip", "list").paged; len(got) != 1 || got[0] != "most" {
+		t.Errorf("EBO_PAGER over config: paged %v", got)
+	}
+	doc, _ = config.SetString(baseDoc(t), "pager", "off")
+	if got := runPaged(t, cliopts.MapEnv{}, doc, "trip", "list").paged; len(got) != 0 {
+		t.Errorf("pager: off: paged %v", got)
+	}
+}
+
+func TestPager_IsBypassed(t *testing.T) {
+	cases := []struct {
+		env  cliopts.MapEnv
+		args []string
+	}{
+		{cliopts.MapEnv{}, []string{"--no-pager", "trip", "list"}},
+		{cliopts.MapEnv{"EBO_NO_PAGER": "1"}, []string{"trip", "list"}},
+		{cliopts.MapEnv{}, []string{"--output", "json", "trip", "list"}},
+		{cliopts.MapEnv{}, []string{"--output", "csv", "trip", "list"}},
+		{cliopts.MapEnv{}, []string{"trip", "list", "--format", "{{.TripId}}"}},
+		{cliopts.MapEnv{}, []string{"profile", "list"}},
+	}
+	for _, tc := range cases {
+		out := runPaged(t, tc.env, baseDoc(t), tc.args...)
+		if len(out.paged) != 0 {
+			t.Errorf("%v: paged %v", tc.args, out.paged)
+		}
+		if out.Len() == 0 {
+			t.Errorf("%v: expected output on stdout", tc.args)
+		}
+	}
+}
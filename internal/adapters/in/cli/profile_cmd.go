@@ -6,8 +6,8 @@ import (
 	"io"
 
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/app/profileapp"
-	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/envelope"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/table"
 	"github.com/spf13/cobra"
 )
 
@@ -44,8 +44,8 @@ func newProfileListCmd(deps RootDeps, svc profileapp.Service) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
 					Data: map[string]any{
 						"currentProfile": current,
 						"profiles":       profiles,
@@ -54,15 +54,15 @@ func newProfileListCmd(deps RootDeps, svc profileapp.Service) *cobra.Command {
 				})
 			}
 
-			_, _ = io.WriteString(deps.Stdout, "NAME\tAPI URL\tCURRENT\n")
+			t := table.New(table.Column{Header: "NAME"}, table.Column{Header: "API URL", Flex: true}, table.Column{Header: "CURRENT"})
 			for _, p := range profiles {
 				mark := ""
 				if p.Name == current {
 					mark = "*"
 				}
-				_, _ = fmt.Fprintf(deps.Stdout, "%s\t%s\t%s\n", p.Name, p.APIURL, mark)
+				t.Row(p.Name, p.APIURL, mark)
 			}
-			return nil
+			return writeTable(deps, resolved, t)
 		},
 	}
 }
@@ -88,8 +88,8 @@ func newProfileShowCmd(deps RootDeps, svc profileapp.Service) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
 					Data: map[string]any{"profile": p},
 					Meta: envelope.Meta{APIURL: resolved.Options.APIURL, Profile: resolved.Options.Profile},
 				})
@@ -118,8 +118,8 @@ func newProfileCreateCmd(deps RootDeps, svc profileapp.Service) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
 					Data: map[string]any{"profile": name, "apiUrl": apiURL},
 					Meta: envelope.Meta{APIURL: resolved.Options.APIURL, Profile: resolved.Options.Profile},
 				})
@@ -150,8 +150,8 @@ func newProfileSetCmd(deps RootDeps, svc profileapp.Service) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
 					Data: map[string]any{"profile": name, "apiUrl": apiURL},
 					Meta: envelope.Meta{APIURL: resolved.Options.APIURL, Profile: resolved.Options.Profile},
 				})
@@ -181,8 +181,8 @@ func newProfileUseCmd(deps RootDeps, svc profileapp.Service) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
 					Data: map[string]any{"currentProfile": name},
 					Meta: envelope.Meta{APIURL: resolved.Options.APIURL, Profile: resolved.Options.Profile},
 				})
@@ -209,8 +209,8 @@ func newProfileDeleteCmd(deps RootDeps, svc profileapp.Service) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
 					Data: map[string]any{"deleted": name},
 					Meta: envelope.Meta{APIURL: resolved.Options.APIURL, Profile: resolved.Options.Profile},
 				})
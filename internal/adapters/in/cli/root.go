@@ -4,11 +4,17 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"net/http"
 
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/browseropen"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/config"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/envelope"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/format"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/pager"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/query"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/table"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out"
 	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
 	"github.com/spf13/cobra"
@@ -20,13 +26,28 @@ type RootDeps struct {
 	ConfigStore out.ConfigStore
 	PlannerAPI  outplannerapi.Client
 
+	// ProfileAPI builds a Planner API client for the named profile, with that
+	// profile's tls/proxy settings and cache scope, for commands that talk to
+	// several profiles in one run (`calendar serve`). Nil means PlannerAPI.
+	ProfileAPI func(profile string) outplannerapi.Client
+
 	Stdout io.Writer
 	Stderr io.Writer
 
+	// HTTPClient is used for non-API HTTP calls (e.g. OIDC). It should share the
+	// invocation's transport so --trace/--har see every request.
+	// Nil means a default client.
+	HTTPClient *http.Client
+
 	// BrowserOpener is used by interactive commands to open URLs.
 	// Tests should supply a no-op opener to avoid launching a browser.
 	BrowserOpener browseropen.Opener
 
+	// MockAPI builds the handler served by `ebo dev mock-server` from a
+	// fixtures file ("" means the built-in fixtures), plus a bearer token for
+	// each fixture member keyed by subject.
+	MockAPI func(fixturesPath string) (http.Handler, map[string]string, error)
+
 	// OnResolved is a test hook invoked after flags/env are resolved.
 	OnResolved func(cliopts.Resolved)
 }
@@ -45,11 +66,34 @@ func NewRootCmd(deps RootDeps) *cobra.Command {
 		SilenceErrors: true,
 		SilenceUsage:  true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			r, err := cliopts.ResolveGlobalOptions(cmd.Flags(), deps.Env, defaults)
+			flags := cmd.Flags()
+			if cmd.LocalNonPersistentFlags().Lookup("format") != nil {
+				// The command's own --format (trip export's file format)
+				// shadows the global template flag.
+				flags = cmd.InheritedFlags()
+			}
+			r, err := cliopts.ResolveGlobalOptions(flags, deps.Env, defaults)
 			if err != nil {
 				return exitcode.New(exitcode.KindUsage, "invalid flags", err)
 			}
 			resolved = r
+			if r.Options.Output.Delimited() && cmd.Annotations[annotationTabular] == "" {
+				return exitcode.WithHint(
+					exitcode.New(exitcode.KindUsage, fmt.Sprintf("--output %s is not supported by %q: its output is not a list", r.Options.Output, cmd.CommandPath()), nil),
+					"use --output json or yaml; csv and tsv work with trip list, trip drafts, trip rsvp summary, member list and member search")
+			}
+			if r.Options.Output == cliopts.OutputICS && cmd.Annotations[annotationCalendar] == "" {
+				return exitcode.WithHint(
+					exitcode.New(exitcode.KindUsage, fmt.Sprintf("--output ics is not supported by %q: its output is not a list of trips", cmd.CommandPath()), nil),
+					"ics works with trip list, trip drafts and trip export")
+			}
+			if err := checkQuery(r); err != nil {
+				return err
+			}
+			if err := checkFormat(r); err != nil {
+				return err
+			}
+			startPager(cmd, deps, r)
 			if deps.OnResolved != nil {
 				deps.OnResolved(r)
 			}
@@ -57,13 +101,13 @@ func NewRootCmd(deps RootDeps) *cobra.Command {
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Root currently has no subcommands (Issue #9 scope). Print help.
-			if resolved.Options.Output == cliopts.OutputJSON {
+			if resolved.Options.Output.Envelope() {
 				b := &bytes.Buffer{}
 				cmd.SetOut(b)
 				_ = cmd.Help()
 				cmd.SetOut(deps.Stdout)
 
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
+				return writeEnvelope(deps, resolved, envelope.Envelope{
 					Data: map[string]any{
 						"help": b.String(),
 					},
@@ -89,6 +133,144 @@ func NewRootCmd(deps RootDeps) *cobra.Command {
 	addAuthCommands(cmd, deps)
 	addTripCommands(cmd, deps)
 	addMemberCommands(cmd, deps)
+	addCalendarCommands(cmd, deps)
+	addDevCommands(cmd, deps)
+	addDoctorCommand(cmd, deps)
+	addSchemaCommand(cmd, deps)
 
 	return cmd
 }
+
+// writeTable renders human output: aligned columns, fitted to the terminal
+// and colored when stdout is one.
+func writeTable(deps RootDeps, resolved cliopts.Resolved, t *table.Table) error {
+	return t.Render(deps.Stdout, table.OptionsFor(deps.Stdout, resolved.Options.NoColor, resolved.Options.Wide))
+}
+
+// annotationTabular marks commands whose data flattens to rows, i.e. that
+// support --output csv|tsv. Other commands reject those formats up front,
+// before calling the API.
+const annotationTabular = "ebo/tabular"
+
+var tabularAnnotations = map[string]string{annotationTabular: "true", annotationPaged: "true"}
+
+// annotationCalendar marks commands that list trips and so support --output
+// ics (an iCalendar file, one event per trip).
+const annotationCalendar = "ebo/calendar"
+
+var tripListAnnotations = map[string]string{annotationTabular: "true", annotationPaged: "true", annotationCalendar: "true"}
+
+// annotationPaged marks commands whose human output can run long (lists and
+// detail views); it is sent through the pager when stdout is a terminal.
+// Interactive commands must not have it: the pager would take the terminal.
+const annotationPaged = "ebo/paged"
+
+var pagedAnnotations = map[string]string{annotationPaged: "true"}
+
+// pagedStdout is a Stdout that can page (pager.Writer, set up by main).
+type pagedStdout interface {
+	IsTerminal() bool
+	Page(command string)
+}
+
+// startPager pages a paged command's table output when stdout is a terminal,
+// unless --no-pager is set. JSON, YAML, CSV/TSV and --format output are never
+// paged.
+func startPager(cmd *cobra.Command, deps RootDeps, r cliopts.Resolved) {
+	pw, ok := deps.Stdout.(pagedStdout)
+	if !ok || r.Options.NoPager || r.Options.Output != cliopts.OutputTable || cmd.Annotations[annotationPaged] == "" || !pw.IsTerminal() {
+		return
+	}
+	configured := ""
+	if deps.ConfigStore != nil {
+		if doc, err := deps.ConfigStore.Load(cmd.Context()); err == nil {
+			// A malformed config is reported by the command itself.
+			configured, _ = config.PagerOf(doc)
+		}
+	}
+	if command, ok := pager.Command(deps.Env.LookupEnv, configured); ok {
+		pw.Page(command)
+	}
+}
+
+// checkQuery rejects a bad --query (or --query/--raw with a non-envelope
+// format) before the command runs, so nothing is sent.
+func checkQuery(r cliopts.Resolved) error {
+	if r.Options.Query == "" && !r.Options.Raw {
+		return nil
+	}
+	if !r.Options.Output.Envelope() {
+		return exitcode.WithHint(
+			exitcode.New(exitcode.KindUsage, fmt.Sprintf("--query and --raw apply to json and yaml output, not --output %s", r.Options.Output), nil),
+			"drop --output (json is implied) or use --output yaml")
+	}
+	if _, err := query.Compile(r.Options.Query); err != nil {
+		return exitcode.WithHint(exitcode.New(exitcode.KindUsage, "invalid --query", err),
+			"--query takes a JMESPath expression (https://jmespath.org), e.g. --query trip.tripId")
+	}
+	return nil
+}
+
+// checkFormat rejects a --format template that does not parse (or names a
+// missing file), or that is combined with --raw or a non-envelope format.
+func checkFormat(r cliopts.Resolved) error {
+	if r.Options.Format == "" {
+		return nil
+	}
+	if r.Options.Raw {
+		return exitcode.New(exitcode.KindUsage, "--format and --raw cannot be combined", nil)
+	}
+	if !r.Options.Output.Envelope() {
+		return exitcode.WithHint(
+			exitcode.New(exitcode.KindUsage, fmt.Sprintf("--format renders the data behind json and yaml output, not --output %s", r.Options.Output), nil),
+			"drop --output")
+	}
+	if _, err := format.Parse(r.Options.Format); err != nil {
+		return exitcode.WithHint(exitcode.New(exitcode.KindUsage, "invalid --format", err),
+			"--format takes a Go template (https://pkg.go.dev/text/template) or @file, e.g. --format '{{.TripId}} {{.Name}}'")
+	}
+	return nil
+}
+
+// writeEnvelope writes a JSON or YAML envelope, per --output, after applying
+// --query to its data. With --raw only the data is written; with --format
+// the data is rendered through the template instead.
+func writeEnvelope(deps RootDeps, resolved cliopts.Resolved, env envelope.Envelope) error {
+	if resolved.Options.Query != "" || resolved.Options.Raw || resolved.Options.Format != "" {
+		q, err := query.Compile(resolved.Options.Query)
+		if err != nil {
+			return exitcode.New(exitcode.KindUsage, "invalid --query", err)
+		}
+		data, err := q.Apply(env.Data)
+		if err != nil {
+			return exitcode.New(exitcode.KindUsage, "--query", err)
+		}
+		if resolved.Options.Raw {
+			return query.WriteRaw(deps.Stdout, data)
+		}
+		if resolved.Options.Format != "" {
+			tmpl, err := format.Parse(resolved.Options.Format)
+			if err != nil {
+				return exitcode.New(exitcode.KindUsage, "invalid --format", err)
+			}
+			if err := tmpl.Execute(deps.Stdout, data); err != nil {
+				return exitcode.New(exitcode.KindUsage, "--format", err)
+			}
+			return nil
+		}
+		env.Data = data
+	}
+	if resolved.Options.Output == cliopts.OutputYAML {
+		return envelope.WriteYAML(deps.Stdout, env)
+	}
+	return envelope.WriteJSON(deps.Stdout, env)
+}
+
+// writeDelimited writes t as CSV or TSV, per --output.
+func writeDelimited(deps RootDeps, resolved cliopts.Resolved, t *table.Table) error {
+	comma := ','
+	if resolved.Options.Output == cliopts.OutputTSV {
+		comma = '\t'
+	}
+	return t.WriteDelimited(deps.Stdout, comma)
+}
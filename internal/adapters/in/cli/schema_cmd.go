@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/apispec"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/envelope"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/table"
+	"github.com/spf13/cobra"
+)
+
+// schemaKind is a document `ebo schema` emits: a request body, by its
+// pinned OpenAPI type, or an API command's --output json envelope, whose
+// data is the operation's success response.
+type schemaKind struct {
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+
+	schema    string
+	operation string
+}
+
+var schemaKinds = []schemaKind{
+	{Kind: "request/trip-create", Description: "trip create --from-file body (CreateTripDraftRequest)", schema: "CreateTripDraftRequest"},
+	{Kind: "request/trip-update", Description: "trip update --from-file/--edit body (UpdateTripRequest)", schema: "UpdateTripRequest"},
+	{Kind: "request/member-create", Description: "member create body (CreateMemberRequest)", schema: "CreateMemberRequest"},
+	{Kind: "request/member-update", Description: "member update --from-file/--edit body (UpdateMyMemberProfileRequest)", schema: "UpdateMyMemberProfileRequest"},
+
+	envelopeKind("trip list", "listVisibleTripsForMember"),
+	envelopeKind("trip drafts", "listMyDraftTrips"),
+	envelopeKind("trip get", "getTripDetails"),
+	envelopeKind("trip create", "createTripDraft"),
+	envelopeKind("trip update", "updateTrip"),
+	envelopeKind("trip visibility", "setTripDraftVisibility"),
+	envelopeKind("trip publish", "publishTrip"),
+	envelopeKind("trip cancel", "cancelTrip"),
+	envelopeKind("trip organizer add", "addTripOrganizer"),
+	envelopeKind("trip organizer remove", "removeTripOrganizer"),
+	envelopeKind("trip rsvp set", "setMyRSVP"),
+	envelopeKind("trip rsvp get", "getMyRSVPForTrip"),
+	envelopeKind("trip rsvp summary", "getTripRSVPSummary"),
+	envelopeKind("member list", "listMembers"),
+	envelopeKind("member search", "searchMembers"),
+	envelopeKind("member me", "getMyMemberProfile"),
+	envelopeKind("member create", "createMyMember"),
+	envelopeKind("member update", "updateMyMemberProfile"),
+	envelopeKind("member delete", "deleteMyMemberAccount"),
+}
+
+func envelopeKind(command, operationID string) schemaKind {
+	return schemaKind{
+		Kind:        "envelope/" + strings.ReplaceAll(command, " ", "-"),
+		Description: fmt.Sprintf("%s --output json (%s)", command, operationID),
+		operation:   operationID,
+	}
+}
+
+// document builds the kind's JSON Schema from the pinned spec.
+func (k schemaKind) document() (map[string]any, error) {
+	spec, err := apispec.Pinned()
+	if err != nil {
+		return nil, err
+	}
+	if k.schema != "" {
+		return spec.JSONSchema(k.schema)
+	}
+	op := spec.Operation(k.operation)
+	if op == nil {
+		return nil, fmt.Errorf("unknown operation %q", k.operation)
+	}
+	doc := envelope.JSONSchema(op.SuccessSchema().JSONSchema())
+	doc["$schema"] = apispec.JSONSchemaDraft
+	doc["title"] = "ebo " + k.Description
+	return doc, nil
+}
+
+func addSchemaCommand(root *cobra.Command, deps RootDeps) {
+	root.AddCommand(newSchemaCmd(deps))
+}
+
+func newSchemaCmd(deps RootDeps) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema [kind]",
+		Short: "Print JSON Schema for request files and JSON envelopes",
+		Long: `Print JSON Schema (draft-07) for request files and JSON envelopes.
+
+Schemas are derived from the OpenAPI spec this binary was built against.
+request/<command> kinds describe the --from-file (and --edit) bodies;
+envelope/<command> kinds describe a command's --output json envelope.
+Without a kind, lists the kinds.
+
+Point an editor at a request schema to validate and autocomplete request
+files, e.g. in a YAML file:
+
+  # yaml-language-server: $schema=trip-update.schema.json
+
+after ebo schema request/trip-update > trip-update.schema.json.`,
+		Args: cobra.MaximumNArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			var kinds []string
+			for _, k := range schemaKinds {
+				kinds = append(kinds, k.Kind+"\t"+k.Description)
+			}
+			return kinds, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resolved, err := resolvedFromRoot(cmd, deps)
+			if err != nil {
+				return err
+			}
+			meta := envelope.Meta{APIURL: resolved.Options.APIURL, Profile: resolved.Options.Profile}
+
+			if len(args) == 0 {
+				if resolved.Options.Output.Envelope() {
+					return writeEnvelope(deps, resolved, envelope.Envelope{
+						Data: map[string]any{"kinds": schemaKinds},
+						Meta: meta,
+					})
+				}
+				t := table.New(
+					table.Column{Header: "KIND"},
+					table.Column{Header: "DESCRIPTION", Flex: true},
+				)
+				for _, k := range schemaKinds {
+					t.Row(k.Kind, k.Description)
+				}
+				return writeTable(deps, resolved, t)
+			}
+
+			var kind *schemaKind
+			for i := range schemaKinds {
+				if schemaKinds[i].Kind == args[0] {
+					kind = &schemaKinds[i]
+				}
+			}
+			if kind == nil {
+				return exitcode.WithHint(
+					exitcode.New(exitcode.KindUsage, fmt.Sprintf("unknown schema kind %q", args[0]), nil),
+					"run `ebo schema` to list the kinds")
+			}
+			doc, err := kind.document()
+			if err != nil {
+				return exitcode.New(exitcode.KindUnexpected, "build schema", err)
+			}
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{Data: doc, Meta: meta})
+			}
+			b, err := json.MarshalIndent(doc, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(deps.Stdout, "%s\n", b)
+			return err
+		},
+	}
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/spf13/cobra"
+)
+
+func TestSchema_CoversEveryAPICommand(t *testing.T) {
+	root := NewRootCmd(RootDeps{Env: cliopts.MapEnv{}, Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}})
+	kinds := map[string]bool{}
+	for _, k := range schemaKinds {
+		kinds[k.Kind] = true
+		if _, err := k.document(); err != nil {
+			t.Errorf("%s: %v", k.Kind, err)
+		}
+	}
+
+	// trip export writes iCalendar only.
+	noEnvelope := map[string]bool{"ebo trip export": true}
+
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		if !c.HasSubCommands() && c.RunE != nil && !noEnvelope[c.CommandPath()] {
+			path := strings.Fields(c.CommandPath())[1:]
+			if kind := "envelope/" + strings.Join(path, "-"); !kinds[kind] {
+				t.Errorf("%s: no %s schema", c.CommandPath(), kind)
+			}
+		}
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	for _, name := range []string{"trip", "member"} {
+		c, _, err := root.Find([]string{name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		walk(c)
+	}
+}
+
+func TestSchema_PrintsDocument(t *testing.T) {
+	out, err := runOutput(t, listAPI(), cliopts.MapEnv{}, "schema", "request/trip-update")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("not JSON: %v\n%s", err, out)
+	}
+	if doc["title"] != "UpdateTripRequest" || doc["additionalProperties"] != false {
+		t.Fatalf("doc: %v", doc)
+	}
+
+	out, err = runOutput(t, listAPI(), cliopts.MapEnv{}, "--output", "json", "--raw", "--query", "properties.data.properties.trips.items.required", "schema", "envelope/trip-list")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if !strings.Contains(out, "tripId") {
+		t.Fatalf("trip list envelope schema: %s", out)
+	}
+}
+
+func TestSchema_ListsKindsAndRejectsUnknown(t *testing.T) {
+	out, err := runOutput(t, listAPI(), cliopts.MapEnv{}, "schema")
+	if err != nil || !strings.HasPrefix(out, "KIND") || !strings.Contains(out, "envelope/member-me") {
+		t.Fatalf("list: %v\n%s", err, out)
+	}
+
+	_, err = runOutput(t, listAPI(), cliopts.MapEnv{}, "schema", "request/trip-delete")
+	if exitcode.Code(err) != exitcode.Usage || !strings.Contains(exitcode.HintOf(err), "ebo schema") {
+		t.Fatalf("err=%v hint=%q", err, exitcode.HintOf(err))
+	}
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/app/calendarapp"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/app/tripapp"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/ical"
+	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+	"github.com/spf13/cobra"
+)
+
+// iCalendar export (`trip export`, `trip list|drafts --output ics`); the
+// events are built by calendarapp, as for the `calendar serve` feeds.
+
+// writeTripCalendar fetches each trip's details and writes them as an
+// iCalendar file. Trips without a start date are skipped with a note on
+// stderr.
+func writeTripCalendar(ctx context.Context, deps RootDeps, svc tripapp.Service, apiCtx apiContext, tripIDs []string) error {
+	trips, err := svc.Details(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripIDs)
+	if err != nil {
+		return apiCtx.apiError(err, "")
+	}
+	events, skipped := calendarapp.Events(trips)
+	for _, id := range skipped {
+		_, _ = fmt.Fprintf(deps.Stderr, "Skipped %s: no start date\n", id)
+	}
+	return ical.Write(deps.Stdout, events, time.Now())
+}
+
+// datedTripIDs lists the trips worth fetching for a calendar.
+func datedTripIDs(trips []outplannerapi.TripSummary) []string {
+	var ids []string
+	for _, t := range trips {
+		if t.StartDate != nil {
+			ids = append(ids, t.TripID)
+		}
+	}
+	return ids
+}
+
+func newTripExportCmd(deps RootDeps, svc tripapp.Service) *cobra.Command {
+	var format string
+	cmd := &cobra.Command{
+		Use:   "export <tripId>...",
+		Short: "Export trips as an iCalendar (.ics) file",
+		Long: `Export trips as an iCalendar (.ics) file.
+
+Writes one all-day event per trip, from startDate to endDate, to stdout:
+SUMMARY is the name; DESCRIPTION the description, comms and recommended
+requirements; LOCATION and GEO the meeting location; STATUS is CONFIRMED,
+CANCELLED or (for drafts) TENTATIVE. Event UIDs derive from the trip ID, so
+importing a newer export updates the events. Trips without a start date are
+skipped with a note on stderr.
+
+  ebo trip export t1 t2 > trips.ics`,
+		Args:        cobra.MinimumNArgs(1),
+		Annotations: map[string]string{annotationCalendar: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if deps.PlannerAPI == nil {
+				return exitcode.New(exitcode.KindUnexpected, "planner api", fmt.Errorf("nil planner api client"))
+			}
+			resolved, err := resolvedFromRoot(cmd, deps)
+			if err != nil {
+				return err
+			}
+			if format != "ics" {
+				return exitcode.New(exitcode.KindUsage, fmt.Sprintf("invalid --format %q (expected ics)", format), nil)
+			}
+			switch resolved.Options.Output {
+			case cliopts.OutputTable, cliopts.OutputICS:
+			default:
+				return exitcode.WithHint(
+					exitcode.New(exitcode.KindUsage, fmt.Sprintf("trip export writes --format ics, not --output %s", resolved.Options.Output), nil),
+					"for JSON, use trip get --output json")
+			}
+			apiCtx, err := resolveAPIContext(ctx, deps, resolved)
+			if err != nil {
+				return err
+			}
+			return writeTripCalendar(ctx, deps, svc, apiCtx, args)
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", "ics", "Export format: ics")
+	return cmd
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+// calendarAPI serves trip details by ID.
+type calendarAPI struct {
+	*fakeTripReadAPI
+	trips   map[string]outplannerapi.Trip
+	fetched []string
+}
+
+func (f *calendarAPI) GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.TripResult, error) {
+	f.fetched = append(f.fetched, tripID)
+	t, ok := f.trips[tripID]
+	if !ok {
+		return nil, exitcode.New(exitcode.KindNotFound, "trip not found", nil)
+	}
+	return &outplannerapi.TripResult{Trip: t}, nil
+}
+
+func newCalendarAPI() *calendarAPI {
+	date := func(s string) *outplannerapi.Date {
+		d, _ := time.Parse(outplannerapi.DateLayout, s)
+		return &outplannerapi.Date{Time: d}
+	}
+	str := func(s string) *string { return &s }
+	lat, lng := 38.98, -120.32
+	return &calendarAPI{
+		fakeTripReadAPI: &fakeTripReadAPI{listTrips: []outplannerapi.TripSummary{
+			{TripID: "t1", Status: outplannerapi.TripStatusPublished, StartDate: date("2026-05-02")},
+			{TripID: "t2", Status: outplannerapi.TripStatusDraft},
+			{TripID: "t3", Status: outplannerapi.TripStatusCanceled, StartDate: date("2026-06-01")},
+		}},
+		trips: map[string]outplannerapi.Trip{
+			"t1": {
+				TripID:                "t1",
+				Status:                outplannerapi.TripStatusPublished,
+				Name:                  str("Rubicon, spring run"),
+				StartDate:             date("2026-05-02"),
+				EndDate:               date("2026-05-04"),
+				Description:           str("Three days on the trail."),
+				CommsRequirementsText: str("GMRS"),
+				MeetingLocation: &outplannerapi.Location{
+					Label:             "Loon Lake",
+					Address:           str("Ice House Rd"),
+					LatitudeLongitude: &outplannerapi.LatLng{Latitude: &lat, Longitude: &lng},
+				},
+			},
+			"t2": {TripID: "t2", Status: outplannerapi.TripStatusDraft},
+			"t3": {TripID: "t3", Status: outplannerapi.TripStatusCanceled, Name: str("Snow Run"), StartDate: date("2026-06-01")},
+		},
+	}
+}
+
+var dtstamp = regexp.MustCompile(`DTSTAMP:\d{8}T\d{6}Z`)
+
+func runCalendar(t *testing.T, api outplannerapi.Client, args ...string) (string, string, error) {
+	t.Helper()
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd := NewRootCmd(RootDeps{Env: cliopts.MapEnv{}, ConfigStore: &memStore{path: "/x", doc: baseDoc(t)}, PlannerAPI: api, Stdout: stdout, Stderr: stderr})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return dtstamp.ReplaceAllString(stdout.String(), "DTSTAMP:X"), stderr.String(), err
+}
+
+func TestTripExport_WritesAllDayEvents(t *testing.T) {
+	api := newCalendarAPI()
+	out, stderr, err := runCalendar(t, api, "trip", "export", "t1", "t2", "--format", "ics")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Overland East Bay//ebo//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"BEGIN:VEVENT",
+		"UID:ebo-trip-t1",
+		"DTSTAMP:X",
+		"DTSTART;VALUE=DATE:20260502",
+		"DTEND;VALUE=DATE:20260505",
+		`SUMMARY:Rubicon\, spring run`,
+		`DESCRIPTION:Three days on the trail.\n\nComms requirements:\nGMRS`,
+		`LOCATION:Loon Lake\, Ice House Rd`,
+		"GEO:38.98;-120.32",
+		"STATUS:CONFIRMED",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	if out != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", out, want)
+	}
+	if stderr != "Skipped t2: no start date\n" {
+		t.Fatalf("stderr: %q", stderr)
+	}
+}
+
+func TestTripList_OutputICSFetchesDatedTrips(t *testing.T) {
+	api := newCalendarAPI()
+	out, _, err := runCalendar(t, api, "trip", "list", "--output", "ics")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if strings.Join(api.fetched, ",") != "t1,t3" {
+		t.Fatalf("fetched %v", api.fetched)
+	}
+	if strings.Count(out, "BEGIN:VEVENT") != 2 || !strings.Contains(out, "UID:ebo-trip-t3\r\n") || !strings.Contains(out, "STATUS:CANCELLED\r\n") {
+		t.Fatalf("got:\n%s", out)
+	}
+}
+
+func TestCalendar_ErrorsAreUsageAndMakeNoRequest(t *testing.T) {
+	for _, args := range [][]string{
+		{"trip", "export", "t1", "--format", "pdf"},
+		{"--output", "json", "trip", "export", "t1"},
+		{"--output", "ics", "trip", "get", "t1"},
+		{"--output", "ics", "trip", "list", "--columns", "tripId"},
+	} {
+		api := newCalendarAPI()
+		_, _, err := runCalendar(t, api, args...)
+		if exitcode.Code(err) != exitcode.Usage {
+			t.Errorf("%v: err=%v code=%d", args, err, exitcode.Code(err))
+		}
+		if api.listCalls+len(api.fetched)+api.getCalls != 0 {
+			t.Errorf("%v: expected no request", args)
+		}
+	}
+}
@@ -1,54 +1,58 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"io"
-	"os"
+	"strconv"
 	"strings"
 
-	gen "github.com/Overland-East-Bay/trip-planner-cli/internal/gen/plannerapi"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/app/tripapp"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/editmode"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/envelope"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
-	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/idempotency"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/prompt"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/requestfile"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/table"
+	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
 	"github.com/spf13/cobra"
 )
 
 func addTripCommands(root *cobra.Command, deps RootDeps) {
+	svc := tripapp.Service{API: deps.PlannerAPI}
 	tripCmd := &cobra.Command{
 		Use:   "trip",
 		Short: "Trip operations",
 	}
 
-	tripCmd.AddCommand(newTripListCmd(deps))
-	tripCmd.AddCommand(newTripDraftsCmd(deps))
-	tripCmd.AddCommand(newTripGetCmd(deps))
-	tripCmd.AddCommand(newTripCreateCmd(deps))
-	tripCmd.AddCommand(newTripUpdateCmd(deps))
-	tripCmd.AddCommand(newTripVisibilityCmd(deps))
-	tripCmd.AddCommand(newTripPublishCmd(deps))
-	tripCmd.AddCommand(newTripCancelCmd(deps))
-	tripCmd.AddCommand(newTripOrganizerCmd(deps))
-	tripCmd.AddCommand(newTripRSVPCmd(deps))
+	tripCmd.AddCommand(newTripListCmd(deps, svc))
+	tripCmd.AddCommand(newTripDraftsCmd(deps, svc))
+	tripCmd.AddCommand(newTripGetCmd(deps, svc))
+	tripCmd.AddCommand(newTripCreateCmd(deps, svc))
+	tripCmd.AddCommand(newTripUpdateCmd(deps, svc))
+	tripCmd.AddCommand(newTripVisibilityCmd(deps, svc))
+	tripCmd.AddCommand(newTripPublishCmd(deps, svc))
+	tripCmd.AddCommand(newTripCancelCmd(deps, svc))
+	tripCmd.AddCommand(newTripOrganizerCmd(deps, svc))
+	tripCmd.AddCommand(newTripRSVPCmd(deps, svc))
+	tripCmd.AddCommand(newTripExportCmd(deps, svc))
 
 	root.AddCommand(tripCmd)
 }
 
-func newTripRSVPCmd(deps RootDeps) *cobra.Command {
+func newTripRSVPCmd(deps RootDeps, svc tripapp.Service) *cobra.Command {
 	rsvpCmd := &cobra.Command{
 		Use:   "rsvp",
 		Short: "Trip RSVP",
 	}
-	rsvpCmd.AddCommand(newTripRSVPSetCmd(deps))
-	rsvpCmd.AddCommand(newTripRSVPGetCmd(deps))
-	rsvpCmd.AddCommand(newTripRSVPSummaryCmd(deps))
+	rsvpCmd.AddCommand(newTripRSVPSetCmd(deps, svc))
+	rsvpCmd.AddCommand(newTripRSVPGetCmd(deps, svc))
+	rsvpCmd.AddCommand(newTripRSVPSummaryCmd(deps, svc))
 	return rsvpCmd
 }
 
-func newTripRSVPSetCmd(deps RootDeps) *cobra.Command {
+func newTripRSVPSetCmd(deps RootDeps, svc tripapp.Service) *cobra.Command {
 	var (
 		yes            bool
 		no             bool
@@ -72,6 +76,7 @@ func newTripRSVPSetCmd(deps RootDeps) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			ctx, respMeta := outplannerapi.WithResponseMeta(ctx)
 
 			mode := 0
 			if yes {
@@ -86,28 +91,26 @@ func newTripRSVPSetCmd(deps RootDeps) *cobra.Command {
 			if mode != 1 {
 				return exitcode.New(exitcode.KindUsage, "choose exactly one of --yes, --no, or --unset", nil)
 			}
-			if strings.TrimSpace(idempotencyKey) == "" {
-				idempotencyKey = idempotency.NewKey()
-			}
 
-			resp := gen.YES
+			resp := outplannerapi.RSVPYes
 			if no {
-				resp = gen.NO
+				resp = outplannerapi.RSVPNo
 			}
 			if unset {
-				resp = gen.UNSET
+				resp = outplannerapi.RSVPUnset
 			}
 
-			tripID := gen.TripId(args[0])
-			out, err := deps.PlannerAPI.SetMyRSVP(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID, idempotencyKey, gen.SetMyRSVPRequest{Response: resp})
+			tripID := args[0]
+			out, idempotencyKey, err := svc.SetRSVP(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID, idempotencyKey, resp)
 			if err != nil {
-				return err
+				return apiCtx.apiError(err, idempotencyKey)
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
-					Data: out.JSON200,
-					Meta: envelope.Meta{APIURL: apiCtx.APIURL, Profile: apiCtx.Profile, IdempotencyKey: idempotencyKey},
+			writeResponseMeta(deps, resolved, respMeta)
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: out,
+					Meta: apiCtx.envelopeMeta(respMeta, idempotencyKey),
 				})
 			}
 
@@ -123,7 +126,7 @@ func newTripRSVPSetCmd(deps RootDeps) *cobra.Command {
 	return cmd
 }
 
-func newTripRSVPGetCmd(deps RootDeps) *cobra.Command {
+func newTripRSVPGetCmd(deps RootDeps, svc tripapp.Service) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "get <tripId>",
 		Short: "Get my RSVP for a trip",
@@ -141,37 +144,44 @@ func newTripRSVPGetCmd(deps RootDeps) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			ctx, respMeta := outplannerapi.WithResponseMeta(ctx)
 
-			tripID := gen.TripId(args[0])
-			resp, err := deps.PlannerAPI.GetMyRSVPForTrip(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID)
+			tripID := args[0]
+			resp, err := svc.GetRSVP(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID)
 			if err != nil {
-				return err
+				return apiCtx.apiError(err, "")
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
-					Data: resp.JSON200,
-					Meta: envelope.Meta{APIURL: apiCtx.APIURL, Profile: apiCtx.Profile},
+			writeResponseMeta(deps, resolved, respMeta)
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: resp,
+					Meta: apiCtx.envelopeMeta(respMeta, ""),
 				})
 			}
 
-			if resp.JSON200 == nil {
+			if resp == nil {
 				_, _ = io.WriteString(deps.Stdout, "OK\n")
 				return nil
 			}
-			_, _ = io.WriteString(deps.Stdout, "TRIP_ID\tRESPONSE\n")
-			_, _ = fmt.Fprintf(deps.Stdout, "%s\t%s\n", tripID, resp.JSON200.MyRsvp.Response)
-			return nil
+			t := table.New(table.Column{Header: "TRIP_ID"}, table.Column{Header: "RESPONSE"})
+			t.Row(tripID, string(resp.MyRSVP.Response))
+			return writeTable(deps, resolved, t)
 		},
 	}
 	return cmd
 }
 
-func newTripRSVPSummaryCmd(deps RootDeps) *cobra.Command {
+func newTripRSVPSummaryCmd(deps RootDeps, svc tripapp.Service) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "summary <tripId>",
 		Short: "Get RSVP summary for a trip",
-		Args:  cobra.ExactArgs(1),
+		Long: `Get RSVP summary for a trip.
+
+With --output csv|tsv, prints the roster instead: one row per member who
+answered, with their response.`,
+		Args:        cobra.ExactArgs(1),
+		Annotations: tabularAnnotations,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			if deps.PlannerAPI == nil {
@@ -185,44 +195,58 @@ func newTripRSVPSummaryCmd(deps RootDeps) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			ctx, respMeta := outplannerapi.WithResponseMeta(ctx)
 
-			tripID := gen.TripId(args[0])
-			resp, err := deps.PlannerAPI.GetTripRSVPSummary(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID)
+			tripID := args[0]
+			resp, err := svc.RSVPSummary(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID)
 			if err != nil {
-				return err
+				return apiCtx.apiError(err, "")
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
-					Data: resp.JSON200,
-					Meta: envelope.Meta{APIURL: apiCtx.APIURL, Profile: apiCtx.Profile},
+			writeResponseMeta(deps, resolved, respMeta)
+			if resolved.Options.Output.Delimited() {
+				var s outplannerapi.RSVPSummary
+				if resp != nil {
+					s = resp.RSVPSummary
+				}
+				return writeDelimited(deps, resolved, rsvpRosterTable(tripID, s))
+			}
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: resp,
+					Meta: apiCtx.envelopeMeta(respMeta, ""),
 				})
 			}
 
-			if resp.JSON200 == nil {
+			if resp == nil {
 				_, _ = io.WriteString(deps.Stdout, "OK\n")
 				return nil
 			}
-			s := resp.JSON200.RsvpSummary
-			_, _ = io.WriteString(deps.Stdout, "TRIP_ID\tATTENDING_RIGS\tATTENDING_MEMBERS\tNOT_ATTENDING_MEMBERS\n")
-			_, _ = fmt.Fprintf(deps.Stdout, "%s\t%d\t%d\t%d\n", tripID, s.AttendingRigs, len(s.AttendingMembers), len(s.NotAttendingMembers))
-			return nil
+			s := resp.RSVPSummary
+			t := table.New(
+				table.Column{Header: "TRIP_ID"},
+				table.Column{Header: "ATTENDING_RIGS"},
+				table.Column{Header: "ATTENDING_MEMBERS"},
+				table.Column{Header: "NOT_ATTENDING_MEMBERS"},
+			)
+			t.Row(tripID, strconv.Itoa(s.AttendingRigs), strconv.Itoa(len(s.AttendingMembers)), strconv.Itoa(len(s.NotAttendingMembers)))
+			return writeTable(deps, resolved, t)
 		},
 	}
 	return cmd
 }
 
-func newTripOrganizerCmd(deps RootDeps) *cobra.Command {
+func newTripOrganizerCmd(deps RootDeps, svc tripapp.Service) *cobra.Command {
 	orgCmd := &cobra.Command{
 		Use:   "organizer",
 		Short: "Trip organizer management",
 	}
-	orgCmd.AddCommand(newTripOrganizerAddCmd(deps))
-	orgCmd.AddCommand(newTripOrganizerRemoveCmd(deps))
+	orgCmd.AddCommand(newTripOrganizerAddCmd(deps, svc))
+	orgCmd.AddCommand(newTripOrganizerRemoveCmd(deps, svc))
 	return orgCmd
 }
 
-func newTripOrganizerAddCmd(deps RootDeps) *cobra.Command {
+func newTripOrganizerAddCmd(deps RootDeps, svc tripapp.Service) *cobra.Command {
 	var (
 		memberID       string
 		idempotencyKey string
@@ -244,24 +268,19 @@ func newTripOrganizerAddCmd(deps RootDeps) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			ctx, respMeta := outplannerapi.WithResponseMeta(ctx)
 
-			if strings.TrimSpace(memberID) == "" {
-				return exitcode.New(exitcode.KindUsage, "missing --member", nil)
-			}
-			if strings.TrimSpace(idempotencyKey) == "" {
-				idempotencyKey = idempotency.NewKey()
-			}
-
-			tripID := gen.TripId(args[0])
-			resp, err := deps.PlannerAPI.AddTripOrganizer(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID, idempotencyKey, gen.AddOrganizerRequest{MemberId: memberID})
+			tripID := args[0]
+			resp, idempotencyKey, err := svc.AddOrganizer(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID, memberID, idempotencyKey)
 			if err != nil {
-				return err
+				return apiCtx.apiError(err, idempotencyKey)
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
-					Data: resp.JSON200,
-					Meta: envelope.Meta{APIURL: apiCtx.APIURL, Profile: apiCtx.Profile, IdempotencyKey: idempotencyKey},
+			writeResponseMeta(deps, resolved, respMeta)
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: resp,
+					Meta: apiCtx.envelopeMeta(respMeta, idempotencyKey),
 				})
 			}
 
@@ -275,7 +294,7 @@ func newTripOrganizerAddCmd(deps RootDeps) *cobra.Command {
 	return cmd
 }
 
-func newTripOrganizerRemoveCmd(deps RootDeps) *cobra.Command {
+func newTripOrganizerRemoveCmd(deps RootDeps, svc tripapp.Service) *cobra.Command {
 	var (
 		memberID       string
 		force          bool
@@ -298,27 +317,19 @@ func newTripOrganizerRemoveCmd(deps RootDeps) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			ctx, respMeta := outplannerapi.WithResponseMeta(ctx)
 
-			if strings.TrimSpace(memberID) == "" {
-				return exitcode.New(exitcode.KindUsage, "missing --member", nil)
-			}
-			if !force {
-				return exitcode.New(exitcode.KindUsage, "refusing to remove organizer without --force", nil)
-			}
-			if strings.TrimSpace(idempotencyKey) == "" {
-				idempotencyKey = idempotency.NewKey()
-			}
-
-			tripID := gen.TripId(args[0])
-			resp, err := deps.PlannerAPI.RemoveTripOrganizer(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID, gen.MemberId(memberID), idempotencyKey)
+			tripID := args[0]
+			resp, idempotencyKey, err := svc.RemoveOrganizer(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID, memberID, idempotencyKey, force)
 			if err != nil {
-				return err
+				return apiCtx.apiError(err, idempotencyKey)
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
-					Data: resp.JSON200,
-					Meta: envelope.Meta{APIURL: apiCtx.APIURL, Profile: apiCtx.Profile, IdempotencyKey: idempotencyKey},
+			writeResponseMeta(deps, resolved, respMeta)
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: resp,
+					Meta: apiCtx.envelopeMeta(respMeta, idempotencyKey),
 				})
 			}
 
@@ -333,10 +344,78 @@ func newTripOrganizerRemoveCmd(deps RootDeps) *cobra.Command {
 	return cmd
 }
 
-func newTripListCmd(deps RootDeps) *cobra.Command {
-	return &cobra.Command{
-		Use:   "list",
-		Short: "List visible trips",
+// tripSummaryTable is the table and CSV/TSV output of `trip list` and
+// `trip drafts`. Unknown values (e.g. attending rigs on a draft) render as
+// "-" in the table and as empty CSV cells.
+func tripSummaryTable(trips []outplannerapi.TripSummary) *table.Table {
+	key := func(field string) string { return jsonKey(outplannerapi.TripSummary{}, field) }
+	t := table.New(
+		table.Column{Header: "TRIP_ID", Key: key("TripID")},
+		table.Column{Header: "STATUS", Key: key("Status"), Style: table.TripStatus},
+		table.Column{Header: "START_DATE", Key: key("StartDate"), Empty: "-", Kind: table.Date},
+		table.Column{Header: "END_DATE", Key: key("EndDate"), Empty: "-", Kind: table.Date},
+		table.Column{Header: "ATTENDING_RIGS", Key: key("AttendingRigs"), Empty: "-", Kind: table.Int},
+		table.Column{Header: "CAPACITY_RIGS", Key: key("CapacityRigs"), Empty: "-", Kind: table.Int},
+		table.Column{Header: "DRAFT_VISIBILITY", Key: key("DraftVisibility"), Empty: "-", Wide: true},
+		table.Column{Header: "NAME", Key: key("Name"), Flex: true},
+	)
+	for _, s := range trips {
+		visibility := ""
+		if s.DraftVisibility != nil {
+			visibility = string(*s.DraftVisibility)
+		}
+		t.Row(s.TripID, string(s.Status), dateCell(s.StartDate), dateCell(s.EndDate),
+			intCell(s.AttendingRigs), intCell(s.CapacityRigs), visibility, stringCell(s.Name))
+	}
+	return t
+}
+
+// rsvpRosterTable flattens an RSVP summary to one row per member who
+// answered (attending first), for CSV/TSV.
+func rsvpRosterTable(tripID string, s outplannerapi.RSVPSummary) *table.Table {
+	t := table.New(
+		table.Column{Header: "TRIP_ID", Key: "tripId"},
+		table.Column{Header: "RESPONSE", Key: "response"},
+		table.Column{Header: "MEMBER_ID", Key: "memberId"},
+		table.Column{Header: "DISPLAY_NAME", Key: "displayName"},
+		table.Column{Header: "EMAIL", Key: "email"},
+	)
+	for _, m := range s.AttendingMembers {
+		t.Row(tripID, string(outplannerapi.RSVPYes), m.MemberID, m.DisplayName, m.Email)
+	}
+	for _, m := range s.NotAttendingMembers {
+		t.Row(tripID, string(outplannerapi.RSVPNo), m.MemberID, m.DisplayName, m.Email)
+	}
+	return t
+}
+
+func dateCell(d *outplannerapi.Date) string {
+	if d == nil {
+		return ""
+	}
+	return d.String()
+}
+
+func intCell(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
+
+func stringCell(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func newTripListCmd(deps RootDeps, svc tripapp.Service) *cobra.Command {
+	var list listFlags
+	cmd := &cobra.Command{
+		Use:         "list",
+		Short:       "List visible trips",
+		Annotations: tripListAnnotations,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			_ = args
 			ctx := cmd.Context()
@@ -348,45 +427,49 @@ func newTripListCmd(deps RootDeps) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if err := list.check(resolved, tripSummaryTable(nil)); err != nil {
+				return err
+			}
 			apiCtx, err := resolveAPIContext(ctx, deps, resolved)
 			if err != nil {
 				return err
 			}
+			ctx, respMeta := outplannerapi.WithResponseMeta(ctx)
 
-			resp, err := deps.PlannerAPI.ListVisibleTripsForMember(ctx, apiCtx.APIURL, apiCtx.BearerToken)
+			resp, err := svc.List(ctx, apiCtx.APIURL, apiCtx.BearerToken)
 			if err != nil {
-				return err
+				return apiCtx.apiError(err, "")
 			}
 
-			trips := []gen.TripSummary{}
-			if resp.JSON200 != nil {
-				trips = resp.JSON200.Trips
+			trips := []outplannerapi.TripSummary{}
+			if resp != nil {
+				trips = resp.Trips
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
-					Data: resp.JSON200,
-					Meta: envelope.Meta{APIURL: apiCtx.APIURL, Profile: apiCtx.Profile},
+			writeResponseMeta(deps, resolved, respMeta)
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: resp,
+					Meta: apiCtx.envelopeMeta(respMeta, ""),
 				})
 			}
-
-			_, _ = io.WriteString(deps.Stdout, "TRIP_ID\tSTATUS\tNAME\n")
-			for _, t := range trips {
-				name := ""
-				if t.Name != nil {
-					name = *t.Name
-				}
-				_, _ = fmt.Fprintf(deps.Stdout, "%s\t%s\t%s\n", t.TripId, t.Status, name)
+			if resolved.Options.Output == cliopts.OutputICS {
+				return writeTripCalendar(ctx, deps, svc, apiCtx, datedTripIDs(trips))
 			}
-			return nil
+
+			return writeList(deps, resolved, list, tripSummaryTable(trips))
 		},
 	}
+	addListFlags(cmd, &list)
+	return cmd
 }
 
-func newTripDraftsCmd(deps RootDeps) *cobra.Command {
-	return &cobra.Command{
-		Use:   "drafts",
-		Short: "List my draft trips",
+func newTripDraftsCmd(deps RootDeps, svc tripapp.Service) *cobra.Command {
+	var list listFlags
+	cmd := &cobra.Command{
+		Use:         "drafts",
+		Short:       "List my draft trips",
+		Annotations: tripListAnnotations,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			_ = args
 			ctx := cmd.Context()
@@ -398,46 +481,49 @@ func newTripDraftsCmd(deps RootDeps) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if err := list.check(resolved, tripSummaryTable(nil)); err != nil {
+				return err
+			}
 			apiCtx, err := resolveAPIContext(ctx, deps, resolved)
 			if err != nil {
 				return err
 			}
+			ctx, respMeta := outplannerapi.WithResponseMeta(ctx)
 
-			resp, err := deps.PlannerAPI.ListMyDraftTrips(ctx, apiCtx.APIURL, apiCtx.BearerToken)
+			resp, err := svc.Drafts(ctx, apiCtx.APIURL, apiCtx.BearerToken)
 			if err != nil {
-				return err
+				return apiCtx.apiError(err, "")
 			}
 
-			trips := []gen.TripSummary{}
-			if resp.JSON200 != nil {
-				trips = resp.JSON200.Trips
+			trips := []outplannerapi.TripSummary{}
+			if resp != nil {
+				trips = resp.Trips
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
-					Data: resp.JSON200,
-					Meta: envelope.Meta{APIURL: apiCtx.APIURL, Profile: apiCtx.Profile},
+			writeResponseMeta(deps, resolved, respMeta)
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: resp,
+					Meta: apiCtx.envelopeMeta(respMeta, ""),
 				})
 			}
-
-			_, _ = io.WriteString(deps.Stdout, "TRIP_ID\tSTATUS\tNAME\n")
-			for _, t := range trips {
-				name := ""
-				if t.Name != nil {
-					name = *t.Name
-				}
-				_, _ = fmt.Fprintf(deps.Stdout, "%s\t%s\t%s\n", t.TripId, t.Status, name)
+			if resolved.Options.Output == cliopts.OutputICS {
+				return writeTripCalendar(ctx, deps, svc, apiCtx, datedTripIDs(trips))
 			}
-			return nil
+
+			return writeList(deps, resolved, list, tripSummaryTable(trips))
 		},
 	}
+	addListFlags(cmd, &list)
+	return cmd
 }
 
-func newTripGetCmd(deps RootDeps) *cobra.Command {
+func newTripGetCmd(deps RootDeps, svc tripapp.Service) *cobra.Command {
 	return &cobra.Command{
-		Use:   "get <tripId>",
-		Short: "Get trip details",
-		Args:  cobra.ExactArgs(1),
+		Use:         "get <tripId>",
+		Short:       "Get trip details",
+		Annotations: pagedAnnotations,
+		Args:        cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 
@@ -452,37 +538,35 @@ func newTripGetCmd(deps RootDeps) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			ctx, respMeta := outplannerapi.WithResponseMeta(ctx)
 
-			tripID := gen.TripId(args[0])
-			resp, err := deps.PlannerAPI.GetTripDetails(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID)
+			tripID := args[0]
+			resp, err := svc.Get(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID)
 			if err != nil {
-				return err
+				return apiCtx.apiError(err, "")
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
-					Data: resp.JSON200,
-					Meta: envelope.Meta{APIURL: apiCtx.APIURL, Profile: apiCtx.Profile},
+			writeResponseMeta(deps, resolved, respMeta)
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: resp,
+					Meta: apiCtx.envelopeMeta(respMeta, ""),
 				})
 			}
 
-			if resp.JSON200 == nil {
+			if resp == nil {
 				_, _ = io.WriteString(deps.Stdout, "OK\n")
 				return nil
 			}
 
-			t := resp.JSON200.Trip
-			name := ""
-			if t.Name != nil {
-				name = *t.Name
-			}
-			_, _ = fmt.Fprintf(deps.Stdout, "TripId: %s\nStatus: %s\nName: %s\n", t.TripId, t.Status, name)
+			opts := table.OptionsFor(deps.Stdout, resolved.Options.NoColor, resolved.Options.Wide)
+			_, _ = io.WriteString(deps.Stdout, tripDetailView(resp.Trip, opts))
 			return nil
 		},
 	}
 }
 
-func newTripCreateCmd(deps RootDeps) *cobra.Command {
+func newTripCreateCmd(deps RootDeps, svc tripapp.Service) *cobra.Command {
 	var (
 		name           string
 		fromFile       string
@@ -509,6 +593,7 @@ func newTripCreateCmd(deps RootDeps) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			ctx, respMeta := outplannerapi.WithResponseMeta(ctx)
 
 			if strings.TrimSpace(name) != "" && strings.TrimSpace(fromFile) != "" {
 				return exitcode.New(exitcode.KindUsage, "choose exactly one of --name or --from-file", nil)
@@ -517,51 +602,46 @@ func newTripCreateCmd(deps RootDeps) *cobra.Command {
 				return exitcode.New(exitcode.KindUsage, "choose exactly one of --name, --from-file, or --prompt", nil)
 			}
 
-			var req gen.CreateTripDraftRequest
+			var req outplannerapi.CreateTripDraftRequest
 			switch {
 			case strings.TrimSpace(fromFile) != "":
-				if err := requestfile.LoadStrict(fromFile, &req); err != nil {
-					return exitcode.New(exitcode.KindUsage, "parse request file", err)
+				req, err = tripapp.LoadDraft(fromFile)
+				if err != nil {
+					return err
 				}
 			case promptMode:
 				p := prompt.New(cmd.InOrStdin(), deps.Stderr, nil)
 				n, err := p.PromptRequiredString(ctx, "Name")
 				if err != nil {
-					if err == prompt.ErrAborted {
-						return exitcode.New(exitcode.KindInterrupted, "interrupted", err)
-					}
-					return exitcode.New(exitcode.KindServer, "prompt", err)
+					return promptError(err)
 				}
-				req = gen.CreateTripDraftRequest{Name: n}
+				req = outplannerapi.CreateTripDraftRequest{Name: n}
 			case strings.TrimSpace(name) != "":
-				if err := validateSingleLineFlag(name, "--name"); err != nil {
-					return exitcode.New(exitcode.KindUsage, "invalid flag", err)
+				req, err = tripapp.DraftFromName(name)
+				if err != nil {
+					return err
 				}
-				req = gen.CreateTripDraftRequest{Name: name}
 			default:
 				return exitcode.New(exitcode.KindUsage, "missing input (use --name, --from-file, or --prompt)", nil)
 			}
 
-			if strings.TrimSpace(idempotencyKey) == "" {
-				idempotencyKey = idempotency.NewKey()
-			}
-
-			resp, err := deps.PlannerAPI.CreateTripDraft(ctx, apiCtx.APIURL, apiCtx.BearerToken, idempotencyKey, req)
+			resp, idempotencyKey, err := svc.Create(ctx, apiCtx.APIURL, apiCtx.BearerToken, idempotencyKey, req)
 			if err != nil {
-				return err
+				return apiCtx.apiError(err, idempotencyKey)
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
-					Data: resp.JSON201,
-					Meta: envelope.Meta{APIURL: apiCtx.APIURL, Profile: apiCtx.Profile, IdempotencyKey: idempotencyKey},
+			writeResponseMeta(deps, resolved, respMeta)
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: resp,
+					Meta: apiCtx.envelopeMeta(respMeta, idempotencyKey),
 				})
 			}
 
 			// Keep stdout clean-ish but still human-friendly.
 			_, _ = fmt.Fprintf(deps.Stderr, "Idempotency-Key: %s\n", idempotencyKey)
-			if resp.JSON201 != nil {
-				_, _ = fmt.Fprintf(deps.Stdout, "tripId=%s\n", resp.JSON201.Trip.TripId)
+			if resp != nil {
+				_, _ = fmt.Fprintf(deps.Stdout, "tripId=%s\n", resp.Trip.TripID)
 			} else {
 				_, _ = io.WriteString(deps.Stdout, "OK\n")
 			}
@@ -577,7 +657,7 @@ func newTripCreateCmd(deps RootDeps) *cobra.Command {
 	return cmd
 }
 
-func newTripUpdateCmd(deps RootDeps) *cobra.Command {
+func newTripUpdateCmd(deps RootDeps, svc tripapp.Service) *cobra.Command {
 	var (
 		fromFile       string
 		edit           bool
@@ -618,6 +698,45 @@ func newTripUpdateCmd(deps RootDeps) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			ctx, respMeta := outplannerapi.WithResponseMeta(ctx)
+
+			flags := cmd.Flags()
+			var patch tripapp.Patch
+			if flags.Changed("name") {
+				patch.Name = &patchName
+			}
+			if flags.Changed("description") {
+				patch.Description = &patchDescription
+			}
+			if flags.Changed("difficulty-text") {
+				patch.DifficultyText = &patchDifficultyText
+			}
+			if flags.Changed("comms-requirements-text") {
+				patch.CommsRequirementsText = &patchCommsRequirementsText
+			}
+			if flags.Changed("recommended-requirements-text") {
+				patch.RecommendedRequirementsText = &patchRecommendedRequirements
+			}
+			if flags.Changed("capacity-rigs") {
+				patch.CapacityRigs = &patchCapacityRigs
+			}
+			if flags.Changed("meeting-label") {
+				patch.MeetingLabel = &meetingLabel
+			}
+			if flags.Changed("meeting-address") {
+				patch.MeetingAddress = &meetingAddress
+			}
+			if flags.Changed("meeting-lat") {
+				patch.MeetingLat = &meetingLat
+			}
+			if flags.Changed("meeting-lng") {
+				patch.MeetingLng = &meetingLng
+			}
+			if flags.Changed("artifact-id") {
+				patch.ArtifactIDs = artifactIDs
+			}
+			patch.ClearMeetingLocation = clearMeetingLocation
+			patch.ClearArtifacts = clearArtifacts
 
 			modeCount := 0
 			if strings.TrimSpace(fromFile) != "" {
@@ -629,20 +748,7 @@ func newTripUpdateCmd(deps RootDeps) *cobra.Command {
 			if promptMode {
 				modeCount++
 			}
-			patchMode := cmd.Flags().Changed("name") ||
-				cmd.Flags().Changed("description") ||
-				cmd.Flags().Changed("difficulty-text") ||
-				cmd.Flags().Changed("comms-requirements-text") ||
-				cmd.Flags().Changed("recommended-requirements-text") ||
-				cmd.Flags().Changed("capacity-rigs") ||
-				cmd.Flags().Changed("meeting-label") ||
-				cmd.Flags().Changed("meeting-address") ||
-				cmd.Flags().Changed("meeting-lat") ||
-				cmd.Flags().Changed("meeting-lng") ||
-				cmd.Flags().Changed("artifact-id") ||
-				clearMeetingLocation ||
-				clearArtifacts
-			if patchMode {
+			if !patch.IsEmpty() {
 				modeCount++
 			}
 			if modeCount == 0 {
@@ -652,173 +758,32 @@ func newTripUpdateCmd(deps RootDeps) *cobra.Command {
 				return exitcode.New(exitcode.KindUsage, "choose exactly one patch mode: flags, --from-file, --edit, or --prompt", nil)
 			}
 
-			var req gen.UpdateTripRequest
+			var req outplannerapi.UpdateTripRequest
 			switch {
 			case edit:
-				edited, err := editmode.EditTemp(updateTripTemplateYAML)
-				if err != nil {
-					return exitcode.New(exitcode.KindServer, "open editor", err)
-				}
-				tmp, err := os.CreateTemp("", "ebo-editbuf-*.req")
-				if err != nil {
-					return exitcode.New(exitcode.KindServer, "temp file", err)
-				}
-				tmpName := tmp.Name()
-				_ = tmp.Close()
-				defer func() { _ = os.Remove(tmpName) }()
-				if err := os.WriteFile(tmpName, edited, 0o600); err != nil {
-					return exitcode.New(exitcode.KindServer, "write edited buffer", err)
-				}
-				if err := requestfile.LoadStrict(tmpName, &req); err != nil {
-					return exitcode.New(exitcode.KindUsage, "parse edited buffer", err)
-				}
+				req, err = svc.EditPatch()
 			case promptMode:
-				p := prompt.New(cmd.InOrStdin(), deps.Stderr, func(template string) ([]byte, error) { return editmode.EditTemp(template) })
-				desc, usedEditor, err := p.PromptMultilineOrInline(ctx, "description (optional)", editTextTemplateYAML("text", "Description"))
-				if err != nil {
-					if err == prompt.ErrAborted {
-						return exitcode.New(exitcode.KindInterrupted, "interrupted", err)
-					}
-					return exitcode.New(exitcode.KindServer, "prompt", err)
-				}
-				if usedEditor {
-					type textDoc struct {
-						Text string `json:"text"`
-					}
-					tmp, err := os.CreateTemp("", "ebo-edittext-*.req")
-					if err != nil {
-						return exitcode.New(exitcode.KindServer, "temp file", err)
-					}
-					tmpName := tmp.Name()
-					_ = tmp.Close()
-					defer func() { _ = os.Remove(tmpName) }()
-					if err := os.WriteFile(tmpName, []byte(desc), 0o600); err != nil {
-						return exitcode.New(exitcode.KindServer, "write edited buffer", err)
-					}
-					var td textDoc
-					if err := requestfile.LoadStrict(tmpName, &td); err != nil {
-						return exitcode.New(exitcode.KindUsage, "parse edited buffer", err)
-					}
-					desc = td.Text
-				}
-				if strings.TrimSpace(desc) != "" {
-					req.Description = &desc
-				}
-
-				ids, err := p.PromptStringList(ctx, "artifactIds")
-				if err != nil {
-					if err == prompt.ErrAborted {
-						return exitcode.New(exitcode.KindInterrupted, "interrupted", err)
-					}
-					return exitcode.New(exitcode.KindServer, "prompt", err)
-				}
-				if len(ids) > 0 {
-					req.ArtifactIds = &ids
-				}
-			case patchMode:
-				// Validation: multiline text is not allowed via flags.
-				for _, v := range []struct {
-					changed bool
-					val     string
-					name    string
-				}{
-					{cmd.Flags().Changed("name"), patchName, "--name"},
-					{cmd.Flags().Changed("description"), patchDescription, "--description"},
-					{cmd.Flags().Changed("difficulty-text"), patchDifficultyText, "--difficulty-text"},
-					{cmd.Flags().Changed("comms-requirements-text"), patchCommsRequirementsText, "--comms-requirements-text"},
-					{cmd.Flags().Changed("recommended-requirements-text"), patchRecommendedRequirements, "--recommended-requirements-text"},
-					{cmd.Flags().Changed("meeting-label"), meetingLabel, "--meeting-label"},
-					{cmd.Flags().Changed("meeting-address"), meetingAddress, "--meeting-address"},
-				} {
-					if v.changed {
-						if err := validateSingleLineFlag(v.val, v.name); err != nil {
-							return exitcode.New(exitcode.KindUsage, "invalid flag", err)
-						}
-					}
-				}
-
-				if clearArtifacts && cmd.Flags().Changed("artifact-id") {
-					return exitcode.New(exitcode.KindUsage, "choose exactly one of --artifact-id or --clear-artifacts", nil)
-				}
-
-				if clearMeetingLocation && (cmd.Flags().Changed("meeting-label") || cmd.Flags().Changed("meeting-address") || cmd.Flags().Changed("meeting-lat") || cmd.Flags().Changed("meeting-lng")) {
-					return exitcode.New(exitcode.KindUsage, "--clear-meeting-location is mutually exclusive with meeting location flags", nil)
-				}
-
-				latSet := cmd.Flags().Changed("meeting-lat")
-				lngSet := cmd.Flags().Changed("meeting-lng")
-				if latSet != lngSet {
-					return exitcode.New(exitcode.KindUsage, "meeting lat/lng must be provided as a pair", nil)
-				}
-
-				if cmd.Flags().Changed("name") {
-					req.Name = &patchName
-				}
-				if cmd.Flags().Changed("description") {
-					req.Description = &patchDescription
-				}
-				if cmd.Flags().Changed("difficulty-text") {
-					req.DifficultyText = &patchDifficultyText
-				}
-				if cmd.Flags().Changed("comms-requirements-text") {
-					req.CommsRequirementsText = &patchCommsRequirementsText
-				}
-				if cmd.Flags().Changed("recommended-requirements-text") {
-					req.RecommendedRequirementsText = &patchRecommendedRequirements
-				}
-				if cmd.Flags().Changed("capacity-rigs") {
-					req.CapacityRigs = &patchCapacityRigs
-				}
-
-				if clearArtifacts {
-					empty := []string{}
-					req.ArtifactIds = &empty
-				}
-				if cmd.Flags().Changed("artifact-id") {
-					dedup := dedupStringsPreserveFirst(artifactIDs)
-					req.ArtifactIds = &dedup
-				}
-
-				if clearMeetingLocation {
-					req.MeetingLocation = &gen.LocationPatch{}
-				} else if cmd.Flags().Changed("meeting-label") || cmd.Flags().Changed("meeting-address") || latSet || lngSet {
-					loc := &gen.LocationPatch{}
-					if cmd.Flags().Changed("meeting-label") {
-						loc.Label = &meetingLabel
-					}
-					if cmd.Flags().Changed("meeting-address") {
-						loc.Address = &meetingAddress
-					}
-					if latSet && lngSet {
-						lat := meetingLat
-						lng := meetingLng
-						loc.LatitudeLongitude = &struct {
-							Latitude  *float64 `json:"latitude"`
-							Longitude *float64 `json:"longitude"`
-						}{Latitude: &lat, Longitude: &lng}
-					}
-					req.MeetingLocation = loc
-				}
+				req, err = promptTripPatch(ctx, cmd, deps)
+			case strings.TrimSpace(fromFile) != "":
+				req, err = tripapp.LoadPatch(fromFile)
 			default:
-				if err := requestfile.LoadStrict(fromFile, &req); err != nil {
-					return exitcode.New(exitcode.KindUsage, "parse request file", err)
-				}
+				req, err = tripapp.BuildPatch(patch)
 			}
-
-			if strings.TrimSpace(idempotencyKey) == "" {
-				idempotencyKey = idempotency.NewKey()
+			if err != nil {
+				return err
 			}
 
-			tripID := gen.TripId(args[0])
-			resp, err := deps.PlannerAPI.UpdateTrip(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID, idempotencyKey, req)
+			tripID := args[0]
+			resp, idempotencyKey, err := svc.Update(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID, idempotencyKey, req)
 			if err != nil {
-				return err
+				return apiCtx.apiError(err, idempotencyKey)
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
-					Data: resp.JSON200,
-					Meta: envelope.Meta{APIURL: apiCtx.APIURL, Profile: apiCtx.Profile, IdempotencyKey: idempotencyKey},
+			writeResponseMeta(deps, resolved, respMeta)
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: resp,
+					Meta: apiCtx.envelopeMeta(respMeta, idempotencyKey),
 				})
 			}
 
@@ -849,7 +814,7 @@ func newTripUpdateCmd(deps RootDeps) *cobra.Command {
 	return cmd
 }
 
-func newTripVisibilityCmd(deps RootDeps) *cobra.Command {
+func newTripVisibilityCmd(deps RootDeps, svc tripapp.Service) *cobra.Command {
 	var (
 		public         bool
 		private        bool
@@ -872,29 +837,28 @@ func newTripVisibilityCmd(deps RootDeps) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			ctx, respMeta := outplannerapi.WithResponseMeta(ctx)
 
 			if public == private {
 				return exitcode.New(exitcode.KindUsage, "choose exactly one of --public or --private", nil)
 			}
-			if strings.TrimSpace(idempotencyKey) == "" {
-				idempotencyKey = idempotency.NewKey()
-			}
 
-			vis := gen.DraftVisibility("PRIVATE")
+			vis := outplannerapi.DraftVisibilityPrivate
 			if public {
-				vis = gen.DraftVisibility("PUBLIC")
+				vis = outplannerapi.DraftVisibilityPublic
 			}
 
-			tripID := gen.TripId(args[0])
-			resp, err := deps.PlannerAPI.SetTripDraftVisibility(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID, idempotencyKey, gen.SetDraftVisibilityRequest{DraftVisibility: vis})
+			tripID := args[0]
+			resp, idempotencyKey, err := svc.SetVisibility(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID, idempotencyKey, vis)
 			if err != nil {
-				return err
+				return apiCtx.apiError(err, idempotencyKey)
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
-					Data: resp.JSON200,
-					Meta: envelope.Meta{APIURL: apiCtx.APIURL, Profile: apiCtx.Profile, IdempotencyKey: idempotencyKey},
+			writeResponseMeta(deps, resolved, respMeta)
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: resp,
+					Meta: apiCtx.envelopeMeta(respMeta, idempotencyKey),
 				})
 			}
 
@@ -910,7 +874,7 @@ func newTripVisibilityCmd(deps RootDeps) *cobra.Command {
 	return cmd
 }
 
-func newTripPublishCmd(deps RootDeps) *cobra.Command {
+func newTripPublishCmd(deps RootDeps, svc tripapp.Service) *cobra.Command {
 	var printAnnouncement bool
 	cmd := &cobra.Command{
 		Use:   "publish <tripId>",
@@ -929,24 +893,30 @@ func newTripPublishCmd(deps RootDeps) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			ctx, respMeta := outplannerapi.WithResponseMeta(ctx)
 
-			tripID := gen.TripId(args[0])
-			resp, err := deps.PlannerAPI.PublishTrip(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID)
+			tripID := args[0]
+			resp, err := svc.Publish(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID)
 			if err != nil {
-				return err
+				if exitcode.Code(err) == exitcode.Validation {
+					// The draft is missing required fields (listed in the error details).
+					return apiCtx.apiError(exitcode.WithHint(err, fmt.Sprintf("ebo trip update %s --prompt\n  ebo trip publish %s", tripID, tripID)), "")
+				}
+				return apiCtx.apiError(err, "")
 			}
 
 			if printAnnouncement {
-				if resp.JSON200 != nil {
-					_, _ = io.WriteString(deps.Stdout, resp.JSON200.AnnouncementCopy)
+				if resp != nil {
+					_, _ = io.WriteString(deps.Stdout, resp.AnnouncementCopy)
 				}
 				return nil
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
-					Data: resp.JSON200,
-					Meta: envelope.Meta{APIURL: apiCtx.APIURL, Profile: apiCtx.Profile},
+			writeResponseMeta(deps, resolved, respMeta)
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: resp,
+					Meta: apiCtx.envelopeMeta(respMeta, ""),
 				})
 			}
 
@@ -958,7 +928,7 @@ func newTripPublishCmd(deps RootDeps) *cobra.Command {
 	return cmd
 }
 
-func newTripCancelCmd(deps RootDeps) *cobra.Command {
+func newTripCancelCmd(deps RootDeps, svc tripapp.Service) *cobra.Command {
 	var (
 		force          bool
 		idempotencyKey string
@@ -980,34 +950,23 @@ func newTripCancelCmd(deps RootDeps) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			ctx, respMeta := outplannerapi.WithResponseMeta(ctx)
 
-			if !force {
-				return exitcode.New(exitcode.KindUsage, "refusing to cancel without --force", nil)
-			}
-
-			var idemPtr *string
-			if strings.TrimSpace(idempotencyKey) != "" {
-				idemPtr = &idempotencyKey
-			}
-
-			tripID := gen.TripId(args[0])
-			resp, err := deps.PlannerAPI.CancelTrip(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID, idemPtr)
+			tripID := args[0]
+			resp, idempotencyKey, err := svc.Cancel(ctx, apiCtx.APIURL, apiCtx.BearerToken, tripID, idempotencyKey, force)
 			if err != nil {
-				return err
+				return apiCtx.apiError(err, idempotencyKey)
 			}
 
-			if resolved.Options.Output == cliopts.OutputJSON {
-				meta := envelope.Meta{APIURL: apiCtx.APIURL, Profile: apiCtx.Profile}
-				if idemPtr != nil {
-					meta.IdempotencyKey = idempotencyKey
-				}
-				return envelope.WriteJSON(deps.Stdout, envelope.Envelope{
-					Data: resp.JSON200,
-					Meta: meta,
+			writeResponseMeta(deps, resolved, respMeta)
+			if resolved.Options.Output.Envelope() {
+				return writeEnvelope(deps, resolved, envelope.Envelope{
+					Data: resp,
+					Meta: apiCtx.envelopeMeta(respMeta, idempotencyKey),
 				})
 			}
 
-			if idemPtr != nil {
+			if idempotencyKey != "" {
 				_, _ = fmt.Fprintf(deps.Stderr, "Idempotency-Key: %s\n", idempotencyKey)
 			}
 			_, _ = io.WriteString(deps.Stdout, "OK\n")
@@ -1019,58 +978,56 @@ func newTripCancelCmd(deps RootDeps) *cobra.Command {
 	return cmd
 }
 
-const updateTripTemplateYAML = `# UpdateTripRequest (patch)
-#
-# - Omitted fields are unchanged.
-# - Set fields to update.
-#
-# Example:
-# description: |-
-#   line1
-#   line2
-#
-# name:
-# description:
-# startDate:
-# endDate:
-# capacityRigs:
-# difficultyText:
-# commsRequirementsText:
-# recommendedRequirementsText:
-# artifactIds:
-# meetingLocation:
-#   label:
-#   address:
-#   latitudeLongitude:
-#     latitude:
-#     longitude:
-{}
-`
+// promptTripPatch collects a trip patch interactively (`trip update --prompt`).
+func promptTripPatch(ctx context.Context, cmd *cobra.Command, deps RootDeps) (outplannerapi.UpdateTripRequest, error) {
+	var req outplannerapi.UpdateTripRequest
+	p := prompt.New(cmd.InOrStdin(), deps.Stderr, func(template string) ([]byte, error) { return editmode.EditTemp(template) })
+	desc, usedEditor, err := p.PromptMultilineOrInline(ctx, "description (optional)", editTextTemplateYAML("text", "Description"))
+	if err != nil {
+		return req, promptError(err)
+	}
+	if usedEditor {
+		if desc, err = parseEditedText(desc); err != nil {
+			return req, err
+		}
+	}
+	if strings.TrimSpace(desc) != "" {
+		req.Description = &desc
+	}
 
-func editTextTemplateYAML(key string, title string) string {
-	return fmt.Sprintf(`# %s (plain text)
-#
-# Put your text under "%s".
-%s: |-
-  `+"\n", title, key, key)
+	ids, err := p.PromptStringList(ctx, "artifactIds")
+	if err != nil {
+		return req, promptError(err)
+	}
+	if len(ids) > 0 {
+		req.ArtifactIDs = &ids
+	}
+	return req, nil
 }
 
-func validateSingleLineFlag(v string, flagName string) error {
-	if strings.Contains(v, "\n") || strings.Contains(v, "\r") {
-		return fmt.Errorf("%s must be single line (no newlines)", flagName)
+func promptError(err error) error {
+	if err == prompt.ErrAborted {
+		return exitcode.New(exitcode.KindInterrupted, "interrupted", err)
 	}
-	return nil
+	return exitcode.New(exitcode.KindServer, "prompt", err)
 }
 
-func dedupStringsPreserveFirst(in []string) []string {
-	seen := make(map[string]struct{}, len(in))
-	out := make([]string, 0, len(in))
-	for _, v := range in {
-		if _, ok := seen[v]; ok {
-			continue
-		}
-		seen[v] = struct{}{}
-		out = append(out, v)
+// parseEditedText extracts the text from a buffer edited from
+// editTextTemplateYAML.
+func parseEditedText(buf string) (string, error) {
+	var td struct {
+		Text string `json:"text"`
 	}
-	return out
+	if err := requestfile.DecodeStrict("edited buffer", []byte(buf), &td); err != nil {
+		return "", exitcode.New(exitcode.KindUsage, "parse edited buffer", err)
+	}
+	return td.Text, nil
+}
+
+func editTextTemplateYAML(key string, title string) string {
+	return fmt.Sprintf(`# %s (plain text)
+#
+# Put your text under "%s".
+%s: |-
+  `+"\n", title, key, key)
 }
@@ -8,7 +8,6 @@ import (
 	"path/filepath"
 	"testing"
 
-	gen "github.com/Overland-East-Bay/trip-planner-cli/internal/gen/plannerapi"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/config"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
 	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
@@ -23,24 +22,24 @@ type fakePlannerAPI struct {
 	lastUpdateIdem string
 	lastMemberIdem string
 
-	lastCreateReq gen.CreateTripDraftJSONRequestBody
-	lastUpdateReq gen.UpdateTripJSONRequestBody
-	lastMemberReq gen.UpdateMyMemberProfileJSONRequestBody
+	lastCreateReq outplannerapi.CreateTripDraftRequest
+	lastUpdateReq outplannerapi.UpdateTripRequest
+	lastMemberReq outplannerapi.UpdateMemberRequest
 }
 
 var _ outplannerapi.Client = (*fakePlannerAPI)(nil)
 
-func (f *fakePlannerAPI) CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.CreateTripDraftJSONRequestBody) (*gen.CreateTripDraftClientResponse, error) {
+func (f *fakePlannerAPI) CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.CreateTripDraftRequest) (*outplannerapi.TripCreatedResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
 	f.createCalls++
 	f.lastCreateIdem = idempotencyKey
 	f.lastCreateReq = req
-	return &gen.CreateTripDraftClientResponse{JSON201: &gen.CreateTripDraftResponse{Trip: gen.TripCreated{TripId: "t1"}}}, nil
+	return &outplannerapi.TripCreatedResult{Trip: outplannerapi.TripCreated{TripID: "t1"}}, nil
 }
 
-func (f *fakePlannerAPI) UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.UpdateTripJSONRequestBody) (*gen.UpdateTripClientResponse, error) {
+func (f *fakePlannerAPI) UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.UpdateTripRequest) (*outplannerapi.TripResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -48,36 +47,36 @@ func (f *fakePlannerAPI) UpdateTrip(ctx context.Context, baseURL string, bearerT
 	f.updateCalls++
 	f.lastUpdateIdem = idempotencyKey
 	f.lastUpdateReq = req
-	return &gen.UpdateTripClientResponse{JSON200: &gen.TripResponse{}}, nil
+	return &outplannerapi.TripResult{}, nil
 }
 
-func (f *fakePlannerAPI) CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey *string) (*gen.CancelTripClientResponse, error) {
+func (f *fakePlannerAPI) CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey *string) (*outplannerapi.TripResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
 	_ = tripID
 	_ = idempotencyKey
-	return &gen.CancelTripClientResponse{}, nil
+	return nil, nil
 }
 
-func (f *fakePlannerAPI) ListMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.ListMembersParams) (*gen.ListMembersClientResponse, error) {
+func (f *fakePlannerAPI) ListMembers(ctx context.Context, baseURL string, bearerToken string, includeInactive bool) (*outplannerapi.MemberList, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
-	_ = params
-	return &gen.ListMembersClientResponse{}, nil
+	_ = includeInactive
+	return nil, nil
 }
 
-func (f *fakePlannerAPI) UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.UpdateMyMemberProfileJSONRequestBody) (*gen.UpdateMyMemberProfileClientResponse, error) {
+func (f *fakePlannerAPI) UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.UpdateMemberRequest) (*outplannerapi.MemberResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
 	f.memberCalls++
 	f.lastMemberIdem = idempotencyKey
 	f.lastMemberReq = req
-	return &gen.UpdateMyMemberProfileClientResponse{JSON200: &gen.UpdateMyMemberProfileResponse{
-		Member: gen.MemberProfile{MemberId: "m1", DisplayName: "n", Email: "a@example.com"},
-	}}, nil
+	return &outplannerapi.MemberResult{
+		Member: outplannerapi.Member{MemberID: "m1", DisplayName: "n", Email: "a@example.com"},
+	}, nil
 }
 
 func writeTempFile(t *testing.T, name string, content string) string {
@@ -272,11 +271,11 @@ func TestTripUpdate_Prompt_MapsToRequestStruct(t *testing.T) {
 		}
 		t.Fatalf("description: %#v", *api.lastUpdateReq.Description)
 	}
-	if api.lastUpdateReq.ArtifactIds == nil || len(*api.lastUpdateReq.ArtifactIds) != 2 {
-		t.Fatalf("artifactIds: %#v", api.lastUpdateReq.ArtifactIds)
+	if api.lastUpdateReq.ArtifactIDs == nil || len(*api.lastUpdateReq.ArtifactIDs) != 2 {
+		t.Fatalf("artifactIds: %#v", api.lastUpdateReq.ArtifactIDs)
 	}
-	if (*api.lastUpdateReq.ArtifactIds)[0] != "id1" || (*api.lastUpdateReq.ArtifactIds)[1] != "id2" {
-		t.Fatalf("artifactIds: %#v", *api.lastUpdateReq.ArtifactIds)
+	if (*api.lastUpdateReq.ArtifactIDs)[0] != "id1" || (*api.lastUpdateReq.ArtifactIDs)[1] != "id2" {
+		t.Fatalf("artifactIds: %#v", *api.lastUpdateReq.ArtifactIDs)
 	}
 }
 
@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"testing"
 
-	gen "github.com/Overland-East-Bay/trip-planner-cli/internal/gen/plannerapi"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
 	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
 )
@@ -18,62 +17,62 @@ type fakeTripOrganizerAPI struct {
 	lastAddIdem    string
 	lastRemoveIdem string
 
-	lastAddTripID    gen.TripId
-	lastRemoveTripID gen.TripId
-	lastAddReq       gen.AddTripOrganizerJSONRequestBody
-	lastRemoveMember gen.MemberId
+	lastAddTripID    string
+	lastRemoveTripID string
+	lastAddReq       outplannerapi.AddOrganizerRequest
+	lastRemoveMember string
 }
 
 var _ outplannerapi.Client = (*fakeTripOrganizerAPI)(nil)
 
-func (f *fakeTripOrganizerAPI) ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*gen.ListVisibleTripsForMemberClientResponse, error) {
+func (f *fakeTripOrganizerAPI) ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.TripList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeTripOrganizerAPI) ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*gen.ListMyDraftTripsClientResponse, error) {
+func (f *fakeTripOrganizerAPI) ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.TripList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeTripOrganizerAPI) GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripDetailsClientResponse, error) {
+func (f *fakeTripOrganizerAPI) GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeTripOrganizerAPI) CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.CreateTripDraftJSONRequestBody) (*gen.CreateTripDraftClientResponse, error) {
+func (f *fakeTripOrganizerAPI) CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.CreateTripDraftRequest) (*outplannerapi.TripCreatedResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeTripOrganizerAPI) UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.UpdateTripJSONRequestBody) (*gen.UpdateTripClientResponse, error) {
+func (f *fakeTripOrganizerAPI) UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.UpdateTripRequest) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeTripOrganizerAPI) SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetTripDraftVisibilityJSONRequestBody) (*gen.SetTripDraftVisibilityClientResponse, error) {
+func (f *fakeTripOrganizerAPI) SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.SetDraftVisibilityRequest) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeTripOrganizerAPI) PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.PublishTripClientResponse, error) {
+func (f *fakeTripOrganizerAPI) PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.PublishResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeTripOrganizerAPI) CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey *string) (*gen.CancelTripClientResponse, error) {
+func (f *fakeTripOrganizerAPI) CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey *string) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeTripOrganizerAPI) ListMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.ListMembersParams) (*gen.ListMembersClientResponse, error) {
+func (f *fakeTripOrganizerAPI) ListMembers(ctx context.Context, baseURL string, bearerToken string, includeInactive bool) (*outplannerapi.MemberList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeTripOrganizerAPI) UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.UpdateMyMemberProfileJSONRequestBody) (*gen.UpdateMyMemberProfileClientResponse, error) {
+func (f *fakeTripOrganizerAPI) UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.UpdateMemberRequest) (*outplannerapi.MemberResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripOrganizerAPI) SearchMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.SearchMembersParams) (*gen.SearchMembersClientResponse, error) {
+func (f *fakeTripOrganizerAPI) SearchMembers(ctx context.Context, baseURL string, bearerToken string, query string) (*outplannerapi.MemberList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripOrganizerAPI) GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*gen.GetMyMemberProfileClientResponse, error) {
+func (f *fakeTripOrganizerAPI) GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.MemberResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripOrganizerAPI) DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.DeleteMyMemberAccountJSONRequestBody) (*gen.DeleteMyMemberAccountClientResponse, error) {
+func (f *fakeTripOrganizerAPI) DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.DeleteMemberRequest) (*outplannerapi.MemberDeleted, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripOrganizerAPI) CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req gen.CreateMyMemberJSONRequestBody) (*gen.CreateMyMemberClientResponse, error) {
+func (f *fakeTripOrganizerAPI) CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req outplannerapi.CreateMemberRequest) (*outplannerapi.MemberResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripOrganizerAPI) AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.AddTripOrganizerJSONRequestBody) (*gen.AddTripOrganizerClientResponse, error) {
+func (f *fakeTripOrganizerAPI) AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.AddOrganizerRequest) (*outplannerapi.TripResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -81,10 +80,10 @@ func (f *fakeTripOrganizerAPI) AddTripOrganizer(ctx context.Context, baseURL str
 	f.lastAddTripID = tripID
 	f.lastAddIdem = idempotencyKey
 	f.lastAddReq = req
-	return &gen.AddTripOrganizerClientResponse{JSON200: &gen.TripResponse{}}, nil
+	return &outplannerapi.TripResult{}, nil
 }
 
-func (f *fakeTripOrganizerAPI) RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, memberID gen.MemberId, idempotencyKey string) (*gen.RemoveTripOrganizerClientResponse, error) {
+func (f *fakeTripOrganizerAPI) RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, memberID string, idempotencyKey string) (*outplannerapi.TripResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -92,10 +91,10 @@ func (f *fakeTripOrganizerAPI) RemoveTripOrganizer(ctx context.Context, baseURL
 	f.lastRemoveTripID = tripID
 	f.lastRemoveMember = memberID
 	f.lastRemoveIdem = idempotencyKey
-	return &gen.RemoveTripOrganizerClientResponse{JSON200: &gen.TripResponse{}}, nil
+	return &outplannerapi.TripResult{}, nil
 }
 
-func (f *fakeTripOrganizerAPI) SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetMyRSVPJSONRequestBody) (*gen.SetMyRSVPClientResponse, error) {
+func (f *fakeTripOrganizerAPI) SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.SetMyRSVPRequest) (*outplannerapi.RSVPResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -105,7 +104,7 @@ func (f *fakeTripOrganizerAPI) SetMyRSVP(ctx context.Context, baseURL string, be
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripOrganizerAPI) GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetMyRSVPForTripClientResponse, error) {
+func (f *fakeTripOrganizerAPI) GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.RSVPResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -113,7 +112,7 @@ func (f *fakeTripOrganizerAPI) GetMyRSVPForTrip(ctx context.Context, baseURL str
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripOrganizerAPI) GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripRSVPSummaryClientResponse, error) {
+func (f *fakeTripOrganizerAPI) GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.RSVPSummaryResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -135,8 +134,8 @@ func TestTripOrganizerAdd_AutoGeneratesIdempotency_JSONMetaAndCall(t *testing.T)
 	if api.addCalls != 1 {
 		t.Fatalf("expected 1 call, got %d", api.addCalls)
 	}
-	if api.lastAddTripID != "t1" || api.lastAddReq.MemberId != "m1" {
-		t.Fatalf("call args: trip=%q member=%q", api.lastAddTripID, api.lastAddReq.MemberId)
+	if api.lastAddTripID != "t1" || api.lastAddReq.MemberID != "m1" {
+		t.Fatalf("call args: trip=%q member=%q", api.lastAddTripID, api.lastAddReq.MemberID)
 	}
 	if api.lastAddIdem == "" {
 		t.Fatalf("expected generated idempotency key")
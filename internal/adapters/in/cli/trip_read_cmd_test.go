@@ -4,9 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
-	gen "github.com/Overland-East-Bay/trip-planner-cli/internal/gen/plannerapi"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/config"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
 	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
@@ -17,47 +18,39 @@ type fakeTripReadAPI struct {
 	draftsCalls int
 	getCalls    int
 
-	listTrips   []gen.TripSummary
-	draftsTrips []gen.TripSummary
-	getTrip     *gen.TripResponse
+	listTrips   []outplannerapi.TripSummary
+	draftsTrips []outplannerapi.TripSummary
+	getTrip     *outplannerapi.TripResult
 }
 
 var _ outplannerapi.Client = (*fakeTripReadAPI)(nil)
 
-func (f *fakeTripReadAPI) ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*gen.ListVisibleTripsForMemberClientResponse, error) {
+func (f *fakeTripReadAPI) ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.TripList, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
 	f.listCalls++
-	return &gen.ListVisibleTripsForMemberClientResponse{
-		JSON200: &struct {
-			Trips []gen.TripSummary `json:"trips"`
-		}{Trips: f.listTrips},
-	}, nil
+	return &outplannerapi.TripList{Trips: f.listTrips}, nil
 }
 
-func (f *fakeTripReadAPI) ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*gen.ListMyDraftTripsClientResponse, error) {
+func (f *fakeTripReadAPI) ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.TripList, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
 	f.draftsCalls++
-	return &gen.ListMyDraftTripsClientResponse{
-		JSON200: &struct {
-			Trips []gen.TripSummary `json:"trips"`
-		}{Trips: f.draftsTrips},
-	}, nil
+	return &outplannerapi.TripList{Trips: f.draftsTrips}, nil
 }
 
-func (f *fakeTripReadAPI) GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripDetailsClientResponse, error) {
+func (f *fakeTripReadAPI) GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.TripResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
 	_ = tripID
 	f.getCalls++
-	return &gen.GetTripDetailsClientResponse{JSON200: f.getTrip}, nil
+	return f.getTrip, nil
 }
 
-func (f *fakeTripReadAPI) SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetTripDraftVisibilityJSONRequestBody) (*gen.SetTripDraftVisibilityClientResponse, error) {
+func (f *fakeTripReadAPI) SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.SetDraftVisibilityRequest) (*outplannerapi.TripResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -67,7 +60,7 @@ func (f *fakeTripReadAPI) SetTripDraftVisibility(ctx context.Context, baseURL st
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripReadAPI) PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.PublishTripClientResponse, error) {
+func (f *fakeTripReadAPI) PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.PublishResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -75,7 +68,7 @@ func (f *fakeTripReadAPI) PublishTrip(ctx context.Context, baseURL string, beare
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripReadAPI) AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.AddTripOrganizerJSONRequestBody) (*gen.AddTripOrganizerClientResponse, error) {
+func (f *fakeTripReadAPI) AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.AddOrganizerRequest) (*outplannerapi.TripResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -85,7 +78,7 @@ func (f *fakeTripReadAPI) AddTripOrganizer(ctx context.Context, baseURL string,
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripReadAPI) RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, memberID gen.MemberId, idempotencyKey string) (*gen.RemoveTripOrganizerClientResponse, error) {
+func (f *fakeTripReadAPI) RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, memberID string, idempotencyKey string) (*outplannerapi.TripResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -95,26 +88,26 @@ func (f *fakeTripReadAPI) RemoveTripOrganizer(ctx context.Context, baseURL strin
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripReadAPI) SearchMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.SearchMembersParams) (*gen.SearchMembersClientResponse, error) {
+func (f *fakeTripReadAPI) SearchMembers(ctx context.Context, baseURL string, bearerToken string, query string) (*outplannerapi.MemberList, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
-	_ = params
+	_ = query
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripReadAPI) GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*gen.GetMyMemberProfileClientResponse, error) {
+func (f *fakeTripReadAPI) GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.MemberResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripReadAPI) DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.DeleteMyMemberAccountJSONRequestBody) (*gen.DeleteMyMemberAccountClientResponse, error) {
+func (f *fakeTripReadAPI) DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.DeleteMemberRequest) (*outplannerapi.MemberDeleted, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripReadAPI) CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req gen.CreateMyMemberJSONRequestBody) (*gen.CreateMyMemberClientResponse, error) {
+func (f *fakeTripReadAPI) CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req outplannerapi.CreateMemberRequest) (*outplannerapi.MemberResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -122,7 +115,7 @@ func (f *fakeTripReadAPI) CreateMyMember(ctx context.Context, baseURL string, be
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripReadAPI) SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetMyRSVPJSONRequestBody) (*gen.SetMyRSVPClientResponse, error) {
+func (f *fakeTripReadAPI) SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.SetMyRSVPRequest) (*outplannerapi.RSVPResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -132,7 +125,7 @@ func (f *fakeTripReadAPI) SetMyRSVP(ctx context.Context, baseURL string, bearerT
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripReadAPI) GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetMyRSVPForTripClientResponse, error) {
+func (f *fakeTripReadAPI) GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.RSVPResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -140,7 +133,7 @@ func (f *fakeTripReadAPI) GetMyRSVPForTrip(ctx context.Context, baseURL string,
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripReadAPI) GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripRSVPSummaryClientResponse, error) {
+func (f *fakeTripReadAPI) GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.RSVPSummaryResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -149,7 +142,7 @@ func (f *fakeTripReadAPI) GetTripRSVPSummary(ctx context.Context, baseURL string
 }
 
 // Unused for these tests, but required by the interface.
-func (f *fakeTripReadAPI) CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.CreateTripDraftJSONRequestBody) (*gen.CreateTripDraftClientResponse, error) {
+func (f *fakeTripReadAPI) CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.CreateTripDraftRequest) (*outplannerapi.TripCreatedResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -157,7 +150,7 @@ func (f *fakeTripReadAPI) CreateTripDraft(ctx context.Context, baseURL string, b
 	_ = req
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeTripReadAPI) UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.UpdateTripJSONRequestBody) (*gen.UpdateTripClientResponse, error) {
+func (f *fakeTripReadAPI) UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.UpdateTripRequest) (*outplannerapi.TripResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -166,7 +159,7 @@ func (f *fakeTripReadAPI) UpdateTrip(ctx context.Context, baseURL string, bearer
 	_ = req
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeTripReadAPI) CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey *string) (*gen.CancelTripClientResponse, error) {
+func (f *fakeTripReadAPI) CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey *string) (*outplannerapi.TripResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -174,14 +167,14 @@ func (f *fakeTripReadAPI) CancelTrip(ctx context.Context, baseURL string, bearer
 	_ = idempotencyKey
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeTripReadAPI) ListMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.ListMembersParams) (*gen.ListMembersClientResponse, error) {
+func (f *fakeTripReadAPI) ListMembers(ctx context.Context, baseURL string, bearerToken string, includeInactive bool) (*outplannerapi.MemberList, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
-	_ = params
+	_ = includeInactive
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeTripReadAPI) UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.UpdateMyMemberProfileJSONRequestBody) (*gen.UpdateMyMemberProfileClientResponse, error) {
+func (f *fakeTripReadAPI) UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.UpdateMemberRequest) (*outplannerapi.MemberResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -192,8 +185,14 @@ func (f *fakeTripReadAPI) UpdateMyMemberProfile(ctx context.Context, baseURL str
 
 func TestTripList_TableOutput(t *testing.T) {
 	name := "Trip"
+	start := outplannerapi.Date{Time: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)}
+	end := outplannerapi.Date{Time: time.Date(2026, 6, 3, 0, 0, 0, 0, time.UTC)}
+	attending, capacity := 3, 12
 	api := &fakeTripReadAPI{
-		listTrips: []gen.TripSummary{{TripId: "t1", Status: "PUBLISHED", Name: &name}},
+		listTrips: []outplannerapi.TripSummary{
+			{TripID: "t1", Status: "PUBLISHED", Name: &name, StartDate: &start, EndDate: &end, AttendingRigs: &attending, CapacityRigs: &capacity},
+			{TripID: "t-long", Status: "CANCELED"},
+		},
 	}
 	store := &memStore{path: "/x", doc: baseDoc(t)}
 	stdout := &bytes.Buffer{}
@@ -207,18 +206,19 @@ func TestTripList_TableOutput(t *testing.T) {
 	if api.listCalls != 1 {
 		t.Fatalf("expected 1 call, got %d", api.listCalls)
 	}
-	if !bytes.Contains(stdout.Bytes(), []byte("TRIP_ID\tSTATUS\tNAME\n")) {
-		t.Fatalf("stdout: %q", stdout.String())
-	}
-	if !bytes.Contains(stdout.Bytes(), []byte("t1\tPUBLISHED\tTrip\n")) {
-		t.Fatalf("stdout: %q", stdout.String())
+	want := "" +
+		"TRIP_ID  STATUS     START_DATE  END_DATE    ATTENDING_RIGS  CAPACITY_RIGS  NAME\n" +
+		"t1       PUBLISHED  2026-06-01  2026-06-03  3               12             Trip\n" +
+		"t-long   CANCELED   -           -           -               -\n"
+	if stdout.String() != want {
+		t.Fatalf("stdout:\n%s\nwant:\n%s", stdout.String(), want)
 	}
 }
 
 func TestTripList_JSONOutput(t *testing.T) {
 	name := "Trip"
 	api := &fakeTripReadAPI{
-		listTrips: []gen.TripSummary{{TripId: "t1", Status: "PUBLISHED", Name: &name}},
+		listTrips: []outplannerapi.TripSummary{{TripID: "t1", Status: "PUBLISHED", Name: &name}},
 	}
 	store := &memStore{path: "/x", doc: baseDoc(t)}
 	stdout := &bytes.Buffer{}
@@ -242,7 +242,7 @@ func TestTripList_JSONOutput(t *testing.T) {
 func TestTripDrafts_TableOutput(t *testing.T) {
 	name := "Draft"
 	api := &fakeTripReadAPI{
-		draftsTrips: []gen.TripSummary{{TripId: "t1", Status: "DRAFT", Name: &name}},
+		draftsTrips: []outplannerapi.TripSummary{{TripID: "t1", Status: "DRAFT", Name: &name}},
 	}
 	store := &memStore{path: "/x", doc: baseDoc(t)}
 	stdout := &bytes.Buffer{}
@@ -256,15 +256,38 @@ func TestTripDrafts_TableOutput(t *testing.T) {
 	if api.draftsCalls != 1 {
 		t.Fatalf("expected 1 call, got %d", api.draftsCalls)
 	}
-	if !bytes.Contains(stdout.Bytes(), []byte("t1\tDRAFT\tDraft\n")) {
+	if !strings.Contains(stdout.String(), "DRAFT") || !strings.HasSuffix(stdout.String(), "  Draft\n") {
 		t.Fatalf("stdout: %q", stdout.String())
 	}
 }
 
+func TestTripDrafts_WideShowsDraftVisibility(t *testing.T) {
+	name := "Draft"
+	vis := outplannerapi.DraftVisibilityPrivate
+	api := &fakeTripReadAPI{
+		draftsTrips: []outplannerapi.TripSummary{{TripID: "t1", Status: "DRAFT", Name: &name, DraftVisibility: &vis}},
+	}
+	run := func(args ...string) string {
+		stdout := &bytes.Buffer{}
+		cmd := NewRootCmd(RootDeps{ConfigStore: &memStore{path: "/x", doc: baseDoc(t)}, PlannerAPI: api, Stdout: stdout, Stderr: &bytes.Buffer{}})
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+		return stdout.String()
+	}
+	if out := run("trip", "drafts"); strings.Contains(out, "DRAFT_VISIBILITY") {
+		t.Fatalf("visibility is a --wide column: %q", out)
+	}
+	if out := run("--wide", "trip", "drafts"); !strings.Contains(out, "DRAFT_VISIBILITY") || !strings.Contains(out, "PRIVATE") {
+		t.Fatalf("stdout: %q", out)
+	}
+}
+
 func TestTripDrafts_JSONOutput(t *testing.T) {
 	name := "Draft"
 	api := &fakeTripReadAPI{
-		draftsTrips: []gen.TripSummary{{TripId: "t1", Status: "DRAFT", Name: &name}},
+		draftsTrips: []outplannerapi.TripSummary{{TripID: "t1", Status: "DRAFT", Name: &name}},
 	}
 	store := &memStore{path: "/x", doc: baseDoc(t)}
 	stdout := &bytes.Buffer{}
@@ -288,7 +311,7 @@ func TestTripDrafts_JSONOutput(t *testing.T) {
 func TestTripGet_TableOutput(t *testing.T) {
 	name := "Trip"
 	api := &fakeTripReadAPI{
-		getTrip: &gen.TripResponse{Trip: gen.TripDetails{TripId: "t1", Status: "PUBLISHED", Name: &name}},
+		getTrip: &outplannerapi.TripResult{Trip: outplannerapi.Trip{TripID: "t1", Status: "PUBLISHED", Name: &name}},
 	}
 	store := &memStore{path: "/x", doc: baseDoc(t)}
 	stdout := &bytes.Buffer{}
@@ -302,15 +325,110 @@ func TestTripGet_TableOutput(t *testing.T) {
 	if api.getCalls != 1 {
 		t.Fatalf("expected 1 call, got %d", api.getCalls)
 	}
-	if !bytes.Contains(stdout.Bytes(), []byte("TripId: t1")) {
+	if !strings.HasPrefix(stdout.String(), "TripId:             t1\nStatus:             PUBLISHED\nName:               Trip\n") {
 		t.Fatalf("stdout: %q", stdout.String())
 	}
+	if strings.Contains(stdout.String(), "missing for publish") {
+		t.Fatalf("published trips have no publish markers: %q", stdout.String())
+	}
+}
+
+func TestTripGet_TableOutputShowsEveryFieldAndPublishGaps(t *testing.T) {
+	name, desc, comms := "Rubicon", "Line one\n\n  indented line\n", "GMRS ch 16"
+	capacity, lat, lng := 12, 38.97, -120.1
+	visibility := outplannerapi.DraftVisibilityPublic
+	api := &fakeTripReadAPI{getTrip: &outplannerapi.TripResult{Trip: outplannerapi.Trip{
+		TripID:                "t1",
+		Status:                outplannerapi.TripStatusDraft,
+		DraftVisibility:       &visibility,
+		Name:                  &name,
+		CapacityRigs:          &capacity,
+		Description:           &desc,
+		CommsRequirementsText: &comms,
+		MeetingLocation: &outplannerapi.Location{
+			Label:             "Loon Lake",
+			LatitudeLongitude: &outplannerapi.LatLng{Latitude: &lat, Longitude: &lng},
+		},
+		Organizers: []outplannerapi.MemberSummary{{MemberID: "m1", DisplayName: "Alice", Email: "alice@example.com"}},
+		Artifacts:  []outplannerapi.Artifact{{ArtifactID: "a1", Title: "Route", Type: outplannerapi.ArtifactGPX, URL: "https://x/r.gpx"}},
+	}}}
+	stdout := &bytes.Buffer{}
+	cmd := NewRootCmd(RootDeps{ConfigStore: &memStore{path: "/x", doc: baseDoc(t)}, PlannerAPI: api, Stdout: stdout, Stderr: &bytes.Buffer{}})
+	cmd.SetArgs([]string{"trip", "get", "t1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+
+	want := "" +
+		"TripId:             t1\n" +
+		"Status:             DRAFT\n" +
+		"DraftVisibility:    PUBLIC\n" +
+		"Name:               Rubicon\n" +
+		"StartDate:          - (missing for publish)\n" +
+		"EndDate:            - (missing for publish)\n" +
+		"CapacityRigs:       12\n" +
+		"MyRsvp:             -\n" +
+		"RsvpActionsEnabled: false\n" +
+		"\n" +
+		"MeetingLocation:\n" +
+		"  Label:             Loon Lake\n" +
+		"  Address:           -\n" +
+		"  LatitudeLongitude: 38.97, -120.1\n" +
+		"\n" +
+		"Description:\n" +
+		"Line one\n" +
+		"\n" +
+		"  indented line\n" +
+		"\n" +
+		"DifficultyText: -\n" +
+		"\n" +
+		"CommsRequirementsText:\n" +
+		"GMRS ch 16\n" +
+		"\n" +
+		"RecommendedRequirementsText: -\n" +
+		"\n" +
+		"Organizers:\n" +
+		"  MEMBER_ID  DISPLAY_NAME  EMAIL\n" +
+		"  m1         Alice         alice@example.com\n" +
+		"\n" +
+		"Artifacts:\n" +
+		"  TITLE  TYPE  URL\n" +
+		"  Route  GPX   https://x/r.gpx\n" +
+		"\n" +
+		"Missing for publish: startDate, endDate\n"
+	if got := stdout.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTripGet_TableOutputRSVPHeading(t *testing.T) {
+	capacity := 4
+	for _, tc := range []struct {
+		summary outplannerapi.RSVPSummary
+		want    string
+	}{
+		{outplannerapi.RSVPSummary{AttendingRigs: 1, CapacityRigs: &capacity, AttendingMembers: []outplannerapi.MemberSummary{{MemberID: "m1", DisplayName: "Alice"}}}, "\nRSVPs (1 attending rig of 4):\n"},
+		{outplannerapi.RSVPSummary{AttendingRigs: 2, AttendingMembers: []outplannerapi.MemberSummary{{MemberID: "m1"}, {MemberID: "m2"}}}, "\nRSVPs (2 attending rigs):\n"},
+		{outplannerapi.RSVPSummary{}, "\nRSVPs (0 attending rigs): no responses\n"},
+	} {
+		summary := tc.summary
+		api := &fakeTripReadAPI{getTrip: &outplannerapi.TripResult{Trip: outplannerapi.Trip{TripID: "t1", Status: outplannerapi.TripStatusPublished, RSVPSummary: &summary}}}
+		stdout := &bytes.Buffer{}
+		cmd := NewRootCmd(RootDeps{ConfigStore: &memStore{path: "/x", doc: baseDoc(t)}, PlannerAPI: api, Stdout: stdout, Stderr: &bytes.Buffer{}})
+		cmd.SetArgs([]string{"trip", "get", "t1"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+		if !strings.Contains(stdout.String(), tc.want) {
+			t.Errorf("want %q in:\n%s", tc.want, stdout.String())
+		}
+	}
 }
 
 func TestTripGet_JSONOutput(t *testing.T) {
 	name := "Trip"
 	api := &fakeTripReadAPI{
-		getTrip: &gen.TripResponse{Trip: gen.TripDetails{TripId: "t1", Status: "PUBLISHED", Name: &name}},
+		getTrip: &outplannerapi.TripResult{Trip: outplannerapi.Trip{TripID: "t1", Status: "PUBLISHED", Name: &name}},
 	}
 	store := &memStore{path: "/x", doc: baseDoc(t)}
 	stdout := &bytes.Buffer{}
@@ -6,8 +6,8 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
-	gen "github.com/Overland-East-Bay/trip-planner-cli/internal/gen/plannerapi"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
 	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
 )
@@ -18,68 +18,74 @@ type fakeRSVPAPI struct {
 	summaryCalls int
 
 	lastSetIdem string
-	lastSetReq  gen.SetMyRSVPRequest
+	lastSetReq  outplannerapi.SetMyRSVPRequest
 
-	getResp     *gen.GetMyRSVPForTripClientResponse
-	summaryResp *gen.GetTripRSVPSummaryClientResponse
+	// getNoBody/summaryNoBody make the reads succeed without a response body.
+	getNoBody     bool
+	summaryNoBody bool
+
+	// requestID is recorded into the response meta, as the HTTP adapter does.
+	requestID string
+	// cachedAt, when set, marks the response as served from the offline cache.
+	cachedAt time.Time
 }
 
 var _ outplannerapi.Client = (*fakeRSVPAPI)(nil)
 
-func (f *fakeRSVPAPI) ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*gen.ListVisibleTripsForMemberClientResponse, error) {
+func (f *fakeRSVPAPI) ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.TripList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeRSVPAPI) ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*gen.ListMyDraftTripsClientResponse, error) {
+func (f *fakeRSVPAPI) ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.TripList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeRSVPAPI) GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripDetailsClientResponse, error) {
+func (f *fakeRSVPAPI) GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeRSVPAPI) CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.CreateTripDraftJSONRequestBody) (*gen.CreateTripDraftClientResponse, error) {
+func (f *fakeRSVPAPI) CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.CreateTripDraftRequest) (*outplannerapi.TripCreatedResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeRSVPAPI) UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.UpdateTripJSONRequestBody) (*gen.UpdateTripClientResponse, error) {
+func (f *fakeRSVPAPI) UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.UpdateTripRequest) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeRSVPAPI) SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetTripDraftVisibilityJSONRequestBody) (*gen.SetTripDraftVisibilityClientResponse, error) {
+func (f *fakeRSVPAPI) SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.SetDraftVisibilityRequest) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeRSVPAPI) PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.PublishTripClientResponse, error) {
+func (f *fakeRSVPAPI) PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.PublishResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeRSVPAPI) AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.AddTripOrganizerJSONRequestBody) (*gen.AddTripOrganizerClientResponse, error) {
+func (f *fakeRSVPAPI) AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.AddOrganizerRequest) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeRSVPAPI) RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, memberID gen.MemberId, idempotencyKey string) (*gen.RemoveTripOrganizerClientResponse, error) {
+func (f *fakeRSVPAPI) RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, memberID string, idempotencyKey string) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeRSVPAPI) CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey *string) (*gen.CancelTripClientResponse, error) {
+func (f *fakeRSVPAPI) CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey *string) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeRSVPAPI) ListMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.ListMembersParams) (*gen.ListMembersClientResponse, error) {
+func (f *fakeRSVPAPI) ListMembers(ctx context.Context, baseURL string, bearerToken string, includeInactive bool) (*outplannerapi.MemberList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeRSVPAPI) UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.UpdateMyMemberProfileJSONRequestBody) (*gen.UpdateMyMemberProfileClientResponse, error) {
+func (f *fakeRSVPAPI) UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.UpdateMemberRequest) (*outplannerapi.MemberResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeRSVPAPI) SearchMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.SearchMembersParams) (*gen.SearchMembersClientResponse, error) {
+func (f *fakeRSVPAPI) SearchMembers(ctx context.Context, baseURL string, bearerToken string, query string) (*outplannerapi.MemberList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeRSVPAPI) GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*gen.GetMyMemberProfileClientResponse, error) {
+func (f *fakeRSVPAPI) GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.MemberResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeRSVPAPI) DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.DeleteMyMemberAccountJSONRequestBody) (*gen.DeleteMyMemberAccountClientResponse, error) {
+func (f *fakeRSVPAPI) DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.DeleteMemberRequest) (*outplannerapi.MemberDeleted, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeRSVPAPI) CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req gen.CreateMyMemberJSONRequestBody) (*gen.CreateMyMemberClientResponse, error) {
+func (f *fakeRSVPAPI) CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req outplannerapi.CreateMemberRequest) (*outplannerapi.MemberResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeRSVPAPI) SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetMyRSVPJSONRequestBody) (*gen.SetMyRSVPClientResponse, error) {
+func (f *fakeRSVPAPI) SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.SetMyRSVPRequest) (*outplannerapi.RSVPResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -87,35 +93,36 @@ func (f *fakeRSVPAPI) SetMyRSVP(ctx context.Context, baseURL string, bearerToken
 	f.setCalls++
 	f.lastSetIdem = idempotencyKey
 	f.lastSetReq = req
-	return &gen.SetMyRSVPClientResponse{JSON200: &gen.SetMyRSVPResponse{MyRsvp: gen.MyRSVP{Response: req.Response}}}, nil
+	return &outplannerapi.RSVPResult{MyRSVP: outplannerapi.RSVP{Response: req.Response}}, nil
 }
 
-func (f *fakeRSVPAPI) GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetMyRSVPForTripClientResponse, error) {
+func (f *fakeRSVPAPI) GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.RSVPResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
 	_ = tripID
 	f.getCalls++
-	if f.getResp != nil {
-		return f.getResp, nil
+	if m := outplannerapi.ResponseMetaFrom(ctx); m != nil {
+		m.RequestID = f.requestID
+		m.Cached = !f.cachedAt.IsZero()
+		m.FetchedAt = f.cachedAt
+	}
+	if f.getNoBody {
+		return nil, nil
 	}
-	return &gen.GetMyRSVPForTripClientResponse{JSON200: &struct {
-		MyRsvp gen.MyRSVP `json:"myRsvp"`
-	}{MyRsvp: gen.MyRSVP{Response: gen.YES}}}, nil
+	return &outplannerapi.RSVPResult{MyRSVP: outplannerapi.RSVP{Response: outplannerapi.RSVPYes}}, nil
 }
 
-func (f *fakeRSVPAPI) GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripRSVPSummaryClientResponse, error) {
+func (f *fakeRSVPAPI) GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.RSVPSummaryResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
 	_ = tripID
 	f.summaryCalls++
-	if f.summaryResp != nil {
-		return f.summaryResp, nil
+	if f.summaryNoBody {
+		return nil, nil
 	}
-	return &gen.GetTripRSVPSummaryClientResponse{JSON200: &struct {
-		RsvpSummary gen.TripRSVPSummary `json:"rsvpSummary"`
-	}{RsvpSummary: gen.TripRSVPSummary{AttendingRigs: 1}}}, nil
+	return &outplannerapi.RSVPSummaryResult{RSVPSummary: outplannerapi.RSVPSummary{AttendingRigs: 1}}, nil
 }
 
 func TestTripRSVPSet_MutuallyExclusiveFlags(t *testing.T) {
@@ -175,7 +182,7 @@ func TestTripRSVPSet_AutoGeneratesIdempotency_JSONMeta(t *testing.T) {
 	if api.lastSetIdem == "" {
 		t.Fatalf("expected generated idempotency key")
 	}
-	if api.lastSetReq.Response != gen.YES {
+	if api.lastSetReq.Response != outplannerapi.RSVPYes {
 		t.Fatalf("response: %q", api.lastSetReq.Response)
 	}
 
@@ -229,7 +236,7 @@ func TestTripRSVPSet_Unset_SendsUNSET(t *testing.T) {
 	if api.lastSetIdem != "k1" {
 		t.Fatalf("idempotency: %q", api.lastSetIdem)
 	}
-	if api.lastSetReq.Response != gen.UNSET {
+	if api.lastSetReq.Response != outplannerapi.RSVPUnset {
 		t.Fatalf("response: %q", api.lastSetReq.Response)
 	}
 }
@@ -287,7 +294,7 @@ func TestTripRSVPGet_TableOutput(t *testing.T) {
 	if err := cmd.Execute(); err != nil {
 		t.Fatalf("execute: %v", err)
 	}
-	if want := "TRIP_ID\tRESPONSE\nt1\tYES\n"; stdout.String() != want {
+	if want := "TRIP_ID  RESPONSE\nt1       YES\n"; stdout.String() != want {
 		t.Fatalf("stdout: %q", stdout.String())
 	}
 }
@@ -303,7 +310,7 @@ func TestTripRSVPSummary_TableOutput(t *testing.T) {
 	if err := cmd.Execute(); err != nil {
 		t.Fatalf("execute: %v", err)
 	}
-	if want := "TRIP_ID\tATTENDING_RIGS\tATTENDING_MEMBERS\tNOT_ATTENDING_MEMBERS\nt1\t1\t0\t0\n"; stdout.String() != want {
+	if want := "TRIP_ID  ATTENDING_RIGS  ATTENDING_MEMBERS  NOT_ATTENDING_MEMBERS\nt1       1               0                  0\n"; stdout.String() != want {
 		t.Fatalf("stdout: %q", stdout.String())
 	}
 }
@@ -312,7 +319,7 @@ func TestTripRSVPGet_TableOutput_JSON200Nil_PrintsOK(t *testing.T) {
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
 	store := &memStore{path: "/x", doc: baseDoc(t)}
-	api := &fakeRSVPAPI{getResp: &gen.GetMyRSVPForTripClientResponse{}}
+	api := &fakeRSVPAPI{getNoBody: true}
 
 	cmd := NewRootCmd(RootDeps{ConfigStore: store, PlannerAPI: api, Stdout: stdout, Stderr: stderr})
 	cmd.SetArgs([]string{"trip", "rsvp", "get", "t1"})
@@ -328,7 +335,7 @@ func TestTripRSVPSummary_TableOutput_JSON200Nil_PrintsOK(t *testing.T) {
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
 	store := &memStore{path: "/x", doc: baseDoc(t)}
-	api := &fakeRSVPAPI{summaryResp: &gen.GetTripRSVPSummaryClientResponse{}}
+	api := &fakeRSVPAPI{summaryNoBody: true}
 
 	cmd := NewRootCmd(RootDeps{ConfigStore: store, PlannerAPI: api, Stdout: stdout, Stderr: stderr})
 	cmd.SetArgs([]string{"trip", "rsvp", "summary", "t1"})
@@ -339,3 +346,98 @@ func TestTripRSVPSummary_TableOutput_JSON200Nil_PrintsOK(t *testing.T) {
 		t.Fatalf("stdout: %q", stdout.String())
 	}
 }
+
+func TestTripRSVPGet_JSONMetaIncludesRequestID(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	store := &memStore{path: "/x", doc: baseDoc(t)}
+	api := &fakeRSVPAPI{requestID: "req-abc"}
+
+	cmd := NewRootCmd(RootDeps{ConfigStore: store, PlannerAPI: api, Stdout: stdout, Stderr: stderr})
+	cmd.SetArgs([]string{"--output", "json", "trip", "rsvp", "get", "t1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	var env map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &env); err != nil {
+		t.Fatalf("stdout not json: %v\n%s", err, stdout.String())
+	}
+	meta, _ := env["meta"].(map[string]any)
+	if meta == nil || meta["requestId"] != "req-abc" {
+		t.Fatalf("meta: %#v", meta)
+	}
+	if stderr.Len() != 0 {
+		t.Fatalf("expected no stderr in json mode, got %q", stderr.String())
+	}
+}
+
+func TestTripRSVPGet_VerboseTable_PrintsRequestIDToStderr(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	store := &memStore{path: "/x", doc: baseDoc(t)}
+	api := &fakeRSVPAPI{requestID: "req-abc"}
+
+	cmd := NewRootCmd(RootDeps{ConfigStore: store, PlannerAPI: api, Stdout: stdout, Stderr: stderr})
+	cmd.SetArgs([]string{"--verbose", "trip", "rsvp", "get", "t1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if !strings.Contains(stderr.String(), "Request-Id: req-abc") {
+		t.Fatalf("expected request id on stderr, got %q", stderr.String())
+	}
+	if strings.Contains(stdout.String(), "req-abc") {
+		t.Fatalf("request id leaked to stdout: %q", stdout.String())
+	}
+}
+
+func TestTripRSVPGet_Table_NoRequestIDWithoutVerbose(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	store := &memStore{path: "/x", doc: baseDoc(t)}
+	api := &fakeRSVPAPI{requestID: "req-abc"}
+
+	cmd := NewRootCmd(RootDeps{ConfigStore: store, PlannerAPI: api, Stdout: stdout, Stderr: stderr})
+	cmd.SetArgs([]string{"trip", "rsvp", "get", "t1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if strings.Contains(stderr.String(), "Request-Id") {
+		t.Fatalf("did not expect request id without --verbose, got %q", stderr.String())
+	}
+}
+
+func TestTripRSVPGet_OfflineCache_JSONMeta(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	store := &memStore{path: "/x", doc: baseDoc(t)}
+	api := &fakeRSVPAPI{cachedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+	cmd := NewRootCmd(RootDeps{ConfigStore: store, PlannerAPI: api, Stdout: stdout, Stderr: &bytes.Buffer{}})
+	cmd.SetArgs([]string{"--output", "json", "--offline", "trip", "rsvp", "get", "t1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	var env map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &env); err != nil {
+		t.Fatalf("stdout not json: %v\n%s", err, stdout.String())
+	}
+	meta, _ := env["meta"].(map[string]any)
+	if meta == nil || meta["cached"] != true || meta["fetchedAt"] != "2026-01-02T03:04:05Z" {
+		t.Fatalf("meta: %#v", meta)
+	}
+}
+
+func TestTripRSVPGet_OfflineCache_TablePrintsAge(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	store := &memStore{path: "/x", doc: baseDoc(t)}
+	api := &fakeRSVPAPI{cachedAt: time.Now().Add(-3*time.Hour - 12*time.Minute)}
+
+	cmd := NewRootCmd(RootDeps{ConfigStore: store, PlannerAPI: api, Stdout: stdout, Stderr: stderr})
+	cmd.SetArgs([]string{"--offline", "trip", "rsvp", "get", "t1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if !strings.Contains(stderr.String(), "Offline: showing cached data fetched 3h12m ago") {
+		t.Fatalf("stderr: %q", stderr.String())
+	}
+}
@@ -23,10 +23,10 @@ func TestTripUpdate_Flags_ArtifactIDs_DedupPreserveFirst(t *testing.T) {
 	if api.updateCalls != 1 {
 		t.Fatalf("expected 1 api call, got %d", api.updateCalls)
 	}
-	if api.lastUpdateReq.ArtifactIds == nil {
+	if api.lastUpdateReq.ArtifactIDs == nil {
 		t.Fatalf("expected artifactIds set")
 	}
-	got := *api.lastUpdateReq.ArtifactIds
+	got := *api.lastUpdateReq.ArtifactIDs
 	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
 		t.Fatalf("artifactIds: %#v", got)
 	}
@@ -6,7 +6,8 @@ import (
 	"encoding/json"
 	"testing"
 
-	gen "github.com/Overland-East-Bay/trip-planner-cli/internal/gen/plannerapi"
+	plannerapiout "github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/plannerapi"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/config"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
 	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
 )
@@ -17,54 +18,56 @@ type fakeTripMutationsAPI struct {
 	cancelCalls  int
 
 	lastVisIdem string
-	lastVisReq  gen.SetDraftVisibilityRequest
+	lastVisReq  outplannerapi.SetDraftVisibilityRequest
 
 	lastCancelIdem *string
 
-	publishResp *gen.PublishTripResponse
+	publishResp *outplannerapi.PublishResult
+	publishErr  error
+	visErr      error
 }
 
 var _ outplannerapi.Client = (*fakeTripMutationsAPI)(nil)
 
-func (f *fakeTripMutationsAPI) ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*gen.ListVisibleTripsForMemberClientResponse, error) {
+func (f *fakeTripMutationsAPI) ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.TripList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeTripMutationsAPI) ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*gen.ListMyDraftTripsClientResponse, error) {
+func (f *fakeTripMutationsAPI) ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.TripList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeTripMutationsAPI) GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripDetailsClientResponse, error) {
+func (f *fakeTripMutationsAPI) GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeTripMutationsAPI) CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.CreateTripDraftJSONRequestBody) (*gen.CreateTripDraftClientResponse, error) {
+func (f *fakeTripMutationsAPI) CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.CreateTripDraftRequest) (*outplannerapi.TripCreatedResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeTripMutationsAPI) UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.UpdateTripJSONRequestBody) (*gen.UpdateTripClientResponse, error) {
+func (f *fakeTripMutationsAPI) UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.UpdateTripRequest) (*outplannerapi.TripResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeTripMutationsAPI) ListMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.ListMembersParams) (*gen.ListMembersClientResponse, error) {
+func (f *fakeTripMutationsAPI) ListMembers(ctx context.Context, baseURL string, bearerToken string, includeInactive bool) (*outplannerapi.MemberList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripMutationsAPI) SearchMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.SearchMembersParams) (*gen.SearchMembersClientResponse, error) {
+func (f *fakeTripMutationsAPI) SearchMembers(ctx context.Context, baseURL string, bearerToken string, query string) (*outplannerapi.MemberList, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripMutationsAPI) GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*gen.GetMyMemberProfileClientResponse, error) {
+func (f *fakeTripMutationsAPI) GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.MemberResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripMutationsAPI) DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.DeleteMyMemberAccountJSONRequestBody) (*gen.DeleteMyMemberAccountClientResponse, error) {
+func (f *fakeTripMutationsAPI) DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.DeleteMemberRequest) (*outplannerapi.MemberDeleted, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripMutationsAPI) CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req gen.CreateMyMemberJSONRequestBody) (*gen.CreateMyMemberClientResponse, error) {
+func (f *fakeTripMutationsAPI) CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req outplannerapi.CreateMemberRequest) (*outplannerapi.MemberResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
-func (f *fakeTripMutationsAPI) UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.UpdateMyMemberProfileJSONRequestBody) (*gen.UpdateMyMemberProfileClientResponse, error) {
+func (f *fakeTripMutationsAPI) UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.UpdateMemberRequest) (*outplannerapi.MemberResult, error) {
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripMutationsAPI) SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetTripDraftVisibilityJSONRequestBody) (*gen.SetTripDraftVisibilityClientResponse, error) {
+func (f *fakeTripMutationsAPI) SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.SetDraftVisibilityRequest) (*outplannerapi.TripResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -72,33 +75,39 @@ func (f *fakeTripMutationsAPI) SetTripDraftVisibility(ctx context.Context, baseU
 	f.visCalls++
 	f.lastVisIdem = idempotencyKey
 	f.lastVisReq = req
-	return &gen.SetTripDraftVisibilityClientResponse{JSON200: &gen.TripResponse{}}, nil
+	if f.visErr != nil {
+		return nil, f.visErr
+	}
+	return &outplannerapi.TripResult{}, nil
 }
 
-func (f *fakeTripMutationsAPI) PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.PublishTripClientResponse, error) {
+func (f *fakeTripMutationsAPI) PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.PublishResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
 	_ = tripID
 	f.publishCalls++
+	if f.publishErr != nil {
+		return nil, f.publishErr
+	}
 	resp := f.publishResp
 	if resp == nil {
-		resp = &gen.PublishTripResponse{AnnouncementCopy: "ANNOUNCE\n"}
+		resp = &outplannerapi.PublishResult{AnnouncementCopy: "ANNOUNCE\n"}
 	}
-	return &gen.PublishTripClientResponse{JSON200: resp}, nil
+	return resp, nil
 }
 
-func (f *fakeTripMutationsAPI) CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey *string) (*gen.CancelTripClientResponse, error) {
+func (f *fakeTripMutationsAPI) CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey *string) (*outplannerapi.TripResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
 	_ = tripID
 	f.cancelCalls++
 	f.lastCancelIdem = idempotencyKey
-	return &gen.CancelTripClientResponse{JSON200: &gen.TripResponse{}}, nil
+	return &outplannerapi.TripResult{}, nil
 }
 
-func (f *fakeTripMutationsAPI) AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.AddTripOrganizerJSONRequestBody) (*gen.AddTripOrganizerClientResponse, error) {
+func (f *fakeTripMutationsAPI) AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.AddOrganizerRequest) (*outplannerapi.TripResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -108,7 +117,7 @@ func (f *fakeTripMutationsAPI) AddTripOrganizer(ctx context.Context, baseURL str
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripMutationsAPI) RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, memberID gen.MemberId, idempotencyKey string) (*gen.RemoveTripOrganizerClientResponse, error) {
+func (f *fakeTripMutationsAPI) RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, memberID string, idempotencyKey string) (*outplannerapi.TripResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -118,7 +127,7 @@ func (f *fakeTripMutationsAPI) RemoveTripOrganizer(ctx context.Context, baseURL
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripMutationsAPI) SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetMyRSVPJSONRequestBody) (*gen.SetMyRSVPClientResponse, error) {
+func (f *fakeTripMutationsAPI) SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.SetMyRSVPRequest) (*outplannerapi.RSVPResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -128,7 +137,7 @@ func (f *fakeTripMutationsAPI) SetMyRSVP(ctx context.Context, baseURL string, be
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripMutationsAPI) GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetMyRSVPForTripClientResponse, error) {
+func (f *fakeTripMutationsAPI) GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.RSVPResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -136,7 +145,7 @@ func (f *fakeTripMutationsAPI) GetMyRSVPForTrip(ctx context.Context, baseURL str
 	return nil, exitcode.New(exitcode.KindUnexpected, "not implemented in test", nil)
 }
 
-func (f *fakeTripMutationsAPI) GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripRSVPSummaryClientResponse, error) {
+func (f *fakeTripMutationsAPI) GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.RSVPSummaryResult, error) {
 	_ = ctx
 	_ = baseURL
 	_ = bearerToken
@@ -148,7 +157,7 @@ func TestTripPublish_PrintAnnouncement_StdoutOnly(t *testing.T) {
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
 	store := &memStore{path: "/x", doc: baseDoc(t)}
-	api := &fakeTripMutationsAPI{publishResp: &gen.PublishTripResponse{AnnouncementCopy: "HELLO\n"}}
+	api := &fakeTripMutationsAPI{publishResp: &outplannerapi.PublishResult{AnnouncementCopy: "HELLO\n"}}
 
 	cmd := NewRootCmd(RootDeps{ConfigStore: store, PlannerAPI: api, Stdout: stdout, Stderr: stderr})
 	cmd.SetArgs([]string{"trip", "publish", "t1", "--print-announcement"})
@@ -254,7 +263,7 @@ func TestTripPublish_JSONOutput_Envelope(t *testing.T) {
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
 	store := &memStore{path: "/x", doc: baseDoc(t)}
-	api := &fakeTripMutationsAPI{publishResp: &gen.PublishTripResponse{AnnouncementCopy: "HELLO\n"}}
+	api := &fakeTripMutationsAPI{publishResp: &outplannerapi.PublishResult{AnnouncementCopy: "HELLO\n"}}
 
 	cmd := NewRootCmd(RootDeps{ConfigStore: store, PlannerAPI: api, Stdout: stdout, Stderr: stderr})
 	cmd.SetArgs([]string{"--output", "json", "trip", "publish", "t1"})
@@ -304,3 +313,46 @@ func TestTripCancel_WithIdempotency_JSONMetaAndPointer(t *testing.T) {
 		t.Fatalf("meta: %#v", meta)
 	}
 }
+
+func TestTripPublish_ValidationFailure_HintsUpdateThenPublish(t *testing.T) {
+	store := &memStore{path: "/x", doc: baseDoc(t)}
+	ae := &plannerapiout.APIError{StatusCode: 422, ErrorCode: "VALIDATION_FAILED", Message: "trip is not ready", Details: map[string]any{"capacityRigs": "must be >= 1"}}
+	api := &fakeTripMutationsAPI{publishErr: exitcode.New(exitcode.KindValidation, ae.Error(), ae)}
+
+	cmd := NewRootCmd(RootDeps{ConfigStore: store, PlannerAPI: api, Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}})
+	cmd.SetArgs([]string{"trip", "publish", "t1"})
+	err := cmd.Execute()
+	if exitcode.Code(err) != exitcode.Validation {
+		t.Fatalf("expected exit 6, got %d (%v)", exitcode.Code(err), err)
+	}
+	if got := exitcode.HintOf(err); got != "ebo trip update t1 --prompt\n  ebo trip publish t1" {
+		t.Fatalf("hint: %q", got)
+	}
+}
+
+func TestTripVisibility_Failure_ErrorCarriesResolvedContext(t *testing.T) {
+	doc := baseDoc(t)
+	doc, err := config.WithProfileAPIURL(doc, "staging", "https://staging.api")
+	if err != nil {
+		t.Fatalf("api url: %v", err)
+	}
+	if doc, err = config.SetString(doc, "profiles.staging.auth.accessToken", "tok"); err != nil {
+		t.Fatalf("token: %v", err)
+	}
+	if doc, err = config.WithCurrentProfile(doc, "staging"); err != nil {
+		t.Fatalf("current profile: %v", err)
+	}
+	store := &memStore{path: "/x", doc: doc}
+	api := &fakeTripMutationsAPI{visErr: exitcode.New(exitcode.KindServer, "request failed", nil)}
+
+	cmd := NewRootCmd(RootDeps{ConfigStore: store, PlannerAPI: api, Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}})
+	cmd.SetArgs([]string{"--output", "json", "trip", "visibility", "t1", "--public", "--idempotency-key", "idem-1"})
+	err = cmd.Execute()
+	if exitcode.Code(err) != exitcode.Server {
+		t.Fatalf("expected exit 7, got %d (%v)", exitcode.Code(err), err)
+	}
+	c, ok := exitcode.ContextOf(err)
+	if !ok || c.Profile != "staging" || c.APIURL != "https://staging.api" || c.IdempotencyKey != "idem-1" {
+		t.Fatalf("context: %#v %v", c, ok)
+	}
+}
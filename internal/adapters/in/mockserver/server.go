@@ -0,0 +1,214 @@
+// Package mockserver serves a plannerapi.Client over HTTP using the Planner
+// API's routes and wire format.
+//
+// Paired with the in-memory backend (adapters/out/plannerapimem) it is the
+// stand-in API behind `ebo dev mock-server` and the e2e tests: the real CLI
+// adapter talks to it exactly as it would to the deployed service.
+package mockserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	plannerapiout "github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/plannerapi"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+const idempotencyHeader = "Idempotency-Key"
+
+type server struct {
+	api   plannerapi.Client
+	reqID atomic.Int64
+}
+
+// Handler returns an http.Handler serving every Client operation.
+func Handler(api plannerapi.Client) http.Handler {
+	s := &server{api: api}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /trips", s.handle(func(r *http.Request, token string) (any, error) {
+		return s.api.ListVisibleTripsForMember(r.Context(), "", token)
+	}))
+	mux.HandleFunc("GET /trips/drafts", s.handle(func(r *http.Request, token string) (any, error) {
+		return s.api.ListMyDraftTrips(r.Context(), "", token)
+	}))
+	mux.HandleFunc("POST /trips", s.handleCreated(func(r *http.Request, token string) (any, error) {
+		var req plannerapi.CreateTripDraftRequest
+		if err := decodeBody(r, &req); err != nil {
+			return nil, err
+		}
+		return s.api.CreateTripDraft(r.Context(), "", token, r.Header.Get(idempotencyHeader), req)
+	}))
+	mux.HandleFunc("GET /trips/{tripId}", s.handle(func(r *http.Request, token string) (any, error) {
+		return s.api.GetTripDetails(r.Context(), "", token, r.PathValue("tripId"))
+	}))
+	mux.HandleFunc("PATCH /trips/{tripId}", s.handle(func(r *http.Request, token string) (any, error) {
+		var req plannerapi.UpdateTripRequest
+		if err := decodeBody(r, &req); err != nil {
+			return nil, err
+		}
+		return s.api.UpdateTrip(r.Context(), "", token, r.PathValue("tripId"), r.Header.Get(idempotencyHeader), req)
+	}))
+	mux.HandleFunc("PUT /trips/{tripId}/draft-visibility", s.handle(func(r *http.Request, token string) (any, error) {
+		var req plannerapi.SetDraftVisibilityRequest
+		if err := decodeBody(r, &req); err != nil {
+			return nil, err
+		}
+		return s.api.SetTripDraftVisibility(r.Context(), "", token, r.PathValue("tripId"), r.Header.Get(idempotencyHeader), req)
+	}))
+	mux.HandleFunc("POST /trips/{tripId}/publish", s.handle(func(r *http.Request, token string) (any, error) {
+		return s.api.PublishTrip(r.Context(), "", token, r.PathValue("tripId"))
+	}))
+	mux.HandleFunc("POST /trips/{tripId}/cancel", s.handle(func(r *http.Request, token string) (any, error) {
+		var key *string
+		if k := r.Header.Get(idempotencyHeader); k != "" {
+			key = &k
+		}
+		return s.api.CancelTrip(r.Context(), "", token, r.PathValue("tripId"), key)
+	}))
+	mux.HandleFunc("POST /trips/{tripId}/organizers", s.handle(func(r *http.Request, token string) (any, error) {
+		var req plannerapi.AddOrganizerRequest
+		if err := decodeBody(r, &req); err != nil {
+			return nil, err
+		}
+		return s.api.AddTripOrganizer(r.Context(), "", token, r.PathValue("tripId"), r.Header.Get(idempotencyHeader), req)
+	}))
+	mux.HandleFunc("DELETE /trips/{tripId}/organizers/{memberId}", s.handle(func(r *http.Request, token string) (any, error) {
+		return s.api.RemoveTripOrganizer(r.Context(), "", token, r.PathValue("tripId"), r.PathValue("memberId"), r.Header.Get(idempotencyHeader))
+	}))
+	mux.HandleFunc("PUT /trips/{tripId}/rsvp", s.handle(func(r *http.Request, token string) (any, error) {
+		var req plannerapi.SetMyRSVPRequest
+		if err := decodeBody(r, &req); err != nil {
+			return nil, err
+		}
+		return s.api.SetMyRSVP(r.Context(), "", token, r.PathValue("tripId"), r.Header.Get(idempotencyHeader), req)
+	}))
+	mux.HandleFunc("GET /trips/{tripId}/rsvp/me", s.handle(func(r *http.Request, token string) (any, error) {
+		return s.api.GetMyRSVPForTrip(r.Context(), "", token, r.PathValue("tripId"))
+	}))
+	mux.HandleFunc("GET /trips/{tripId}/rsvps", s.handle(func(r *http.Request, token string) (any, error) {
+		return s.api.GetTripRSVPSummary(r.Context(), "", token, r.PathValue("tripId"))
+	}))
+
+	mux.HandleFunc("GET /members", s.handle(func(r *http.Request, token string) (any, error) {
+		includeInactive := false
+		if v := r.URL.Query().Get("includeInactive"); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, badRequest("includeInactive", "must be true or false")
+			}
+			includeInactive = b
+		}
+		return s.api.ListMembers(r.Context(), "", token, includeInactive)
+	}))
+	mux.HandleFunc("POST /members", s.handleCreated(func(r *http.Request, token string) (any, error) {
+		var req plannerapi.CreateMemberRequest
+		if err := decodeBody(r, &req); err != nil {
+			return nil, err
+		}
+		return s.api.CreateMyMember(r.Context(), "", token, req)
+	}))
+	mux.HandleFunc("GET /members/search", s.handle(func(r *http.Request, token string) (any, error) {
+		return s.api.SearchMembers(r.Context(), "", token, r.URL.Query().Get("q"))
+	}))
+	mux.HandleFunc("GET /members/me", s.handle(func(r *http.Request, token string) (any, error) {
+		return s.api.GetMyMemberProfile(r.Context(), "", token)
+	}))
+	mux.HandleFunc("PATCH /members/me", s.handle(func(r *http.Request, token string) (any, error) {
+		var req plannerapi.UpdateMemberRequest
+		if err := decodeBody(r, &req); err != nil {
+			return nil, err
+		}
+		return s.api.UpdateMyMemberProfile(r.Context(), "", token, r.Header.Get(idempotencyHeader), req)
+	}))
+	mux.HandleFunc("DELETE /members/me", s.handle(func(r *http.Request, token string) (any, error) {
+		var req plannerapi.DeleteMemberRequest
+		if err := decodeBody(r, &req); err != nil {
+			return nil, err
+		}
+		return s.api.DeleteMyMemberAccount(r.Context(), "", token, r.Header.Get(idempotencyHeader), req)
+	}))
+
+	mux.HandleFunc("/", s.handle(func(r *http.Request, token string) (any, error) {
+		return nil, plannerapiout.NewAPIError(http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path), nil)
+	}))
+	return mux
+}
+
+type operation func(r *http.Request, bearerToken string) (any, error)
+
+func (s *server) handle(op operation) http.HandlerFunc {
+	return s.respond(http.StatusOK, op)
+}
+
+func (s *server) handleCreated(op operation) http.HandlerFunc {
+	return s.respond(http.StatusCreated, op)
+}
+
+func (s *server) respond(status int, op operation) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := fmt.Sprintf("mock-%d", s.reqID.Add(1))
+		w.Header().Set("X-Request-Id", requestID)
+
+		res, err := op(r, bearerToken(r))
+		if err != nil {
+			writeError(w, requestID, err)
+			return
+		}
+		writeJSON(w, status, res)
+	}
+}
+
+func bearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// decodeBody decodes a JSON request body, rejecting unknown fields the way
+// the API's request validation does.
+func decodeBody(r *http.Request, out any) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(out); err != nil {
+		return badRequest("body", err.Error())
+	}
+	return nil
+}
+
+func badRequest(field, problem string) error {
+	return plannerapiout.NewAPIError(http.StatusUnprocessableEntity, "VALIDATION_FAILED", "request validation failed", map[string]any{field: problem})
+}
+
+type errorBody struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Code      string         `json:"code"`
+	Message   string         `json:"message"`
+	Details   map[string]any `json:"details,omitempty"`
+	RequestID string         `json:"requestId"`
+}
+
+func writeError(w http.ResponseWriter, requestID string, err error) {
+	var ae *plannerapiout.APIError
+	if !errors.As(err, &ae) {
+		writeJSON(w, http.StatusInternalServerError, errorBody{Error: errorDetail{Code: "INTERNAL", Message: err.Error(), RequestID: requestID}})
+		return
+	}
+	writeJSON(w, ae.StatusCode, errorBody{Error: errorDetail{Code: ae.ErrorCode, Message: ae.Message, Details: ae.Details, RequestID: requestID}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,95 @@
+package mockserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	plannerapiout "github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/plannerapi"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/plannerapimem"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	b, err := plannerapimem.New(plannerapimem.DefaultFixtures())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	srv := httptest.NewServer(Handler(b))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// The real HTTP adapter must be able to talk to the mock server: this keeps
+// routes, status codes and bodies in line with the generated client.
+func TestHandler_ServesTheHTTPAdapter(t *testing.T) {
+	srv := newServer(t)
+	ctx, meta := plannerapi.WithResponseMeta(context.Background())
+	a := plannerapiout.Adapter{}
+
+	created, err := a.CreateTripDraft(ctx, srv.URL, "bob", "k1", plannerapi.CreateTripDraftRequest{Name: "Moab"})
+	if err != nil || created.Trip.Status != plannerapi.TripStatusDraft {
+		t.Fatalf("create=%+v err=%v", created, err)
+	}
+	if !strings.HasPrefix(meta.RequestID, "mock-") {
+		t.Fatalf("request id %q", meta.RequestID)
+	}
+
+	desc := "Slickrock"
+	res, err := a.UpdateTrip(ctx, srv.URL, "bob", created.Trip.TripID, "k2", plannerapi.UpdateTripRequest{Description: &desc})
+	if err != nil || res.Trip.Description == nil || *res.Trip.Description != desc {
+		t.Fatalf("update=%+v err=%v", res, err)
+	}
+
+	_, err = a.SetMyRSVP(ctx, srv.URL, "carol", "t-rubicon", "k3", plannerapi.SetMyRSVPRequest{Response: plannerapi.RSVPYes})
+	if err != nil {
+		t.Fatalf("rsvp: %v", err)
+	}
+	_, err = a.SetMyRSVP(ctx, srv.URL, "bob", "t-rubicon", "k4", plannerapi.SetMyRSVPRequest{Response: plannerapi.RSVPYes})
+	var ae *plannerapiout.APIError
+	if !errors.As(err, &ae) || ae.StatusCode != http.StatusConflict || ae.ErrorCode != "TRIP_AT_CAPACITY" || ae.RequestID == "" {
+		t.Fatalf("want TRIP_AT_CAPACITY, got %v", err)
+	}
+
+	_, err = a.GetMyMemberProfile(ctx, srv.URL, "")
+	if !errors.As(err, &ae) || ae.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("want 401, got %v", err)
+	}
+
+	members, err := a.ListMembers(ctx, srv.URL, "alice", true)
+	if err != nil || len(members.Members) != 3 {
+		t.Fatalf("members=%+v err=%v", members, err)
+	}
+}
+
+func TestHandler_UnknownRouteAndBadBody(t *testing.T) {
+	srv := newServer(t)
+
+	resp, err := http.Get(srv.URL + "/nope")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound || !strings.Contains(string(body), `"code":"NOT_FOUND"`) {
+		t.Fatalf("status=%d body=%s", resp.StatusCode, body)
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/trips", strings.NewReader(`{"name":"x","bogus":1}`))
+	req.Header.Set("Authorization", "Bearer alice")
+	req.Header.Set("Idempotency-Key", "k1")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity || !strings.Contains(string(body), "bogus") {
+		t.Fatalf("status=%d body=%s", resp.StatusCode, body)
+	}
+}
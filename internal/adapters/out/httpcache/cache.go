@@ -0,0 +1,229 @@
+// Package httpcache is an on-disk HTTP response cache for Planner API reads.
+//
+// Only GET responses are cached. Online, cached entries are revalidated with
+// If-None-Match / If-Modified-Since; with Offline set, the last cached response
+// is served without touching the network.
+package httpcache
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/httpx"
+)
+
+// Transport is an http.RoundTripper that caches GET responses under Dir.
+//
+// Entries are keyed by Scope (profile), the bearer token's subject, and the
+// full request URL (which includes the API base URL), so responses for
+// different users or environments never cross.
+type Transport struct {
+	Base    http.RoundTripper
+	Dir     string
+	Scope   string
+	Offline bool
+	Now     func() time.Time
+}
+
+type entry struct {
+	URL       string      `json:"url"`
+	Status    int         `json:"status"`
+	Header    http.Header `json:"header"`
+	Body      []byte      `json:"body"`
+	FetchedAt time.Time   `json:"fetchedAt"`
+}
+
+func (t *Transport) now() time.Time {
+	if t.Now != nil {
+		return t.Now()
+	}
+	return time.Now()
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		if t.Offline {
+			if req.Body != nil {
+				_ = req.Body.Close()
+			}
+			return nil, fmt.Errorf("%w: %s %s needs the network", httpx.ErrOffline, req.Method, req.URL.Path)
+		}
+		return t.base().RoundTrip(req)
+	}
+
+	path := t.entryPath(req)
+	cached, _ := readEntry(path)
+
+	if t.Offline {
+		if cached == nil {
+			return nil, fmt.Errorf("%w: no cached response for GET %s", httpx.ErrOffline, req.URL.Path)
+		}
+		return cached.response(req, httpx.CacheSourceOffline), nil
+	}
+
+	if cached != nil {
+		req = req.Clone(req.Context())
+		if etag := cached.Header.Get("ETag"); etag != "" && req.Header.Get("If-None-Match") == "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lm := cached.Header.Get("Last-Modified"); lm != "" && req.Header.Get("If-Modified-Since") == "" {
+			req.Header.Set("If-Modified-Since", lm)
+		}
+	}
+
+	resp, err := t.base().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		for k, vs := range resp.Header {
+			cached.Header[k] = vs
+		}
+		cached.FetchedAt = t.now().UTC()
+		_ = writeEntry(path, cached)
+		return cached.response(req, httpx.CacheSourceRevalidated), nil
+	}
+
+	if resp.StatusCode != http.StatusOK || !storable(resp.Header) {
+		return resp, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	_ = writeEntry(path, &entry{
+		URL:       req.URL.String(),
+		Status:    resp.StatusCode,
+		Header:    resp.Header.Clone(),
+		Body:      body,
+		FetchedAt: t.now().UTC(),
+	})
+	return resp, nil
+}
+
+func storable(h http.Header) bool {
+	return !strings.Contains(strings.ToLower(h.Get("Cache-Control")), "no-store")
+}
+
+func (e *entry) response(req *http.Request, source string) *http.Response {
+	h := e.Header.Clone()
+	if h == nil {
+		h = http.Header{}
+	}
+	h.Set(httpx.HeaderCacheSource, source)
+	h.Set(httpx.HeaderCacheFetchedAt, e.FetchedAt.UTC().Format(time.RFC3339))
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+		StatusCode:    e.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        h,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+func (t *Transport) entryPath(req *http.Request) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{t.Scope, subject(req.Header.Get("Authorization")), req.URL.String()}, "\n")))
+	return filepath.Join(t.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// subject identifies the caller behind a bearer token: the JWT "sub" claim when
+// the token is a JWT (not verified; only used for cache scoping), otherwise a
+// hash of the whole header.
+func subject(authorization string) string {
+	tok := strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer "))
+	if tok == "" {
+		return ""
+	}
+	if parts := strings.Split(tok, "."); len(parts) == 3 {
+		if payload, err := base64.RawURLEncoding.DecodeString(parts[1]); err == nil {
+			var claims struct {
+				Iss string `json:"iss"`
+				Sub string `json:"sub"`
+			}
+			if json.Unmarshal(payload, &claims) == nil && claims.Sub != "" {
+				return "sub:" + claims.Iss + "|" + claims.Sub
+			}
+		}
+	}
+	sum := sha256.Sum256([]byte(tok))
+	return "tok:" + hex.EncodeToString(sum[:])
+}
+
+func readEntry(path string) (*entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	var e entry
+	if err := json.NewDecoder(bufio.NewReader(f)).Decode(&e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func writeEntry(path string, e *entry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Env looks up environment variables (cliopts.OSEnv satisfies it).
+type Env interface {
+	LookupEnv(key string) (string, bool)
+}
+
+// DefaultDir returns the cache directory:
+//
+//  1. EBO_CACHE_DIR, if set
+//  2. <EBO_CONFIG_DIR>/ebo/cache, if EBO_CONFIG_DIR is set (keeps test/dev sandboxes self-contained)
+//  3. <os.UserCacheDir()>/ebo/http
+func DefaultDir(env Env) (string, error) {
+	if v, ok := env.LookupEnv("EBO_CACHE_DIR"); ok && v != "" {
+		return v, nil
+	}
+	if v, ok := env.LookupEnv("EBO_CONFIG_DIR"); ok && v != "" {
+		return filepath.Join(v, "ebo", "cache"), nil
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "ebo", "http"), nil
+}
@@ -0,0 +1,196 @@
+package httpcache
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/httpx"
+)
+
+type mapEnv map[string]string
+
+func (m mapEnv) LookupEnv(key string) (string, bool) {
+	v, ok := m[key]
+	return v, ok
+}
+
+func jwtWithSub(sub string) string {
+	enc := base64.RawURLEncoding.EncodeToString
+	return enc([]byte(`{"alg":"none"}`)) + "." + enc([]byte(`{"iss":"https://issuer","sub":"`+sub+`"}`)) + ".sig"
+}
+
+func get(t *testing.T, c *http.Client, url, token string) (*http.Response, string, error) {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	b, _ := io.ReadAll(resp.Body)
+	return resp, string(b), nil
+}
+
+func TestTransport_RevalidatesWithETag(t *testing.T) {
+	calls := 0
+	conditional := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"trips":[]}`))
+	}))
+	defer srv.Close()
+
+	c := &http.Client{Transport: &Transport{Dir: t.TempDir(), Scope: "default"}}
+	if _, body, err := get(t, c, srv.URL+"/trips", "tok"); err != nil || body != `{"trips":[]}` {
+		t.Fatalf("first get: %q %v", body, err)
+	}
+	resp, body, err := get(t, c, srv.URL+"/trips", "tok")
+	if err != nil {
+		t.Fatalf("second get: %v", err)
+	}
+	if conditional != 1 || calls != 2 {
+		t.Fatalf("expected one conditional request, calls=%d conditional=%d", calls, conditional)
+	}
+	if resp.StatusCode != 200 || body != `{"trips":[]}` {
+		t.Fatalf("expected cached body as 200, got %d %q", resp.StatusCode, body)
+	}
+	if resp.Header.Get(httpx.HeaderCacheSource) != httpx.CacheSourceRevalidated || resp.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("headers: %#v", resp.Header)
+	}
+}
+
+func TestTransport_RevalidatesWithLastModified(t *testing.T) {
+	lm := "Mon, 02 Jan 2006 15:04:05 GMT"
+	seen := ""
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r.Header.Get("If-Modified-Since")
+		w.Header().Set("Last-Modified", lm)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c := &http.Client{Transport: &Transport{Dir: t.TempDir()}}
+	_, _, _ = get(t, c, srv.URL+"/x", "tok")
+	_, _, _ = get(t, c, srv.URL+"/x", "tok")
+	if seen != lm {
+		t.Fatalf("If-Modified-Since: got %q", seen)
+	}
+}
+
+func TestTransport_OfflineServesLastResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"trip":{"tripId":"t1"}}`))
+	}))
+	dir := t.TempDir()
+	fetched := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	online := &http.Client{Transport: &Transport{Dir: dir, Scope: "default", Now: func() time.Time { return fetched }}}
+	if _, _, err := get(t, online, srv.URL+"/trips/t1", "tok"); err != nil {
+		t.Fatalf("online get: %v", err)
+	}
+	srv.Close()
+
+	offline := &http.Client{Transport: &Transport{Dir: dir, Scope: "default", Offline: true}}
+	resp, body, err := get(t, offline, srv.URL+"/trips/t1", "tok")
+	if err != nil {
+		t.Fatalf("offline get: %v", err)
+	}
+	if body != `{"trip":{"tripId":"t1"}}` {
+		t.Fatalf("body: %q", body)
+	}
+	if resp.Header.Get(httpx.HeaderCacheSource) != httpx.CacheSourceOffline {
+		t.Fatalf("source: %q", resp.Header.Get(httpx.HeaderCacheSource))
+	}
+	if resp.Header.Get(httpx.HeaderCacheFetchedAt) != "2026-01-02T03:04:05Z" {
+		t.Fatalf("fetchedAt: %q", resp.Header.Get(httpx.HeaderCacheFetchedAt))
+	}
+}
+
+func TestTransport_OfflineMissAndWritesFail(t *testing.T) {
+	c := &http.Client{Transport: &Transport{Dir: t.TempDir(), Offline: true}}
+	if _, _, err := get(t, c, "http://api.invalid/trips", "tok"); !errors.Is(err, httpx.ErrOffline) {
+		t.Fatalf("expected httpx.ErrOffline on miss, got %v", err)
+	}
+	_, err := c.Post("http://api.invalid/trips", "application/json", strings.NewReader(`{}`))
+	if !errors.Is(err, httpx.ErrOffline) {
+		t.Fatalf("expected httpx.ErrOffline on POST, got %v", err)
+	}
+}
+
+func TestTransport_ScopedBySubjectAndProfile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"member":"me"}`))
+	}))
+	defer srv.Close()
+	dir := t.TempDir()
+
+	alice := jwtWithSub("alice")
+	online := &http.Client{Transport: &Transport{Dir: dir, Scope: "default"}}
+	if _, _, err := get(t, online, srv.URL+"/members/me", alice); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+
+	offline := &http.Client{Transport: &Transport{Dir: dir, Scope: "default", Offline: true}}
+	// A refreshed token for the same subject still hits.
+	if _, _, err := get(t, offline, srv.URL+"/members/me", alice); err != nil {
+		t.Fatalf("same subject should hit: %v", err)
+	}
+	if _, _, err := get(t, offline, srv.URL+"/members/me", jwtWithSub("bob")); !errors.Is(err, httpx.ErrOffline) {
+		t.Fatalf("different subject must miss, got %v", err)
+	}
+	if _, _, err := get(t, offline, srv.URL+"/members/me", "opaque-token"); !errors.Is(err, httpx.ErrOffline) {
+		t.Fatalf("different token must miss, got %v", err)
+	}
+	otherProfile := &http.Client{Transport: &Transport{Dir: dir, Scope: "staging", Offline: true}}
+	if _, _, err := get(t, otherProfile, srv.URL+"/members/me", alice); !errors.Is(err, httpx.ErrOffline) {
+		t.Fatalf("different profile must miss, got %v", err)
+	}
+}
+
+func TestTransport_DoesNotStoreNoStoreOrErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/nostore" {
+			w.Header().Set("Cache-Control", "no-store")
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	dir := t.TempDir()
+
+	online := &http.Client{Transport: &Transport{Dir: dir}}
+	_, _, _ = get(t, online, srv.URL+"/nostore", "tok")
+	_, _, _ = get(t, online, srv.URL+"/missing", "tok")
+
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(matches) != 0 {
+		t.Fatalf("expected nothing cached, got %v", matches)
+	}
+}
+
+func TestDefaultDir_Precedence(t *testing.T) {
+	if got, _ := DefaultDir(mapEnv{"EBO_CACHE_DIR": "/c", "EBO_CONFIG_DIR": "/cfg"}); got != "/c" {
+		t.Fatalf("got %q", got)
+	}
+	if got, _ := DefaultDir(mapEnv{"EBO_CONFIG_DIR": "/cfg"}); got != filepath.Join("/cfg", "ebo", "cache") {
+		t.Fatalf("got %q", got)
+	}
+}
@@ -5,12 +5,14 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/httpx"
 
 	gen "github.com/Overland-East-Bay/trip-planner-cli/internal/gen/plannerapi"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
 )
 
 type Adapter struct {
@@ -18,13 +20,44 @@ type Adapter struct {
 	Timeout    time.Duration
 	Verbose    bool
 	LogSink    io.Writer
+	// RequestIDHeader names the response header carrying the server request ID
+	// (default DefaultRequestIDHeader).
+	RequestIDHeader string
+
+	mu      sync.Mutex
+	hc      *http.Client
+	clients map[clientKey]*gen.ClientWithResponses
+}
+
+type clientKey struct {
+	baseURL     string
+	bearerToken string
 }
 
-func (a Adapter) newClient(baseURL string, bearerToken string) (*gen.ClientWithResponses, error) {
+// client returns the generated client for baseURL/bearerToken, building it on
+// first use.
+//
+// All generated clients share one wrapped http.Client, so connections (and TLS
+// sessions) are pooled across calls within an invocation.
+func (a *Adapter) client(baseURL string, bearerToken string) (*gen.ClientWithResponses, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := clientKey{baseURL: baseURL, bearerToken: bearerToken}
+	if c, ok := a.clients[key]; ok {
+		return c, nil
+	}
+
+	if a.hc == nil {
+		base := a.HTTPClient
+		if base == nil {
+			base = &http.Client{Transport: httpx.SharedTransport()}
+		}
+		a.hc = httpx.NewClient(base, httpx.Options{Timeout: a.Timeout, Verbose: a.Verbose, LogSink: a.LogSink})
+	}
+
 	opts := []gen.ClientOption{}
-	hc := a.HTTPClient
-	hc = httpx.NewClient(hc, httpx.Options{Timeout: a.Timeout, Verbose: a.Verbose, LogSink: a.LogSink})
-	opts = append(opts, gen.WithHTTPClient(hc))
+	opts = append(opts, gen.WithHTTPClient(responseMetaDoer{next: a.hc, header: a.requestIDHeader()}))
 	opts = append(opts, gen.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
 		_ = ctx
 		if strings.TrimSpace(bearerToken) != "" {
@@ -32,299 +65,386 @@ func (a Adapter) newClient(baseURL string, bearerToken string) (*gen.ClientWithR
 		}
 		return nil
 	}))
-	return gen.NewClientWithResponses(baseURL, opts...)
+	c, err := gen.NewClientWithResponses(baseURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if a.clients == nil {
+		a.clients = map[clientKey]*gen.ClientWithResponses{}
+	}
+	a.clients[key] = c
+	return c, nil
 }
-
-func (a Adapter) ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*gen.ListVisibleTripsForMemberClientResponse, error) {
-	client, err := a.newClient(baseURL, bearerToken)
+func (a *Adapter) ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.TripList, error) {
+	client, err := a.client(baseURL, bearerToken)
 	if err != nil {
 		return nil, exitcode.New(exitcode.KindServer, "failed to init client", err)
 	}
 	resp, err := client.ListVisibleTripsForMemberWithResponse(ctx)
 	if err != nil {
-		return nil, exitcode.New(exitcode.KindServer, "request failed", err)
+		return nil, transportError(baseURL, err)
 	}
 	if resp.StatusCode() >= 400 {
-		return nil, apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON500)
+		return nil, withResponseRequestID(apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON500), resp.HTTPResponse, a.requestIDHeader())
+	}
+	if resp.JSON200 == nil {
+		return nil, nil
 	}
-	return resp, nil
+	return &outplannerapi.TripList{Trips: toTripSummaries(resp.JSON200.Trips)}, nil
 }
 
-func (a Adapter) ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*gen.ListMyDraftTripsClientResponse, error) {
-	client, err := a.newClient(baseURL, bearerToken)
+func (a *Adapter) ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.TripList, error) {
+	client, err := a.client(baseURL, bearerToken)
 	if err != nil {
 		return nil, exitcode.New(exitcode.KindServer, "failed to init client", err)
 	}
 	resp, err := client.ListMyDraftTripsWithResponse(ctx)
 	if err != nil {
-		return nil, exitcode.New(exitcode.KindServer, "request failed", err)
+		return nil, transportError(baseURL, err)
 	}
 	if resp.StatusCode() >= 400 {
-		return nil, apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON500)
+		return nil, withResponseRequestID(apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON500), resp.HTTPResponse, a.requestIDHeader())
 	}
-	return resp, nil
+	if resp.JSON200 == nil {
+		return nil, nil
+	}
+	return &outplannerapi.TripList{Trips: toTripSummaries(resp.JSON200.Trips)}, nil
 }
 
-func (a Adapter) GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripDetailsClientResponse, error) {
-	client, err := a.newClient(baseURL, bearerToken)
+func (a *Adapter) GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.TripResult, error) {
+	client, err := a.client(baseURL, bearerToken)
 	if err != nil {
 		return nil, exitcode.New(exitcode.KindServer, "failed to init client", err)
 	}
 	resp, err := client.GetTripDetailsWithResponse(ctx, tripID)
 	if err != nil {
-		return nil, exitcode.New(exitcode.KindServer, "request failed", err)
+		return nil, transportError(baseURL, err)
 	}
 	if resp.StatusCode() >= 400 {
-		return nil, apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON500)
+		return nil, withResponseRequestID(apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON500), resp.HTTPResponse, a.requestIDHeader())
 	}
-	return resp, nil
+	return tripResult(resp.JSON200), nil
 }
 
-func (a Adapter) CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.CreateTripDraftJSONRequestBody) (*gen.CreateTripDraftClientResponse, error) {
-	client, err := a.newClient(baseURL, bearerToken)
+func (a *Adapter) CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.CreateTripDraftRequest) (*outplannerapi.TripCreatedResult, error) {
+	client, err := a.client(baseURL, bearerToken)
 	if err != nil {
 		return nil, exitcode.New(exitcode.KindServer, "failed to init client", err)
 	}
+	body, err := jsonBody(req)
+	if err != nil {
+		return nil, err
+	}
 	params := &gen.CreateTripDraftParams{IdempotencyKey: idempotencyKey}
-	resp, err := client.CreateTripDraftWithResponse(ctx, params, req)
+	resp, err := client.CreateTripDraftWithBodyWithResponse(ctx, params, jsonContentType, body)
 	if err != nil {
-		return nil, exitcode.New(exitcode.KindServer, "request failed", err)
+		return nil, transportError(baseURL, err)
 	}
 	if resp.StatusCode() >= 400 {
-		return nil, apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON500)
+		return nil, withResponseRequestID(apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON500), resp.HTTPResponse, a.requestIDHeader())
 	}
-	return resp, nil
+	if resp.JSON201 == nil {
+		return nil, nil
+	}
+	t := resp.JSON201.Trip
+	return &outplannerapi.TripCreatedResult{Trip: outplannerapi.TripCreated{
+		DraftVisibility: outplannerapi.DraftVisibility(t.DraftVisibility),
+		Status:          outplannerapi.TripStatus(t.Status),
+		TripID:          t.TripId,
+	}}, nil
 }
 
-func (a Adapter) UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.UpdateTripJSONRequestBody) (*gen.UpdateTripClientResponse, error) {
-	client, err := a.newClient(baseURL, bearerToken)
+func (a *Adapter) UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.UpdateTripRequest) (*outplannerapi.TripResult, error) {
+	client, err := a.client(baseURL, bearerToken)
 	if err != nil {
 		return nil, exitcode.New(exitcode.KindServer, "failed to init client", err)
 	}
+	body, err := jsonBody(req)
+	if err != nil {
+		return nil, err
+	}
 	params := &gen.UpdateTripParams{IdempotencyKey: idempotencyKey}
-	resp, err := client.UpdateTripWithResponse(ctx, tripID, params, req)
+	resp, err := client.UpdateTripWithBodyWithResponse(ctx, tripID, params, jsonContentType, body)
 	if err != nil {
-		return nil, exitcode.New(exitcode.KindServer, "request failed", err)
+		return nil, transportError(baseURL, err)
 	}
 	if resp.StatusCode() >= 400 {
-		return nil, apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON422, resp.JSON500)
+		return nil, withResponseRequestID(apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON422, resp.JSON500), resp.HTTPResponse, a.requestIDHeader())
 	}
-	return resp, nil
+	return tripResult(resp.JSON200), nil
 }
 
-func (a Adapter) SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetTripDraftVisibilityJSONRequestBody) (*gen.SetTripDraftVisibilityClientResponse, error) {
-	client, err := a.newClient(baseURL, bearerToken)
+func (a *Adapter) SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.SetDraftVisibilityRequest) (*outplannerapi.TripResult, error) {
+	client, err := a.client(baseURL, bearerToken)
 	if err != nil {
 		return nil, exitcode.New(exitcode.KindServer, "failed to init client", err)
 	}
+	body, err := jsonBody(req)
+	if err != nil {
+		return nil, err
+	}
 	params := &gen.SetTripDraftVisibilityParams{IdempotencyKey: idempotencyKey}
-	resp, err := client.SetTripDraftVisibilityWithResponse(ctx, tripID, params, req)
+	resp, err := client.SetTripDraftVisibilityWithBodyWithResponse(ctx, tripID, params, jsonContentType, body)
 	if err != nil {
-		return nil, exitcode.New(exitcode.KindServer, "request failed", err)
+		return nil, transportError(baseURL, err)
 	}
 	if resp.StatusCode() >= 400 {
-		return nil, apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON422, resp.JSON500)
+		return nil, withResponseRequestID(apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON422, resp.JSON500), resp.HTTPResponse, a.requestIDHeader())
 	}
-	return resp, nil
+	return tripResult(resp.JSON200), nil
 }
 
-func (a Adapter) PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.PublishTripClientResponse, error) {
-	client, err := a.newClient(baseURL, bearerToken)
+func (a *Adapter) PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.PublishResult, error) {
+	client, err := a.client(baseURL, bearerToken)
 	if err != nil {
 		return nil, exitcode.New(exitcode.KindServer, "failed to init client", err)
 	}
 	resp, err := client.PublishTripWithResponse(ctx, tripID)
 	if err != nil {
-		return nil, exitcode.New(exitcode.KindServer, "request failed", err)
+		return nil, transportError(baseURL, err)
 	}
 	if resp.StatusCode() >= 400 {
-		return nil, apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON422, resp.JSON500)
+		return nil, withResponseRequestID(apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON422, resp.JSON500), resp.HTTPResponse, a.requestIDHeader())
 	}
-	return resp, nil
+	if resp.JSON200 == nil {
+		return nil, nil
+	}
+	return &outplannerapi.PublishResult{AnnouncementCopy: resp.JSON200.AnnouncementCopy, Trip: toTrip(resp.JSON200.Trip)}, nil
 }
 
-func (a Adapter) AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.AddTripOrganizerJSONRequestBody) (*gen.AddTripOrganizerClientResponse, error) {
-	client, err := a.newClient(baseURL, bearerToken)
+func (a *Adapter) AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.AddOrganizerRequest) (*outplannerapi.TripResult, error) {
+	client, err := a.client(baseURL, bearerToken)
 	if err != nil {
 		return nil, exitcode.New(exitcode.KindServer, "failed to init client", err)
 	}
+	body, err := jsonBody(req)
+	if err != nil {
+		return nil, err
+	}
 	params := &gen.AddTripOrganizerParams{IdempotencyKey: idempotencyKey}
-	resp, err := client.AddTripOrganizerWithResponse(ctx, tripID, params, req)
+	resp, err := client.AddTripOrganizerWithBodyWithResponse(ctx, tripID, params, jsonContentType, body)
 	if err != nil {
-		return nil, exitcode.New(exitcode.KindServer, "request failed", err)
+		return nil, transportError(baseURL, err)
 	}
 	if resp.StatusCode() >= 400 {
-		return nil, apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON422, resp.JSON500)
+		return nil, withResponseRequestID(apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON422, resp.JSON500), resp.HTTPResponse, a.requestIDHeader())
 	}
-	return resp, nil
+	return tripResult(resp.JSON200), nil
 }
 
-func (a Adapter) RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, memberID gen.MemberId, idempotencyKey string) (*gen.RemoveTripOrganizerClientResponse, error) {
-	client, err := a.newClient(baseURL, bearerToken)
+func (a *Adapter) RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, memberID string, idempotencyKey string) (*outplannerapi.TripResult, error) {
+	client, err := a.client(baseURL, bearerToken)
 	if err != nil {
 		return nil, exitcode.New(exitcode.KindServer, "failed to init client", err)
 	}
 	params := &gen.RemoveTripOrganizerParams{IdempotencyKey: idempotencyKey}
 	resp, err := client.RemoveTripOrganizerWithResponse(ctx, tripID, memberID, params)
 	if err != nil {
-		return nil, exitcode.New(exitcode.KindServer, "request failed", err)
+		return nil, transportError(baseURL, err)
 	}
 	if resp.StatusCode() >= 400 {
-		return nil, apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON422, resp.JSON500)
+		return nil, withResponseRequestID(apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON422, resp.JSON500), resp.HTTPResponse, a.requestIDHeader())
 	}
-	return resp, nil
+	return tripResult(resp.JSON200), nil
 }
 
-func (a Adapter) SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetMyRSVPJSONRequestBody) (*gen.SetMyRSVPClientResponse, error) {
-	client, err := a.newClient(baseURL, bearerToken)
+func (a *Adapter) SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req outplannerapi.SetMyRSVPRequest) (*outplannerapi.RSVPResult, error) {
+	client, err := a.client(baseURL, bearerToken)
 	if err != nil {
 		return nil, exitcode.New(exitcode.KindServer, "failed to init client", err)
 	}
+	body, err := jsonBody(req)
+	if err != nil {
+		return nil, err
+	}
 	params := &gen.SetMyRSVPParams{IdempotencyKey: idempotencyKey}
-	resp, err := client.SetMyRSVPWithResponse(ctx, tripID, params, req)
+	resp, err := client.SetMyRSVPWithBodyWithResponse(ctx, tripID, params, jsonContentType, body)
 	if err != nil {
-		return nil, exitcode.New(exitcode.KindServer, "request failed", err)
+		return nil, transportError(baseURL, err)
 	}
 	if resp.StatusCode() >= 400 {
-		return nil, apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON422, resp.JSON500)
+		return nil, withResponseRequestID(apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON422, resp.JSON500), resp.HTTPResponse, a.requestIDHeader())
+	}
+	if resp.JSON200 == nil {
+		return nil, nil
 	}
-	return resp, nil
+	return &outplannerapi.RSVPResult{MyRSVP: toRSVP(resp.JSON200.MyRsvp)}, nil
 }
 
-func (a Adapter) GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetMyRSVPForTripClientResponse, error) {
-	client, err := a.newClient(baseURL, bearerToken)
+func (a *Adapter) GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.RSVPResult, error) {
+	client, err := a.client(baseURL, bearerToken)
 	if err != nil {
 		return nil, exitcode.New(exitcode.KindServer, "failed to init client", err)
 	}
 	resp, err := client.GetMyRSVPForTripWithResponse(ctx, tripID)
 	if err != nil {
-		return nil, exitcode.New(exitcode.KindServer, "request failed", err)
+		return nil, transportError(baseURL, err)
 	}
 	if resp.StatusCode() >= 400 {
-		return nil, apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON500)
+		return nil, withResponseRequestID(apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON500), resp.HTTPResponse, a.requestIDHeader())
 	}
-	return resp, nil
+	if resp.JSON200 == nil {
+		return nil, nil
+	}
+	return &outplannerapi.RSVPResult{MyRSVP: toRSVP(resp.JSON200.MyRsvp)}, nil
 }
 
-func (a Adapter) GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripRSVPSummaryClientResponse, error) {
-	client, err := a.newClient(baseURL, bearerToken)
+func (a *Adapter) GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID string) (*outplannerapi.RSVPSummaryResult, error) {
+	client, err := a.client(baseURL, bearerToken)
 	if err != nil {
 		return nil, exitcode.New(exitcode.KindServer, "failed to init client", err)
 	}
 	resp, err := client.GetTripRSVPSummaryWithResponse(ctx, tripID)
 	if err != nil {
-		return nil, exitcode.New(exitcode.KindServer, "request failed", err)
+		return nil, transportError(baseURL, err)
 	}
 	if resp.StatusCode() >= 400 {
-		return nil, apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON500)
+		return nil, withResponseRequestID(apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON500), resp.HTTPResponse, a.requestIDHeader())
+	}
+	if resp.JSON200 == nil {
+		return nil, nil
 	}
-	return resp, nil
+	return &outplannerapi.RSVPSummaryResult{RSVPSummary: toRSVPSummary(resp.JSON200.RsvpSummary)}, nil
 }
 
-func (a Adapter) CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey *string) (*gen.CancelTripClientResponse, error) {
-	client, err := a.newClient(baseURL, bearerToken)
+func (a *Adapter) CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey *string) (*outplannerapi.TripResult, error) {
+	client, err := a.client(baseURL, bearerToken)
 	if err != nil {
 		return nil, exitcode.New(exitcode.KindServer, "failed to init client", err)
 	}
 	params := &gen.CancelTripParams{IdempotencyKey: idempotencyKey}
 	resp, err := client.CancelTripWithResponse(ctx, tripID, params)
 	if err != nil {
-		return nil, exitcode.New(exitcode.KindServer, "request failed", err)
+		return nil, transportError(baseURL, err)
 	}
 	if resp.StatusCode() >= 400 {
-		return nil, apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON422, resp.JSON500)
+		return nil, withResponseRequestID(apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON422, resp.JSON500), resp.HTTPResponse, a.requestIDHeader())
 	}
-	return resp, nil
+	return tripResult(resp.JSON200), nil
 }
 
-func (a Adapter) ListMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.ListMembersParams) (*gen.ListMembersClientResponse, error) {
-	client, err := a.newClient(baseURL, bearerToken)
+func (a *Adapter) ListMembers(ctx context.Context, baseURL string, bearerToken string, includeInactive bool) (*outplannerapi.MemberList, error) {
+	client, err := a.client(baseURL, bearerToken)
 	if err != nil {
 		return nil, exitcode.New(exitcode.KindServer, "failed to init client", err)
 	}
+	var params *gen.ListMembersParams
+	if includeInactive {
+		params = &gen.ListMembersParams{IncludeInactive: &includeInactive}
+	}
 	resp, err := client.ListMembersWithResponse(ctx, params)
 	if err != nil {
-		return nil, exitcode.New(exitcode.KindServer, "request failed", err)
+		return nil, transportError(baseURL, err)
 	}
 	if resp.StatusCode() >= 400 {
-		return nil, apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON500)
+		return nil, withResponseRequestID(apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON500), resp.HTTPResponse, a.requestIDHeader())
 	}
-	return resp, nil
+	if resp.JSON200 == nil {
+		return nil, nil
+	}
+	return &outplannerapi.MemberList{Members: toDirectory(resp.JSON200.Members)}, nil
 }
 
-func (a Adapter) SearchMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.SearchMembersParams) (*gen.SearchMembersClientResponse, error) {
-	client, err := a.newClient(baseURL, bearerToken)
+func (a *Adapter) SearchMembers(ctx context.Context, baseURL string, bearerToken string, query string) (*outplannerapi.MemberList, error) {
+	client, err := a.client(baseURL, bearerToken)
 	if err != nil {
 		return nil, exitcode.New(exitcode.KindServer, "failed to init client", err)
 	}
-	resp, err := client.SearchMembersWithResponse(ctx, params)
+	resp, err := client.SearchMembersWithResponse(ctx, &gen.SearchMembersParams{Q: query})
 	if err != nil {
-		return nil, exitcode.New(exitcode.KindServer, "request failed", err)
+		return nil, transportError(baseURL, err)
 	}
 	if resp.StatusCode() >= 400 {
-		return nil, apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON422, resp.JSON500)
+		return nil, withResponseRequestID(apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON422, resp.JSON500), resp.HTTPResponse, a.requestIDHeader())
+	}
+	if resp.JSON200 == nil {
+		return nil, nil
 	}
-	return resp, nil
+	return &outplannerapi.MemberList{Members: toDirectory(resp.JSON200.Members)}, nil
 }
 
-func (a Adapter) GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*gen.GetMyMemberProfileClientResponse, error) {
-	client, err := a.newClient(baseURL, bearerToken)
+func (a *Adapter) GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*outplannerapi.MemberResult, error) {
+	client, err := a.client(baseURL, bearerToken)
 	if err != nil {
 		return nil, exitcode.New(exitcode.KindServer, "failed to init client", err)
 	}
 	resp, err := client.GetMyMemberProfileWithResponse(ctx)
 	if err != nil {
-		return nil, exitcode.New(exitcode.KindServer, "request failed", err)
+		return nil, transportError(baseURL, err)
 	}
 	if resp.StatusCode() >= 400 {
-		return nil, apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON500)
+		return nil, withResponseRequestID(apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON500), resp.HTTPResponse, a.requestIDHeader())
 	}
-	return resp, nil
+	if resp.JSON200 == nil {
+		return nil, nil
+	}
+	return &outplannerapi.MemberResult{Member: toMember(resp.JSON200.Member)}, nil
 }
 
-func (a Adapter) DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.DeleteMyMemberAccountJSONRequestBody) (*gen.DeleteMyMemberAccountClientResponse, error) {
-	client, err := a.newClient(baseURL, bearerToken)
+func (a *Adapter) DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.DeleteMemberRequest) (*outplannerapi.MemberDeleted, error) {
+	client, err := a.client(baseURL, bearerToken)
 	if err != nil {
 		return nil, exitcode.New(exitcode.KindServer, "failed to init client", err)
 	}
+	body, err := jsonBody(req)
+	if err != nil {
+		return nil, err
+	}
 	params := &gen.DeleteMyMemberAccountParams{IdempotencyKey: idempotencyKey}
-	resp, err := client.DeleteMyMemberAccountWithResponse(ctx, params, req)
+	resp, err := client.DeleteMyMemberAccountWithBodyWithResponse(ctx, params, jsonContentType, body)
 	if err != nil {
-		return nil, exitcode.New(exitcode.KindServer, "request failed", err)
+		return nil, transportError(baseURL, err)
 	}
 	if resp.StatusCode() >= 400 {
-		return nil, apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON500)
+		return nil, withResponseRequestID(apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON500), resp.HTTPResponse, a.requestIDHeader())
 	}
-	return resp, nil
+	if resp.JSON200 == nil {
+		return nil, nil
+	}
+	return &outplannerapi.MemberDeleted{Deleted: resp.JSON200.Deleted, DeletedAt: resp.JSON200.DeletedAt}, nil
 }
 
-func (a Adapter) CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req gen.CreateMyMemberJSONRequestBody) (*gen.CreateMyMemberClientResponse, error) {
-	client, err := a.newClient(baseURL, bearerToken)
+func (a *Adapter) CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req outplannerapi.CreateMemberRequest) (*outplannerapi.MemberResult, error) {
+	client, err := a.client(baseURL, bearerToken)
 	if err != nil {
 		return nil, exitcode.New(exitcode.KindServer, "failed to init client", err)
 	}
-	resp, err := client.CreateMyMemberWithResponse(ctx, req)
+	body, err := jsonBody(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.CreateMyMemberWithBodyWithResponse(ctx, jsonContentType, body)
 	if err != nil {
-		return nil, exitcode.New(exitcode.KindServer, "request failed", err)
+		return nil, transportError(baseURL, err)
 	}
 	if resp.StatusCode() >= 400 {
-		return nil, apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON409, resp.JSON422, resp.JSON500)
+		return nil, withResponseRequestID(apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON409, resp.JSON422, resp.JSON500), resp.HTTPResponse, a.requestIDHeader())
+	}
+	if resp.JSON201 == nil {
+		return nil, nil
 	}
-	return resp, nil
+	return &outplannerapi.MemberResult{Member: toMember(resp.JSON201.Member)}, nil
 }
 
-func (a Adapter) UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.UpdateMyMemberProfileJSONRequestBody) (*gen.UpdateMyMemberProfileClientResponse, error) {
-	client, err := a.newClient(baseURL, bearerToken)
+func (a *Adapter) UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req outplannerapi.UpdateMemberRequest) (*outplannerapi.MemberResult, error) {
+	client, err := a.client(baseURL, bearerToken)
 	if err != nil {
 		return nil, exitcode.New(exitcode.KindServer, "failed to init client", err)
 	}
+	body, err := jsonBody(req)
+	if err != nil {
+		return nil, err
+	}
 	params := &gen.UpdateMyMemberProfileParams{IdempotencyKey: idempotencyKey}
-	resp, err := client.UpdateMyMemberProfileWithResponse(ctx, params, req)
+	resp, err := client.UpdateMyMemberProfileWithBodyWithResponse(ctx, params, jsonContentType, body)
 	if err != nil {
-		return nil, exitcode.New(exitcode.KindServer, "request failed", err)
+		return nil, transportError(baseURL, err)
 	}
 	if resp.StatusCode() >= 400 {
-		return nil, apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON422, resp.JSON500)
+		return nil, withResponseRequestID(apiErrorFromAny(resp.StatusCode(), resp.JSON401, resp.JSON404, resp.JSON409, resp.JSON422, resp.JSON500), resp.HTTPResponse, a.requestIDHeader())
+	}
+	if resp.JSON200 == nil {
+		return nil, nil
 	}
-	return resp, nil
+	return &outplannerapi.MemberResult{Member: toMember(resp.JSON200.Member)}, nil
 }
@@ -0,0 +1,46 @@
+package plannerapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// BenchmarkAdapter_ListMembers compares one adapter reused across calls (the
+// per-invocation behavior) with rebuilding the client and transport for every
+// call, which pays a fresh TLS handshake each time.
+func BenchmarkAdapter_ListMembers(b *testing.B) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"members":[{"memberId":"m1","displayName":"Alice"}]}`))
+	}))
+	defer srv.Close()
+
+	tlsTransport := srv.Client().Transport.(*http.Transport)
+	ctx := context.Background()
+
+	b.Run("reused", func(b *testing.B) {
+		a := &Adapter{HTTPClient: &http.Client{Transport: tlsTransport.Clone()}}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := a.ListMembers(ctx, srv.URL, "tok", false); err != nil {
+				b.Fatalf("list: %v", err)
+			}
+		}
+	})
+
+	b.Run("rebuilt_per_call", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			tr := tlsTransport.Clone()
+			a := &Adapter{HTTPClient: &http.Client{Transport: tr}}
+			if _, err := a.ListMembers(ctx, srv.URL, "tok", false); err != nil {
+				b.Fatalf("list: %v", err)
+			}
+			tr.CloseIdleConnections()
+		}
+	})
+}
@@ -4,15 +4,28 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
-	gen "github.com/Overland-East-Bay/trip-planner-cli/internal/gen/plannerapi"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/apispec"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/httpx"
+	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
 )
 
+// newTestAdapter returns an Adapter whose traffic is checked against the
+// pinned OpenAPI document; any mismatch fails the test.
+func newTestAdapter(t *testing.T) *Adapter {
+	t.Helper()
+	return &Adapter{HTTPClient: &http.Client{Transport: &apispec.Transport{
+		Base:   httpx.SharedTransport(),
+		Report: func(m apispec.Mismatch) { t.Errorf("contract mismatch: %s", m) },
+	}}}
+}
+
 func TestAdapter_SendsAuthorizationHeader(t *testing.T) {
 	seenAuth := ""
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -22,8 +35,8 @@ func TestAdapter_SendsAuthorizationHeader(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.ListMembers(context.Background(), srv.URL, "tok", nil)
+	a := newTestAdapter(t)
+	_, err := a.ListMembers(context.Background(), srv.URL, "tok", false)
 	if err != nil {
 		t.Fatalf("err: %v", err)
 	}
@@ -41,8 +54,8 @@ func TestAdapter_SearchMembers_HitsEndpointWithQuery(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.SearchMembers(context.Background(), srv.URL, "tok", &gen.SearchMembersParams{Q: "bob"})
+	a := newTestAdapter(t)
+	_, err := a.SearchMembers(context.Background(), srv.URL, "tok", "bob")
 	if err != nil {
 		t.Fatalf("err: %v", err)
 	}
@@ -56,11 +69,11 @@ func TestAdapter_GetMyMemberProfile_HitsEndpoint(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		seen = r.Method + " " + r.URL.Path
 		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`{"member":{"memberId":"m1","displayName":"D","email":"d@example.com","groupAliasEmail":null,"vehicleProfile":null}}`))
+		_, _ = w.Write([]byte(`{"member":{"memberId":"m1","displayName":"D","email":"d@example.com","groupAliasEmail":null}}`))
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
+	a := newTestAdapter(t)
 	_, err := a.GetMyMemberProfile(context.Background(), srv.URL, "tok")
 	if err != nil {
 		t.Fatalf("err: %v", err)
@@ -80,8 +93,8 @@ func TestAdapter_SearchMembers_Maps401ToAuth(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.SearchMembers(context.Background(), srv.URL, "tok", &gen.SearchMembersParams{Q: "bob"})
+	a := newTestAdapter(t)
+	_, err := a.SearchMembers(context.Background(), srv.URL, "tok", "bob")
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -100,7 +113,7 @@ func TestAdapter_GetMyMemberProfile_Maps404ToNotFound(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
+	a := newTestAdapter(t)
 	_, err := a.GetMyMemberProfile(context.Background(), srv.URL, "tok")
 	if err == nil {
 		t.Fatalf("expected error")
@@ -124,8 +137,8 @@ func TestAdapter_DeleteMyMemberAccount_HitsEndpointWithIdempotencyAndBody(t *tes
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.DeleteMyMemberAccount(context.Background(), srv.URL, "tok", "k1", gen.DeleteMyMemberRequest{Confirm: true, Reason: ptr("bye")})
+	a := newTestAdapter(t)
+	_, err := a.DeleteMyMemberAccount(context.Background(), srv.URL, "tok", "k1", outplannerapi.DeleteMemberRequest{Confirm: true, Reason: ptr("bye")})
 	if err != nil {
 		t.Fatalf("err: %v", err)
 	}
@@ -153,8 +166,8 @@ func TestAdapter_DeleteMyMemberAccount_Maps409ToConflict(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.DeleteMyMemberAccount(context.Background(), srv.URL, "tok", "k1", gen.DeleteMyMemberRequest{Confirm: true})
+	a := newTestAdapter(t)
+	_, err := a.DeleteMyMemberAccount(context.Background(), srv.URL, "tok", "k1", outplannerapi.DeleteMemberRequest{Confirm: true})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -171,12 +184,13 @@ func TestAdapter_CreateMyMember_HitsEndpoint(t *testing.T) {
 		b, _ := io.ReadAll(r.Body)
 		seenBody = string(b)
 		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`{"member":{"memberId":"m1","displayName":"D","email":"d@example.com","groupAliasEmail":null,"vehicleProfile":null}}`))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"member":{"memberId":"m1","displayName":"D","email":"d@example.com","groupAliasEmail":null}}`))
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.CreateMyMember(context.Background(), srv.URL, "tok", gen.CreateMyMemberJSONRequestBody{DisplayName: "D", Email: "d@example.com"})
+	a := newTestAdapter(t)
+	_, err := a.CreateMyMember(context.Background(), srv.URL, "tok", outplannerapi.CreateMemberRequest{DisplayName: "D", Email: "d@example.com"})
 	if err != nil {
 		t.Fatalf("err: %v", err)
 	}
@@ -200,8 +214,8 @@ func TestAdapter_CreateMyMember_Maps409ToConflict(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.CreateMyMember(context.Background(), srv.URL, "tok", gen.CreateMyMemberJSONRequestBody{DisplayName: "D", Email: "d@example.com"})
+	a := newTestAdapter(t)
+	_, err := a.CreateMyMember(context.Background(), srv.URL, "tok", outplannerapi.CreateMemberRequest{DisplayName: "D", Email: "d@example.com"})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -220,8 +234,8 @@ func TestAdapter_CreateMyMember_Maps401ToAuth(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.CreateMyMember(context.Background(), srv.URL, "tok", gen.CreateMyMemberJSONRequestBody{DisplayName: "D", Email: "d@example.com"})
+	a := newTestAdapter(t)
+	_, err := a.CreateMyMember(context.Background(), srv.URL, "tok", outplannerapi.CreateMemberRequest{DisplayName: "D", Email: "d@example.com"})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -240,8 +254,8 @@ func TestAdapter_CreateMyMember_Maps422ToValidation(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.CreateMyMember(context.Background(), srv.URL, "tok", gen.CreateMyMemberJSONRequestBody{DisplayName: "D", Email: "d@example.com"})
+	a := newTestAdapter(t)
+	_, err := a.CreateMyMember(context.Background(), srv.URL, "tok", outplannerapi.CreateMemberRequest{DisplayName: "D", Email: "d@example.com"})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -251,8 +265,8 @@ func TestAdapter_CreateMyMember_Maps422ToValidation(t *testing.T) {
 }
 
 func TestAdapter_CreateMyMember_RequestErrorIsServer(t *testing.T) {
-	a := Adapter{}
-	_, err := a.CreateMyMember(context.Background(), "://bad", "tok", gen.CreateMyMemberJSONRequestBody{DisplayName: "D", Email: "d@example.com"})
+	a := newTestAdapter(t)
+	_, err := a.CreateMyMember(context.Background(), "://bad", "tok", outplannerapi.CreateMemberRequest{DisplayName: "D", Email: "d@example.com"})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -268,19 +282,20 @@ func TestAdapter_IdempotencyHeader_RequiredAndOptional(t *testing.T) {
 		case r.Method == http.MethodPost && r.URL.Path == "/trips":
 			createKey = r.Header.Get("Idempotency-Key")
 			w.Header().Set("Content-Type", "application/json")
-			_, _ = w.Write([]byte(`{"trip":{"tripId":"t1"}}`))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"trip":{"tripId":"t1","status":"DRAFT","draftVisibility":"PRIVATE"}}`))
 		case r.Method == http.MethodPost && r.URL.Path == "/trips/t1/cancel":
 			cancelKey = r.Header.Get("Idempotency-Key")
 			w.Header().Set("Content-Type", "application/json")
-			_, _ = w.Write([]byte(`{}`))
+			_, _ = w.Write([]byte(`{"trip":{"tripId":"t1","status":"CANCELED","organizers":[],"artifacts":[],"rsvpActionsEnabled":false}}`))
 		default:
 			w.WriteHeader(500)
 		}
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.CreateTripDraft(context.Background(), srv.URL, "tok", "k1", gen.CreateTripDraftJSONRequestBody{Name: "n"})
+	a := newTestAdapter(t)
+	_, err := a.CreateTripDraft(context.Background(), srv.URL, "tok", "k1", outplannerapi.CreateTripDraftRequest{Name: "n"})
 	if err != nil {
 		t.Fatalf("create err: %v", err)
 	}
@@ -288,7 +303,7 @@ func TestAdapter_IdempotencyHeader_RequiredAndOptional(t *testing.T) {
 		t.Fatalf("create idempotency: got %q", createKey)
 	}
 
-	_, err = a.CancelTrip(context.Background(), srv.URL, "tok", gen.TripId("t1"), nil)
+	_, err = a.CancelTrip(context.Background(), srv.URL, "tok", "t1", nil)
 	if err != nil {
 		t.Fatalf("cancel err: %v", err)
 	}
@@ -297,7 +312,7 @@ func TestAdapter_IdempotencyHeader_RequiredAndOptional(t *testing.T) {
 	}
 
 	k := "k2"
-	_, err = a.CancelTrip(context.Background(), srv.URL, "tok", gen.TripId("t1"), &k)
+	_, err = a.CancelTrip(context.Background(), srv.URL, "tok", "t1", &k)
 	if err != nil {
 		t.Fatalf("cancel2 err: %v", err)
 	}
@@ -320,8 +335,8 @@ func TestAdapter_ErrorDecoding_MapsExitKind(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.ListMembers(context.Background(), srv.URL, "tok", nil)
+	a := newTestAdapter(t)
+	_, err := a.ListMembers(context.Background(), srv.URL, "tok", false)
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -332,8 +347,8 @@ func TestAdapter_ErrorDecoding_MapsExitKind(t *testing.T) {
 
 func TestAdapter_CreateTripDraft_RequestErrorIsServer(t *testing.T) {
 	// invalid base URL forces client.NewClientWithResponses to error
-	a := Adapter{}
-	_, err := a.CreateTripDraft(context.Background(), "://bad", "tok", "k1", gen.CreateTripDraftJSONRequestBody{Name: "n"})
+	a := newTestAdapter(t)
+	_, err := a.CreateTripDraft(context.Background(), "://bad", "tok", "k1", outplannerapi.CreateTripDraftRequest{Name: "n"})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -352,8 +367,8 @@ func TestAdapter_CancelTrip_Maps404ToNotFound(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.CancelTrip(context.Background(), srv.URL, "tok", gen.TripId("t1"), nil)
+	a := newTestAdapter(t)
+	_, err := a.CancelTrip(context.Background(), srv.URL, "tok", "t1", nil)
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -375,12 +390,12 @@ func TestAdapter_UpdateTrip_SendsIdempotencyHeader(t *testing.T) {
 		}
 		seenKey = r.Header.Get("Idempotency-Key")
 		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`{"trip":{"tripId":"t1"}}`))
+		_, _ = w.Write([]byte(`{"trip":{"tripId":"t1","status":"DRAFT","organizers":[],"artifacts":[],"rsvpActionsEnabled":false}}`))
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.UpdateTrip(context.Background(), srv.URL, "tok", gen.TripId("t1"), "k1", gen.UpdateTripJSONRequestBody{})
+	a := newTestAdapter(t)
+	_, err := a.UpdateTrip(context.Background(), srv.URL, "tok", "t1", "k1", outplannerapi.UpdateTripRequest{})
 	if err != nil {
 		t.Fatalf("err: %v", err)
 	}
@@ -390,8 +405,8 @@ func TestAdapter_UpdateTrip_SendsIdempotencyHeader(t *testing.T) {
 }
 
 func TestAdapter_UpdateTrip_RequestErrorIsServer(t *testing.T) {
-	a := Adapter{}
-	_, err := a.UpdateTrip(context.Background(), "://bad", "tok", gen.TripId("t1"), "k1", gen.UpdateTripJSONRequestBody{})
+	a := newTestAdapter(t)
+	_, err := a.UpdateTrip(context.Background(), "://bad", "tok", "t1", "k1", outplannerapi.UpdateTripRequest{})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -423,8 +438,8 @@ func TestAdapter_UpdateMyMemberProfile_SendsIdempotencyHeader(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.UpdateMyMemberProfile(context.Background(), srv.URL, "tok", "k1", gen.UpdateMyMemberProfileJSONRequestBody{})
+	a := newTestAdapter(t)
+	_, err := a.UpdateMyMemberProfile(context.Background(), srv.URL, "tok", "k1", outplannerapi.UpdateMemberRequest{})
 	if err != nil {
 		t.Fatalf("err: %v", err)
 	}
@@ -434,8 +449,8 @@ func TestAdapter_UpdateMyMemberProfile_SendsIdempotencyHeader(t *testing.T) {
 }
 
 func TestAdapter_UpdateMyMemberProfile_RequestErrorIsServer(t *testing.T) {
-	a := Adapter{}
-	_, err := a.UpdateMyMemberProfile(context.Background(), "://bad", "tok", "k1", gen.UpdateMyMemberProfileJSONRequestBody{})
+	a := newTestAdapter(t)
+	_, err := a.UpdateMyMemberProfile(context.Background(), "://bad", "tok", "k1", outplannerapi.UpdateMemberRequest{})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -453,7 +468,7 @@ func TestAdapter_ListVisibleTripsForMember_HitsTripsEndpoint(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
+	a := newTestAdapter(t)
 	_, err := a.ListVisibleTripsForMember(context.Background(), srv.URL, "tok")
 	if err != nil {
 		t.Fatalf("err: %v", err)
@@ -472,7 +487,7 @@ func TestAdapter_ListMyDraftTrips_HitsDraftsEndpoint(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
+	a := newTestAdapter(t)
 	_, err := a.ListMyDraftTrips(context.Background(), srv.URL, "tok")
 	if err != nil {
 		t.Fatalf("err: %v", err)
@@ -491,8 +506,8 @@ func TestAdapter_GetTripDetails_HitsTripEndpoint(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.GetTripDetails(context.Background(), srv.URL, "tok", gen.TripId("t1"))
+	a := newTestAdapter(t)
+	_, err := a.GetTripDetails(context.Background(), srv.URL, "tok", "t1")
 	if err != nil {
 		t.Fatalf("err: %v", err)
 	}
@@ -511,8 +526,8 @@ func TestAdapter_GetTripDetails_Maps404ToNotFound(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.GetTripDetails(context.Background(), srv.URL, "tok", gen.TripId("t1"))
+	a := newTestAdapter(t)
+	_, err := a.GetTripDetails(context.Background(), srv.URL, "tok", "t1")
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -532,8 +547,8 @@ func TestAdapter_SetTripDraftVisibility_SendsIdempotencyHeader(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.SetTripDraftVisibility(context.Background(), srv.URL, "tok", gen.TripId("t1"), "k1", gen.SetDraftVisibilityRequest{DraftVisibility: "PUBLIC"})
+	a := newTestAdapter(t)
+	_, err := a.SetTripDraftVisibility(context.Background(), srv.URL, "tok", "t1", "k1", outplannerapi.SetDraftVisibilityRequest{DraftVisibility: "PUBLIC"})
 	if err != nil {
 		t.Fatalf("err: %v", err)
 	}
@@ -554,8 +569,8 @@ func TestAdapter_PublishTrip_HitsPublishEndpoint(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.PublishTrip(context.Background(), srv.URL, "tok", gen.TripId("t1"))
+	a := newTestAdapter(t)
+	_, err := a.PublishTrip(context.Background(), srv.URL, "tok", "t1")
 	if err != nil {
 		t.Fatalf("err: %v", err)
 	}
@@ -574,8 +589,8 @@ func TestAdapter_PublishTrip_Maps404ToNotFound(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.PublishTrip(context.Background(), srv.URL, "tok", gen.TripId("t1"))
+	a := newTestAdapter(t)
+	_, err := a.PublishTrip(context.Background(), srv.URL, "tok", "t1")
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -594,8 +609,8 @@ func TestAdapter_PublishTrip_Maps401ToAuth(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.PublishTrip(context.Background(), srv.URL, "tok", gen.TripId("t1"))
+	a := newTestAdapter(t)
+	_, err := a.PublishTrip(context.Background(), srv.URL, "tok", "t1")
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -614,8 +629,8 @@ func TestAdapter_SetTripDraftVisibility_Maps404ToNotFound(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.SetTripDraftVisibility(context.Background(), srv.URL, "tok", gen.TripId("t1"), "k1", gen.SetDraftVisibilityRequest{DraftVisibility: "PUBLIC"})
+	a := newTestAdapter(t)
+	_, err := a.SetTripDraftVisibility(context.Background(), srv.URL, "tok", "t1", "k1", outplannerapi.SetDraftVisibilityRequest{DraftVisibility: "PUBLIC"})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -638,8 +653,8 @@ func TestAdapter_AddTripOrganizer_HitsEndpointAndSendsIdempotencyHeader(t *testi
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.AddTripOrganizer(context.Background(), srv.URL, "tok", gen.TripId("t1"), "k1", gen.AddOrganizerRequest{MemberId: "m1"})
+	a := newTestAdapter(t)
+	_, err := a.AddTripOrganizer(context.Background(), srv.URL, "tok", "t1", "k1", outplannerapi.AddOrganizerRequest{MemberID: "m1"})
 	if err != nil {
 		t.Fatalf("err: %v", err)
 	}
@@ -665,8 +680,8 @@ func TestAdapter_RemoveTripOrganizer_HitsEndpointAndSendsIdempotencyHeader(t *te
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.RemoveTripOrganizer(context.Background(), srv.URL, "tok", gen.TripId("t1"), gen.MemberId("m1"), "k1")
+	a := newTestAdapter(t)
+	_, err := a.RemoveTripOrganizer(context.Background(), srv.URL, "tok", "t1", "m1", "k1")
 	if err != nil {
 		t.Fatalf("err: %v", err)
 	}
@@ -692,8 +707,8 @@ func TestAdapter_SetMyRSVP_HitsEndpointAndSendsIdempotencyHeader(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.SetMyRSVP(context.Background(), srv.URL, "tok", gen.TripId("t1"), "k1", gen.SetMyRSVPRequest{Response: "YES"})
+	a := newTestAdapter(t)
+	_, err := a.SetMyRSVP(context.Background(), srv.URL, "tok", "t1", "k1", outplannerapi.SetMyRSVPRequest{Response: "YES"})
 	if err != nil {
 		t.Fatalf("err: %v", err)
 	}
@@ -717,8 +732,8 @@ func TestAdapter_GetMyRSVPForTrip_HitsEndpoint(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.GetMyRSVPForTrip(context.Background(), srv.URL, "tok", gen.TripId("t1"))
+	a := newTestAdapter(t)
+	_, err := a.GetMyRSVPForTrip(context.Background(), srv.URL, "tok", "t1")
 	if err != nil {
 		t.Fatalf("err: %v", err)
 	}
@@ -736,8 +751,8 @@ func TestAdapter_GetTripRSVPSummary_HitsEndpoint(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.GetTripRSVPSummary(context.Background(), srv.URL, "tok", gen.TripId("t1"))
+	a := newTestAdapter(t)
+	_, err := a.GetTripRSVPSummary(context.Background(), srv.URL, "tok", "t1")
 	if err != nil {
 		t.Fatalf("err: %v", err)
 	}
@@ -747,8 +762,8 @@ func TestAdapter_GetTripRSVPSummary_HitsEndpoint(t *testing.T) {
 }
 
 func TestAdapter_SetMyRSVP_RequestErrorIsServer(t *testing.T) {
-	a := Adapter{}
-	_, err := a.SetMyRSVP(context.Background(), "://bad", "tok", gen.TripId("t1"), "k1", gen.SetMyRSVPRequest{Response: "YES"})
+	a := newTestAdapter(t)
+	_, err := a.SetMyRSVP(context.Background(), "://bad", "tok", "t1", "k1", outplannerapi.SetMyRSVPRequest{Response: "YES"})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -767,8 +782,8 @@ func TestAdapter_GetMyRSVPForTrip_Maps404ToNotFound(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.GetMyRSVPForTrip(context.Background(), srv.URL, "tok", gen.TripId("t1"))
+	a := newTestAdapter(t)
+	_, err := a.GetMyRSVPForTrip(context.Background(), srv.URL, "tok", "t1")
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -787,8 +802,8 @@ func TestAdapter_SetMyRSVP_Maps401ToAuth(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.SetMyRSVP(context.Background(), srv.URL, "tok", gen.TripId("t1"), "k1", gen.SetMyRSVPRequest{Response: "YES"})
+	a := newTestAdapter(t)
+	_, err := a.SetMyRSVP(context.Background(), srv.URL, "tok", "t1", "k1", outplannerapi.SetMyRSVPRequest{Response: "YES"})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -807,8 +822,8 @@ func TestAdapter_SetMyRSVP_Maps404ToNotFound(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.SetMyRSVP(context.Background(), srv.URL, "tok", gen.TripId("t1"), "k1", gen.SetMyRSVPRequest{Response: "YES"})
+	a := newTestAdapter(t)
+	_, err := a.SetMyRSVP(context.Background(), srv.URL, "tok", "t1", "k1", outplannerapi.SetMyRSVPRequest{Response: "YES"})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -818,8 +833,8 @@ func TestAdapter_SetMyRSVP_Maps404ToNotFound(t *testing.T) {
 }
 
 func TestAdapter_GetTripRSVPSummary_RequestErrorIsServer(t *testing.T) {
-	a := Adapter{}
-	_, err := a.GetTripRSVPSummary(context.Background(), "://bad", "tok", gen.TripId("t1"))
+	a := newTestAdapter(t)
+	_, err := a.GetTripRSVPSummary(context.Background(), "://bad", "tok", "t1")
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -829,8 +844,8 @@ func TestAdapter_GetTripRSVPSummary_RequestErrorIsServer(t *testing.T) {
 }
 
 func TestAdapter_GetMyRSVPForTrip_RequestErrorIsServer(t *testing.T) {
-	a := Adapter{}
-	_, err := a.GetMyRSVPForTrip(context.Background(), "://bad", "tok", gen.TripId("t1"))
+	a := newTestAdapter(t)
+	_, err := a.GetMyRSVPForTrip(context.Background(), "://bad", "tok", "t1")
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -849,8 +864,8 @@ func TestAdapter_GetTripRSVPSummary_Maps401ToAuth(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.GetTripRSVPSummary(context.Background(), srv.URL, "tok", gen.TripId("t1"))
+	a := newTestAdapter(t)
+	_, err := a.GetTripRSVPSummary(context.Background(), srv.URL, "tok", "t1")
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -869,8 +884,8 @@ func TestAdapter_GetTripRSVPSummary_Maps404ToNotFound(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.GetTripRSVPSummary(context.Background(), srv.URL, "tok", gen.TripId("t1"))
+	a := newTestAdapter(t)
+	_, err := a.GetTripRSVPSummary(context.Background(), srv.URL, "tok", "t1")
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -880,8 +895,8 @@ func TestAdapter_GetTripRSVPSummary_Maps404ToNotFound(t *testing.T) {
 }
 
 func TestAdapter_AddTripOrganizer_RequestErrorIsServer(t *testing.T) {
-	a := Adapter{}
-	_, err := a.AddTripOrganizer(context.Background(), "://bad", "tok", gen.TripId("t1"), "k1", gen.AddOrganizerRequest{MemberId: "m1"})
+	a := newTestAdapter(t)
+	_, err := a.AddTripOrganizer(context.Background(), "://bad", "tok", "t1", "k1", outplannerapi.AddOrganizerRequest{MemberID: "m1"})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -891,8 +906,8 @@ func TestAdapter_AddTripOrganizer_RequestErrorIsServer(t *testing.T) {
 }
 
 func TestAdapter_RemoveTripOrganizer_RequestErrorIsServer(t *testing.T) {
-	a := Adapter{}
-	_, err := a.RemoveTripOrganizer(context.Background(), "://bad", "tok", gen.TripId("t1"), gen.MemberId("m1"), "k1")
+	a := newTestAdapter(t)
+	_, err := a.RemoveTripOrganizer(context.Background(), "://bad", "tok", "t1", "m1", "k1")
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -911,8 +926,8 @@ func TestAdapter_AddTripOrganizer_Maps401ToAuth(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.AddTripOrganizer(context.Background(), srv.URL, "tok", gen.TripId("t1"), "k1", gen.AddOrganizerRequest{MemberId: "m1"})
+	a := newTestAdapter(t)
+	_, err := a.AddTripOrganizer(context.Background(), srv.URL, "tok", "t1", "k1", outplannerapi.AddOrganizerRequest{MemberID: "m1"})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -931,8 +946,8 @@ func TestAdapter_RemoveTripOrganizer_Maps404ToNotFound(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.RemoveTripOrganizer(context.Background(), srv.URL, "tok", gen.TripId("t1"), gen.MemberId("m1"), "k1")
+	a := newTestAdapter(t)
+	_, err := a.RemoveTripOrganizer(context.Background(), srv.URL, "tok", "t1", "m1", "k1")
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -951,8 +966,8 @@ func TestAdapter_AddTripOrganizer_Maps404ToNotFound(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.AddTripOrganizer(context.Background(), srv.URL, "tok", gen.TripId("t1"), "k1", gen.AddOrganizerRequest{MemberId: "m1"})
+	a := newTestAdapter(t)
+	_, err := a.AddTripOrganizer(context.Background(), srv.URL, "tok", "t1", "k1", outplannerapi.AddOrganizerRequest{MemberID: "m1"})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -971,7 +986,7 @@ func TestAdapter_ListVisibleTripsForMember_Maps401ToAuth(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
+	a := newTestAdapter(t)
 	_, err := a.ListVisibleTripsForMember(context.Background(), srv.URL, "tok")
 	if err == nil {
 		t.Fatalf("expected error")
@@ -982,7 +997,7 @@ func TestAdapter_ListVisibleTripsForMember_Maps401ToAuth(t *testing.T) {
 }
 
 func TestAdapter_ListMyDraftTrips_RequestErrorIsServer(t *testing.T) {
-	a := Adapter{}
+	a := newTestAdapter(t)
 	_, err := a.ListMyDraftTrips(context.Background(), "://bad", "tok")
 	if err == nil {
 		t.Fatalf("expected error")
@@ -993,8 +1008,8 @@ func TestAdapter_ListMyDraftTrips_RequestErrorIsServer(t *testing.T) {
 }
 
 func TestAdapter_PublishTrip_RequestErrorIsServer(t *testing.T) {
-	a := Adapter{}
-	_, err := a.PublishTrip(context.Background(), "://bad", "tok", gen.TripId("t1"))
+	a := newTestAdapter(t)
+	_, err := a.PublishTrip(context.Background(), "://bad", "tok", "t1")
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -1004,8 +1019,8 @@ func TestAdapter_PublishTrip_RequestErrorIsServer(t *testing.T) {
 }
 
 func TestAdapter_SetTripDraftVisibility_RequestErrorIsServer(t *testing.T) {
-	a := Adapter{}
-	_, err := a.SetTripDraftVisibility(context.Background(), "://bad", "tok", gen.TripId("t1"), "k1", gen.SetDraftVisibilityRequest{DraftVisibility: "PUBLIC"})
+	a := newTestAdapter(t)
+	_, err := a.SetTripDraftVisibility(context.Background(), "://bad", "tok", "t1", "k1", outplannerapi.SetDraftVisibilityRequest{DraftVisibility: "PUBLIC"})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -1024,8 +1039,8 @@ func TestAdapter_SetTripDraftVisibility_Maps401ToAuth(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	a := Adapter{}
-	_, err := a.SetTripDraftVisibility(context.Background(), srv.URL, "tok", gen.TripId("t1"), "k1", gen.SetDraftVisibilityRequest{DraftVisibility: "PUBLIC"})
+	a := newTestAdapter(t)
+	_, err := a.SetTripDraftVisibility(context.Background(), srv.URL, "tok", "t1", "k1", outplannerapi.SetDraftVisibilityRequest{DraftVisibility: "PUBLIC"})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -1033,3 +1048,57 @@ func TestAdapter_SetTripDraftVisibility_Maps401ToAuth(t *testing.T) {
 		t.Fatalf("expected auth exit 3, got %d", exitcode.Code(err))
 	}
 }
+
+func TestAdapter_ReusesClientPerBaseURLAndToken(t *testing.T) {
+	a := newTestAdapter(t)
+	c1, err := a.client("http://api", "tok")
+	if err != nil {
+		t.Fatalf("client: %v", err)
+	}
+	c2, err := a.client("http://api", "tok")
+	if err != nil {
+		t.Fatalf("client: %v", err)
+	}
+	if c1 != c2 {
+		t.Fatalf("expected same client for same base url + token")
+	}
+	c3, err := a.client("http://api", "other")
+	if err != nil {
+		t.Fatalf("client: %v", err)
+	}
+	if c3 == c1 {
+		t.Fatalf("expected distinct client for a different token")
+	}
+	c4, err := a.client("http://other-api", "tok")
+	if err != nil {
+		t.Fatalf("client: %v", err)
+	}
+	if c4 == c1 {
+		t.Fatalf("expected distinct client for a different base url")
+	}
+}
+
+func TestAdapter_ReusesConnectionsAcrossCalls(t *testing.T) {
+	conns := 0
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"members":[]}`))
+	}))
+	srv.Config.ConnState = func(c net.Conn, s http.ConnState) {
+		if s == http.StateNew {
+			conns++
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	a := Adapter{HTTPClient: &http.Client{Transport: httpx.NewTransport()}}
+	for i := 0; i < 5; i++ {
+		if _, err := a.ListMembers(context.Background(), srv.URL, "tok", false); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+	if conns != 1 {
+		t.Fatalf("expected 1 pooled connection, got %d", conns)
+	}
+}
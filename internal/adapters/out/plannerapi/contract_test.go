@@ -0,0 +1,280 @@
+package plannerapi
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	gen "github.com/Overland-East-Bay/trip-planner-cli/internal/gen/plannerapi"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/apispec"
+)
+
+// The generated client is produced by make gen from the spec at the pinned
+// ref, so it is the one checked-in artifact of the real contract. These tests
+// hold the embedded OpenAPI document (which validates traffic and feeds ebo
+// schema) to it.
+
+const genFile = "../../../gen/plannerapi/client.gen.go"
+
+// genField is one field of a generated struct: its JSON name, whether it is
+// optional (omitempty) and whether it is a pointer.
+type genField struct {
+	name     string
+	optional bool
+	pointer  bool
+}
+
+type genSource struct {
+	src     string
+	structs map[string][]genField
+	enums   map[string][]string
+}
+
+func parseGen(t *testing.T) genSource {
+	t.Helper()
+	b, err := os.ReadFile(filepath.FromSlash(genFile))
+	if err != nil {
+		t.Fatalf("read generated client: %v", err)
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), genFile, b, 0)
+	if err != nil {
+		t.Fatalf("parse generated client: %v", err)
+	}
+	g := genSource{src: string(b), structs: map[string][]genField{}, enums: map[string][]string{}}
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				st, ok := s.Type.(*ast.StructType)
+				if !ok || s.Assign != 0 {
+					continue
+				}
+				for _, field := range st.Fields.List {
+					if field.Tag == nil {
+						continue
+					}
+					tag, _ := strconv.Unquote(field.Tag.Value)
+					name, opts, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+					if name == "" {
+						continue
+					}
+					_, pointer := field.Type.(*ast.StarExpr)
+					g.structs[s.Name.Name] = append(g.structs[s.Name.Name], genField{name: name, optional: opts == "omitempty", pointer: pointer})
+				}
+			case *ast.ValueSpec:
+				typ, ok := s.Type.(*ast.Ident)
+				if !ok || len(s.Values) != 1 {
+					continue
+				}
+				if lit, ok := s.Values[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					v, _ := strconv.Unquote(lit.Value)
+					g.enums[typ.Name] = append(g.enums[typ.Name], v)
+				}
+			}
+		}
+	}
+	return g
+}
+
+func pinnedSpec(t *testing.T) *apispec.Spec {
+	t.Helper()
+	spec, err := apispec.Pinned()
+	if err != nil {
+		t.Fatalf("Pinned: %v", err)
+	}
+	return spec
+}
+
+func TestContract_SchemasMatchGeneratedModels(t *testing.T) {
+	gen, spec := parseGen(t), pinnedSpec(t)
+	for name, s := range spec.Schemas {
+		if len(s.Enum) > 0 {
+			var want []string
+			for _, v := range s.Enum {
+				want = append(want, v.(string))
+			}
+			got := slices.Clone(gen.enums[name])
+			sort.Strings(want)
+			sort.Strings(got)
+			if !slices.Equal(got, want) {
+				t.Errorf("%s: enum %v, generated client has %v", name, want, got)
+			}
+			continue
+		}
+		if s.Type != "object" {
+			continue
+		}
+		fields, ok := gen.structs[name]
+		if !ok {
+			t.Errorf("%s: no generated model", name)
+			continue
+		}
+		var props []string
+		for p := range s.Properties {
+			props = append(props, p)
+		}
+		var genProps []string
+		for _, f := range fields {
+			genProps = append(genProps, f.name)
+		}
+		sort.Strings(props)
+		sort.Strings(genProps)
+		if !slices.Equal(props, genProps) {
+			t.Errorf("%s: properties %v, generated client has %v", name, props, genProps)
+			continue
+		}
+		// oapi-codegen drops omitempty for required and for nullable
+		// properties, and makes nullable ones pointers.
+		for _, f := range fields {
+			p := s.Properties[f.name]
+			required, nullable := slices.Contains(s.Required, f.name), p.Nullable || anyNullable(p)
+			if f.optional != (!required && !nullable) {
+				t.Errorf("%s.%s: required=%v nullable=%v, generated client has omitempty=%v", name, f.name, required, nullable, f.optional)
+			}
+			if !f.optional && !required && !f.pointer {
+				t.Errorf("%s.%s: optional but not a pointer in the generated client", name, f.name)
+			}
+		}
+	}
+	for name := range gen.enums {
+		if _, ok := spec.Schemas[name]; !ok {
+			t.Errorf("generated enum %s has no schema", name)
+		}
+	}
+}
+
+func anyNullable(s *apispec.Schema) bool {
+	for _, sub := range slices.Concat(s.AllOf, s.OneOf, s.AnyOf) {
+		if sub.Nullable {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	genRequestFunc = regexp.MustCompile(`(?s)func New(\w+?)Request(?:WithBody)?\(server string.*?operationPath := fmt\.Sprintf\("([^"]*)".*?http\.NewRequest\("(\w+)"`)
+	genPathParam   = regexp.MustCompile(`\{[^}]*\}`)
+	genResponse    = regexp.MustCompile(`(?m)^type (\w+)ClientResponse struct \{([^}]*(?:\{[^}]*\}[^}]*)*)\n\}`)
+	genJSONStatus  = regexp.MustCompile(`\bJSON(\d{3})\b`)
+)
+
+func TestContract_OperationsMatchGeneratedClient(t *testing.T) {
+	gen, spec := parseGen(t), pinnedSpec(t)
+	ops := map[string]*apispec.Operation{}
+	for _, op := range spec.Operations {
+		ops[strings.ToUpper(op.ID[:1])+op.ID[1:]] = op
+	}
+
+	seen := map[string]bool{}
+	for _, m := range genRequestFunc.FindAllStringSubmatch(gen.src, -1) {
+		name, path, method := m[1], m[2], m[3]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		op, ok := ops[name]
+		if !ok {
+			t.Errorf("generated operation %s is missing from the document", name)
+			continue
+		}
+		if got := genPathParam.ReplaceAllString(op.Path, "%s"); op.Method != method || got != path {
+			t.Errorf("%s: %s %s, generated client has %s %s", name, op.Method, op.Path, method, path)
+		}
+	}
+	for name := range ops {
+		if !seen[name] {
+			t.Errorf("%s: not in the generated client", name)
+		}
+	}
+
+	for name, op := range ops {
+		var got, want []string
+		for _, p := range op.Parameters {
+			if p.In == "query" || p.In == "header" {
+				got = append(got, p.Name+":"+strconv.FormatBool(p.Required))
+			}
+		}
+		for _, f := range gen.structs[name+"Params"] {
+			want = append(want, f.name+":"+strconv.FormatBool(!f.optional))
+		}
+		sort.Strings(got)
+		sort.Strings(want)
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: query/header parameters %v, generated client has %v", name, got, want)
+		}
+	}
+
+	checked := 0
+	for _, m := range genResponse.FindAllStringSubmatch(gen.src, -1) {
+		op, ok := ops[m[1]]
+		if !ok {
+			continue
+		}
+		checked++
+		var want []string
+		for _, s := range genJSONStatus.FindAllStringSubmatch(m[2], -1) {
+			want = append(want, s[1])
+		}
+		var got []string
+		for status, r := range op.Responses {
+			if _, ok := r.Content["application/json"]; ok {
+				got = append(got, status)
+			}
+		}
+		sort.Strings(want)
+		sort.Strings(got)
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: JSON responses %v, generated client has %v", m[1], got, want)
+		}
+	}
+	if checked != len(ops) {
+		t.Errorf("compared responses of %d operations, want %d", checked, len(ops))
+	}
+}
+
+// TestContract_RequestSchemasMatchGeneratedTypes checks the JSON Schemas
+// ebo schema emits for request bodies against the json tags of the
+// generated request types.
+func TestContract_RequestSchemasMatchGeneratedTypes(t *testing.T) {
+	spec := pinnedSpec(t)
+	for name, typ := range map[string]reflect.Type{
+		"CreateTripDraftRequest":       reflect.TypeFor[gen.CreateTripDraftRequest](),
+		"UpdateTripRequest":            reflect.TypeFor[gen.UpdateTripRequest](),
+		"CreateMemberRequest":          reflect.TypeFor[gen.CreateMemberRequest](),
+		"UpdateMyMemberProfileRequest": reflect.TypeFor[gen.UpdateMyMemberProfileRequest](),
+	} {
+		doc, err := spec.JSONSchema(name)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		var props []string
+		for p := range doc["properties"].(map[string]any) {
+			props = append(props, p)
+		}
+		var tags []string
+		for i := range typ.NumField() {
+			if tag, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ","); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+		sort.Strings(props)
+		sort.Strings(tags)
+		if !slices.Equal(props, tags) {
+			t.Errorf("%s: schema properties %v, json tags of %s %v", name, props, typ, tags)
+		}
+	}
+}
@@ -1,10 +1,15 @@
 package plannerapi
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	gen "github.com/Overland-East-Bay/trip-planner-cli/internal/gen/plannerapi"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/httpx"
 )
 
 type APIError struct {
@@ -12,6 +17,9 @@ type APIError struct {
 	ErrorCode  string
 	Message    string
 	RequestID  string
+	// Details is the server's free-form error.details (e.g. per-field
+	// validation problems on 422).
+	Details map[string]any
 }
 
 func (e *APIError) Error() string {
@@ -24,6 +32,71 @@ func (e *APIError) Error() string {
 	return e.Message
 }
 
+// DetailLines renders Details as sorted "field: problem" lines. Nested objects
+// use dotted paths; list values are joined with "; ".
+func (e *APIError) DetailLines() []string {
+	if e == nil || len(e.Details) == 0 {
+		return nil
+	}
+	var out []string
+	appendDetailLines(&out, "", e.Details)
+	sort.Strings(out)
+	return out
+}
+
+func appendDetailLines(out *[]string, prefix string, m map[string]any) {
+	for k, v := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]any); ok {
+			appendDetailLines(out, key, nested)
+			continue
+		}
+		*out = append(*out, key+": "+detailValue(v))
+	}
+}
+
+func detailValue(v any) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case []any:
+		parts := make([]string, 0, len(t))
+		for _, item := range t {
+			parts = append(parts, detailValue(item))
+		}
+		return strings.Join(parts, "; ")
+	default:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return fmt.Sprint(t)
+		}
+		return string(b)
+	}
+}
+
+// NewAPIError returns the error a Client method reports for an API error
+// response. In-memory implementations of the port use it so callers see the
+// same exit code, error.code and details as over HTTP.
+func NewAPIError(status int, code, message string, details map[string]any) error {
+	ae := &APIError{StatusCode: status, ErrorCode: code, Message: message, Details: details}
+	return exitcode.New(exitKindForStatus(status), ae.Error(), ae)
+}
+
+// transportError classifies a request that got no HTTP response (DNS,
+// refused, TLS, timeout, proxy, offline cache miss) into an error with a stable
+// error.code and a hint.
+func transportError(baseURL string, err error) error {
+	if errors.Is(err, httpx.ErrOffline) {
+		return &exitcode.Error{Kind: exitcode.KindNetwork, Msg: "offline", Err: err, Code: "offline", Hint: "run without --offline to fetch from the API"}
+	}
+	class := httpx.ClassifyNetError(err)
+	summary, hint := httpx.NetErrorSummary(class, baseURL)
+	return &exitcode.Error{Kind: exitcode.KindNetwork, Msg: summary, Err: err, Code: string(class), Hint: hint}
+}
+
 func exitKindForStatus(status int) exitcode.Kind {
 	switch status {
 	case 401:
@@ -51,7 +124,8 @@ func apiErrorFromAny(status int, variants ...any) error {
 		if v == nil {
 			continue
 		}
-		if er, ok := v.(*gen.ErrorResponse); ok {
+		// Unset variants are typed nil pointers, so check the pointer too.
+		if er, ok := v.(*gen.ErrorResponse); ok && er != nil {
 			return apiErrorFromErrorResponse(status, er)
 		}
 	}
@@ -67,5 +141,8 @@ func apiErrorFromErrorResponse(status int, er *gen.ErrorResponse) *exitcode.Erro
 		requestID = *er.Error.RequestId
 	}
 	ae := &APIError{StatusCode: status, ErrorCode: er.Error.Code, Message: er.Error.Message, RequestID: requestID}
+	if er.Error.Details != nil {
+		ae.Details = *er.Error.Details
+	}
 	return exitcode.New(exitKindForStatus(status), ae.Error(), ae)
 }
@@ -1,11 +1,16 @@
 package plannerapi
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net"
+	"strings"
 	"testing"
 
 	gen "github.com/Overland-East-Bay/trip-planner-cli/internal/gen/plannerapi"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/httpx"
 )
 
 func TestAPIError_Error_NilReceiver(t *testing.T) {
@@ -55,6 +60,18 @@ func TestAPIErrorFromAny_NoVariantUsesStatusOnly(t *testing.T) {
 	}
 }
 
+func TestAPIErrorFromAny_SkipsUnsetVariants(t *testing.T) {
+	er := &gen.ErrorResponse{}
+	er.Error.Code = "TRIP_AT_CAPACITY"
+	er.Error.Message = "full"
+
+	var unauthorized *gen.Unauthorized
+	var ae *APIError
+	if err := apiErrorFromAny(409, unauthorized, er); !errors.As(err, &ae) || ae.ErrorCode != "TRIP_AT_CAPACITY" {
+		t.Fatalf("got %v", err)
+	}
+}
+
 func TestAPIErrorFromErrorResponse_WrapsAPIError(t *testing.T) {
 	rid := "req-1"
 	er := &gen.ErrorResponse{}
@@ -75,3 +92,64 @@ func TestAPIErrorFromErrorResponse_WrapsAPIError(t *testing.T) {
 		t.Fatalf("requestId: got %q", ae.RequestID)
 	}
 }
+
+func TestAPIErrorFromErrorResponse_CarriesDetails(t *testing.T) {
+	details := map[string]any{
+		"capacityRigs": "must be >= 1",
+		"startDate":    []any{"required", "must be before endDate"},
+		"location":     map[string]any{"label": "required"},
+		"maxLength":    float64(80),
+	}
+	er := &gen.ErrorResponse{}
+	er.Error.Code = "VALIDATION_FAILED"
+	er.Error.Message = "invalid trip"
+	er.Error.Details = &details
+
+	var ae *APIError
+	if err := apiErrorFromErrorResponse(422, er); !errors.As(err, &ae) {
+		t.Fatalf("expected APIError")
+	}
+	want := []string{
+		"capacityRigs: must be >= 1",
+		"location.label: required",
+		"maxLength: 80",
+		"startDate: required; must be before endDate",
+	}
+	got := ae.DetailLines()
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("lines:\n%s", strings.Join(got, "\n"))
+	}
+	if (&APIError{}).DetailLines() != nil {
+		t.Fatalf("expected no lines without details")
+	}
+}
+
+func TestAdapter_ConnectionRefused_IsClassified(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	baseURL := "http://" + l.Addr().String()
+	_ = l.Close()
+
+	_, err = (&Adapter{}).ListVisibleTripsForMember(context.Background(), baseURL, "tok")
+	if exitcode.Code(err) != exitcode.Server {
+		t.Fatalf("expected exit 7, got %d (%v)", exitcode.Code(err), err)
+	}
+	if got := exitcode.ErrorCode(err); got != "connection_refused" {
+		t.Fatalf("code: %q", got)
+	}
+	if !strings.HasPrefix(err.Error(), "connection refused to "+baseURL) {
+		t.Fatalf("message: %q", err.Error())
+	}
+	if !strings.Contains(exitcode.HintOf(err), "docker compose up") {
+		t.Fatalf("hint: %q", exitcode.HintOf(err))
+	}
+}
+
+func TestTransportError_OfflineMiss(t *testing.T) {
+	err := transportError("http://api", fmt.Errorf("get: %w", httpx.ErrOffline))
+	if exitcode.ErrorCode(err) != "offline" || exitcode.HintOf(err) == "" {
+		t.Fatalf("got %q / %q", exitcode.ErrorCode(err), exitcode.HintOf(err))
+	}
+}
@@ -0,0 +1,196 @@
+package plannerapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	gen "github.com/Overland-East-Bay/trip-planner-cli/internal/gen/plannerapi"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Mapping between generated API models and the port's domain types.
+//
+// Responses are converted field by field, so a spec regeneration that renames
+// or reshapes a generated type breaks here (at compile time) rather than in
+// the commands. Request types already carry the wire field names and are
+// encoded directly (see jsonBody).
+
+const jsonContentType = "application/json"
+
+// jsonBody encodes a domain request for the generated *WithBody methods.
+//
+// Encoding ourselves (instead of via the generated request types) keeps
+// values the generated types cannot represent, such as an empty
+// groupAliasEmail used to clear the alias.
+func jsonBody(v any) (io.Reader, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, exitcode.New(exitcode.KindUnexpected, "encode request body", err)
+	}
+	return bytes.NewReader(b), nil
+}
+
+func toDate(d *openapi_types.Date) *outplannerapi.Date {
+	if d == nil {
+		return nil
+	}
+	return &outplannerapi.Date{Time: d.Time}
+}
+
+func toEmail(e *openapi_types.Email) *string {
+	if e == nil {
+		return nil
+	}
+	s := string(*e)
+	return &s
+}
+
+func toDraftVisibility(v *gen.DraftVisibility) *outplannerapi.DraftVisibility {
+	if v == nil {
+		return nil
+	}
+	out := outplannerapi.DraftVisibility(*v)
+	return &out
+}
+
+func toTripSummaries(in []gen.TripSummary) []outplannerapi.TripSummary {
+	if in == nil {
+		return nil
+	}
+	out := make([]outplannerapi.TripSummary, 0, len(in))
+	for _, t := range in {
+		out = append(out, outplannerapi.TripSummary{
+			AttendingRigs:   t.AttendingRigs,
+			CapacityRigs:    t.CapacityRigs,
+			DraftVisibility: toDraftVisibility(t.DraftVisibility),
+			EndDate:         toDate(t.EndDate),
+			Name:            t.Name,
+			StartDate:       toDate(t.StartDate),
+			Status:          outplannerapi.TripStatus(t.Status),
+			TripID:          t.TripId,
+		})
+	}
+	return out
+}
+
+func toTrip(t gen.TripDetails) outplannerapi.Trip {
+	out := outplannerapi.Trip{
+		CapacityRigs:                t.CapacityRigs,
+		CommsRequirementsText:       t.CommsRequirementsText,
+		Description:                 t.Description,
+		DifficultyText:              t.DifficultyText,
+		DraftVisibility:             toDraftVisibility(t.DraftVisibility),
+		EndDate:                     toDate(t.EndDate),
+		MeetingLocation:             toLocation(t.MeetingLocation),
+		Name:                        t.Name,
+		Organizers:                  toMemberSummaries(t.Organizers),
+		RecommendedRequirementsText: t.RecommendedRequirementsText,
+		RSVPActionsEnabled:          t.RsvpActionsEnabled,
+		StartDate:                   toDate(t.StartDate),
+		Status:                      outplannerapi.TripStatus(t.Status),
+		TripID:                      t.TripId,
+	}
+	if t.Artifacts != nil {
+		out.Artifacts = make([]outplannerapi.Artifact, 0, len(t.Artifacts))
+		for _, a := range t.Artifacts {
+			out.Artifacts = append(out.Artifacts, outplannerapi.Artifact{
+				ArtifactID: a.ArtifactId,
+				Title:      a.Title,
+				Type:       outplannerapi.ArtifactType(a.Type),
+				URL:        a.Url,
+			})
+		}
+	}
+	if t.MyRsvp != nil {
+		r := toRSVP(*t.MyRsvp)
+		out.MyRSVP = &r
+	}
+	if t.RsvpSummary != nil {
+		s := toRSVPSummary(*t.RsvpSummary)
+		out.RSVPSummary = &s
+	}
+	return out
+}
+
+func toLocation(l *gen.Location) *outplannerapi.Location {
+	if l == nil {
+		return nil
+	}
+	out := &outplannerapi.Location{Address: l.Address, Label: l.Label}
+	if l.LatitudeLongitude != nil {
+		out.LatitudeLongitude = &outplannerapi.LatLng{
+			Latitude:  l.LatitudeLongitude.Latitude,
+			Longitude: l.LatitudeLongitude.Longitude,
+		}
+	}
+	return out
+}
+
+func toMemberSummaries(in []gen.MemberSummary) []outplannerapi.MemberSummary {
+	if in == nil {
+		return nil
+	}
+	out := make([]outplannerapi.MemberSummary, 0, len(in))
+	for _, m := range in {
+		out = append(out, outplannerapi.MemberSummary{
+			DisplayName:     m.DisplayName,
+			Email:           string(m.Email),
+			GroupAliasEmail: toEmail(m.GroupAliasEmail),
+			MemberID:        m.MemberId,
+		})
+	}
+	return out
+}
+
+func toRSVP(r gen.MyRSVP) outplannerapi.RSVP {
+	return outplannerapi.RSVP{
+		MemberID:  r.MemberId,
+		Response:  outplannerapi.RSVPResponse(r.Response),
+		TripID:    r.TripId,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func toRSVPSummary(s gen.TripRSVPSummary) outplannerapi.RSVPSummary {
+	return outplannerapi.RSVPSummary{
+		AttendingMembers:    toMemberSummaries(s.AttendingMembers),
+		AttendingRigs:       s.AttendingRigs,
+		CapacityRigs:        s.CapacityRigs,
+		NotAttendingMembers: toMemberSummaries(s.NotAttendingMembers),
+	}
+}
+
+func toDirectory(in []gen.MemberDirectoryEntry) []outplannerapi.MemberDirectoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := make([]outplannerapi.MemberDirectoryEntry, 0, len(in))
+	for _, m := range in {
+		out = append(out, outplannerapi.MemberDirectoryEntry{DisplayName: m.DisplayName, MemberID: m.MemberId})
+	}
+	return out
+}
+
+func toMember(m gen.MemberProfile) outplannerapi.Member {
+	out := outplannerapi.Member{
+		DisplayName:     m.DisplayName,
+		Email:           string(m.Email),
+		GroupAliasEmail: toEmail(m.GroupAliasEmail),
+		MemberID:        m.MemberId,
+	}
+	if m.VehicleProfile != nil {
+		vp := outplannerapi.VehicleProfile(*m.VehicleProfile)
+		out.VehicleProfile = &vp
+	}
+	return out
+}
+
+func tripResult(t *gen.TripResponse) *outplannerapi.TripResult {
+	if t == nil {
+		return nil
+	}
+	return &outplannerapi.TripResult{Trip: toTrip(t.Trip)}
+}
@@ -0,0 +1,96 @@
+package plannerapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+const tripDetailsJSON = `{"trip":{
+	"tripId":"t1","status":"PUBLISHED","name":"Rubicon","draftVisibility":"PUBLIC",
+	"startDate":"2026-05-01","endDate":"2026-05-03","capacityRigs":8,
+	"description":"desc","difficultyText":null,"commsRequirementsText":null,"recommendedRequirementsText":null,
+	"rsvpActionsEnabled":true,
+	"meetingLocation":{"label":"Loon Lake","address":null,"latitudeLongitude":{"latitude":38.98,"longitude":-120.32}},
+	"organizers":[{"memberId":"m1","displayName":"Alice","email":"a@example.com","groupAliasEmail":null}],
+	"artifacts":[{"artifactId":"a1","title":"Route","type":"GPX","url":"https://example.com/r.gpx"}],
+	"myRsvp":{"memberId":"m1","tripId":"t1","response":"YES","updatedAt":"2026-04-01T10:00:00Z"},
+	"rsvpSummary":{"attendingRigs":1,"capacityRigs":8,"attendingMembers":[],"notAttendingMembers":[]}
+}}`
+
+func TestAdapter_GetTripDetails_MapsToDomain(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, tripDetailsJSON)
+	}))
+	defer srv.Close()
+
+	a := newTestAdapter(t)
+	res, err := a.GetTripDetails(context.Background(), srv.URL, "tok", "t1")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	trip := res.Trip
+	if trip.TripID != "t1" || trip.Status != outplannerapi.TripStatusPublished || trip.StartDate.String() != "2026-05-01" {
+		t.Fatalf("trip: %+v", trip)
+	}
+	if trip.MeetingLocation == nil || *trip.MeetingLocation.LatitudeLongitude.Latitude != 38.98 {
+		t.Fatalf("meetingLocation: %+v", trip.MeetingLocation)
+	}
+	if trip.MyRSVP == nil || trip.MyRSVP.Response != outplannerapi.RSVPYes || trip.Artifacts[0].URL != "https://example.com/r.gpx" {
+		t.Fatalf("rsvp/artifacts: %+v %+v", trip.MyRSVP, trip.Artifacts)
+	}
+
+	// --output json emits the domain value, so it must encode back to the
+	// API's own payload.
+	got, _ := json.Marshal(res)
+	var want, have any
+	_ = json.Unmarshal([]byte(tripDetailsJSON), &want)
+	_ = json.Unmarshal(got, &have)
+	if !reflect.DeepEqual(want, have) {
+		t.Fatalf("round trip:\nwant %v\nhave %v", want, have)
+	}
+}
+
+func TestAdapter_GetTripDetails_NoBodyIsNil(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	// Deliberately off-contract (a 200 without a body), so not validated.
+	a := Adapter{}
+	res, err := a.GetTripDetails(context.Background(), srv.URL, "tok", "t1")
+	if err != nil || res != nil {
+		t.Fatalf("got %+v, %v", res, err)
+	}
+}
+
+func TestAdapter_UpdateMyMemberProfile_SendsEmptyGroupAliasToClear(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"member":{"memberId":"m1","displayName":"n","email":"a@example.com","groupAliasEmail":null}}`)
+	}))
+	defer srv.Close()
+
+	empty := ""
+	a := newTestAdapter(t)
+	res, err := a.UpdateMyMemberProfile(context.Background(), srv.URL, "tok", "k1", outplannerapi.UpdateMemberRequest{GroupAliasEmail: &empty})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if v, ok := body["groupAliasEmail"]; !ok || v != "" {
+		t.Fatalf("body: %v", body)
+	}
+	if res.Member.GroupAliasEmail != nil {
+		t.Fatalf("member: %+v", res.Member)
+	}
+}
@@ -0,0 +1,69 @@
+package plannerapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	gen "github.com/Overland-East-Bay/trip-planner-cli/internal/gen/plannerapi"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/httpx"
+	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+// DefaultRequestIDHeader is the response header read for the server-assigned
+// request ID when Adapter.RequestIDHeader is empty.
+const DefaultRequestIDHeader = "X-Request-Id"
+
+func (a *Adapter) requestIDHeader() string {
+	if h := strings.TrimSpace(a.RequestIDHeader); h != "" {
+		return h
+	}
+	return DefaultRequestIDHeader
+}
+
+// responseMetaDoer records the request ID and cache state of every response
+// into the ResponseMeta attached to the request context (if any).
+type responseMetaDoer struct {
+	next   gen.HttpRequestDoer
+	header string
+}
+
+func (d responseMetaDoer) Do(req *http.Request) (*http.Response, error) {
+	resp, err := d.next.Do(req)
+	if resp != nil {
+		if m := outplannerapi.ResponseMetaFrom(req.Context()); m != nil {
+			m.RequestID = resp.Header.Get(d.header)
+			m.Cached = resp.Header.Get(httpx.HeaderCacheSource) == httpx.CacheSourceOffline
+			m.FetchedAt = time.Time{}
+			if m.Cached {
+				m.FetchedAt, _ = time.Parse(time.RFC3339, resp.Header.Get(httpx.HeaderCacheFetchedAt))
+			}
+		}
+	}
+	return resp, err
+}
+
+// withResponseRequestID fills in APIError.RequestID from the response header
+// when the error body did not carry one.
+func withResponseRequestID(err error, resp *http.Response, header string) error {
+	if err == nil || resp == nil {
+		return err
+	}
+	rid := resp.Header.Get(header)
+	if rid == "" {
+		return err
+	}
+	var ae *APIError
+	if errors.As(err, &ae) && ae != nil {
+		if ae.RequestID == "" {
+			ae.RequestID = rid
+		}
+		return err
+	}
+	// Status-only error (no parseable body): still surface the request ID.
+	ae = &APIError{StatusCode: resp.StatusCode, Message: fmt.Sprintf("http %d", resp.StatusCode), RequestID: rid}
+	return exitcode.New(exitKindForStatus(resp.StatusCode), "", ae)
+}
@@ -0,0 +1,140 @@
+package plannerapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/httpcache"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	outplannerapi "github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+func TestAdapter_RecordsRequestIDOnSuccess(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-1")
+		_, _ = w.Write([]byte(`{"members":[]}`))
+	}))
+	defer srv.Close()
+
+	ctx, meta := outplannerapi.WithResponseMeta(context.Background())
+	a := newTestAdapter(t)
+	if _, err := a.ListMembers(ctx, srv.URL, "tok", false); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if meta.RequestID != "req-1" {
+		t.Fatalf("requestId: got %q", meta.RequestID)
+	}
+}
+
+func TestAdapter_RequestIDHeaderIsConfigurable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "wrong")
+		w.Header().Set("X-Correlation-Id", "corr-9")
+		_, _ = w.Write([]byte(`{"members":[]}`))
+	}))
+	defer srv.Close()
+
+	ctx, meta := outplannerapi.WithResponseMeta(context.Background())
+	a := Adapter{RequestIDHeader: "X-Correlation-Id"}
+	if _, err := a.ListMembers(ctx, srv.URL, "tok", false); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if meta.RequestID != "corr-9" {
+		t.Fatalf("requestId: got %q", meta.RequestID)
+	}
+}
+
+func TestAdapter_ErrorRequestIDFallsBackToHeader(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-err")
+		w.WriteHeader(404)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"error": map[string]any{"code": "NOT_FOUND", "message": "nope"},
+		})
+	}))
+	defer srv.Close()
+
+	a := newTestAdapter(t)
+	_, err := a.GetMyMemberProfile(context.Background(), srv.URL, "tok")
+	var ae *APIError
+	if !errors.As(err, &ae) {
+		t.Fatalf("expected APIError, got %T (%v)", err, err)
+	}
+	if ae.RequestID != "req-err" {
+		t.Fatalf("requestId: got %q", ae.RequestID)
+	}
+	if exitcode.Code(err) != exitcode.NotFound {
+		t.Fatalf("expected not found, got %d", exitcode.Code(err))
+	}
+}
+
+func TestAdapter_ErrorBodyRequestIDWinsOverHeader(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "from-header")
+		w.WriteHeader(401)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"error": map[string]any{"code": "UNAUTHORIZED", "message": "nope", "requestId": "from-body"},
+		})
+	}))
+	defer srv.Close()
+
+	a := newTestAdapter(t)
+	_, err := a.GetMyMemberProfile(context.Background(), srv.URL, "tok")
+	var ae *APIError
+	if !errors.As(err, &ae) {
+		t.Fatalf("expected APIError, got %T", err)
+	}
+	if ae.RequestID != "from-body" {
+		t.Fatalf("requestId: got %q", ae.RequestID)
+	}
+}
+
+func TestWithResponseRequestID_StatusOnlyErrorKeepsKind(t *testing.T) {
+	resp := &http.Response{StatusCode: 500, Header: http.Header{"X-Request-Id": []string{"r5"}}}
+	err := withResponseRequestID(apiErrorFromAny(500), resp, DefaultRequestIDHeader)
+	if exitcode.Code(err) != exitcode.Server {
+		t.Fatalf("expected server exit, got %d", exitcode.Code(err))
+	}
+	var ae *APIError
+	if !errors.As(err, &ae) || ae.RequestID != "r5" {
+		t.Fatalf("expected APIError with request id, got %v", err)
+	}
+	if err.Error() != "http 500" {
+		t.Fatalf("message: got %q", err.Error())
+	}
+}
+
+func TestAdapter_RecordsOfflineCacheState(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"members":[]}`))
+	}))
+	dir := t.TempDir()
+
+	online := &Adapter{HTTPClient: &http.Client{Transport: &httpcache.Transport{Dir: dir}}}
+	ctx, meta := outplannerapi.WithResponseMeta(context.Background())
+	if _, err := online.ListMembers(ctx, srv.URL, "tok", false); err != nil {
+		t.Fatalf("online: %v", err)
+	}
+	if meta.Cached {
+		t.Fatalf("online response must not be marked cached")
+	}
+	srv.Close()
+
+	offline := &Adapter{HTTPClient: &http.Client{Transport: &httpcache.Transport{Dir: dir, Offline: true}}}
+	ctx, meta = outplannerapi.WithResponseMeta(context.Background())
+	if _, err := offline.ListMembers(ctx, srv.URL, "tok", false); err != nil {
+		t.Fatalf("offline: %v", err)
+	}
+	if !meta.Cached || meta.FetchedAt.IsZero() {
+		t.Fatalf("expected cached meta with fetch time, got %#v", meta)
+	}
+}
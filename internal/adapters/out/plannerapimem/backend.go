@@ -0,0 +1,241 @@
+// Package plannerapimem is an in-memory Planner API.
+//
+// Backend implements the plannerapi.Client port with the API's authorization
+// rules, status codes and idempotency semantics, so it can replace the HTTP
+// adapter in tests and backs `ebo dev mock-server` (see adapters/in/mockserver).
+package plannerapimem
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	plannerapiout "github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/plannerapi"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+var _ plannerapi.Client = (*Backend)(nil)
+
+// Backend holds all state in memory; it is safe for concurrent use.
+//
+// Callers are identified by bearer token: a JWT's "sub" claim, or the raw token
+// otherwise. Any non-empty token is authenticated; whether it maps to a member
+// is decided by the fixtures and by `member create`.
+type Backend struct {
+	// Now is the clock; nil means time.Now.
+	Now func() time.Time
+
+	mu        sync.Mutex
+	members   map[string]*member // by member ID
+	subjects  map[string]string  // subject -> active member ID
+	trips     map[string]*trip
+	tripOrder []string
+	artifacts map[string]plannerapi.Artifact
+	idem      map[string]idemEntry
+	seq       int
+}
+
+type member struct {
+	data      plannerapi.Member
+	subject   string
+	active    bool
+	deletedAt *time.Time
+}
+
+type trip struct {
+	// data holds the stored fields; organizers, RSVPs and the per-caller
+	// fields are filled in by view.
+	data       plannerapi.Trip
+	creator    string
+	organizers []string
+	rsvps      map[string]rsvp
+	rsvpOrder  []string
+}
+
+type rsvp struct {
+	response  plannerapi.RSVPResponse
+	updatedAt time.Time
+}
+
+type idemEntry struct {
+	fingerprint string
+	result      any
+}
+
+// New returns a Backend seeded with f.
+func New(f Fixtures) (*Backend, error) {
+	b := &Backend{
+		members:   map[string]*member{},
+		subjects:  map[string]string{},
+		trips:     map[string]*trip{},
+		artifacts: map[string]plannerapi.Artifact{},
+		idem:      map[string]idemEntry{},
+	}
+	if err := b.seed(f); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b *Backend) now() time.Time {
+	if b.Now != nil {
+		return b.Now().UTC()
+	}
+	return time.Now().UTC()
+}
+
+func (b *Backend) nextID(prefix string) string {
+	for {
+		b.seq++
+		id := fmt.Sprintf("%s-%d", prefix, b.seq)
+		if _, ok := b.trips[id]; ok {
+			continue
+		}
+		if _, ok := b.members[id]; ok {
+			continue
+		}
+		return id
+	}
+}
+
+// Errors. Codes follow the API's error.code values.
+
+func unauthorized() error {
+	return plannerapiout.NewAPIError(http.StatusUnauthorized, "UNAUTHORIZED", "missing or invalid bearer token", nil)
+}
+
+func notFound(what string) error {
+	return plannerapiout.NewAPIError(http.StatusNotFound, "NOT_FOUND", what+" not found", nil)
+}
+
+func conflict(code, message string) error {
+	return plannerapiout.NewAPIError(http.StatusConflict, code, message, nil)
+}
+
+func invalid(details map[string]any) error {
+	return plannerapiout.NewAPIError(http.StatusUnprocessableEntity, "VALIDATION_FAILED", "request validation failed", details)
+}
+
+// TokenFor returns an unsigned JWT whose "sub" claim is subject. The CLI only
+// accepts JWT-shaped tokens, so this is how to authenticate as a fixture
+// member.
+func TokenFor(subject string) string {
+	enc := base64.RawURLEncoding
+	claims, _ := json.Marshal(map[string]string{"sub": subject})
+	return enc.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + enc.EncodeToString(claims) + ".mock"
+}
+
+// subjectOf returns the caller identity for a bearer token.
+func subjectOf(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) == 3 {
+		if payload, err := base64.RawURLEncoding.DecodeString(parts[1]); err == nil {
+			var claims struct {
+				Sub string `json:"sub"`
+			}
+			if json.Unmarshal(payload, &claims) == nil && claims.Sub != "" {
+				return claims.Sub
+			}
+		}
+	}
+	return token
+}
+
+func (b *Backend) auth(bearerToken string) (string, error) {
+	if strings.TrimSpace(bearerToken) == "" {
+		return "", unauthorized()
+	}
+	return subjectOf(bearerToken), nil
+}
+
+// caller returns the caller's active member, or MEMBER_NOT_PROVISIONED with
+// status (operations differ in which statuses the contract allows).
+func (b *Backend) caller(bearerToken string, status int) (*member, error) {
+	subject, err := b.auth(bearerToken)
+	if err != nil {
+		return nil, err
+	}
+	if id, ok := b.subjects[subject]; ok {
+		return b.members[id], nil
+	}
+	return nil, plannerapiout.NewAPIError(status, "MEMBER_NOT_PROVISIONED", "no member profile for the authenticated subject", nil)
+}
+
+// listCaller returns the caller's member ID, or "" for an authenticated
+// subject without a member profile (list endpoints answer those with empty
+// lists rather than an error).
+func (b *Backend) listCaller(bearerToken string) (string, error) {
+	subject, err := b.auth(bearerToken)
+	if err != nil {
+		return "", err
+	}
+	return b.subjects[subject], nil
+}
+
+// idempotent runs do once per (subject, key) and replays its result for
+// retries with the same request. Reusing a key for a different request is
+// rejected. Failures are not stored, so a failed request can be retried with
+// its key.
+func idempotent[T any](b *Backend, subject, key string, required bool, request any, do func() (*T, error)) (*T, error) {
+	if strings.TrimSpace(key) == "" {
+		if required {
+			return nil, invalid(map[string]any{"Idempotency-Key": "required"})
+		}
+		return do()
+	}
+	fp, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	slot := subject + "\x00" + key
+	if e, ok := b.idem[slot]; ok {
+		if e.fingerprint != string(fp) {
+			return nil, plannerapiout.NewAPIError(http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED", "idempotency key was already used for a different request", nil)
+		}
+		return e.result.(*T), nil
+	}
+	res, err := do()
+	if err != nil {
+		return nil, err
+	}
+	b.idem[slot] = idemEntry{fingerprint: string(fp), result: res}
+	return res, nil
+}
+
+func (b *Backend) memberSummary(id string) plannerapi.MemberSummary {
+	m, ok := b.members[id]
+	if !ok {
+		return plannerapi.MemberSummary{MemberID: id}
+	}
+	return plannerapi.MemberSummary{
+		DisplayName:     m.data.DisplayName,
+		Email:           m.data.Email,
+		GroupAliasEmail: m.data.GroupAliasEmail,
+		MemberID:        id,
+	}
+}
+
+func (b *Backend) sortedMembers(includeInactive bool, match func(*member) bool) []plannerapi.MemberDirectoryEntry {
+	out := []plannerapi.MemberDirectoryEntry{}
+	for _, m := range b.members {
+		if !m.active && !includeInactive {
+			continue
+		}
+		if match != nil && !match(m) {
+			continue
+		}
+		out = append(out, plannerapi.MemberDirectoryEntry{DisplayName: m.data.DisplayName, MemberID: m.data.MemberID})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].DisplayName != out[j].DisplayName {
+			return out[i].DisplayName < out[j].DisplayName
+		}
+		return out[i].MemberID < out[j].MemberID
+	})
+	return out
+}
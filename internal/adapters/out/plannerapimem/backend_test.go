@@ -0,0 +1,298 @@
+package plannerapimem
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	plannerapiout "github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/plannerapi"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+var ctx = context.Background()
+
+func newBackend(t *testing.T) *Backend {
+	t.Helper()
+	b, err := New(DefaultFixtures())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return b
+}
+
+// wantAPIError asserts err is an API error with the given status and code.
+func wantAPIError(t *testing.T, err error, status int, code string) *plannerapiout.APIError {
+	t.Helper()
+	var ae *plannerapiout.APIError
+	if !errors.As(err, &ae) || ae.StatusCode != status || ae.ErrorCode != code {
+		t.Fatalf("want %d %s, got %v", status, code, err)
+	}
+	return ae
+}
+
+func TestAuth_EmptyTokenIs401AndUnknownSubjectIsNotProvisioned(t *testing.T) {
+	b := newBackend(t)
+	_, err := b.GetTripDetails(ctx, "", "", "t-rubicon")
+	wantAPIError(t, err, 401, "UNAUTHORIZED")
+	if exitcode.Code(err) != 3 {
+		t.Fatalf("exit code %d", exitcode.Code(err))
+	}
+
+	_, err = b.GetMyMemberProfile(ctx, "", "stranger")
+	wantAPIError(t, err, 404, "MEMBER_NOT_PROVISIONED")
+
+	list, err := b.ListVisibleTripsForMember(ctx, "", "stranger")
+	if err != nil || len(list.Trips) != 0 {
+		t.Fatalf("list=%+v err=%v", list, err)
+	}
+}
+
+func TestAuth_JWTSubjectIdentifiesCaller(t *testing.T) {
+	b := newBackend(t)
+	res, err := b.GetMyMemberProfile(ctx, "", TokenFor("bob"))
+	if err != nil || res.Member.MemberID != "m-bob" {
+		t.Fatalf("res=%+v err=%v", res, err)
+	}
+}
+
+func TestDrafts_VisibilityAndPublish(t *testing.T) {
+	b := newBackend(t)
+
+	created, err := b.CreateTripDraft(ctx, "", "alice", "k1", plannerapi.CreateTripDraftRequest{Name: "Moab"})
+	if err != nil || created.Trip.DraftVisibility != plannerapi.DraftVisibilityPrivate {
+		t.Fatalf("created=%+v err=%v", created, err)
+	}
+	id := created.Trip.TripID
+
+	// Bob is not the creator: the private draft does not exist for him.
+	_, err = b.GetTripDetails(ctx, "", "bob", id)
+	wantAPIError(t, err, 404, "NOT_FOUND")
+
+	_, err = b.PublishTrip(ctx, "", "alice", id)
+	wantAPIError(t, err, 409, "DRAFT_NOT_PUBLIC")
+
+	if _, err := b.SetTripDraftVisibility(ctx, "", "alice", id, "k2", plannerapi.SetDraftVisibilityRequest{DraftVisibility: plannerapi.DraftVisibilityPublic}); err != nil {
+		t.Fatalf("visibility: %v", err)
+	}
+	_, err = b.PublishTrip(ctx, "", "alice", id)
+	ae := wantAPIError(t, err, 422, "TRIP_NOT_PUBLISHABLE")
+	if ae.Details["startDate"] != "required" || ae.Details["name"] != nil {
+		t.Fatalf("details: %v", ae.Details)
+	}
+
+	start := plannerapi.Date{}
+	_ = start.UnmarshalJSON([]byte(`"2026-09-01"`))
+	end := plannerapi.Date{}
+	_ = end.UnmarshalJSON([]byte(`"2026-09-03"`))
+	desc, label, capacity := "Slickrock", "Lion's Back", 4
+	_, err = b.UpdateTrip(ctx, "", "alice", id, "k3", plannerapi.UpdateTripRequest{
+		Description:     &desc,
+		StartDate:       &start,
+		EndDate:         &end,
+		CapacityRigs:    &capacity,
+		MeetingLocation: &plannerapi.LocationPatch{Label: &label},
+	})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	pub, err := b.PublishTrip(ctx, "", "alice", id)
+	if err != nil || pub.Trip.Status != plannerapi.TripStatusPublished || pub.AnnouncementCopy == "" {
+		t.Fatalf("publish=%+v err=%v", pub, err)
+	}
+	// Publishing again is a no-op success.
+	if _, err := b.PublishTrip(ctx, "", "alice", id); err != nil {
+		t.Fatalf("republish: %v", err)
+	}
+	if _, err := b.GetTripDetails(ctx, "", "bob", id); err != nil {
+		t.Fatalf("published trip should be visible: %v", err)
+	}
+}
+
+func TestUpdateTrip_NonOrganizerAndValidation(t *testing.T) {
+	b := newBackend(t)
+	name := "Renamed"
+	_, err := b.UpdateTrip(ctx, "", "bob", "t-rubicon", "k1", plannerapi.UpdateTripRequest{Name: &name})
+	wantAPIError(t, err, 409, "NOT_TRIP_ORGANIZER")
+
+	zero, ids := 0, []string{"a-route", "a-missing"}
+	_, err = b.UpdateTrip(ctx, "", "alice", "t-rubicon", "k2", plannerapi.UpdateTripRequest{CapacityRigs: &zero, ArtifactIDs: &ids})
+	ae := wantAPIError(t, err, 422, "VALIDATION_FAILED")
+	if ae.Details["capacityRigs"] == nil || ae.Details["artifactIds"] == nil {
+		t.Fatalf("details: %v", ae.Details)
+	}
+
+	// An empty LocationPatch clears the meeting location.
+	res, err := b.UpdateTrip(ctx, "", "alice", "t-rubicon", "k3", plannerapi.UpdateTripRequest{MeetingLocation: &plannerapi.LocationPatch{}})
+	if err != nil || res.Trip.MeetingLocation != nil {
+		t.Fatalf("res=%+v err=%v", res, err)
+	}
+}
+
+func TestIdempotency_ReplayAndKeyReuse(t *testing.T) {
+	b := newBackend(t)
+	first, err := b.CreateTripDraft(ctx, "", "bob", "same", plannerapi.CreateTripDraftRequest{Name: "A"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	again, err := b.CreateTripDraft(ctx, "", "bob", "same", plannerapi.CreateTripDraftRequest{Name: "A"})
+	if err != nil || again.Trip.TripID != first.Trip.TripID {
+		t.Fatalf("replay=%+v err=%v", again, err)
+	}
+	drafts, _ := b.ListMyDraftTrips(ctx, "", "bob")
+	if len(drafts.Trips) != 1 {
+		t.Fatalf("replay created a second draft: %+v", drafts.Trips)
+	}
+
+	_, err = b.CreateTripDraft(ctx, "", "bob", "same", plannerapi.CreateTripDraftRequest{Name: "B"})
+	wantAPIError(t, err, 422, "IDEMPOTENCY_KEY_REUSED")
+
+	// Keys are scoped to the caller.
+	if _, err := b.CreateTripDraft(ctx, "", "carol", "same", plannerapi.CreateTripDraftRequest{Name: "B"}); err != nil {
+		t.Fatalf("other caller: %v", err)
+	}
+
+	_, err = b.CreateTripDraft(ctx, "", "bob", "", plannerapi.CreateTripDraftRequest{Name: "C"})
+	wantAPIError(t, err, 422, "VALIDATION_FAILED")
+}
+
+func TestRSVP_CapacityAndDrafts(t *testing.T) {
+	b := newBackend(t)
+	yes := plannerapi.SetMyRSVPRequest{Response: plannerapi.RSVPYes}
+
+	// Capacity is 2 and alice is already attending.
+	if _, err := b.SetMyRSVP(ctx, "", "bob", "t-rubicon", "k1", yes); err != nil {
+		t.Fatalf("bob: %v", err)
+	}
+	_, err := b.SetMyRSVP(ctx, "", "carol", "t-rubicon", "k1", yes)
+	wantAPIError(t, err, 409, "TRIP_AT_CAPACITY")
+
+	if _, err := b.SetMyRSVP(ctx, "", "bob", "t-rubicon", "k2", plannerapi.SetMyRSVPRequest{Response: plannerapi.RSVPUnset}); err != nil {
+		t.Fatalf("unset: %v", err)
+	}
+	res, err := b.SetMyRSVP(ctx, "", "carol", "t-rubicon", "k2", yes)
+	if err != nil || res.MyRSVP.Response != plannerapi.RSVPYes {
+		t.Fatalf("res=%+v err=%v", res, err)
+	}
+	got, _ := b.GetMyRSVPForTrip(ctx, "", "bob", "t-rubicon")
+	if got.MyRSVP.Response != plannerapi.RSVPUnset {
+		t.Fatalf("bob rsvp: %+v", got.MyRSVP)
+	}
+	sum, _ := b.GetTripRSVPSummary(ctx, "", "bob", "t-rubicon")
+	if sum.RSVPSummary.AttendingRigs != 2 {
+		t.Fatalf("summary: %+v", sum.RSVPSummary)
+	}
+
+	_, err = b.SetMyRSVP(ctx, "", "alice", "t-draft", "k3", yes)
+	wantAPIError(t, err, 409, "TRIP_NOT_PUBLISHED")
+}
+
+func TestOrganizers_AddRemoveAndLastOrganizer(t *testing.T) {
+	b := newBackend(t)
+	_, err := b.RemoveTripOrganizer(ctx, "", "alice", "t-rubicon", "m-alice", "k1")
+	wantAPIError(t, err, 409, "LAST_ORGANIZER")
+
+	_, err = b.AddTripOrganizer(ctx, "", "alice", "t-rubicon", "k2", plannerapi.AddOrganizerRequest{MemberID: "m-nobody"})
+	wantAPIError(t, err, 422, "VALIDATION_FAILED")
+
+	res, err := b.AddTripOrganizer(ctx, "", "alice", "t-rubicon", "k3", plannerapi.AddOrganizerRequest{MemberID: "m-bob"})
+	if err != nil || len(res.Trip.Organizers) != 2 {
+		t.Fatalf("res=%+v err=%v", res, err)
+	}
+	res, err = b.RemoveTripOrganizer(ctx, "", "bob", "t-rubicon", "m-alice", "k4")
+	if err != nil || len(res.Trip.Organizers) != 1 || res.Trip.Organizers[0].MemberID != "m-bob" {
+		t.Fatalf("res=%+v err=%v", res, err)
+	}
+}
+
+func TestCancel_IdempotentAndBlocksChanges(t *testing.T) {
+	b := newBackend(t)
+	for i := 0; i < 2; i++ {
+		res, err := b.CancelTrip(ctx, "", "alice", "t-rubicon", nil)
+		if err != nil || res.Trip.Status != plannerapi.TripStatusCanceled || res.Trip.RSVPActionsEnabled {
+			t.Fatalf("cancel #%d: res=%+v err=%v", i, res, err)
+		}
+	}
+	name := "x"
+	_, err := b.UpdateTrip(ctx, "", "alice", "t-rubicon", "k1", plannerapi.UpdateTripRequest{Name: &name})
+	wantAPIError(t, err, 409, "TRIP_CANCELED")
+}
+
+func TestMembers_CreateUpdateDelete(t *testing.T) {
+	b := newBackend(t)
+	_, err := b.CreateMyMember(ctx, "", "alice", plannerapi.CreateMemberRequest{DisplayName: "A", Email: "a@example.com"})
+	wantAPIError(t, err, 409, "MEMBER_ALREADY_EXISTS")
+
+	_, err = b.CreateMyMember(ctx, "", "dave", plannerapi.CreateMemberRequest{DisplayName: " ", Email: "nope"})
+	ae := wantAPIError(t, err, 422, "VALIDATION_FAILED")
+	if ae.Details["email"] == nil || ae.Details["displayName"] == nil {
+		t.Fatalf("details: %v", ae.Details)
+	}
+
+	vehicleMake := "Jeep"
+	created, err := b.CreateMyMember(ctx, "", "dave", plannerapi.CreateMemberRequest{
+		DisplayName:    "  Dave   Driver ",
+		Email:          "dave@example.com",
+		VehicleProfile: &plannerapi.VehicleProfile{Make: &vehicleMake},
+	})
+	if err != nil || created.Member.DisplayName != "Dave Driver" {
+		t.Fatalf("created=%+v err=%v", created, err)
+	}
+
+	model, empty := "Wrangler", ""
+	updated, err := b.UpdateMyMemberProfile(ctx, "", "dave", "k1", plannerapi.UpdateMemberRequest{
+		VehicleProfile: &plannerapi.VehicleProfile{Model: &model},
+	})
+	vp := updated.Member.VehicleProfile
+	if err != nil || vp == nil || *vp.Make != "Jeep" || *vp.Model != "Wrangler" {
+		t.Fatalf("updated=%+v err=%v", updated, err)
+	}
+	updated, _ = b.UpdateMyMemberProfile(ctx, "", "dave", "k2", plannerapi.UpdateMemberRequest{
+		VehicleProfile: &plannerapi.VehicleProfile{Make: &empty, Model: &empty},
+	})
+	if updated.Member.VehicleProfile != nil {
+		t.Fatalf("vehicle not cleared: %+v", updated.Member.VehicleProfile)
+	}
+
+	found, _ := b.SearchMembers(ctx, "", "alice", "DRIV")
+	if len(found.Members) != 1 || found.Members[0].MemberID != created.Member.MemberID {
+		t.Fatalf("search: %+v", found.Members)
+	}
+	_, err = b.SearchMembers(ctx, "", "alice", " ab ")
+	wantAPIError(t, err, 422, "VALIDATION_FAILED")
+
+	_, err = b.DeleteMyMemberAccount(ctx, "", "dave", "k3", plannerapi.DeleteMemberRequest{})
+	wantAPIError(t, err, 422, "VALIDATION_FAILED")
+	if _, err := b.DeleteMyMemberAccount(ctx, "", "dave", "k4", plannerapi.DeleteMemberRequest{Confirm: true}); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	_, err = b.GetMyMemberProfile(ctx, "", "dave")
+	wantAPIError(t, err, 404, "MEMBER_NOT_PROVISIONED")
+
+	active, _ := b.ListMembers(ctx, "", "alice", false)
+	all, _ := b.ListMembers(ctx, "", "alice", true)
+	if len(all.Members) != len(active.Members)+1 {
+		t.Fatalf("active=%d all=%d", len(active.Members), len(all.Members))
+	}
+}
+
+func TestLoadFixtures_RejectsUnknownFieldsAndDanglingReferences(t *testing.T) {
+	dir := t.TempDir()
+	unknown := filepath.Join(dir, "unknown.yaml")
+	_ = os.WriteFile(unknown, []byte("members: []\nbogus: 1\n"), 0o600)
+	if _, err := LoadFixtures(unknown); exitcode.Code(err) != 2 {
+		t.Fatalf("want usage error, got %v", err)
+	}
+
+	dangling := filepath.Join(dir, "dangling.yaml")
+	_ = os.WriteFile(dangling, []byte("trips:\n  - tripId: t1\n    status: DRAFT\n    organizerIds: [m-ghost]\n"), 0o600)
+	f, err := LoadFixtures(dangling)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if _, err := New(f); exitcode.Code(err) != 2 {
+		t.Fatalf("want usage error, got %v", err)
+	}
+}
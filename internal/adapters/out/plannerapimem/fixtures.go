@@ -0,0 +1,180 @@
+package plannerapimem
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/requestfile"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+// Fixtures is the initial state of a Backend. Field names match the API's JSON,
+// so fixture files read like API payloads.
+type Fixtures struct {
+	Members   []FixtureMember       `json:"members"`
+	Artifacts []plannerapi.Artifact `json:"artifacts"`
+	Trips     []FixtureTrip         `json:"trips"`
+}
+
+// FixtureMember is a member profile plus the bearer-token subject it belongs
+// to (a JWT "sub" claim, or the raw token).
+type FixtureMember struct {
+	plannerapi.Member
+	Subject  string `json:"subject"`
+	Inactive bool   `json:"inactive,omitempty"`
+}
+
+// FixtureTrip is a stored trip. Organizers and RSVPs are member IDs; the
+// creator defaults to the first organizer.
+type FixtureTrip struct {
+	TripID                      string                             `json:"tripId"`
+	Status                      plannerapi.TripStatus              `json:"status"`
+	DraftVisibility             plannerapi.DraftVisibility         `json:"draftVisibility,omitempty"`
+	Name                        *string                            `json:"name"`
+	Description                 *string                            `json:"description"`
+	StartDate                   *plannerapi.Date                   `json:"startDate"`
+	EndDate                     *plannerapi.Date                   `json:"endDate"`
+	CapacityRigs                *int                               `json:"capacityRigs"`
+	DifficultyText              *string                            `json:"difficultyText"`
+	CommsRequirementsText       *string                            `json:"commsRequirementsText"`
+	RecommendedRequirementsText *string                            `json:"recommendedRequirementsText"`
+	MeetingLocation             *plannerapi.Location               `json:"meetingLocation"`
+	ArtifactIDs                 []string                           `json:"artifactIds"`
+	CreatorID                   string                             `json:"creatorId"`
+	OrganizerIDs                []string                           `json:"organizerIds"`
+	RSVPs                       map[string]plannerapi.RSVPResponse `json:"rsvps"`
+}
+
+//go:embed fixtures.yaml
+var defaultFixtures []byte
+
+// DefaultFixtures returns the built-in fixtures: three members (subjects
+// "alice", "bob" and "carol"), a published trip and a private draft.
+func DefaultFixtures() Fixtures {
+	var f Fixtures
+	if err := requestfile.DecodeStrict("fixtures.yaml", defaultFixtures, &f); err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// Tokens returns a bearer token (see TokenFor) for each active member, keyed
+// by subject.
+func (f Fixtures) Tokens() map[string]string {
+	out := map[string]string{}
+	for _, m := range f.Members {
+		if !m.Inactive {
+			out[m.Subject] = TokenFor(m.Subject)
+		}
+	}
+	return out
+}
+
+// LoadFixtures reads fixtures from a JSON or YAML file.
+func LoadFixtures(path string) (Fixtures, error) {
+	var f Fixtures
+	if err := requestfile.LoadStrict(path, &f); err != nil {
+		return Fixtures{}, exitcode.New(exitcode.KindUsage, fmt.Sprintf("load fixtures %s", path), err)
+	}
+	return f, nil
+}
+
+func (b *Backend) seed(f Fixtures) error {
+	bad := func(format string, args ...any) error {
+		return exitcode.New(exitcode.KindUsage, "invalid fixtures: "+fmt.Sprintf(format, args...), nil)
+	}
+	for _, fm := range f.Members {
+		id := fm.MemberID
+		if id == "" || fm.Subject == "" {
+			return bad("member needs memberId and subject")
+		}
+		if _, dup := b.members[id]; dup {
+			return bad("duplicate member %q", id)
+		}
+		m := &member{data: fm.Member, subject: fm.Subject, active: !fm.Inactive}
+		b.members[id] = m
+		if m.active {
+			if _, dup := b.subjects[fm.Subject]; dup {
+				return bad("subject %q is used by more than one active member", fm.Subject)
+			}
+			b.subjects[fm.Subject] = id
+		}
+	}
+	for _, a := range f.Artifacts {
+		if a.ArtifactID == "" {
+			return bad("artifact needs artifactId")
+		}
+		b.artifacts[a.ArtifactID] = a
+	}
+	for _, ft := range f.Trips {
+		if ft.TripID == "" {
+			return bad("trip needs tripId")
+		}
+		if _, dup := b.trips[ft.TripID]; dup {
+			return bad("duplicate trip %q", ft.TripID)
+		}
+		switch ft.Status {
+		case plannerapi.TripStatusDraft, plannerapi.TripStatusPublished, plannerapi.TripStatusCanceled:
+		default:
+			return bad("trip %q: unknown status %q", ft.TripID, ft.Status)
+		}
+		if len(ft.OrganizerIDs) == 0 {
+			return bad("trip %q needs at least one organizer", ft.TripID)
+		}
+		t := &trip{
+			creator:    ft.CreatorID,
+			organizers: append([]string(nil), ft.OrganizerIDs...),
+			rsvps:      map[string]rsvp{},
+			data: plannerapi.Trip{
+				TripID:                      ft.TripID,
+				Status:                      ft.Status,
+				Name:                        ft.Name,
+				Description:                 ft.Description,
+				StartDate:                   ft.StartDate,
+				EndDate:                     ft.EndDate,
+				CapacityRigs:                ft.CapacityRigs,
+				DifficultyText:              ft.DifficultyText,
+				CommsRequirementsText:       ft.CommsRequirementsText,
+				RecommendedRequirementsText: ft.RecommendedRequirementsText,
+				MeetingLocation:             ft.MeetingLocation,
+			},
+		}
+		if t.creator == "" {
+			t.creator = ft.OrganizerIDs[0]
+		}
+		if ft.Status == plannerapi.TripStatusDraft {
+			vis := ft.DraftVisibility
+			if vis == "" {
+				vis = plannerapi.DraftVisibilityPrivate
+			}
+			t.data.DraftVisibility = &vis
+		}
+		for _, id := range append([]string{t.creator}, ft.OrganizerIDs...) {
+			if _, ok := b.members[id]; !ok {
+				return bad("trip %q: unknown member %q", ft.TripID, id)
+			}
+		}
+		for _, aid := range ft.ArtifactIDs {
+			a, ok := b.artifacts[aid]
+			if !ok {
+				return bad("trip %q: unknown artifact %q", ft.TripID, aid)
+			}
+			t.data.Artifacts = append(t.data.Artifacts, a)
+		}
+		for _, id := range sortedKeys(ft.RSVPs) {
+			resp := plannerapi.RSVPResponse(strings.ToUpper(string(ft.RSVPs[id])))
+			if _, ok := b.members[id]; !ok {
+				return bad("trip %q: rsvp for unknown member %q", ft.TripID, id)
+			}
+			if resp != plannerapi.RSVPYes && resp != plannerapi.RSVPNo {
+				return bad("trip %q: rsvp %q must be YES or NO", ft.TripID, resp)
+			}
+			t.setRSVP(id, resp, b.now())
+		}
+		b.trips[t.data.TripID] = t
+		b.tripOrder = append(b.tripOrder, t.data.TripID)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package plannerapimem
+
+import (
+	"context"
+	"net/http"
+	"net/mail"
+	"strings"
+
+	plannerapiout "github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/plannerapi"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+// minSearchLen matches the API's minimum `q` length for member search.
+const minSearchLen = 3
+
+func (b *Backend) ListMembers(_ context.Context, _ string, bearerToken string, includeInactive bool) (*plannerapi.MemberList, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	callerID, err := b.listCaller(bearerToken)
+	if err != nil {
+		return nil, err
+	}
+	if callerID == "" {
+		return &plannerapi.MemberList{Members: []plannerapi.MemberDirectoryEntry{}}, nil
+	}
+	return &plannerapi.MemberList{Members: b.sortedMembers(includeInactive, nil)}, nil
+}
+
+func (b *Backend) SearchMembers(_ context.Context, _ string, bearerToken string, query string) (*plannerapi.MemberList, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	callerID, err := b.listCaller(bearerToken)
+	if err != nil {
+		return nil, err
+	}
+	q := strings.ToLower(strings.TrimSpace(query))
+	if len([]rune(q)) < minSearchLen {
+		return nil, invalid(map[string]any{"q": "must be at least 3 characters"})
+	}
+	if callerID == "" {
+		return &plannerapi.MemberList{Members: []plannerapi.MemberDirectoryEntry{}}, nil
+	}
+	return &plannerapi.MemberList{Members: b.sortedMembers(false, func(m *member) bool {
+		return strings.Contains(strings.ToLower(m.data.DisplayName), q)
+	})}, nil
+}
+
+func (b *Backend) GetMyMemberProfile(_ context.Context, _ string, bearerToken string) (*plannerapi.MemberResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, err := b.caller(bearerToken, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+	return &plannerapi.MemberResult{Member: m.data}, nil
+}
+
+func (b *Backend) CreateMyMember(_ context.Context, _ string, bearerToken string, req plannerapi.CreateMemberRequest) (*plannerapi.MemberResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subject, err := b.auth(bearerToken)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := b.subjects[subject]; ok {
+		return nil, plannerapiout.NewAPIError(http.StatusConflict, "MEMBER_ALREADY_EXISTS", "a member profile already exists for the authenticated subject", nil)
+	}
+
+	details := map[string]any{}
+	name := normalizeName(req.DisplayName)
+	if name == "" {
+		details["displayName"] = "must not be blank"
+	}
+	if !validEmail(req.Email) {
+		details["email"] = "must be a valid email address"
+	}
+	alias := req.GroupAliasEmail
+	if alias != nil && *alias == "" {
+		alias = nil
+	}
+	if alias != nil && !validEmail(*alias) {
+		details["groupAliasEmail"] = "must be a valid email address"
+	}
+	if len(details) > 0 {
+		return nil, invalid(details)
+	}
+
+	m := &member{
+		subject: subject,
+		active:  true,
+		data: plannerapi.Member{
+			DisplayName:     name,
+			Email:           req.Email,
+			GroupAliasEmail: alias,
+			MemberID:        b.nextID("m"),
+			VehicleProfile:  mergeVehicle(nil, req.VehicleProfile),
+		},
+	}
+	b.members[m.data.MemberID] = m
+	b.subjects[subject] = m.data.MemberID
+	return &plannerapi.MemberResult{Member: m.data}, nil
+}
+
+func (b *Backend) UpdateMyMemberProfile(_ context.Context, _ string, bearerToken string, idempotencyKey string, req plannerapi.UpdateMemberRequest) (*plannerapi.MemberResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, err := b.caller(bearerToken, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+	return idempotent(b, m.subject, idempotencyKey, true, []any{"updateMyMemberProfile", req}, func() (*plannerapi.MemberResult, error) {
+		details := map[string]any{}
+		next := m.data
+		if req.DisplayName != nil {
+			next.DisplayName = normalizeName(*req.DisplayName)
+			if next.DisplayName == "" {
+				details["displayName"] = "must not be blank"
+			}
+		}
+		if req.Email != nil {
+			next.Email = *req.Email
+			if !validEmail(next.Email) {
+				details["email"] = "must be a valid email address"
+			}
+		}
+		if req.GroupAliasEmail != nil {
+			next.GroupAliasEmail = nil
+			if *req.GroupAliasEmail != "" {
+				next.GroupAliasEmail = req.GroupAliasEmail
+				if !validEmail(*req.GroupAliasEmail) {
+					details["groupAliasEmail"] = "must be a valid email address"
+				}
+			}
+		}
+		if req.VehicleProfile != nil {
+			next.VehicleProfile = mergeVehicle(m.data.VehicleProfile, req.VehicleProfile)
+		}
+		if len(details) > 0 {
+			return nil, invalid(details)
+		}
+		m.data = next
+		return &plannerapi.MemberResult{Member: m.data}, nil
+	})
+}
+
+func (b *Backend) DeleteMyMemberAccount(_ context.Context, _ string, bearerToken string, idempotencyKey string, req plannerapi.DeleteMemberRequest) (*plannerapi.MemberDeleted, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, err := b.caller(bearerToken, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+	return idempotent(b, m.subject, idempotencyKey, true, []any{"deleteMyMemberAccount", req}, func() (*plannerapi.MemberDeleted, error) {
+		if !req.Confirm {
+			return nil, invalid(map[string]any{"confirm": "must be true"})
+		}
+		at := b.now()
+		m.active = false
+		m.deletedAt = &at
+		delete(b.subjects, m.subject)
+		return &plannerapi.MemberDeleted{Deleted: true, DeletedAt: &at}, nil
+	})
+}
+
+// normalizeName trims and collapses whitespace runs, as the API does.
+func normalizeName(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func validEmail(s string) bool {
+	a, err := mail.ParseAddress(s)
+	return err == nil && a.Address == s
+}
+
+// mergeVehicle applies patch to cur: nil fields are unchanged, empty strings
+// clear. A profile with no fields left is dropped.
+func mergeVehicle(cur, patch *plannerapi.VehicleProfile) *plannerapi.VehicleProfile {
+	var out plannerapi.VehicleProfile
+	if cur != nil {
+		out = *cur
+	}
+	if patch != nil {
+		for _, f := range []struct{ dst, src **string }{
+			{&out.FuelRange, &patch.FuelRange},
+			{&out.HamRadioCallSign, &patch.HamRadioCallSign},
+			{&out.LiftLockers, &patch.LiftLockers},
+			{&out.Make, &patch.Make},
+			{&out.Model, &patch.Model},
+			{&out.Notes, &patch.Notes},
+			{&out.RecoveryGear, &patch.RecoveryGear},
+			{&out.TireSize, &patch.TireSize},
+		} {
+			if *f.src == nil {
+				continue
+			}
+			*f.dst = nil
+			if **f.src != "" {
+				v := **f.src
+				*f.dst = &v
+			}
+		}
+	}
+	if out == (plannerapi.VehicleProfile{}) {
+		return nil
+	}
+	return &out
+}
@@ -0,0 +1,603 @@
+package plannerapimem
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	plannerapiout "github.com/Overland-East-Bay/trip-planner-cli/internal/adapters/out/plannerapi"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+// Trip authorization follows the API contract:
+//   - PRIVATE drafts are visible to their creator only, PUBLIC drafts to
+//     their organizers; published and canceled trips to every member.
+//   - Trips the caller cannot see are reported as 404, never 403.
+//   - Mutations other than RSVPs require the caller to be an organizer
+//     (visibility: the creator).
+
+func (t *trip) isOrganizer(memberID string) bool {
+	for _, id := range t.organizers {
+		if id == memberID {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *trip) visibleTo(memberID string) bool {
+	if t.data.Status != plannerapi.TripStatusDraft {
+		return true
+	}
+	if *t.data.DraftVisibility == plannerapi.DraftVisibilityPrivate {
+		return t.creator == memberID
+	}
+	return t.isOrganizer(memberID)
+}
+
+func (t *trip) setRSVP(memberID string, resp plannerapi.RSVPResponse, at time.Time) {
+	if _, ok := t.rsvps[memberID]; !ok {
+		t.rsvpOrder = append(t.rsvpOrder, memberID)
+	}
+	t.rsvps[memberID] = rsvp{response: resp, updatedAt: at}
+}
+
+func (t *trip) attendingRigs() int {
+	n := 0
+	for _, r := range t.rsvps {
+		if r.response == plannerapi.RSVPYes {
+			n++
+		}
+	}
+	return n
+}
+
+// lookupTrip returns a trip the caller can see.
+func (b *Backend) lookupTrip(callerID, tripID string) (*trip, error) {
+	t, ok := b.trips[tripID]
+	if !ok || !t.visibleTo(callerID) {
+		return nil, notFound("trip")
+	}
+	return t, nil
+}
+
+// organizerTrip returns a trip the caller organizes that is not canceled.
+func (b *Backend) organizerTrip(callerID, tripID string) (*trip, error) {
+	t, err := b.lookupTrip(callerID, tripID)
+	if err != nil {
+		return nil, err
+	}
+	if !t.isOrganizer(callerID) {
+		return nil, conflict("NOT_TRIP_ORGANIZER", "only trip organizers can change this trip")
+	}
+	if t.data.Status == plannerapi.TripStatusCanceled {
+		return nil, conflict("TRIP_CANCELED", "trip is canceled")
+	}
+	return t, nil
+}
+
+func (b *Backend) rsvpOf(t *trip, memberID string) plannerapi.RSVP {
+	r, ok := t.rsvps[memberID]
+	if !ok {
+		return plannerapi.RSVP{MemberID: memberID, TripID: t.data.TripID, Response: plannerapi.RSVPUnset, UpdatedAt: b.now()}
+	}
+	return plannerapi.RSVP{MemberID: memberID, TripID: t.data.TripID, Response: r.response, UpdatedAt: r.updatedAt}
+}
+
+func (b *Backend) rsvpSummary(t *trip) plannerapi.RSVPSummary {
+	s := plannerapi.RSVPSummary{
+		AttendingMembers:    []plannerapi.MemberSummary{},
+		CapacityRigs:        t.data.CapacityRigs,
+		NotAttendingMembers: []plannerapi.MemberSummary{},
+	}
+	for _, id := range t.rsvpOrder {
+		switch t.rsvps[id].response {
+		case plannerapi.RSVPYes:
+			s.AttendingMembers = append(s.AttendingMembers, b.memberSummary(id))
+		case plannerapi.RSVPNo:
+			s.NotAttendingMembers = append(s.NotAttendingMembers, b.memberSummary(id))
+		}
+	}
+	s.AttendingRigs = len(s.AttendingMembers)
+	return s
+}
+
+// view renders t as seen by callerID.
+func (b *Backend) view(t *trip, callerID string) plannerapi.Trip {
+	out := t.data
+	out.Artifacts = append([]plannerapi.Artifact{}, t.data.Artifacts...)
+	out.Organizers = make([]plannerapi.MemberSummary, 0, len(t.organizers))
+	for _, id := range t.organizers {
+		out.Organizers = append(out.Organizers, b.memberSummary(id))
+	}
+	out.RSVPActionsEnabled = t.data.Status == plannerapi.TripStatusPublished
+	if t.data.Status != plannerapi.TripStatusDraft {
+		s := b.rsvpSummary(t)
+		out.RSVPSummary = &s
+		if _, ok := t.rsvps[callerID]; ok {
+			r := b.rsvpOf(t, callerID)
+			out.MyRSVP = &r
+		}
+	}
+	return out
+}
+
+func (b *Backend) summary(t *trip) plannerapi.TripSummary {
+	s := plannerapi.TripSummary{
+		CapacityRigs:    t.data.CapacityRigs,
+		DraftVisibility: t.data.DraftVisibility,
+		EndDate:         t.data.EndDate,
+		Name:            t.data.Name,
+		StartDate:       t.data.StartDate,
+		Status:          t.data.Status,
+		TripID:          t.data.TripID,
+	}
+	if t.data.Status == plannerapi.TripStatusPublished {
+		n := t.attendingRigs()
+		s.AttendingRigs = &n
+	}
+	return s
+}
+
+func (b *Backend) listTrips(callerID string, drafts bool) *plannerapi.TripList {
+	out := &plannerapi.TripList{Trips: []plannerapi.TripSummary{}}
+	if callerID == "" {
+		return out
+	}
+	for _, id := range b.tripOrder {
+		t := b.trips[id]
+		if (t.data.Status == plannerapi.TripStatusDraft) != drafts || !t.visibleTo(callerID) {
+			continue
+		}
+		out.Trips = append(out.Trips, b.summary(t))
+	}
+	return out
+}
+
+func (b *Backend) ListVisibleTripsForMember(_ context.Context, _ string, bearerToken string) (*plannerapi.TripList, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	callerID, err := b.listCaller(bearerToken)
+	if err != nil {
+		return nil, err
+	}
+	return b.listTrips(callerID, false), nil
+}
+
+func (b *Backend) ListMyDraftTrips(_ context.Context, _ string, bearerToken string) (*plannerapi.TripList, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	callerID, err := b.listCaller(bearerToken)
+	if err != nil {
+		return nil, err
+	}
+	return b.listTrips(callerID, true), nil
+}
+
+func (b *Backend) GetTripDetails(_ context.Context, _ string, bearerToken string, tripID string) (*plannerapi.TripResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, err := b.caller(bearerToken, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+	t, err := b.lookupTrip(m.data.MemberID, tripID)
+	if err != nil {
+		return nil, err
+	}
+	return &plannerapi.TripResult{Trip: b.view(t, m.data.MemberID)}, nil
+}
+
+func (b *Backend) CreateTripDraft(_ context.Context, _ string, bearerToken string, idempotencyKey string, req plannerapi.CreateTripDraftRequest) (*plannerapi.TripCreatedResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, err := b.caller(bearerToken, http.StatusConflict)
+	if err != nil {
+		return nil, err
+	}
+	callerID := m.data.MemberID
+	return idempotent(b, m.subject, idempotencyKey, true, []any{"createTripDraft", req}, func() (*plannerapi.TripCreatedResult, error) {
+		name := strings.TrimSpace(req.Name)
+		if name == "" {
+			return nil, invalid(map[string]any{"name": "must not be blank"})
+		}
+		vis := plannerapi.DraftVisibilityPrivate
+		t := &trip{
+			creator:    callerID,
+			organizers: []string{callerID},
+			rsvps:      map[string]rsvp{},
+			data: plannerapi.Trip{
+				TripID:          b.nextID("t"),
+				Status:          plannerapi.TripStatusDraft,
+				DraftVisibility: &vis,
+				Name:            &name,
+			},
+		}
+		b.trips[t.data.TripID] = t
+		b.tripOrder = append(b.tripOrder, t.data.TripID)
+		return &plannerapi.TripCreatedResult{Trip: plannerapi.TripCreated{
+			DraftVisibility: vis,
+			Status:          plannerapi.TripStatusDraft,
+			TripID:          t.data.TripID,
+		}}, nil
+	})
+}
+
+func (b *Backend) UpdateTrip(_ context.Context, _ string, bearerToken string, tripID string, idempotencyKey string, req plannerapi.UpdateTripRequest) (*plannerapi.TripResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, err := b.caller(bearerToken, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+	callerID := m.data.MemberID
+	return idempotent(b, m.subject, idempotencyKey, true, []any{"updateTrip", tripID, req}, func() (*plannerapi.TripResult, error) {
+		t, err := b.organizerTrip(callerID, tripID)
+		if err != nil {
+			return nil, err
+		}
+		next, err := b.applyPatch(t, req)
+		if err != nil {
+			return nil, err
+		}
+		t.data = next
+		return &plannerapi.TripResult{Trip: b.view(t, callerID)}, nil
+	})
+}
+
+// applyPatch returns t's data with req applied, or a 422 listing every
+// invalid field. Empty strings clear optional text fields.
+func (b *Backend) applyPatch(t *trip, req plannerapi.UpdateTripRequest) (plannerapi.Trip, error) {
+	details := map[string]any{}
+	cur := t.data
+	next := cur
+
+	if req.Name != nil {
+		if strings.TrimSpace(*req.Name) == "" {
+			details["name"] = "must not be blank"
+		}
+		next.Name = req.Name
+	}
+	setText := func(dst **string, v *string) {
+		if v == nil {
+			return
+		}
+		if *v == "" {
+			*dst = nil
+			return
+		}
+		*dst = v
+	}
+	setText(&next.Description, req.Description)
+	setText(&next.DifficultyText, req.DifficultyText)
+	setText(&next.CommsRequirementsText, req.CommsRequirementsText)
+	setText(&next.RecommendedRequirementsText, req.RecommendedRequirementsText)
+	if req.CapacityRigs != nil {
+		if *req.CapacityRigs < 1 {
+			details["capacityRigs"] = "must be at least 1"
+		} else if cur.Status == plannerapi.TripStatusPublished && *req.CapacityRigs < t.attendingRigs() {
+			details["capacityRigs"] = "must not be below the number of attending rigs"
+		}
+		next.CapacityRigs = req.CapacityRigs
+	}
+	if req.StartDate != nil {
+		next.StartDate = req.StartDate
+	}
+	if req.EndDate != nil {
+		next.EndDate = req.EndDate
+	}
+	if next.StartDate != nil && next.EndDate != nil && next.EndDate.Before(next.StartDate.Time) {
+		details["endDate"] = "must not be before startDate"
+	}
+	if req.ArtifactIDs != nil {
+		next.Artifacts = []plannerapi.Artifact{}
+		var unknown []any
+		for _, id := range *req.ArtifactIDs {
+			a, ok := b.artifacts[id]
+			if !ok {
+				unknown = append(unknown, id)
+				continue
+			}
+			next.Artifacts = append(next.Artifacts, a)
+		}
+		if len(unknown) > 0 {
+			details["artifactIds"] = append([]any{"unknown artifact"}, unknown...)
+		}
+	}
+	if lp := req.MeetingLocation; lp != nil {
+		if lp.Label == nil && lp.Address == nil && lp.LatitudeLongitude == nil {
+			next.MeetingLocation = nil
+		} else {
+			loc := plannerapi.Location{}
+			if cur.MeetingLocation != nil {
+				loc = *cur.MeetingLocation
+			}
+			if lp.Label != nil {
+				loc.Label = *lp.Label
+			}
+			if lp.Address != nil {
+				loc.Address = nil
+				if *lp.Address != "" {
+					loc.Address = lp.Address
+				}
+			}
+			if ll := lp.LatitudeLongitude; ll != nil {
+				loc.LatitudeLongitude = nil
+				if ll.Latitude != nil || ll.Longitude != nil {
+					loc.LatitudeLongitude = &plannerapi.LatLng{Latitude: ll.Latitude, Longitude: ll.Longitude}
+				}
+			}
+			if strings.TrimSpace(loc.Label) == "" {
+				details["meetingLocation.label"] = "is required when a meeting location is set"
+			}
+			next.MeetingLocation = &loc
+		}
+	}
+
+	if len(details) > 0 {
+		return cur, invalid(details)
+	}
+	return next, nil
+}
+
+func (b *Backend) SetTripDraftVisibility(_ context.Context, _ string, bearerToken string, tripID string, idempotencyKey string, req plannerapi.SetDraftVisibilityRequest) (*plannerapi.TripResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, err := b.caller(bearerToken, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+	callerID := m.data.MemberID
+	return idempotent(b, m.subject, idempotencyKey, true, []any{"setTripDraftVisibility", tripID, req}, func() (*plannerapi.TripResult, error) {
+		if req.DraftVisibility != plannerapi.DraftVisibilityPrivate && req.DraftVisibility != plannerapi.DraftVisibilityPublic {
+			return nil, invalid(map[string]any{"draftVisibility": "must be PUBLIC or PRIVATE"})
+		}
+		t, err := b.lookupTrip(callerID, tripID)
+		if err != nil {
+			return nil, err
+		}
+		if t.creator != callerID {
+			return nil, conflict("NOT_TRIP_CREATOR", "only the trip creator can change draft visibility")
+		}
+		if t.data.Status != plannerapi.TripStatusDraft {
+			return nil, conflict("TRIP_NOT_DRAFT", "draft visibility applies to drafts only")
+		}
+		vis := req.DraftVisibility
+		t.data.DraftVisibility = &vis
+		return &plannerapi.TripResult{Trip: b.view(t, callerID)}, nil
+	})
+}
+
+func (b *Backend) PublishTrip(_ context.Context, _ string, bearerToken string, tripID string) (*plannerapi.PublishResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, err := b.caller(bearerToken, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+	callerID := m.data.MemberID
+	t, err := b.lookupTrip(callerID, tripID)
+	if err != nil {
+		return nil, err
+	}
+	if !t.isOrganizer(callerID) {
+		return nil, conflict("NOT_TRIP_ORGANIZER", "only trip organizers can publish")
+	}
+	switch t.data.Status {
+	case plannerapi.TripStatusPublished:
+		return &plannerapi.PublishResult{AnnouncementCopy: announcement(t.data), Trip: b.view(t, callerID)}, nil
+	case plannerapi.TripStatusCanceled:
+		return nil, conflict("TRIP_CANCELED", "trip is canceled")
+	}
+	if *t.data.DraftVisibility != plannerapi.DraftVisibilityPublic {
+		return nil, conflict("DRAFT_NOT_PUBLIC", "only PUBLIC drafts can be published")
+	}
+	details := map[string]any{}
+	if t.data.Name == nil || strings.TrimSpace(*t.data.Name) == "" {
+		details["name"] = "required"
+	}
+	if t.data.Description == nil {
+		details["description"] = "required"
+	}
+	if t.data.StartDate == nil {
+		details["startDate"] = "required"
+	}
+	if t.data.EndDate == nil {
+		details["endDate"] = "required"
+	}
+	if t.data.CapacityRigs == nil {
+		details["capacityRigs"] = "required"
+	}
+	if t.data.MeetingLocation == nil {
+		details["meetingLocation"] = "required"
+	}
+	if len(details) > 0 {
+		return nil, plannerapiout.NewAPIError(http.StatusUnprocessableEntity, "TRIP_NOT_PUBLISHABLE", "trip is missing fields required to publish", details)
+	}
+	t.data.Status = plannerapi.TripStatusPublished
+	t.data.DraftVisibility = nil
+	return &plannerapi.PublishResult{AnnouncementCopy: announcement(t.data), Trip: b.view(t, callerID)}, nil
+}
+
+func announcement(t plannerapi.Trip) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s\n\n", *t.Name)
+	fmt.Fprintf(&sb, "When: %s to %s\n", t.StartDate, t.EndDate)
+	fmt.Fprintf(&sb, "Meeting: %s\n", t.MeetingLocation.Label)
+	fmt.Fprintf(&sb, "Capacity: %d rigs\n", *t.CapacityRigs)
+	if t.Description != nil {
+		fmt.Fprintf(&sb, "\n%s\n", *t.Description)
+	}
+	return sb.String()
+}
+
+func (b *Backend) AddTripOrganizer(_ context.Context, _ string, bearerToken string, tripID string, idempotencyKey string, req plannerapi.AddOrganizerRequest) (*plannerapi.TripResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, err := b.caller(bearerToken, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+	callerID := m.data.MemberID
+	return idempotent(b, m.subject, idempotencyKey, true, []any{"addTripOrganizer", tripID, req}, func() (*plannerapi.TripResult, error) {
+		t, err := b.organizerTrip(callerID, tripID)
+		if err != nil {
+			return nil, err
+		}
+		if target, ok := b.members[req.MemberID]; !ok || !target.active {
+			return nil, invalid(map[string]any{"memberId": "unknown or inactive member"})
+		}
+		if !t.isOrganizer(req.MemberID) {
+			t.organizers = append(t.organizers, req.MemberID)
+		}
+		return &plannerapi.TripResult{Trip: b.view(t, callerID)}, nil
+	})
+}
+
+func (b *Backend) RemoveTripOrganizer(_ context.Context, _ string, bearerToken string, tripID string, memberID string, idempotencyKey string) (*plannerapi.TripResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, err := b.caller(bearerToken, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+	callerID := m.data.MemberID
+	return idempotent(b, m.subject, idempotencyKey, true, []any{"removeTripOrganizer", tripID, memberID}, func() (*plannerapi.TripResult, error) {
+		t, err := b.organizerTrip(callerID, tripID)
+		if err != nil {
+			return nil, err
+		}
+		if !t.isOrganizer(memberID) {
+			return nil, notFound("organizer")
+		}
+		if len(t.organizers) == 1 {
+			return nil, conflict("LAST_ORGANIZER", "a trip must keep at least one organizer")
+		}
+		kept := t.organizers[:0]
+		for _, id := range t.organizers {
+			if id != memberID {
+				kept = append(kept, id)
+			}
+		}
+		t.organizers = kept
+		return &plannerapi.TripResult{Trip: b.view(t, callerID)}, nil
+	})
+}
+
+func (b *Backend) CancelTrip(_ context.Context, _ string, bearerToken string, tripID string, idempotencyKey *string) (*plannerapi.TripResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, err := b.caller(bearerToken, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+	callerID := m.data.MemberID
+	key := ""
+	if idempotencyKey != nil {
+		key = *idempotencyKey
+	}
+	return idempotent(b, m.subject, key, false, []any{"cancelTrip", tripID}, func() (*plannerapi.TripResult, error) {
+		t, err := b.lookupTrip(callerID, tripID)
+		if err != nil {
+			return nil, err
+		}
+		if !t.isOrganizer(callerID) {
+			return nil, conflict("NOT_TRIP_ORGANIZER", "only trip organizers can cancel")
+		}
+		if t.data.Status != plannerapi.TripStatusCanceled {
+			t.data.Status = plannerapi.TripStatusCanceled
+			t.data.DraftVisibility = nil
+		}
+		return &plannerapi.TripResult{Trip: b.view(t, callerID)}, nil
+	})
+}
+
+func (b *Backend) SetMyRSVP(_ context.Context, _ string, bearerToken string, tripID string, idempotencyKey string, req plannerapi.SetMyRSVPRequest) (*plannerapi.RSVPResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, err := b.caller(bearerToken, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+	callerID := m.data.MemberID
+	return idempotent(b, m.subject, idempotencyKey, true, []any{"setMyRSVP", tripID, req}, func() (*plannerapi.RSVPResult, error) {
+		switch req.Response {
+		case plannerapi.RSVPYes, plannerapi.RSVPNo, plannerapi.RSVPUnset:
+		default:
+			return nil, invalid(map[string]any{"response": "must be YES, NO or UNSET"})
+		}
+		t, err := b.lookupTrip(callerID, tripID)
+		if err != nil {
+			return nil, err
+		}
+		if t.data.Status != plannerapi.TripStatusPublished {
+			return nil, conflict("TRIP_NOT_PUBLISHED", "RSVPs are only accepted for published trips")
+		}
+		prev := t.rsvps[callerID].response
+		if req.Response == plannerapi.RSVPYes && prev != plannerapi.RSVPYes &&
+			t.data.CapacityRigs != nil && t.attendingRigs() >= *t.data.CapacityRigs {
+			return nil, conflict("TRIP_AT_CAPACITY", "trip is at capacity")
+		}
+		if req.Response == plannerapi.RSVPUnset {
+			delete(t.rsvps, callerID)
+			t.rsvpOrder = removeString(t.rsvpOrder, callerID)
+		} else if req.Response != prev {
+			t.rsvpOrder = removeString(t.rsvpOrder, callerID)
+			t.setRSVP(callerID, req.Response, b.now())
+		}
+		return &plannerapi.RSVPResult{MyRSVP: b.rsvpOf(t, callerID)}, nil
+	})
+}
+
+func (b *Backend) GetMyRSVPForTrip(_ context.Context, _ string, bearerToken string, tripID string) (*plannerapi.RSVPResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, err := b.caller(bearerToken, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+	t, err := b.lookupTrip(m.data.MemberID, tripID)
+	if err != nil {
+		return nil, err
+	}
+	return &plannerapi.RSVPResult{MyRSVP: b.rsvpOf(t, m.data.MemberID)}, nil
+}
+
+func (b *Backend) GetTripRSVPSummary(_ context.Context, _ string, bearerToken string, tripID string) (*plannerapi.RSVPSummaryResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, err := b.caller(bearerToken, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+	t, err := b.lookupTrip(m.data.MemberID, tripID)
+	if err != nil {
+		return nil, err
+	}
+	if t.data.Status == plannerapi.TripStatusDraft {
+		return nil, conflict("TRIP_NOT_PUBLISHED", "drafts have no RSVPs")
+	}
+	return &plannerapi.RSVPSummaryResult{RSVPSummary: b.rsvpSummary(t)}, nil
+}
+
+func removeString(in []string, s string) []string {
+	out := in[:0]
+	for _, v := range in {
+		if v != s {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/config"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
 	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/oidcdevice"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/oidcfake"
 )
 
 type memStore struct{ doc config.Document }
@@ -26,29 +28,25 @@ type fixedClock struct{ t time.Time }
 
 func (f fixedClock) Now() time.Time { return f.t }
 
+// newIssuer starts a fake OIDC issuer that approves device codes as soon as
+// they are issued.
+func newIssuer(t *testing.T) (*oidcfake.Issuer, *httptest.Server) {
+	t.Helper()
+	iss, err := oidcfake.New(oidcfake.Options{Subjects: []string{"alice"}, ClientID: "cid", AutoApprove: true, TokenTTL: time.Minute})
+	if err != nil {
+		t.Fatalf("issuer: %v", err)
+	}
+	srv := httptest.NewServer(iss.Handler())
+	t.Cleanup(srv.Close)
+	return iss, srv
+}
+
 func TestLogin_PersistsTokenFields(t *testing.T) {
-	var baseURL string
-	var tokenCalls int
-	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("content-type", "application/json")
-		switch r.URL.Path {
-		case "/.well-known/openid-configuration":
-			_, _ = w.Write([]byte(`{"device_authorization_endpoint":"` + baseURL + `/device","token_endpoint":"` + baseURL + `/token"}`))
-		case "/device":
-			_, _ = w.Write([]byte(`{"device_code":"dc","user_code":"UC","verification_uri":"` + baseURL + `/verify","expires_in":600,"interval":1}`))
-		case "/token":
-			tokenCalls++
-			_, _ = w.Write([]byte(`{"access_token":"a.b.c","token_type":"Bearer","expires_in":60}`))
-		default:
-			w.WriteHeader(404)
-		}
-	}))
-	defer srv.Close()
-	baseURL = srv.URL
+	iss, srv := newIssuer(t)
 
 	doc := config.NewEmptyDocument()
 	doc, _ = config.WithProfileAPIURL(doc, "default", "http://x")
-	doc, _ = config.WithProfileOIDC(doc, "default", baseURL, "cid", []string{"openid"})
+	doc, _ = config.WithProfileOIDC(doc, "default", srv.URL, "cid", []string{"openid"})
 
 	m := &memStore{doc: doc}
 	op := &fakeOpen{}
@@ -62,49 +60,31 @@ func TestLogin_PersistsTokenFields(t *testing.T) {
 	if res.Profile != "default" {
 		t.Fatalf("profile: %q", res.Profile)
 	}
-	if op.last == "" {
-		t.Fatalf("expected browser open")
+	if !strings.HasPrefix(op.last, srv.URL+"/device") {
+		t.Fatalf("expected browser open, got %q", op.last)
 	}
 
 	// Verify persisted values.
 	gotTok, _ := config.Get(m.doc, "profiles.default.auth.accessToken")
-	if gotTok != "a.b.c" {
-		t.Fatalf("token: %q", gotTok)
+	if claims, err := iss.Verify(gotTok); err != nil || claims.Subject != "alice" || claims.Audience != "cid" {
+		t.Fatalf("token: %q (%+v, %v)", gotTok, claims, err)
 	}
 	gotType, _ := config.Get(m.doc, "profiles.default.auth.tokenType")
 	if gotType != "Bearer" {
 		t.Fatalf("type: %q", gotType)
 	}
 	gotExp, _ := config.Get(m.doc, "profiles.default.auth.expiresAt")
-	if gotExp == "" {
-		t.Fatalf("expected expiresAt")
-	}
-	if tokenCalls == 0 {
-		t.Fatalf("expected token call")
+	if gotExp != "2026-01-01T00:01:00Z" {
+		t.Fatalf("expiresAt: %q", gotExp)
 	}
 }
 
 func TestLogin_UsesRealClockWhenNil(t *testing.T) {
-	var base string
-	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("content-type", "application/json")
-		switch r.URL.Path {
-		case "/.well-known/openid-configuration":
-			_, _ = w.Write([]byte(`{"device_authorization_endpoint":"` + base + `/device","token_endpoint":"` + base + `/token"}`))
-		case "/device":
-			_, _ = w.Write([]byte(`{"device_code":"dc","user_code":"UC","verification_uri":"` + base + `/verify","expires_in":600,"interval":0}`))
-		case "/token":
-			_, _ = w.Write([]byte(`{"access_token":"a.b.c","token_type":"Bearer","expires_in":1}`))
-		default:
-			w.WriteHeader(404)
-		}
-	}))
-	defer srv.Close()
-	base = srv.URL
+	_, srv := newIssuer(t)
 
 	doc := config.NewEmptyDocument()
 	doc, _ = config.WithProfileAPIURL(doc, "default", "http://x")
-	doc, _ = config.WithProfileOIDC(doc, "default", base, "cid", []string{"openid"})
+	doc, _ = config.WithProfileOIDC(doc, "default", srv.URL, "cid", []string{"openid"})
 	m := &memStore{doc: doc}
 	op := &fakeOpen{}
 
@@ -0,0 +1,256 @@
+// Package calendarapp turns trips into iCalendar events, for `trip export`
+// and the `calendar serve` feeds.
+package calendarapp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/ical"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+// Feeds served by `calendar serve`.
+const (
+	// FeedAttending is the trips the member answered YES to.
+	FeedAttending = "attending"
+	// FeedPublished is every published trip. Canceled trips stay in both
+	// feeds (as CANCELLED) so subscribed calendars see the cancellation.
+	FeedPublished = "published"
+)
+
+// Feeds lists the feed names.
+var Feeds = []string{FeedAttending, FeedPublished}
+
+// DefaultRefresh is how long fetched trips are served before refetching.
+const DefaultRefresh = 15 * time.Minute
+
+// failureRetry caps how long after a failed fetch the next one is tried.
+const failureRetry = time.Minute
+
+var eventStatus = map[plannerapi.TripStatus]string{
+	plannerapi.TripStatusPublished: ical.StatusConfirmed,
+	plannerapi.TripStatusCanceled:  ical.StatusCancelled,
+	plannerapi.TripStatusDraft:     ical.StatusTentative,
+}
+
+// Event converts a trip to an all-day event; ok is false when it has no
+// start date. The UID derives from the trip ID, so importing a newer export
+// (or refreshing a feed) updates the event instead of duplicating it.
+func Event(t plannerapi.Trip) (e ical.Event, ok bool) {
+	if t.StartDate == nil {
+		return e, false
+	}
+	e = ical.Event{
+		UID:    "ebo-trip-" + t.TripID,
+		Start:  t.StartDate.Time,
+		Status: eventStatus[t.Status],
+	}
+	if t.Name != nil {
+		e.Summary = *t.Name
+	}
+	if t.EndDate != nil {
+		e.End = t.EndDate.Time
+	}
+
+	var desc []string
+	for _, s := range []struct {
+		label string
+		text  *string
+	}{
+		{"", t.Description},
+		{"Comms requirements:\n", t.CommsRequirementsText},
+		{"Recommended requirements:\n", t.RecommendedRequirementsText},
+	} {
+		if s.text != nil && strings.TrimSpace(*s.text) != "" {
+			desc = append(desc, s.label+strings.TrimSpace(*s.text))
+		}
+	}
+	e.Description = strings.Join(desc, "\n\n")
+
+	if loc := t.MeetingLocation; loc != nil {
+		var parts []string
+		for _, p := range []*string{&loc.Label, loc.Address} {
+			if p != nil && strings.TrimSpace(*p) != "" {
+				parts = append(parts, strings.TrimSpace(*p))
+			}
+		}
+		e.Location = strings.Join(parts, ", ")
+		if ll := loc.LatitudeLongitude; ll != nil && ll.Latitude != nil && ll.Longitude != nil {
+			e.Geo = &ical.Geo{Latitude: *ll.Latitude, Longitude: *ll.Longitude}
+		}
+	}
+	return e, true
+}
+
+// Events converts trips in order, returning the IDs of those skipped for
+// having no start date.
+func Events(trips []plannerapi.Trip) (events []ical.Event, skipped []string) {
+	events = make([]ical.Event, 0, len(trips))
+	for _, t := range trips {
+		e, ok := Event(t)
+		if !ok {
+			skipped = append(skipped, t.TripID)
+			continue
+		}
+		events = append(events, e)
+	}
+	return events, skipped
+}
+
+// Source is where a profile's trips come from.
+type Source struct {
+	Profile     string
+	APIURL      string
+	BearerToken string
+	// API is the profile's client (its own TLS and proxy settings); nil means
+	// the Service's API.
+	API plannerapi.Client
+}
+
+// Feed is a feed's trips as of FetchedAt. RefreshErr is set when refetching
+// failed and older trips are served instead.
+type Feed struct {
+	Trips      []plannerapi.Trip
+	FetchedAt  time.Time
+	RefreshErr error
+}
+
+// Service builds the calendar feeds, caching each profile's trips in memory
+// for Refresh. After a failed fetch, the API is not tried again for
+// Refresh or a minute, whichever is shorter. Profiles are fetched
+// independently, so a slow one does not hold up the others. Use it by
+// pointer.
+type Service struct {
+	API plannerapi.Client
+	// Refresh is how long fetched trips are served; zero means
+	// DefaultRefresh.
+	Refresh time.Duration
+	// Now is the clock; nil means time.Now.
+	Now func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]*cacheEntry
+}
+
+// cacheEntry is one profile's trips. Its mutex is held while fetching, so
+// concurrent requests for the profile share one fetch.
+type cacheEntry struct {
+	mu   sync.Mutex
+	feed Feed
+	// err is the last failure when no fetch has succeeded yet.
+	err error
+	// triedAt is when the last fetch, successful or not, finished.
+	triedAt time.Time
+}
+
+func (s *Service) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// Feed returns the named feed for src. The profile's visible trips (with
+// details, for myRsvp) are fetched at most once per Refresh and shared by
+// every feed.
+func (s *Service) Feed(ctx context.Context, src Source, name string) (Feed, error) {
+	keep, ok := map[string]func(plannerapi.Trip) bool{
+		FeedAttending: func(t plannerapi.Trip) bool {
+			return t.MyRSVP != nil && t.MyRSVP.Response == plannerapi.RSVPYes
+		},
+		FeedPublished: func(t plannerapi.Trip) bool {
+			return t.Status == plannerapi.TripStatusPublished || t.Status == plannerapi.TripStatusCanceled
+		},
+	}[name]
+	if !ok {
+		return Feed{}, fmt.Errorf("unknown feed %q", name)
+	}
+	all, err := s.trips(ctx, src)
+	if err != nil {
+		return Feed{}, err
+	}
+	feed := all
+	feed.Trips = nil
+	for _, t := range all.Trips {
+		if keep(t) {
+			feed.Trips = append(feed.Trips, t)
+		}
+	}
+	return feed, nil
+}
+
+func (s *Service) trips(ctx context.Context, src Source) (Feed, error) {
+	refresh := s.Refresh
+	if refresh <= 0 {
+		refresh = DefaultRefresh
+	}
+	key := src.Profile + "\x00" + src.APIURL
+	s.mu.Lock()
+	e, ok := s.cache[key]
+	if !ok {
+		if s.cache == nil {
+			s.cache = map[string]*cacheEntry{}
+		}
+		e = &cacheEntry{}
+		s.cache[key] = e
+	}
+	s.mu.Unlock()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	failed := e.err != nil || e.feed.RefreshErr != nil
+	if !e.triedAt.IsZero() {
+		wait := refresh
+		if failed {
+			wait = min(refresh, failureRetry)
+		}
+		if s.now().Sub(e.triedAt) < wait {
+			return e.feed, e.err
+		}
+	}
+
+	trips, err := s.fetch(ctx, src)
+	e.triedAt = s.now()
+	switch {
+	case err == nil:
+		e.feed, e.err = Feed{Trips: trips, FetchedAt: e.triedAt}, nil
+	case e.feed.FetchedAt.IsZero():
+		e.err = err
+	default:
+		e.feed.RefreshErr = err
+	}
+	return e.feed, e.err
+}
+
+// fetch lists the visible trips and gets the details of each dated one.
+func (s *Service) fetch(ctx context.Context, src Source) ([]plannerapi.Trip, error) {
+	api := src.API
+	if api == nil {
+		api = s.API
+	}
+	list, err := api.ListVisibleTripsForMember(ctx, src.APIURL, src.BearerToken)
+	if err != nil {
+		return nil, err
+	}
+	var trips []plannerapi.Trip
+	if list == nil {
+		return trips, nil
+	}
+	for _, summary := range list.Trips {
+		if summary.StartDate == nil {
+			continue
+		}
+		resp, err := api.GetTripDetails(ctx, src.APIURL, src.BearerToken, summary.TripID)
+		if err != nil {
+			return nil, err
+		}
+		if resp != nil {
+			trips = append(trips, resp.Trip)
+		}
+	}
+	return trips, nil
+}
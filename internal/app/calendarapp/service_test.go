@@ -0,0 +1,223 @@
+package calendarapp
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/ical"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+// fakeAPI serves a fixed trip list; methods a test does not use panic via
+// the nil embedded Client.
+type fakeAPI struct {
+	plannerapi.Client
+
+	trips     []plannerapi.Trip
+	err       error
+	listCalls int
+	fetched   []string
+}
+
+func (f *fakeAPI) ListVisibleTripsForMember(ctx context.Context, baseURL, bearerToken string) (*plannerapi.TripList, error) {
+	f.listCalls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	list := &plannerapi.TripList{}
+	for _, t := range f.trips {
+		list.Trips = append(list.Trips, plannerapi.TripSummary{TripID: t.TripID, Status: t.Status, StartDate: t.StartDate})
+	}
+	return list, nil
+}
+
+func (f *fakeAPI) GetTripDetails(ctx context.Context, baseURL, bearerToken, tripID string) (*plannerapi.TripResult, error) {
+	f.fetched = append(f.fetched, tripID)
+	for _, t := range f.trips {
+		if t.TripID == tripID {
+			return &plannerapi.TripResult{Trip: t}, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func newFakeAPI() *fakeAPI {
+	start := &plannerapi.Date{Time: time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC)}
+	yes := &plannerapi.RSVP{Response: plannerapi.RSVPYes}
+	no := &plannerapi.RSVP{Response: plannerapi.RSVPNo}
+	return &fakeAPI{trips: []plannerapi.Trip{
+		{TripID: "going", Status: plannerapi.TripStatusPublished, StartDate: start, MyRSVP: yes},
+		{TripID: "not-going", Status: plannerapi.TripStatusPublished, StartDate: start, MyRSVP: no},
+		{TripID: "canceled", Status: plannerapi.TripStatusCanceled, StartDate: start, MyRSVP: yes},
+		{TripID: "draft", Status: plannerapi.TripStatusDraft, StartDate: start},
+		{TripID: "undated", Status: plannerapi.TripStatusPublished, MyRSVP: yes},
+	}}
+}
+
+func tripIDs(f Feed) []string {
+	var ids []string
+	for _, t := range f.Trips {
+		ids = append(ids, t.TripID)
+	}
+	return ids
+}
+
+func TestFeed_FiltersTrips(t *testing.T) {
+	api := newFakeAPI()
+	svc := &Service{API: api}
+	src := Source{Profile: "p", APIURL: "http://api"}
+
+	for name, want := range map[string][]string{
+		FeedAttending: {"going", "canceled"},
+		FeedPublished: {"going", "not-going", "canceled"},
+	} {
+		f, err := svc.Feed(context.Background(), src, name)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := tripIDs(f); !slices.Equal(got, want) {
+			t.Errorf("%s: got %v, want %v", name, got, want)
+		}
+	}
+	if api.listCalls != 1 {
+		t.Fatalf("feeds should share one fetch, got %d list calls", api.listCalls)
+	}
+	if !slices.Equal(api.fetched, []string{"going", "not-going", "canceled", "draft"}) {
+		t.Fatalf("fetched %v, want only dated trips", api.fetched)
+	}
+	if _, err := svc.Feed(context.Background(), src, "bogus"); err == nil {
+		t.Fatalf("expected error for unknown feed")
+	}
+}
+
+func TestFeed_CachesForRefreshAndServesStaleOnError(t *testing.T) {
+	api := newFakeAPI()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	svc := &Service{API: api, Refresh: time.Minute, Now: func() time.Time { return now }}
+	src := Source{Profile: "p", APIURL: "http://api"}
+
+	first, err := svc.Feed(context.Background(), src, FeedPublished)
+	if err != nil {
+		t.Fatalf("first: %v", err)
+	}
+	now = now.Add(30 * time.Second)
+	if _, err := svc.Feed(context.Background(), src, FeedPublished); err != nil || api.listCalls != 1 {
+		t.Fatalf("within refresh: err=%v listCalls=%d", err, api.listCalls)
+	}
+	if _, err := svc.Feed(context.Background(), Source{Profile: "other", APIURL: "http://api"}, FeedPublished); err != nil || api.listCalls != 2 {
+		t.Fatalf("other profile: err=%v listCalls=%d", err, api.listCalls)
+	}
+
+	now = now.Add(time.Minute)
+	api.err = errors.New("offline")
+	stale, err := svc.Feed(context.Background(), src, FeedPublished)
+	if err != nil {
+		t.Fatalf("stale: %v", err)
+	}
+	if stale.RefreshErr == nil || !stale.FetchedAt.Equal(first.FetchedAt) || len(stale.Trips) != len(first.Trips) {
+		t.Fatalf("expected stale feed, got %+v", stale)
+	}
+
+	if _, err := svc.Feed(context.Background(), Source{Profile: "new"}, FeedPublished); err == nil {
+		t.Fatalf("expected error with nothing cached")
+	}
+
+	now = now.Add(time.Minute)
+	api.err = nil
+	fresh, err := svc.Feed(context.Background(), src, FeedPublished)
+	if err != nil || fresh.RefreshErr != nil || !fresh.FetchedAt.Equal(now) {
+		t.Fatalf("fresh: err=%v feed=%+v", err, fresh)
+	}
+}
+
+func TestFeed_BacksOffAfterFailure(t *testing.T) {
+	api := newFakeAPI()
+	api.err = errors.New("offline")
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	svc := &Service{API: api, Refresh: time.Hour, Now: func() time.Time { return now }}
+	src := Source{Profile: "p", APIURL: "http://api"}
+
+	for range 3 {
+		if _, err := svc.Feed(context.Background(), src, FeedPublished); err == nil {
+			t.Fatalf("expected error with nothing cached")
+		}
+	}
+	if api.listCalls != 1 {
+		t.Fatalf("failed fetch retried on every request: %d list calls", api.listCalls)
+	}
+
+	now = now.Add(failureRetry)
+	api.err = nil
+	if _, err := svc.Feed(context.Background(), src, FeedPublished); err != nil || api.listCalls != 2 {
+		t.Fatalf("after retry delay: err=%v listCalls=%d", err, api.listCalls)
+	}
+
+	// A failed refresh serves the old trips and also waits before retrying.
+	now = now.Add(time.Hour)
+	api.err = errors.New("offline")
+	for range 3 {
+		if f, err := svc.Feed(context.Background(), src, FeedPublished); err != nil || f.RefreshErr == nil {
+			t.Fatalf("stale: err=%v feed=%+v", err, f)
+		}
+	}
+	if api.listCalls != 3 {
+		t.Fatalf("failed refresh retried on every request: %d list calls", api.listCalls)
+	}
+}
+
+// blockingAPI holds ListVisibleTripsForMember until release is closed.
+type blockingAPI struct {
+	*fakeAPI
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingAPI) ListVisibleTripsForMember(ctx context.Context, baseURL, bearerToken string) (*plannerapi.TripList, error) {
+	close(b.started)
+	<-b.release
+	return b.fakeAPI.ListVisibleTripsForMember(ctx, baseURL, bearerToken)
+}
+
+func TestFeed_SlowProfileDoesNotBlockOthers(t *testing.T) {
+	slow := &blockingAPI{fakeAPI: newFakeAPI(), started: make(chan struct{}), release: make(chan struct{})}
+	svc := &Service{API: newFakeAPI()}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := svc.Feed(context.Background(), Source{Profile: "slow", API: slow}, FeedPublished)
+		done <- err
+	}()
+	<-slow.started
+
+	fast := make(chan error, 1)
+	go func() {
+		_, err := svc.Feed(context.Background(), Source{Profile: "fast"}, FeedPublished)
+		fast <- err
+	}()
+	select {
+	case err := <-fast:
+		if err != nil {
+			t.Fatalf("fast: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("fast profile waited for the slow one")
+	}
+
+	close(slow.release)
+	if err := <-done; err != nil {
+		t.Fatalf("slow: %v", err)
+	}
+}
+
+func TestEvents_StableUIDsAndSkipsUndated(t *testing.T) {
+	events, skipped := Events(newFakeAPI().trips)
+	if len(events) != 4 || !slices.Equal(skipped, []string{"undated"}) {
+		t.Fatalf("events=%d skipped=%v", len(events), skipped)
+	}
+	if events[0].UID != "ebo-trip-going" || events[2].Status != ical.StatusCancelled || events[3].Status != ical.StatusTentative {
+		t.Fatalf("events: %+v", events)
+	}
+}
@@ -0,0 +1,444 @@
+// Package doctorapp runs the `ebo doctor` health checks: the checklist we
+// walk through whenever someone reports that "ebo is broken".
+package doctorapp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/config"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/httpx"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/oidcdevice"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+// Status is the outcome of one check.
+type Status string
+
+const (
+	Pass Status = "pass"
+	Warn Status = "warn"
+	Fail Status = "fail"
+)
+
+// Check names, in the order they run.
+const (
+	CheckConfig      = "config"
+	CheckPermissions = "permissions"
+	CheckProfile     = "profile"
+	CheckAPI         = "api"
+	CheckOIDC        = "oidc"
+	CheckToken       = "token"
+	CheckClock       = "clock"
+	CheckMember      = "member"
+	CheckSpec        = "spec"
+)
+
+// Thresholds for warnings and failures.
+const (
+	SlowAPI       = 2 * time.Second
+	ExpiryWarning = 5 * time.Minute
+	SkewWarning   = time.Minute
+	SkewFailure   = 5 * time.Minute
+)
+
+// Check is the result of one check. Kind is the exit-code kind a failure
+// maps to; it is empty unless Status is Fail.
+type Check struct {
+	Name    string        `json:"name"`
+	Status  Status        `json:"status"`
+	Message string        `json:"message"`
+	Hint    string        `json:"hint,omitempty"`
+	Kind    exitcode.Kind `json:"-"`
+}
+
+// Report is the outcome of Run.
+type Report struct {
+	Profile string  `json:"profile"`
+	APIURL  string  `json:"apiUrl"`
+	Checks  []Check `json:"checks"`
+}
+
+// Err returns nil when no check failed. Otherwise it returns an error with
+// the first failed check's kind (so `ebo doctor` exits with the code a real
+// command would have hit), its hint, and the report as envelope data.
+func (r Report) Err() error {
+	var failed []Check
+	for _, c := range r.Checks {
+		if c.Status == Fail {
+			failed = append(failed, c)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	first := failed[0]
+	kind := first.Kind
+	if kind == "" {
+		kind = exitcode.KindUnexpected
+	}
+	msg := fmt.Sprintf("%d check failed: %s", len(failed), first.Name)
+	if len(failed) > 1 {
+		names := make([]string, 0, len(failed))
+		for _, c := range failed {
+			names = append(names, c.Name)
+		}
+		msg = fmt.Sprintf("%d checks failed: %s", len(failed), strings.Join(names, ", "))
+	}
+	err := exitcode.WithHint(exitcode.New(kind, msg, nil), first.Hint)
+	err = exitcode.WithContext(err, exitcode.Context{Profile: r.Profile, APIURL: r.APIURL})
+	return exitcode.WithData(err, r)
+}
+
+// Service runs the checks. Every dependency is injected so the checks can be
+// exercised without a real config dir, API or identity provider.
+type Service struct {
+	Store out.ConfigStore
+	API   plannerapi.Client
+	// HTTP reaches the API base URL and the OIDC issuer directly (no cache).
+	HTTP oidcdevice.HTTPDoer
+	// FileMode reports the config file's permission bits; nil skips the check
+	// (e.g. on Windows, where they mean nothing).
+	FileMode func(path string) (fs.FileMode, error)
+	// Now is the local clock; nil means time.Now.
+	Now func() time.Time
+	// SpecRef is the Planner API spec version the binary was built against.
+	SpecRef string
+}
+
+func (s Service) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// run carries what earlier checks learned to later ones.
+type run struct {
+	doc        config.Document
+	configOK   bool
+	profile    string
+	apiURL     string
+	reachable  bool
+	serverTime time.Time
+	localTime  time.Time
+	token      string
+	tokenOK    bool
+}
+
+// Run runs every check in order. Checks that depend on an earlier failure
+// are reported as warnings saying what they skipped.
+func (s Service) Run(ctx context.Context, resolved cliopts.Resolved) Report {
+	var (
+		r      run
+		checks []Check
+	)
+	add := func(c Check) { checks = append(checks, c) }
+
+	path, _ := s.Store.Path(ctx)
+	add(s.checkConfig(ctx, &r, path))
+	add(s.checkPermissions(path))
+	add(s.checkProfile(&r, resolved))
+	add(s.checkAPI(ctx, &r))
+	add(s.checkOIDC(ctx, &r))
+	add(s.checkToken(&r))
+	add(s.checkClock(&r))
+	add(s.checkMember(ctx, &r))
+	add(s.checkSpec())
+
+	return Report{Profile: r.profile, APIURL: r.apiURL, Checks: checks}
+}
+
+func pass(name, msg string) Check { return Check{Name: name, Status: Pass, Message: msg} }
+
+func warn(name, msg, hint string) Check {
+	return Check{Name: name, Status: Warn, Message: msg, Hint: hint}
+}
+
+func fail(name string, kind exitcode.Kind, msg, hint string) Check {
+	return Check{Name: name, Status: Fail, Message: msg, Hint: hint, Kind: kind}
+}
+
+func skipped(name, why string) Check { return warn(name, "skipped: "+why, "") }
+
+func (s Service) checkConfig(ctx context.Context, r *run, path string) Check {
+	doc, err := s.Store.Load(ctx)
+	if err != nil {
+		return fail(CheckConfig, exitcode.KindUsage, fmt.Sprintf("cannot parse %s: %v", path, err), "fix the YAML, or move the file aside and recreate it with ebo profile create")
+	}
+	view, err := config.ViewOf(doc)
+	if err != nil {
+		return fail(CheckConfig, exitcode.KindUsage, fmt.Sprintf("invalid config %s: %v", path, err), "ebo config list")
+	}
+	names := make([]string, 0, len(view.Profiles))
+	for name := range view.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := config.NetworkOf(doc, name); err != nil {
+			return fail(CheckConfig, exitcode.KindUsage, fmt.Sprintf("profile %q: %v", name, err), "ebo config list")
+		}
+	}
+	r.doc, r.configOK = doc, true
+	return pass(CheckConfig, path+" is valid")
+}
+
+func (s Service) checkPermissions(path string) Check {
+	if s.FileMode == nil {
+		return pass(CheckPermissions, "not checked on this platform")
+	}
+	mode, err := s.FileMode(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return pass(CheckPermissions, "no config file yet")
+	}
+	if err != nil {
+		return fail(CheckPermissions, exitcode.KindUnexpected, fmt.Sprintf("stat %s: %v", path, err), "")
+	}
+	if perm := mode.Perm(); perm&0o077 != 0 {
+		return fail(CheckPermissions, exitcode.KindUsage,
+			fmt.Sprintf("%s is mode %04o; it holds tokens and must not be readable by others (want 0600)", path, perm),
+			"chmod 600 "+path)
+	}
+	return pass(CheckPermissions, fmt.Sprintf("mode %04o", mode.Perm()))
+}
+
+func (s Service) checkProfile(r *run, resolved cliopts.Resolved) Check {
+	view := config.View{}
+	if r.configOK {
+		view, _ = config.ViewOf(r.doc)
+	}
+	eff := config.ResolveEffective(resolved, view)
+	r.profile = eff.Profile
+	if strings.TrimSpace(eff.APIURL) == "" {
+		if !r.configOK {
+			return skipped(CheckProfile, "config is invalid and no --api-url/EBO_API_URL given")
+		}
+		return fail(CheckProfile, exitcode.KindUsage, fmt.Sprintf("profile %q has no apiUrl", eff.Profile),
+			fmt.Sprintf("ebo profile set %s --api-url <url>", eff.Profile))
+	}
+	u, err := url.Parse(eff.APIURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fail(CheckProfile, exitcode.KindUsage, fmt.Sprintf("apiUrl %q is not an http(s) URL", eff.APIURL),
+			fmt.Sprintf("ebo profile set %s --api-url <url>", eff.Profile))
+	}
+	r.apiURL = eff.APIURL
+	msg := fmt.Sprintf("profile %s, apiUrl %s", eff.Profile, eff.APIURL)
+	if _, ok := view.Profiles[eff.Profile]; !ok && eff.Profile != "default" {
+		return warn(CheckProfile, msg+" (profile is not defined in the config file)", "ebo profile create "+eff.Profile+" --api-url <url>")
+	}
+	return pass(CheckProfile, msg)
+}
+
+func (s Service) checkAPI(ctx context.Context, r *run) Check {
+	if r.apiURL == "" {
+		return skipped(CheckAPI, "no apiUrl")
+	}
+	if s.HTTP == nil {
+		return skipped(CheckAPI, "no HTTP client")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.apiURL, nil)
+	if err != nil {
+		return fail(CheckAPI, exitcode.KindUsage, err.Error(), "")
+	}
+	start := s.now()
+	resp, err := s.HTTP.Do(req)
+	r.localTime = s.now()
+	latency := r.localTime.Sub(start)
+	if err != nil {
+		class := httpx.ClassifyNetError(err)
+		summary, hint := httpx.NetErrorSummary(class, r.apiURL)
+		return fail(CheckAPI, exitcode.KindNetwork, fmt.Sprintf("%s (%s)", summary, class), hint)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	r.reachable = true
+	if d, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		r.serverTime = d
+	}
+
+	msg := fmt.Sprintf("reachable in %s (HTTP %d)", latency.Round(time.Millisecond), resp.StatusCode)
+	switch {
+	case resp.StatusCode >= 500:
+		return warn(CheckAPI, msg, "the API is up but failing; check its status before anything else")
+	case latency > SlowAPI:
+		return warn(CheckAPI, msg+"; slow", "check your network/VPN, or raise --timeout")
+	}
+	return pass(CheckAPI, msg)
+}
+
+func (s Service) checkOIDC(ctx context.Context, r *run) Check {
+	if !r.configOK {
+		return skipped(CheckOIDC, "config is invalid")
+	}
+	oc, err := config.OIDCOf(r.doc, r.profile)
+	if err != nil {
+		return warn(CheckOIDC, "not configured; ebo auth login is unavailable for this profile",
+			fmt.Sprintf("ebo config set profiles.%s.oidc.issuerUrl <url> (plus oidc.clientId and oidc.scopes)", r.profile))
+	}
+	if s.HTTP == nil {
+		return skipped(CheckOIDC, "no HTTP client")
+	}
+	d, err := oidcdevice.Discover(ctx, s.HTTP, oc.IssuerURL)
+	if err != nil {
+		return fail(CheckOIDC, exitcode.KindServer, fmt.Sprintf("discovery at %s failed: %v", oc.IssuerURL, err),
+			fmt.Sprintf("check profiles.%s.oidc.issuerUrl and that the issuer supports the device authorization grant", r.profile))
+	}
+	if len(d.GrantTypesSupported) > 0 && !contains(d.GrantTypesSupported, oidcdevice.DeviceCodeGrantType) {
+		return fail(CheckOIDC, exitcode.KindServer, oc.IssuerURL+" does not list the device authorization grant in grant_types_supported",
+			"enable the device authorization grant for client "+oc.ClientID)
+	}
+	return pass(CheckOIDC, oc.IssuerURL+" supports the device authorization grant")
+}
+
+func (s Service) checkToken(r *run) Check {
+	if !r.configOK {
+		return skipped(CheckToken, "config is invalid")
+	}
+	tok, _ := config.Get(r.doc, "profiles."+r.profile+".auth.accessToken")
+	tok = strings.TrimSpace(tok)
+	if tok == "" {
+		return fail(CheckToken, exitcode.KindAuth, "no token configured", "ebo auth login")
+	}
+	r.token = tok
+
+	exp, ok := jwtExpiry(tok)
+	if !ok {
+		stored, _ := config.Get(r.doc, "profiles."+r.profile+".auth.expiresAt")
+		t, err := time.Parse(time.RFC3339, strings.TrimSpace(stored))
+		if err != nil {
+			r.tokenOK = true
+			return pass(CheckToken, "present (expiry unknown)")
+		}
+		exp = t
+	}
+	// Judge expiry by the server's clock when we have it: that is the one
+	// that accepts or rejects the token.
+	now, clock := s.now(), "local"
+	if !r.serverTime.IsZero() {
+		now, clock = r.serverTime, "server"
+	}
+	left := exp.Sub(now)
+	switch {
+	case left <= 0:
+		return fail(CheckToken, exitcode.KindAuth, fmt.Sprintf("expired %s ago (%s clock)", (-left).Round(time.Second), clock), "ebo auth login")
+	case left < ExpiryWarning:
+		r.tokenOK = true
+		return warn(CheckToken, fmt.Sprintf("expires in %s (%s clock)", left.Round(time.Second), clock), "ebo auth login")
+	}
+	r.tokenOK = true
+	return pass(CheckToken, fmt.Sprintf("expires %s (in %s)", exp.UTC().Format(time.RFC3339), left.Round(time.Minute)))
+}
+
+func (s Service) checkClock(r *run) Check {
+	if !r.reachable {
+		return skipped(CheckClock, "API not reachable")
+	}
+	if r.serverTime.IsZero() {
+		return warn(CheckClock, "the API sent no Date header; clock skew unknown", "")
+	}
+	// Date has one-second resolution.
+	skew := r.localTime.Sub(r.serverTime).Truncate(time.Second)
+	abs := skew
+	if abs < 0 {
+		abs = -abs
+	}
+	dir := "ahead of"
+	if skew < 0 {
+		dir = "behind"
+	}
+	msg := fmt.Sprintf("local clock is %s %s the server", abs, dir)
+	switch {
+	case abs >= SkewFailure:
+		return fail(CheckClock, exitcode.KindAuth, msg+"; tokens will look expired or not yet valid", "sync your system clock (NTP)")
+	case abs >= SkewWarning:
+		return warn(CheckClock, msg, "sync your system clock (NTP)")
+	}
+	return pass(CheckClock, fmt.Sprintf("within %s of the server", SkewWarning))
+}
+
+func (s Service) checkMember(ctx context.Context, r *run) Check {
+	switch {
+	case !r.reachable:
+		return skipped(CheckMember, "API not reachable")
+	case !r.tokenOK:
+		return skipped(CheckMember, "no usable token")
+	case s.API == nil:
+		return skipped(CheckMember, "no API client")
+	}
+	res, err := s.API.GetMyMemberProfile(ctx, r.apiURL, r.token)
+	if err != nil {
+		var e *exitcode.Error
+		kind := exitcode.KindUnexpected
+		if errors.As(err, &e) {
+			kind = e.Kind
+		}
+		hint := exitcode.HintOf(err)
+		switch kind {
+		case exitcode.KindNotFound:
+			return fail(CheckMember, kind, "not provisioned as a member", "ebo member create --display-name <name> --email <email>")
+		case exitcode.KindAuth:
+			if hint == "" {
+				hint = "ebo auth login"
+			}
+		}
+		return fail(CheckMember, kind, err.Error(), hint)
+	}
+	if res == nil {
+		return fail(CheckMember, exitcode.KindServer, "empty member profile response", "")
+	}
+	return pass(CheckMember, fmt.Sprintf("provisioned as %s (%s)", res.Member.DisplayName, res.Member.MemberID))
+}
+
+func (s Service) checkSpec() Check {
+	if s.SpecRef == "" {
+		return warn(CheckSpec, "spec version unknown (the embedded OpenAPI document was not generated by make gen)", "")
+	}
+	return pass(CheckSpec, "built against spec "+s.SpecRef)
+}
+
+// jwtExpiry reads the exp claim of a JWT without verifying it; ok is false
+// for opaque tokens.
+func jwtExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp *json.Number `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == nil {
+		return time.Time{}, false
+	}
+	secs, err := claims.Exp.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(secs), 0), true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
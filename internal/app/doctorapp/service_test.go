@@ -0,0 +1,277 @@
+package doctorapp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/config"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/oidcdevice"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+type memStore struct {
+	doc config.Document
+	err error
+}
+
+func (m memStore) Path(ctx context.Context) (string, error)          { return "/cfg/ebo/config.yaml", nil }
+func (m memStore) Load(ctx context.Context) (config.Document, error) { return m.doc, m.err }
+func (m memStore) Save(ctx context.Context, doc config.Document) error {
+	return errors.New("doctor must not save")
+}
+
+// fakeAPI answers GetMyMemberProfile; other methods panic via the nil
+// embedded interface.
+type fakeAPI struct {
+	plannerapi.Client
+	member *plannerapi.MemberResult
+	err    error
+}
+
+func (f fakeAPI) GetMyMemberProfile(ctx context.Context, baseURL, bearerToken string) (*plannerapi.MemberResult, error) {
+	return f.member, f.err
+}
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// server is the API base URL and the OIDC issuer in one: it sends Date
+// (serverTime) and a discovery document listing grants.
+func server(t *testing.T, serverTime time.Time, grants []string) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", serverTime.UTC().Format(http.TimeFormat))
+		if r.URL.Path == "/.well-known/openid-configuration" {
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"issuer":                        srv.URL,
+				"device_authorization_endpoint": srv.URL + "/device",
+				"token_endpoint":                srv.URL + "/token",
+				"grant_types_supported":         grants,
+			})
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func jwtExpiringAt(exp time.Time) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"none"}`)) + "." +
+		enc.EncodeToString([]byte(fmt.Sprintf(`{"sub":"alice","exp":%d}`, exp.Unix()))) + ".sig"
+}
+
+func healthyDoc(t *testing.T, apiURL, token string) config.Document {
+	t.Helper()
+	doc := config.NewEmptyDocument()
+	doc, _ = config.WithCurrentProfile(doc, "dev")
+	doc, _ = config.WithProfileAPIURL(doc, "dev", apiURL)
+	doc, _ = config.WithProfileOIDC(doc, "dev", apiURL, "ebo-cli", []string{"openid"})
+	if token != "" {
+		doc, _ = config.SetString(doc, "profiles.dev.auth.accessToken", token)
+	}
+	return doc
+}
+
+func defaults() cliopts.Resolved {
+	return cliopts.Resolved{
+		Options: cliopts.DefaultGlobalOptions(),
+		Sources: map[string]string{"profile": "default", "api-url": "default"},
+	}
+}
+
+func healthyService(t *testing.T, srv *httptest.Server, token string) Service {
+	return Service{
+		Store:    memStore{doc: healthyDoc(t, srv.URL, token)},
+		API:      fakeAPI{member: &plannerapi.MemberResult{Member: plannerapi.Member{MemberID: "m-alice", DisplayName: "Alice"}}},
+		HTTP:     srv.Client(),
+		FileMode: func(string) (fs.FileMode, error) { return 0o600, nil },
+		Now:      func() time.Time { return now },
+		SpecRef:  "v1.2.3",
+	}
+}
+
+func statuses(r Report) string {
+	var parts []string
+	for _, c := range r.Checks {
+		parts = append(parts, c.Name+"="+string(c.Status))
+	}
+	return strings.Join(parts, " ")
+}
+
+func check(t *testing.T, r Report, name string) Check {
+	t.Helper()
+	for _, c := range r.Checks {
+		if c.Name == name {
+			return c
+		}
+	}
+	t.Fatalf("no %s check in %s", name, statuses(r))
+	return Check{}
+}
+
+func TestRun_AllPass(t *testing.T) {
+	srv := server(t, now, []string{"authorization_code", oidcdevice.DeviceCodeGrantType})
+	s := healthyService(t, srv, jwtExpiringAt(now.Add(time.Hour)))
+
+	r := s.Run(context.Background(), defaults())
+	want := "config=pass permissions=pass profile=pass api=pass oidc=pass token=pass clock=pass member=pass spec=pass"
+	if got := statuses(r); got != want {
+		t.Fatalf("statuses:\n got %s\nwant %s\n%+v", got, want, r.Checks)
+	}
+	if r.Profile != "dev" || r.APIURL != srv.URL {
+		t.Fatalf("report: %+v", r)
+	}
+	if err := r.Err(); err != nil {
+		t.Fatalf("Err: %v", err)
+	}
+	if c := check(t, r, CheckMember); c.Message != "provisioned as Alice (m-alice)" {
+		t.Fatalf("member: %q", c.Message)
+	}
+	if c := check(t, r, CheckSpec); c.Message != "built against spec v1.2.3" {
+		t.Fatalf("spec: %q", c.Message)
+	}
+}
+
+func TestRun_NoTokenFailsWithAuthAndSkipsMember(t *testing.T) {
+	srv := server(t, now, nil)
+	s := healthyService(t, srv, "")
+
+	r := s.Run(context.Background(), defaults())
+	if c := check(t, r, CheckToken); c.Status != Fail || c.Hint != "ebo auth login" {
+		t.Fatalf("token: %+v", c)
+	}
+	if c := check(t, r, CheckMember); c.Status != Warn || !strings.HasPrefix(c.Message, "skipped:") {
+		t.Fatalf("member: %+v", c)
+	}
+	err := r.Err()
+	if exitcode.Code(err) != exitcode.Auth || exitcode.HintOf(err) != "ebo auth login" {
+		t.Fatalf("err: %v code=%d hint=%q", err, exitcode.Code(err), exitcode.HintOf(err))
+	}
+	if data, ok := exitcode.DataOf(err).(Report); !ok || len(data.Checks) != len(r.Checks) {
+		t.Fatalf("expected the report as error data, got %#v", exitcode.DataOf(err))
+	}
+}
+
+func TestRun_TokenExpiryIsJudgedByServerClock(t *testing.T) {
+	// The local clock is 10 minutes behind: locally the token looks valid
+	// for another 5 minutes, but the server already considers it expired.
+	server10 := now.Add(10 * time.Minute)
+	srv := server(t, server10, nil)
+	s := healthyService(t, srv, jwtExpiringAt(now.Add(5*time.Minute)))
+
+	r := s.Run(context.Background(), defaults())
+	if c := check(t, r, CheckToken); c.Status != Fail || !strings.Contains(c.Message, "server clock") {
+		t.Fatalf("token: %+v", c)
+	}
+	if c := check(t, r, CheckClock); c.Status != Fail || c.Message != "local clock is 10m0s behind the server; tokens will look expired or not yet valid" {
+		t.Fatalf("clock: %+v", c)
+	}
+	if got := r.Err().Error(); got != "2 checks failed: token, clock" {
+		t.Fatalf("err: %q", got)
+	}
+}
+
+func TestRun_SmallSkewWarns(t *testing.T) {
+	srv := server(t, now.Add(-90*time.Second), nil)
+	s := healthyService(t, srv, jwtExpiringAt(now.Add(time.Hour)))
+
+	r := s.Run(context.Background(), defaults())
+	if c := check(t, r, CheckClock); c.Status != Warn || c.Message != "local clock is 1m30s ahead of the server" {
+		t.Fatalf("clock: %+v", c)
+	}
+	if r.Err() != nil {
+		t.Fatalf("warnings must not fail: %v", r.Err())
+	}
+}
+
+func TestRun_WorldReadableConfigFails(t *testing.T) {
+	srv := server(t, now, nil)
+	s := healthyService(t, srv, jwtExpiringAt(now.Add(time.Hour)))
+	s.FileMode = func(string) (fs.FileMode, error) { return 0o644, nil }
+
+	r := s.Run(context.Background(), defaults())
+	c := check(t, r, CheckPermissions)
+	if c.Status != Fail || c.Hint != "chmod 600 /cfg/ebo/config.yaml" || !strings.Contains(c.Message, "0644") {
+		t.Fatalf("permissions: %+v", c)
+	}
+	if exitcode.Code(r.Err()) != exitcode.Usage {
+		t.Fatalf("code: %d", exitcode.Code(r.Err()))
+	}
+}
+
+func TestRun_UnparseableConfigSkipsDependentChecks(t *testing.T) {
+	s := Service{
+		Store:   memStore{err: errors.New("yaml: line 3: did not find expected key")},
+		Now:     func() time.Time { return now },
+		SpecRef: "v1",
+	}
+	r := s.Run(context.Background(), defaults())
+	want := "config=fail permissions=pass profile=warn api=warn oidc=warn token=warn clock=warn member=warn spec=pass"
+	if got := statuses(r); got != want {
+		t.Fatalf("statuses:\n got %s\nwant %s\n%+v", got, want, r.Checks)
+	}
+}
+
+func TestRun_MissingAPIURLFails(t *testing.T) {
+	s := Service{Store: memStore{doc: config.NewEmptyDocument()}, Now: func() time.Time { return now }}
+	res := defaults()
+	res.Options.APIURL = ""
+	r := s.Run(context.Background(), res)
+	if c := check(t, r, CheckProfile); c.Status != Fail || c.Hint != "ebo profile set default --api-url <url>" {
+		t.Fatalf("profile: %+v", c)
+	}
+}
+
+func TestRun_UnreachableAPIFailsWithNetwork(t *testing.T) {
+	srv := server(t, now, nil)
+	s := healthyService(t, srv, jwtExpiringAt(now.Add(time.Hour)))
+	dead := httptest.NewServer(http.NotFoundHandler())
+	dead.Close()
+	s.Store = memStore{doc: healthyDoc(t, dead.URL, jwtExpiringAt(now.Add(time.Hour)))}
+
+	r := s.Run(context.Background(), defaults())
+	if c := check(t, r, CheckAPI); c.Status != Fail || !strings.Contains(c.Message, "connection_refused") {
+		t.Fatalf("api: %+v", c)
+	}
+	if exitcode.Code(r.Err()) != exitcode.Server {
+		t.Fatalf("code: %d", exitcode.Code(r.Err()))
+	}
+}
+
+func TestRun_IssuerWithoutDeviceGrantFails(t *testing.T) {
+	srv := server(t, now, []string{"authorization_code"})
+	s := healthyService(t, srv, jwtExpiringAt(now.Add(time.Hour)))
+
+	r := s.Run(context.Background(), defaults())
+	if c := check(t, r, CheckOIDC); c.Status != Fail || !strings.Contains(c.Message, "device authorization grant") {
+		t.Fatalf("oidc: %+v", c)
+	}
+}
+
+func TestRun_UnprovisionedMemberFails(t *testing.T) {
+	srv := server(t, now, nil)
+	s := healthyService(t, srv, jwtExpiringAt(now.Add(time.Hour)))
+	s.API = fakeAPI{err: exitcode.New(exitcode.KindNotFound, "MEMBER_NOT_PROVISIONED", nil)}
+
+	r := s.Run(context.Background(), defaults())
+	c := check(t, r, CheckMember)
+	if c.Status != Fail || !strings.HasPrefix(c.Hint, "ebo member create") {
+		t.Fatalf("member: %+v", c)
+	}
+	if exitcode.Code(r.Err()) != exitcode.NotFound {
+		t.Fatalf("code: %d", exitcode.Code(r.Err()))
+	}
+}
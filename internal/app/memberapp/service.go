@@ -0,0 +1,400 @@
+package memberapp
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/editmode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/idempotency"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/requestfile"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+// Service holds the member use cases. Like tripapp.Service it owns validation
+// and the idempotency-key policy (update and delete always carry a key,
+// generated when the caller has none) and returns the key it used.
+type Service struct {
+	API plannerapi.Client
+	// NewKey generates idempotency keys; nil means idempotency.NewKey.
+	NewKey func() string
+	// Edit lets the user edit a YAML template; nil means editmode.EditRequest
+	// (the template is bound to the request's JSON Schema).
+	Edit func(template string) ([]byte, error)
+}
+
+// MinSearchLen is the shortest query Search accepts.
+const MinSearchLen = 3
+
+func (s Service) key(k string) string {
+	if strings.TrimSpace(k) != "" {
+		return k
+	}
+	if s.NewKey != nil {
+		return s.NewKey()
+	}
+	return idempotency.NewKey()
+}
+
+func (s Service) List(ctx context.Context, baseURL, bearerToken string, includeInactive bool) (*plannerapi.MemberList, error) {
+	return s.API.ListMembers(ctx, baseURL, bearerToken, includeInactive)
+}
+
+func (s Service) Search(ctx context.Context, baseURL, bearerToken, query string) (*plannerapi.MemberList, error) {
+	q := strings.TrimSpace(query)
+	if len(q) < MinSearchLen {
+		return nil, exitcode.New(exitcode.KindUsage, fmt.Sprintf("query must be at least %d characters", MinSearchLen), nil)
+	}
+	return s.API.SearchMembers(ctx, baseURL, bearerToken, q)
+}
+
+func (s Service) Me(ctx context.Context, baseURL, bearerToken string) (*plannerapi.MemberResult, error) {
+	return s.API.GetMyMemberProfile(ctx, baseURL, bearerToken)
+}
+
+// Create provisions the caller's member profile (see BuildCreate).
+func (s Service) Create(ctx context.Context, baseURL, bearerToken string, req plannerapi.CreateMemberRequest) (*plannerapi.MemberResult, error) {
+	return s.API.CreateMyMember(ctx, baseURL, bearerToken, req)
+}
+
+func (s Service) Update(ctx context.Context, baseURL, bearerToken, idempotencyKey string, req plannerapi.UpdateMemberRequest) (*plannerapi.MemberResult, string, error) {
+	key := s.key(idempotencyKey)
+	resp, err := s.API.UpdateMyMemberProfile(ctx, baseURL, bearerToken, key, req)
+	return resp, key, err
+}
+
+// Delete deletes the caller's member account. It is destructive, so confirm
+// must be set; reason is optional and single-line.
+func (s Service) Delete(ctx context.Context, baseURL, bearerToken, idempotencyKey, reason string, confirm bool) (*plannerapi.MemberDeleted, string, error) {
+	if !confirm {
+		return nil, "", exitcode.New(exitcode.KindUsage, "refusing to delete without --force", nil)
+	}
+	if strings.ContainsAny(reason, "\r\n") {
+		return nil, "", exitcode.New(exitcode.KindUsage, "invalid --reason", fmt.Errorf("--reason must be single line (no newlines)"))
+	}
+	req := plannerapi.DeleteMemberRequest{Confirm: true}
+	if r := strings.TrimSpace(reason); r != "" {
+		req.Reason = &r
+	}
+	key := s.key(idempotencyKey)
+	resp, err := s.API.DeleteMyMemberAccount(ctx, baseURL, bearerToken, key, req)
+	return resp, key, err
+}
+
+// Profile is a new member profile as entered (flags, a form). Values are
+// trimmed; blank optional fields are omitted.
+type Profile struct {
+	DisplayName     string
+	Email           string
+	GroupAliasEmail string
+	Vehicle         Vehicle
+}
+
+type Vehicle struct {
+	Make             string
+	Model            string
+	TireSize         string
+	LiftLockers      string
+	FuelRange        string
+	RecoveryGear     string
+	HamRadioCallSign string
+	Notes            string
+}
+
+// BuildCreate validates p and converts it to a create request.
+func BuildCreate(p Profile) (plannerapi.CreateMemberRequest, error) {
+	var req plannerapi.CreateMemberRequest
+	v := p.Vehicle
+	for _, f := range []struct{ label, val string }{
+		{"--display-name", p.DisplayName},
+		{"--email", p.Email},
+		{"--group-alias-email", p.GroupAliasEmail},
+		{"--vehicle-make", v.Make},
+		{"--vehicle-model", v.Model},
+		{"--vehicle-tire-size", v.TireSize},
+		{"--vehicle-lift-lockers", v.LiftLockers},
+		{"--vehicle-fuel-range", v.FuelRange},
+		{"--vehicle-recovery-gear", v.RecoveryGear},
+		{"--vehicle-ham-radio-call-sign", v.HamRadioCallSign},
+		{"--vehicle-notes", v.Notes},
+	} {
+		if err := rejectMultiline(f.label, f.val); err != nil {
+			return req, err
+		}
+	}
+
+	if strings.TrimSpace(p.DisplayName) == "" {
+		return req, exitcode.New(exitcode.KindUsage, "missing --display-name", nil)
+	}
+	if strings.TrimSpace(p.Email) == "" {
+		return req, exitcode.New(exitcode.KindUsage, "missing --email", nil)
+	}
+	if _, err := mail.ParseAddress(p.Email); err != nil {
+		return req, exitcode.New(exitcode.KindUsage, "invalid --email", err)
+	}
+	if strings.TrimSpace(p.GroupAliasEmail) != "" {
+		if _, err := mail.ParseAddress(p.GroupAliasEmail); err != nil {
+			return req, exitcode.New(exitcode.KindUsage, "invalid --group-alias-email", err)
+		}
+	}
+
+	req.DisplayName = strings.TrimSpace(p.DisplayName)
+	req.Email = strings.TrimSpace(p.Email)
+	req.GroupAliasEmail = optional(p.GroupAliasEmail)
+
+	// The vehicle profile is optional; include it if any field is set.
+	vp := plannerapi.VehicleProfile{
+		Make:             optional(v.Make),
+		Model:            optional(v.Model),
+		TireSize:         optional(v.TireSize),
+		LiftLockers:      optional(v.LiftLockers),
+		FuelRange:        optional(v.FuelRange),
+		RecoveryGear:     optional(v.RecoveryGear),
+		HamRadioCallSign: optional(v.HamRadioCallSign),
+		Notes:            optional(v.Notes),
+	}
+	if vp != (plannerapi.VehicleProfile{}) {
+		req.VehicleProfile = &vp
+	}
+	return req, nil
+}
+
+// Patch is a profile update given field by field (flags, a form). Nil fields
+// are left unchanged; Clear* fields send an empty value.
+type Patch struct {
+	DisplayName          *string
+	ClearDisplayName     bool
+	Email                *string
+	GroupAliasEmail      *string
+	ClearGroupAliasEmail bool
+	Vehicle              VehiclePatch
+}
+
+type VehiclePatch struct {
+	Make             *string
+	Model            *string
+	TireSize         *string
+	LiftLockers      *string
+	FuelRange        *string
+	RecoveryGear     *string
+	HamRadioCallSign *string
+	Notes            *string
+
+	ClearMake             bool
+	ClearModel            bool
+	ClearTireSize         bool
+	ClearLiftLockers      bool
+	ClearFuelRange        bool
+	ClearRecoveryGear     bool
+	ClearHamRadioCallSign bool
+	ClearNotes            bool
+
+	// Clear clears the whole profile and cannot be combined with other fields.
+	Clear bool
+}
+
+// IsEmpty reports whether p changes nothing.
+func (p Patch) IsEmpty() bool {
+	return p.DisplayName == nil && !p.ClearDisplayName && p.Email == nil &&
+		p.GroupAliasEmail == nil && !p.ClearGroupAliasEmail && p.Vehicle.IsEmpty()
+}
+
+// IsEmpty reports whether p changes nothing.
+func (p VehiclePatch) IsEmpty() bool {
+	return p == VehiclePatch{}
+}
+
+func (p VehiclePatch) fields() []vehicleField {
+	return []vehicleField{
+		{"--vehicle-make", p.Make, p.ClearMake, func(vp *plannerapi.VehicleProfile) **string { return &vp.Make }},
+		{"--vehicle-model", p.Model, p.ClearModel, func(vp *plannerapi.VehicleProfile) **string { return &vp.Model }},
+		{"--vehicle-tire-size", p.TireSize, p.ClearTireSize, func(vp *plannerapi.VehicleProfile) **string { return &vp.TireSize }},
+		{"--vehicle-lift-lockers", p.LiftLockers, p.ClearLiftLockers, func(vp *plannerapi.VehicleProfile) **string { return &vp.LiftLockers }},
+		{"--vehicle-fuel-range", p.FuelRange, p.ClearFuelRange, func(vp *plannerapi.VehicleProfile) **string { return &vp.FuelRange }},
+		{"--vehicle-recovery-gear", p.RecoveryGear, p.ClearRecoveryGear, func(vp *plannerapi.VehicleProfile) **string { return &vp.RecoveryGear }},
+		{"--vehicle-ham-radio-call-sign", p.HamRadioCallSign, p.ClearHamRadioCallSign, func(vp *plannerapi.VehicleProfile) **string { return &vp.HamRadioCallSign }},
+		{"--vehicle-notes", p.Notes, p.ClearNotes, func(vp *plannerapi.VehicleProfile) **string { return &vp.Notes }},
+	}
+}
+
+type vehicleField struct {
+	label string
+	val   *string
+	clear bool
+	dst   func(*plannerapi.VehicleProfile) **string
+}
+
+// BuildPatch validates p and converts it to an update request.
+func BuildPatch(p Patch) (plannerapi.UpdateMemberRequest, error) {
+	var req plannerapi.UpdateMemberRequest
+
+	if p.DisplayName != nil {
+		if err := rejectMultiline("--display-name", *p.DisplayName); err != nil {
+			return req, err
+		}
+		v := strings.TrimSpace(*p.DisplayName)
+		if v == "" {
+			return req, exitcode.New(exitcode.KindUsage, "--display-name must be non-empty (use --clear-display-name to clear)", nil)
+		}
+		req.DisplayName = &v
+	}
+	if p.ClearDisplayName {
+		if p.DisplayName != nil {
+			return req, exitcode.New(exitcode.KindUsage, "cannot use --display-name with --clear-display-name", nil)
+		}
+		empty := ""
+		req.DisplayName = &empty
+	}
+
+	if p.Email != nil {
+		if err := rejectMultiline("--email", *p.Email); err != nil {
+			return req, err
+		}
+		v := strings.TrimSpace(*p.Email)
+		if v == "" {
+			return req, exitcode.New(exitcode.KindUsage, "--email must be non-empty", nil)
+		}
+		if _, err := mail.ParseAddress(v); err != nil {
+			return req, exitcode.New(exitcode.KindUsage, "invalid --email", err)
+		}
+		req.Email = &v
+	}
+
+	if p.GroupAliasEmail != nil {
+		if err := rejectMultiline("--group-alias-email", *p.GroupAliasEmail); err != nil {
+			return req, err
+		}
+		v := strings.TrimSpace(*p.GroupAliasEmail)
+		if v == "" {
+			return req, exitcode.New(exitcode.KindUsage, "--group-alias-email must be non-empty (use --clear-group-alias-email to clear)", nil)
+		}
+		if _, err := mail.ParseAddress(v); err != nil {
+			return req, exitcode.New(exitcode.KindUsage, "invalid --group-alias-email", err)
+		}
+		req.GroupAliasEmail = &v
+	}
+	if p.ClearGroupAliasEmail {
+		if p.GroupAliasEmail != nil {
+			return req, exitcode.New(exitcode.KindUsage, "cannot use --group-alias-email with --clear-group-alias-email", nil)
+		}
+		empty := ""
+		req.GroupAliasEmail = &empty
+	}
+
+	if !p.Vehicle.IsEmpty() {
+		vp, err := buildVehiclePatch(p.Vehicle)
+		if err != nil {
+			return req, err
+		}
+		req.VehicleProfile = vp
+	}
+
+	if req.DisplayName == nil && req.Email == nil && req.GroupAliasEmail == nil && req.VehicleProfile == nil {
+		return req, exitcode.New(exitcode.KindUsage, "no changes specified", nil)
+	}
+	return req, nil
+}
+
+func buildVehiclePatch(p VehiclePatch) (*plannerapi.VehicleProfile, error) {
+	var vp plannerapi.VehicleProfile
+	fields := p.fields()
+	if p.Clear {
+		rest := p
+		rest.Clear = false
+		if !rest.IsEmpty() {
+			return nil, exitcode.New(exitcode.KindUsage, "--clear-vehicle cannot be combined with other vehicle flags", nil)
+		}
+		for _, f := range fields {
+			empty := ""
+			*f.dst(&vp) = &empty
+		}
+		return &vp, nil
+	}
+
+	for _, f := range fields {
+		if f.val == nil {
+			continue
+		}
+		if err := rejectMultiline(f.label, *f.val); err != nil {
+			return nil, err
+		}
+		v := strings.TrimSpace(*f.val)
+		if v == "" {
+			return nil, exitcode.New(exitcode.KindUsage, fmt.Sprintf("%s must be non-empty (use the corresponding --clear-* flag to clear)", f.label), nil)
+		}
+		*f.dst(&vp) = &v
+	}
+	for _, f := range fields {
+		if f.clear {
+			empty := ""
+			*f.dst(&vp) = &empty
+		}
+	}
+	return &vp, nil
+}
+
+// LoadPatch reads an update request from a JSON or YAML file.
+func LoadPatch(path string) (plannerapi.UpdateMemberRequest, error) {
+	var req plannerapi.UpdateMemberRequest
+	if err := requestfile.LoadStrict(path, &req); err != nil {
+		return req, exitcode.New(exitcode.KindUsage, "parse request file", err)
+	}
+	return req, nil
+}
+
+// EditPatch opens the update template in the user's editor and parses the
+// result.
+func (s Service) EditPatch() (plannerapi.UpdateMemberRequest, error) {
+	edit := s.Edit
+	if edit == nil {
+		edit = func(template string) ([]byte, error) {
+			return editmode.EditRequest("UpdateMyMemberProfileRequest", template)
+		}
+	}
+	var req plannerapi.UpdateMemberRequest
+	edited, err := edit(updateTemplateYAML)
+	if err != nil {
+		return req, exitcode.New(exitcode.KindServer, "open editor", err)
+	}
+	if err := requestfile.DecodeStrict("edited buffer", edited, &req); err != nil {
+		return req, exitcode.New(exitcode.KindUsage, "parse edited buffer", err)
+	}
+	return req, nil
+}
+
+func rejectMultiline(label, v string) error {
+	if strings.ContainsAny(v, "\r\n") {
+		return exitcode.New(exitcode.KindUsage, fmt.Sprintf("%s must not be multi-line; use --from-file/--edit/--prompt instead", label), nil)
+	}
+	return nil
+}
+
+func optional(v string) *string {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+const updateTemplateYAML = `# UpdateMyMemberProfileRequest (patch)
+#
+# - Omitted fields are unchanged.
+# - Set fields to update.
+#
+# displayName:
+# email:
+# groupAliasEmail:
+# vehicleProfile:
+#   make:
+#   model:
+#   tireSize:
+#   liftLockers:
+#   fuelRange:
+#   recoveryGear:
+#   hamRadioCallSign:
+#   notes:
+{}
+`
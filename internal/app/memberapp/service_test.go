@@ -0,0 +1,140 @@
+package memberapp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+// fakeAPI records calls; methods a test does not use panic via the nil
+// embedded Client.
+type fakeAPI struct {
+	plannerapi.Client
+
+	calls     int
+	lastKey   string
+	lastQuery string
+	lastReq   any
+}
+
+func (f *fakeAPI) SearchMembers(ctx context.Context, baseURL, bearerToken, query string) (*plannerapi.MemberList, error) {
+	f.calls++
+	f.lastQuery = query
+	return &plannerapi.MemberList{}, nil
+}
+
+func (f *fakeAPI) DeleteMyMemberAccount(ctx context.Context, baseURL, bearerToken, idempotencyKey string, req plannerapi.DeleteMemberRequest) (*plannerapi.MemberDeleted, error) {
+	f.calls++
+	f.lastKey, f.lastReq = idempotencyKey, req
+	return &plannerapi.MemberDeleted{Deleted: true}, nil
+}
+
+func (f *fakeAPI) UpdateMyMemberProfile(ctx context.Context, baseURL, bearerToken, idempotencyKey string, req plannerapi.UpdateMemberRequest) (*plannerapi.MemberResult, error) {
+	f.calls++
+	f.lastKey, f.lastReq = idempotencyKey, req
+	return &plannerapi.MemberResult{}, nil
+}
+
+func TestSearch_TrimsAndRequiresMinLength(t *testing.T) {
+	api := &fakeAPI{}
+	s := Service{API: api}
+
+	if _, err := s.Search(context.Background(), "u", "tok", "  ab "); exitcode.Code(err) != exitcode.Usage || api.calls != 0 {
+		t.Fatalf("err=%v calls=%d", err, api.calls)
+	}
+	if _, err := s.Search(context.Background(), "u", "tok", " abc "); err != nil || api.lastQuery != "abc" {
+		t.Fatalf("query=%q err=%v", api.lastQuery, err)
+	}
+}
+
+func TestDelete_ConfirmReasonAndKey(t *testing.T) {
+	api := &fakeAPI{}
+	s := Service{API: api, NewKey: func() string { return "gen-key" }}
+
+	if _, _, err := s.Delete(context.Background(), "u", "tok", "", "", false); exitcode.Code(err) != exitcode.Usage {
+		t.Fatalf("confirm: %v", err)
+	}
+	if _, _, err := s.Delete(context.Background(), "u", "tok", "", "a\nb", true); exitcode.Code(err) != exitcode.Usage {
+		t.Fatalf("reason: %v", err)
+	}
+	if api.calls != 0 {
+		t.Fatalf("calls: %d", api.calls)
+	}
+
+	_, key, err := s.Delete(context.Background(), "u", "tok", "", "  moving away ", true)
+	if err != nil || key != "gen-key" || api.lastKey != "gen-key" {
+		t.Fatalf("key=%q sent=%q err=%v", key, api.lastKey, err)
+	}
+	req := api.lastReq.(plannerapi.DeleteMemberRequest)
+	if !req.Confirm || req.Reason == nil || *req.Reason != "moving away" {
+		t.Fatalf("req: %+v", req)
+	}
+}
+
+func TestUpdate_KeepsGivenKey(t *testing.T) {
+	api := &fakeAPI{}
+	s := Service{API: api, NewKey: func() string { return "gen-key" }}
+
+	if _, key, err := s.Update(context.Background(), "u", "tok", "mine", plannerapi.UpdateMemberRequest{}); err != nil || key != "mine" || api.lastKey != "mine" {
+		t.Fatalf("key=%q sent=%q err=%v", key, api.lastKey, err)
+	}
+}
+
+func TestBuildCreate(t *testing.T) {
+	req, err := BuildCreate(Profile{DisplayName: " Alice ", Email: "a@example.com", Vehicle: Vehicle{Make: " Toyota "}})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if req.DisplayName != "Alice" || req.GroupAliasEmail != nil || req.VehicleProfile == nil || *req.VehicleProfile.Make != "Toyota" || req.VehicleProfile.Model != nil {
+		t.Fatalf("req: %+v", req)
+	}
+
+	req, _ = BuildCreate(Profile{DisplayName: "Alice", Email: "a@example.com"})
+	if req.VehicleProfile != nil {
+		t.Fatalf("vehicle profile should be omitted: %+v", req.VehicleProfile)
+	}
+
+	for name, p := range map[string]Profile{
+		"missing name":    {Email: "a@example.com"},
+		"bad email":       {DisplayName: "A", Email: "nope"},
+		"bad alias":       {DisplayName: "A", Email: "a@example.com", GroupAliasEmail: "nope"},
+		"multiline notes": {DisplayName: "A", Email: "a@example.com", Vehicle: Vehicle{Notes: "a\nb"}},
+	} {
+		if _, err := BuildCreate(p); exitcode.Code(err) != exitcode.Usage {
+			t.Fatalf("%s: got %v", name, err)
+		}
+	}
+}
+
+func TestBuildPatch(t *testing.T) {
+	str := func(v string) *string { return &v }
+
+	req, err := BuildPatch(Patch{ClearGroupAliasEmail: true, Vehicle: VehiclePatch{Make: str(" Jeep "), ClearNotes: true}})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	vp := req.VehicleProfile
+	if *req.GroupAliasEmail != "" || *vp.Make != "Jeep" || *vp.Notes != "" || vp.Model != nil {
+		t.Fatalf("req: %+v vp: %+v", req, vp)
+	}
+
+	req, err = BuildPatch(Patch{Vehicle: VehiclePatch{Clear: true}})
+	if err != nil || *req.VehicleProfile.HamRadioCallSign != "" || *req.VehicleProfile.Make != "" {
+		t.Fatalf("clear vehicle: %+v %v", req.VehicleProfile, err)
+	}
+
+	for name, p := range map[string]Patch{
+		"empty":               {},
+		"blank name":          {DisplayName: str(" ")},
+		"name and clear":      {DisplayName: str("A"), ClearDisplayName: true},
+		"bad email":           {Email: str("nope")},
+		"clear vehicle mixed": {Vehicle: VehiclePatch{Clear: true, ClearMake: true}},
+		"blank vehicle field": {Vehicle: VehiclePatch{Model: str("")}},
+	} {
+		if _, err := BuildPatch(p); exitcode.Code(err) != exitcode.Usage {
+			t.Fatalf("%s: got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,363 @@
+package tripapp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/editmode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/idempotency"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/requestfile"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+// Service holds the trip use cases. Front ends own input collection and
+// rendering; the service owns validation and the idempotency-key policy:
+// retryable mutations always carry a key (generated when the caller has
+// none), except cancel, which only sends one when given.
+//
+// Mutations return the key they used so callers can report it, including on
+// failure.
+type Service struct {
+	API plannerapi.Client
+	// NewKey generates idempotency keys; nil means idempotency.NewKey.
+	NewKey func() string
+	// Edit lets the user edit a YAML template; nil means editmode.EditRequest
+	// (the template is bound to the request's JSON Schema).
+	Edit func(template string) ([]byte, error)
+}
+
+func (s Service) key(k string) string {
+	if strings.TrimSpace(k) != "" {
+		return k
+	}
+	if s.NewKey != nil {
+		return s.NewKey()
+	}
+	return idempotency.NewKey()
+}
+
+func (s Service) List(ctx context.Context, baseURL, bearerToken string) (*plannerapi.TripList, error) {
+	return s.API.ListVisibleTripsForMember(ctx, baseURL, bearerToken)
+}
+
+func (s Service) Drafts(ctx context.Context, baseURL, bearerToken string) (*plannerapi.TripList, error) {
+	return s.API.ListMyDraftTrips(ctx, baseURL, bearerToken)
+}
+
+func (s Service) Get(ctx context.Context, baseURL, bearerToken, tripID string) (*plannerapi.TripResult, error) {
+	return s.API.GetTripDetails(ctx, baseURL, bearerToken, tripID)
+}
+
+// Details fetches the full trips, in order, for exports. It stops at the
+// first error.
+func (s Service) Details(ctx context.Context, baseURL, bearerToken string, tripIDs []string) ([]plannerapi.Trip, error) {
+	trips := make([]plannerapi.Trip, 0, len(tripIDs))
+	for _, id := range tripIDs {
+		resp, err := s.API.GetTripDetails(ctx, baseURL, bearerToken, id)
+		if err != nil {
+			return nil, err
+		}
+		if resp != nil {
+			trips = append(trips, resp.Trip)
+		}
+	}
+	return trips, nil
+}
+
+func (s Service) Create(ctx context.Context, baseURL, bearerToken, idempotencyKey string, req plannerapi.CreateTripDraftRequest) (*plannerapi.TripCreatedResult, string, error) {
+	key := s.key(idempotencyKey)
+	resp, err := s.API.CreateTripDraft(ctx, baseURL, bearerToken, key, req)
+	return resp, key, err
+}
+
+func (s Service) Update(ctx context.Context, baseURL, bearerToken, tripID, idempotencyKey string, req plannerapi.UpdateTripRequest) (*plannerapi.TripResult, string, error) {
+	key := s.key(idempotencyKey)
+	resp, err := s.API.UpdateTrip(ctx, baseURL, bearerToken, tripID, key, req)
+	return resp, key, err
+}
+
+func (s Service) SetVisibility(ctx context.Context, baseURL, bearerToken, tripID, idempotencyKey string, vis plannerapi.DraftVisibility) (*plannerapi.TripResult, string, error) {
+	if vis != plannerapi.DraftVisibilityPublic && vis != plannerapi.DraftVisibilityPrivate {
+		return nil, "", exitcode.New(exitcode.KindUsage, fmt.Sprintf("invalid draft visibility %q", vis), nil)
+	}
+	key := s.key(idempotencyKey)
+	resp, err := s.API.SetTripDraftVisibility(ctx, baseURL, bearerToken, tripID, key, plannerapi.SetDraftVisibilityRequest{DraftVisibility: vis})
+	return resp, key, err
+}
+
+func (s Service) Publish(ctx context.Context, baseURL, bearerToken, tripID string) (*plannerapi.PublishResult, error) {
+	return s.API.PublishTrip(ctx, baseURL, bearerToken, tripID)
+}
+
+// Cancel cancels a trip. It is destructive, so confirm must be set; the
+// idempotency key is optional and never generated.
+func (s Service) Cancel(ctx context.Context, baseURL, bearerToken, tripID, idempotencyKey string, confirm bool) (*plannerapi.TripResult, string, error) {
+	if !confirm {
+		return nil, "", exitcode.New(exitcode.KindUsage, "refusing to cancel without --force", nil)
+	}
+	var keyPtr *string
+	key := ""
+	if strings.TrimSpace(idempotencyKey) != "" {
+		key = idempotencyKey
+		keyPtr = &key
+	}
+	resp, err := s.API.CancelTrip(ctx, baseURL, bearerToken, tripID, keyPtr)
+	return resp, key, err
+}
+
+func (s Service) AddOrganizer(ctx context.Context, baseURL, bearerToken, tripID, memberID, idempotencyKey string) (*plannerapi.TripResult, string, error) {
+	if strings.TrimSpace(memberID) == "" {
+		return nil, "", exitcode.New(exitcode.KindUsage, "missing --member", nil)
+	}
+	key := s.key(idempotencyKey)
+	resp, err := s.API.AddTripOrganizer(ctx, baseURL, bearerToken, tripID, key, plannerapi.AddOrganizerRequest{MemberID: memberID})
+	return resp, key, err
+}
+
+// RemoveOrganizer removes an organizer. It is destructive, so confirm must be
+// set.
+func (s Service) RemoveOrganizer(ctx context.Context, baseURL, bearerToken, tripID, memberID, idempotencyKey string, confirm bool) (*plannerapi.TripResult, string, error) {
+	if strings.TrimSpace(memberID) == "" {
+		return nil, "", exitcode.New(exitcode.KindUsage, "missing --member", nil)
+	}
+	if !confirm {
+		return nil, "", exitcode.New(exitcode.KindUsage, "refusing to remove organizer without --force", nil)
+	}
+	key := s.key(idempotencyKey)
+	resp, err := s.API.RemoveTripOrganizer(ctx, baseURL, bearerToken, tripID, memberID, key)
+	return resp, key, err
+}
+
+func (s Service) SetRSVP(ctx context.Context, baseURL, bearerToken, tripID, idempotencyKey string, response plannerapi.RSVPResponse) (*plannerapi.RSVPResult, string, error) {
+	switch response {
+	case plannerapi.RSVPYes, plannerapi.RSVPNo, plannerapi.RSVPUnset:
+	default:
+		return nil, "", exitcode.New(exitcode.KindUsage, fmt.Sprintf("invalid RSVP response %q", response), nil)
+	}
+	key := s.key(idempotencyKey)
+	resp, err := s.API.SetMyRSVP(ctx, baseURL, bearerToken, tripID, key, plannerapi.SetMyRSVPRequest{Response: response})
+	return resp, key, err
+}
+
+func (s Service) GetRSVP(ctx context.Context, baseURL, bearerToken, tripID string) (*plannerapi.RSVPResult, error) {
+	return s.API.GetMyRSVPForTrip(ctx, baseURL, bearerToken, tripID)
+}
+
+func (s Service) RSVPSummary(ctx context.Context, baseURL, bearerToken, tripID string) (*plannerapi.RSVPSummaryResult, error) {
+	return s.API.GetTripRSVPSummary(ctx, baseURL, bearerToken, tripID)
+}
+
+// DraftFromName builds a create request from a single-line name.
+func DraftFromName(name string) (plannerapi.CreateTripDraftRequest, error) {
+	if err := singleLine(name, "--name"); err != nil {
+		return plannerapi.CreateTripDraftRequest{}, exitcode.New(exitcode.KindUsage, "invalid flag", err)
+	}
+	return plannerapi.CreateTripDraftRequest{Name: name}, nil
+}
+
+// LoadDraft reads a create request from a JSON or YAML file.
+func LoadDraft(path string) (plannerapi.CreateTripDraftRequest, error) {
+	var req plannerapi.CreateTripDraftRequest
+	if err := requestfile.LoadStrict(path, &req); err != nil {
+		return req, exitcode.New(exitcode.KindUsage, "parse request file", err)
+	}
+	return req, nil
+}
+
+// LoadPatch reads an update request from a JSON or YAML file.
+func LoadPatch(path string) (plannerapi.UpdateTripRequest, error) {
+	var req plannerapi.UpdateTripRequest
+	if err := requestfile.LoadStrict(path, &req); err != nil {
+		return req, exitcode.New(exitcode.KindUsage, "parse request file", err)
+	}
+	return req, nil
+}
+
+// EditPatch opens the update template in the user's editor and parses the
+// result.
+func (s Service) EditPatch() (plannerapi.UpdateTripRequest, error) {
+	edit := s.Edit
+	if edit == nil {
+		edit = func(template string) ([]byte, error) { return editmode.EditRequest("UpdateTripRequest", template) }
+	}
+	var req plannerapi.UpdateTripRequest
+	edited, err := edit(updateTemplateYAML)
+	if err != nil {
+		return req, exitcode.New(exitcode.KindServer, "open editor", err)
+	}
+	if err := requestfile.DecodeStrict("edited buffer", edited, &req); err != nil {
+		return req, exitcode.New(exitcode.KindUsage, "parse edited buffer", err)
+	}
+	return req, nil
+}
+
+// Patch is a trip update given field by field (flags, a form). Nil fields
+// are left unchanged. Text fields must be single-line; multi-line text goes
+// through LoadPatch or EditPatch.
+type Patch struct {
+	Name                        *string
+	Description                 *string
+	DifficultyText              *string
+	CommsRequirementsText       *string
+	RecommendedRequirementsText *string
+	CapacityRigs                *int
+
+	MeetingLabel         *string
+	MeetingAddress       *string
+	MeetingLat           *float64
+	MeetingLng           *float64
+	ClearMeetingLocation bool
+
+	// ArtifactIDs replaces the artifact list; duplicates are dropped (first
+	// occurrence wins).
+	ArtifactIDs    []string
+	ClearArtifacts bool
+}
+
+// IsEmpty reports whether p changes nothing.
+func (p Patch) IsEmpty() bool {
+	return p.Name == nil && p.Description == nil && p.DifficultyText == nil &&
+		p.CommsRequirementsText == nil && p.RecommendedRequirementsText == nil && p.CapacityRigs == nil &&
+		!p.hasMeeting() && !p.ClearMeetingLocation && p.ArtifactIDs == nil && !p.ClearArtifacts
+}
+
+func (p Patch) hasMeeting() bool {
+	return p.MeetingLabel != nil || p.MeetingAddress != nil || p.MeetingLat != nil || p.MeetingLng != nil
+}
+
+// BuildPatch validates p and converts it to an update request.
+func BuildPatch(p Patch) (plannerapi.UpdateTripRequest, error) {
+	var req plannerapi.UpdateTripRequest
+	for _, f := range []struct {
+		val  *string
+		name string
+	}{
+		{p.Name, "--name"},
+		{p.Description, "--description"},
+		{p.DifficultyText, "--difficulty-text"},
+		{p.CommsRequirementsText, "--comms-requirements-text"},
+		{p.RecommendedRequirementsText, "--recommended-requirements-text"},
+		{p.MeetingLabel, "--meeting-label"},
+		{p.MeetingAddress, "--meeting-address"},
+	} {
+		if f.val == nil {
+			continue
+		}
+		if err := singleLine(*f.val, f.name); err != nil {
+			return req, exitcode.New(exitcode.KindUsage, "invalid flag", err)
+		}
+	}
+
+	if p.ClearArtifacts && p.ArtifactIDs != nil {
+		return req, exitcode.New(exitcode.KindUsage, "choose exactly one of --artifact-id or --clear-artifacts", nil)
+	}
+	if p.ClearMeetingLocation && p.hasMeeting() {
+		return req, exitcode.New(exitcode.KindUsage, "--clear-meeting-location is mutually exclusive with meeting location flags", nil)
+	}
+	if (p.MeetingLat == nil) != (p.MeetingLng == nil) {
+		return req, exitcode.New(exitcode.KindUsage, "meeting lat/lng must be provided as a pair", nil)
+	}
+
+	req.Name = p.Name
+	req.Description = p.Description
+	req.DifficultyText = p.DifficultyText
+	req.CommsRequirementsText = p.CommsRequirementsText
+	req.RecommendedRequirementsText = p.RecommendedRequirementsText
+	req.CapacityRigs = p.CapacityRigs
+
+	if p.ClearArtifacts {
+		empty := []string{}
+		req.ArtifactIDs = &empty
+	}
+	if p.ArtifactIDs != nil {
+		ids := dedupPreserveFirst(p.ArtifactIDs)
+		req.ArtifactIDs = &ids
+	}
+
+	if p.ClearMeetingLocation {
+		req.MeetingLocation = &plannerapi.LocationPatch{}
+	} else if p.hasMeeting() {
+		loc := &plannerapi.LocationPatch{Label: p.MeetingLabel, Address: p.MeetingAddress}
+		if p.MeetingLat != nil {
+			loc.LatitudeLongitude = &plannerapi.LatLngPatch{Latitude: p.MeetingLat, Longitude: p.MeetingLng}
+		}
+		req.MeetingLocation = loc
+	}
+	return req, nil
+}
+
+func singleLine(v string, name string) error {
+	if strings.ContainsAny(v, "\r\n") {
+		return fmt.Errorf("%s must be single line (no newlines)", name)
+	}
+	return nil
+}
+
+func dedupPreserveFirst(in []string) []string {
+	seen := make(map[string]struct{}, len(in))
+	out := make([]string, 0, len(in))
+	for _, v := range in {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
+
+// MissingForPublish lists (by JSON name) the fields a draft must set before
+// the API will publish it; publishing fails with TRIP_NOT_PUBLISHABLE
+// otherwise.
+func MissingForPublish(t plannerapi.Trip) []string {
+	var missing []string
+	if t.Name == nil || strings.TrimSpace(*t.Name) == "" {
+		missing = append(missing, "name")
+	}
+	if t.Description == nil {
+		missing = append(missing, "description")
+	}
+	if t.StartDate == nil {
+		missing = append(missing, "startDate")
+	}
+	if t.EndDate == nil {
+		missing = append(missing, "endDate")
+	}
+	if t.CapacityRigs == nil {
+		missing = append(missing, "capacityRigs")
+	}
+	if t.MeetingLocation == nil {
+		missing = append(missing, "meetingLocation")
+	}
+	return missing
+}
+
+const updateTemplateYAML = `# UpdateTripRequest (patch)
+#
+# - Omitted fields are unchanged.
+# - Set fields to update.
+#
+# Example:
+# description: |-
+#   line1
+#   line2
+#
+# name:
+# description:
+# startDate:
+# endDate:
+# capacityRigs:
+# difficultyText:
+# commsRequirementsText:
+# recommendedRequirementsText:
+# artifactIds:
+# meetingLocation:
+#   label:
+#   address:
+#   latitudeLongitude:
+#     latitude:
+#     longitude:
+{}
+`
@@ -0,0 +1,184 @@
+package tripapp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/exitcode"
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/ports/out/plannerapi"
+)
+
+// fakeAPI records mutations; methods a test does not use panic via the nil
+// embedded Client.
+type fakeAPI struct {
+	plannerapi.Client
+
+	calls     int
+	lastKey   string
+	lastKeyP  *string
+	lastReq   any
+	lastTrip  string
+	lastOther string
+}
+
+func (f *fakeAPI) UpdateTrip(ctx context.Context, baseURL, bearerToken, tripID, idempotencyKey string, req plannerapi.UpdateTripRequest) (*plannerapi.TripResult, error) {
+	f.calls++
+	f.lastTrip, f.lastKey, f.lastReq = tripID, idempotencyKey, req
+	return &plannerapi.TripResult{}, nil
+}
+
+func (f *fakeAPI) CancelTrip(ctx context.Context, baseURL, bearerToken, tripID string, idempotencyKey *string) (*plannerapi.TripResult, error) {
+	f.calls++
+	f.lastTrip, f.lastKeyP = tripID, idempotencyKey
+	return nil, errors.New("boom")
+}
+
+func (f *fakeAPI) RemoveTripOrganizer(ctx context.Context, baseURL, bearerToken, tripID, memberID, idempotencyKey string) (*plannerapi.TripResult, error) {
+	f.calls++
+	f.lastTrip, f.lastOther, f.lastKey = tripID, memberID, idempotencyKey
+	return &plannerapi.TripResult{}, nil
+}
+
+func (f *fakeAPI) SetMyRSVP(ctx context.Context, baseURL, bearerToken, tripID, idempotencyKey string, req plannerapi.SetMyRSVPRequest) (*plannerapi.RSVPResult, error) {
+	f.calls++
+	f.lastKey, f.lastReq = idempotencyKey, req
+	return &plannerapi.RSVPResult{}, nil
+}
+
+func fixedKey() string { return "gen-key" }
+
+func TestUpdate_GeneratesKeyWhenBlankAndKeepsGivenKey(t *testing.T) {
+	api := &fakeAPI{}
+	s := Service{API: api, NewKey: fixedKey}
+
+	_, key, err := s.Update(context.Background(), "u", "tok", "t1", "  ", plannerapi.UpdateTripRequest{})
+	if err != nil || key != "gen-key" || api.lastKey != "gen-key" {
+		t.Fatalf("key=%q sent=%q err=%v", key, api.lastKey, err)
+	}
+	_, key, _ = s.Update(context.Background(), "u", "tok", "t1", "mine", plannerapi.UpdateTripRequest{})
+	if key != "mine" || api.lastKey != "mine" {
+		t.Fatalf("key=%q sent=%q", key, api.lastKey)
+	}
+}
+
+func TestCancel_RequiresConfirmAndNeverGeneratesKey(t *testing.T) {
+	api := &fakeAPI{}
+	s := Service{API: api, NewKey: fixedKey}
+
+	if _, _, err := s.Cancel(context.Background(), "u", "tok", "t1", "", false); exitcode.Code(err) != exitcode.Usage || api.calls != 0 {
+		t.Fatalf("err=%v calls=%d", err, api.calls)
+	}
+
+	_, key, err := s.Cancel(context.Background(), "u", "tok", "t1", "", true)
+	if err == nil || key != "" || api.lastKeyP != nil {
+		t.Fatalf("key=%q sent=%v err=%v", key, api.lastKeyP, err)
+	}
+	// The key is reported even when the call fails.
+	_, key, _ = s.Cancel(context.Background(), "u", "tok", "t1", "k1", true)
+	if key != "k1" || api.lastKeyP == nil || *api.lastKeyP != "k1" {
+		t.Fatalf("key=%q sent=%v", key, api.lastKeyP)
+	}
+}
+
+func TestRemoveOrganizer_Validation(t *testing.T) {
+	api := &fakeAPI{}
+	s := Service{API: api, NewKey: fixedKey}
+
+	if _, _, err := s.RemoveOrganizer(context.Background(), "u", "tok", "t1", " ", "", true); exitcode.Code(err) != exitcode.Usage {
+		t.Fatalf("missing member: %v", err)
+	}
+	if _, _, err := s.RemoveOrganizer(context.Background(), "u", "tok", "t1", "m1", "", false); exitcode.Code(err) != exitcode.Usage {
+		t.Fatalf("missing confirm: %v", err)
+	}
+	if api.calls != 0 {
+		t.Fatalf("calls: %d", api.calls)
+	}
+	if _, key, err := s.RemoveOrganizer(context.Background(), "u", "tok", "t1", "m1", "", true); err != nil || key != "gen-key" || api.lastOther != "m1" {
+		t.Fatalf("key=%q member=%q err=%v", key, api.lastOther, err)
+	}
+}
+
+func TestSetRSVP_RejectsUnknownResponse(t *testing.T) {
+	api := &fakeAPI{}
+	s := Service{API: api, NewKey: fixedKey}
+
+	if _, _, err := s.SetRSVP(context.Background(), "u", "tok", "t1", "", "MAYBE"); exitcode.Code(err) != exitcode.Usage || api.calls != 0 {
+		t.Fatalf("err=%v calls=%d", err, api.calls)
+	}
+	if _, _, err := s.SetRSVP(context.Background(), "u", "tok", "t1", "", plannerapi.RSVPNo); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if req := api.lastReq.(plannerapi.SetMyRSVPRequest); req.Response != plannerapi.RSVPNo {
+		t.Fatalf("req: %+v", req)
+	}
+}
+
+func TestMissingForPublish(t *testing.T) {
+	name, blank, desc, capacity := "Rubicon", "  ", "", 4
+	day := &plannerapi.Date{}
+	if got := strings.Join(MissingForPublish(plannerapi.Trip{Name: &blank}), ","); got != "name,description,startDate,endDate,capacityRigs,meetingLocation" {
+		t.Fatalf("empty draft: %s", got)
+	}
+	full := plannerapi.Trip{Name: &name, Description: &desc, StartDate: day, EndDate: day, CapacityRigs: &capacity, MeetingLocation: &plannerapi.Location{}}
+	if got := MissingForPublish(full); len(got) != 0 {
+		t.Fatalf("complete draft: %v", got)
+	}
+}
+
+func TestBuildPatch(t *testing.T) {
+	str := func(v string) *string { return &v }
+	f := func(v float64) *float64 { return &v }
+
+	req, err := BuildPatch(Patch{
+		Name:         str("Rubicon"),
+		MeetingLabel: str("Loon Lake"),
+		MeetingLat:   f(38.9),
+		MeetingLng:   f(-120.3),
+		ArtifactIDs:  []string{"a2", "a1", "a2"},
+	})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if *req.Name != "Rubicon" || *req.MeetingLocation.Label != "Loon Lake" || *req.MeetingLocation.LatitudeLongitude.Longitude != -120.3 {
+		t.Fatalf("req: %+v", req)
+	}
+	if ids := *req.ArtifactIDs; len(ids) != 2 || ids[0] != "a2" || ids[1] != "a1" {
+		t.Fatalf("artifactIds: %v", ids)
+	}
+
+	req, err = BuildPatch(Patch{ClearMeetingLocation: true, ClearArtifacts: true})
+	if err != nil || req.MeetingLocation == nil || req.MeetingLocation.Label != nil || len(*req.ArtifactIDs) != 0 {
+		t.Fatalf("clear: %+v %v", req, err)
+	}
+
+	for name, p := range map[string]Patch{
+		"multiline":           {Description: str("a\nb")},
+		"artifacts and clear": {ArtifactIDs: []string{"a1"}, ClearArtifacts: true},
+		"meeting and clear":   {MeetingLabel: str("x"), ClearMeetingLocation: true},
+		"lat without lng":     {MeetingLat: f(1)},
+	} {
+		if _, err := BuildPatch(p); exitcode.Code(err) != exitcode.Usage {
+			t.Fatalf("%s: got %v", name, err)
+		}
+	}
+}
+
+func TestEditPatch_ParsesEditedYAML(t *testing.T) {
+	s := Service{Edit: func(template string) ([]byte, error) {
+		return []byte("# edited\ndescription: |-\n  line1\n  line2\ncapacityRigs: 6\n"), nil
+	}}
+	req, err := s.EditPatch()
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if *req.Description != "line1\nline2" || *req.CapacityRigs != 6 {
+		t.Fatalf("req: %+v", req)
+	}
+
+	s.Edit = func(string) ([]byte, error) { return []byte("bogus: 1\n"), nil }
+	if _, err := s.EditPatch(); exitcode.Code(err) != exitcode.Usage {
+		t.Fatalf("unknown field: %v", err)
+	}
+}
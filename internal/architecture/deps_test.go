@@ -54,6 +54,12 @@ func TestArchitecture_DependencyInvariants(t *testing.T) {
 				}
 			}
 
+			if strings.Contains(p.ImportPath, "/internal/ports/") {
+				if strings.Contains(imp, "/internal/gen/") {
+					violations = append(violations, fmt.Sprintf("%s must not import %s (ports must not expose generated code)", p.ImportPath, imp))
+				}
+			}
+
 			if strings.Contains(p.ImportPath, "/internal/adapters/in/") {
 				if strings.Contains(imp, "/internal/gen/") {
 					violations = append(violations, fmt.Sprintf("%s must not import %s (inbound adapter must not depend on generated code)", p.ImportPath, imp))
@@ -64,6 +70,9 @@ func TestArchitecture_DependencyInvariants(t *testing.T) {
 				if strings.Contains(imp, "/internal/adapters/in/") {
 					violations = append(violations, fmt.Sprintf("%s must not import %s (outbound adapters must not depend on inbound adapters)", p.ImportPath, imp))
 				}
+				if strings.Contains(imp, "/internal/adapters/out/") && adapterOf(imp) != adapterOf(p.ImportPath) && !sidewaysAllowed[adapterOf(p.ImportPath)+"->"+adapterOf(imp)] {
+					violations = append(violations, fmt.Sprintf("%s must not import %s (outbound adapters must not depend on each other; share through platform or ports)", p.ImportPath, imp))
+				}
 			}
 		}
 	}
@@ -78,6 +87,8 @@ func mustGoList(t *testing.T) []goListPkg {
 	t.Helper()
 
 	cmd := exec.Command("go", "list", "-json", "./...")
+	// Run from the module root: the test's own directory only lists itself.
+	cmd.Dir = "../.."
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		t.Fatalf("go list failed: %v\n%s", err, string(out))
@@ -94,3 +105,18 @@ func mustGoList(t *testing.T) []goListPkg {
 	}
 	return pkgs
 }
+
+// sidewaysAllowed lists outbound adapters that may import another one.
+var sidewaysAllowed = map[string]bool{
+	// The in-memory API returns the HTTP adapter's APIError, so commands see
+	// the same errors against `ebo dev mock-server` as against the real API.
+	"plannerapimem->plannerapi": true,
+}
+
+// adapterOf returns the outbound adapter a package belongs to
+// (".../internal/adapters/out/<name>").
+func adapterOf(importPath string) string {
+	_, rest, _ := strings.Cut(importPath, "/internal/adapters/out/")
+	name, _, _ := strings.Cut(rest, "/")
+	return name
+}
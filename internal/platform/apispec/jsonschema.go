@@ -0,0 +1,122 @@
+package apispec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// JSONSchemaDraft is the JSON Schema dialect JSONSchema documents declare.
+// Draft-07 is the one editors (yaml-language-server, VS Code) support best.
+const JSONSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// JSONSchema returns the named component schema (e.g.
+// "UpdateTripRequest") as a standalone JSON Schema document.
+func (s *Spec) JSONSchema(name string) (map[string]any, error) {
+	c, ok := s.Schemas[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown schema %q", name)
+	}
+	doc := c.JSONSchema()
+	doc["$schema"] = JSONSchemaDraft
+	doc["title"] = name
+	return doc, nil
+}
+
+// JSONSchema converts s to JSON Schema. $refs are already inlined; the
+// OpenAPI-only nullable becomes a "null" type (and enum value). A schema
+// nested in itself converts to {} (anything) at the point of recursion.
+func (s *Schema) JSONSchema() map[string]any {
+	return s.jsonSchema(map[*Schema]bool{})
+}
+
+func (s *Schema) jsonSchema(open map[*Schema]bool) map[string]any {
+	out := map[string]any{}
+	if s == nil || open[s] {
+		return out
+	}
+	open[s] = true
+	defer delete(open, s)
+
+	if s.Description != "" {
+		out["description"] = s.Description
+	}
+	if s.Type != "" {
+		if s.Nullable {
+			out["type"] = []any{s.Type, "null"}
+		} else {
+			out["type"] = s.Type
+		}
+	}
+	if s.Format != "" {
+		out["format"] = s.Format
+	}
+	if len(s.Enum) > 0 {
+		enum := append([]any(nil), s.Enum...)
+		if s.Nullable {
+			enum = append(enum, nil)
+		}
+		out["enum"] = enum
+	}
+	if len(s.Required) > 0 {
+		out["required"] = s.Required
+	}
+	if s.Properties != nil {
+		props := map[string]any{}
+		for k, p := range s.Properties {
+			props[k] = p.jsonSchema(open)
+		}
+		out["properties"] = props
+	}
+	if a := s.AdditionalProperties; a != nil {
+		switch {
+		case a.Schema != nil:
+			out["additionalProperties"] = a.Schema.jsonSchema(open)
+		default:
+			out["additionalProperties"] = a.Allowed
+		}
+	}
+	if s.Items != nil {
+		out["items"] = s.Items.jsonSchema(open)
+	}
+	for key, v := range map[string]*int{"minLength": s.MinLength, "maxLength": s.MaxLength, "minItems": s.MinItems} {
+		if v != nil {
+			out[key] = *v
+		}
+	}
+	for key, v := range map[string]*float64{"minimum": s.Minimum, "maximum": s.Maximum} {
+		if v != nil {
+			out[key] = *v
+		}
+	}
+	for key, list := range map[string][]*Schema{"allOf": s.AllOf, "oneOf": s.OneOf, "anyOf": s.AnyOf} {
+		if len(list) == 0 {
+			continue
+		}
+		subs := make([]any, len(list))
+		for i, sub := range list {
+			subs[i] = sub.jsonSchema(open)
+		}
+		out[key] = subs
+	}
+	return out
+}
+
+// SuccessSchema returns the JSON body schema of the operation's first
+// documented 2XX response, or nil if it has none.
+func (o *Operation) SuccessSchema() *Schema {
+	statuses := make([]string, 0, len(o.Responses))
+	for status := range o.Responses {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		if !strings.HasPrefix(status, "2") {
+			continue
+		}
+		if mt, ok := o.Responses[status].Content["application/json"]; ok {
+			return mt.Schema
+		}
+	}
+	return nil
+}
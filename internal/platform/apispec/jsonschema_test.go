@@ -0,0 +1,89 @@
+package apispec
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// compact marshals v for comparison; map keys come out sorted.
+func compact(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestSpec_JSONSchema(t *testing.T) {
+	spec, err := Pinned()
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := spec.JSONSchema("UpdateTripRequest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc["$schema"] != JSONSchemaDraft || doc["title"] != "UpdateTripRequest" || doc["additionalProperties"] != false {
+		t.Fatalf("header: %v %v %v", doc["$schema"], doc["title"], doc["additionalProperties"])
+	}
+	props := doc["properties"].(map[string]any)
+	cases := map[string]string{
+		"name":      `{"description":"Title. Server trims leading/trailing whitespace and collapses internal whitespace runs before persisting.","minLength":1,"type":"string"}`,
+		"startDate": `{"format":"date","type":["string","null"]}`,
+		"meetingLocation": `{"additionalProperties":false,"description":"Partial update shape for Location. Omitted fields are unchanged.",` +
+			`"properties":{"address":{"type":["string","null"]},"label":{"type":["string","null"]},` +
+			`"latitudeLongitude":{"additionalProperties":false,"properties":{"latitude":{"type":["number","null"]},"longitude":{"type":["number","null"]}},"type":["object","null"]}},"type":"object"}`,
+	}
+	for key, want := range cases {
+		if got := compact(t, props[key]); got != want {
+			t.Errorf("%s:\n got %s\nwant %s", key, got, want)
+		}
+	}
+
+	if _, err := spec.JSONSchema("Nope"); err == nil {
+		t.Fatal("expected an error for an unknown schema")
+	}
+}
+
+func TestSchema_JSONSchema_EnumsAndRecursion(t *testing.T) {
+	spec, err := Parse([]byte(`
+openapi: 3.0.3
+paths: {}
+components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        kind:
+          type: string
+          nullable: true
+          enum: [A, B]
+        children:
+          type: array
+          items:
+            $ref: "#/components/schemas/Node"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"properties":{"children":{"items":{},"type":"array"},"kind":{"enum":["A","B",null],"type":["string","null"]}},"type":"object"}`
+	if got := compact(t, spec.Schemas["Node"].JSONSchema()); got != want {
+		t.Fatalf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestOperation_SuccessSchema(t *testing.T) {
+	spec, err := Pinned()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, op := range spec.Operations {
+		if op.SuccessSchema() == nil {
+			t.Errorf("%s: no success schema", op.ID)
+		}
+	}
+	if got := spec.Operation("createTripDraft").SuccessSchema(); got != spec.Schemas["CreateTripDraftResponse"] {
+		t.Fatalf("createTripDraft: got %+v", got)
+	}
+}
@@ -0,0 +1,436 @@
+// Package apispec embeds the pinned Planner API OpenAPI document, checks
+// HTTP traffic against it and exports its schemas as JSON Schema.
+//
+// openapi.yaml and spec.lock are written together by `make gen` (via
+// tools/specpin) from the spec repo at the ref in the root spec.lock, and are
+// never edited by hand; scripts/verify_spec_lock.sh and the tests fail when
+// they drift from the pinned ref. An empty embedded spec.lock means the
+// document was not generated from the spec repo, and PinnedRef reports no
+// version. Only the subset of OpenAPI 3.0 the
+// Planner API uses is modelled: paths, parameters, JSON request/response
+// bodies and schemas (type, format, nullable, enum, required, properties,
+// additionalProperties, items, length and numeric bounds, allOf/oneOf/anyOf)
+// with local $refs.
+//
+// Transport wraps a RoundTripper and reports every request or response that
+// does not conform (EBO_VALIDATE_REQUESTS=1); the adapter and e2e tests run
+// with it enabled.
+package apispec
+
+import (
+	_ "embed"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed openapi.yaml
+var pinnedYAML []byte
+
+// pinnedLock is a copy of spec.lock, kept in sync by `make gen`.
+//
+//go:embed spec.lock
+var pinnedLock string
+
+var (
+	pinnedOnce sync.Once
+	pinned     *Spec
+	pinnedErr  error
+)
+
+// Pinned returns the embedded spec, parsed once.
+func Pinned() (*Spec, error) {
+	pinnedOnce.Do(func() {
+		pinned, pinnedErr = Parse(pinnedYAML)
+	})
+	return pinned, pinnedErr
+}
+
+// PinnedYAML returns the embedded spec document as pinned.
+func PinnedYAML() []byte {
+	return append([]byte(nil), pinnedYAML...)
+}
+
+// PinnedRef returns the spec ref (tag or commit) the embedded document was
+// generated from, as recorded in spec.lock; "" when it was not generated.
+func PinnedRef() string {
+	return strings.TrimSpace(pinnedLock)
+}
+
+// Spec is a parsed OpenAPI document with all $refs resolved.
+type Spec struct {
+	Operations []*Operation
+	// Schemas are the named component schemas (request and response types).
+	Schemas map[string]*Schema
+}
+
+// Operation is one method on one path.
+type Operation struct {
+	ID          string
+	Method      string
+	Path        string
+	Parameters  []*Parameter
+	RequestBody *RequestBody
+	// Responses is keyed by status code ("200"), range ("4XX") or "default".
+	Responses map[string]*Response
+
+	segments []string
+}
+
+// Parameter is a path, query or header parameter.
+type Parameter struct {
+	Ref      string  `yaml:"$ref"`
+	Name     string  `yaml:"name"`
+	In       string  `yaml:"in"`
+	Required bool    `yaml:"required"`
+	Schema   *Schema `yaml:"schema"`
+}
+
+// RequestBody describes an operation's request body.
+type RequestBody struct {
+	Ref      string               `yaml:"$ref"`
+	Required bool                 `yaml:"required"`
+	Content  map[string]MediaType `yaml:"content"`
+}
+
+// Response describes one documented response.
+type Response struct {
+	Ref     string               `yaml:"$ref"`
+	Content map[string]MediaType `yaml:"content"`
+}
+
+// MediaType is a content entry of a body.
+type MediaType struct {
+	Schema *Schema `yaml:"schema"`
+}
+
+// Schema is the subset of an OpenAPI 3.0 schema object the validator checks,
+// plus its description (kept for JSON Schema output).
+type Schema struct {
+	Ref                  string             `yaml:"$ref"`
+	Description          string             `yaml:"description"`
+	Type                 string             `yaml:"type"`
+	Format               string             `yaml:"format"`
+	Nullable             bool               `yaml:"nullable"`
+	Enum                 []any              `yaml:"enum"`
+	Required             []string           `yaml:"required"`
+	Properties           map[string]*Schema `yaml:"properties"`
+	AdditionalProperties *Additional        `yaml:"additionalProperties"`
+	Items                *Schema            `yaml:"items"`
+	MinLength            *int               `yaml:"minLength"`
+	MaxLength            *int               `yaml:"maxLength"`
+	MinItems             *int               `yaml:"minItems"`
+	Minimum              *float64           `yaml:"minimum"`
+	Maximum              *float64           `yaml:"maximum"`
+	AllOf                []*Schema          `yaml:"allOf"`
+	OneOf                []*Schema          `yaml:"oneOf"`
+	AnyOf                []*Schema          `yaml:"anyOf"`
+}
+
+// Additional is additionalProperties: a boolean or a schema. A missing
+// keyword allows anything.
+type Additional struct {
+	Allowed bool
+	Schema  *Schema
+}
+
+// UnmarshalYAML accepts both forms of additionalProperties.
+func (a *Additional) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		a.Schema = nil
+		return n.Decode(&a.Allowed)
+	}
+	a.Allowed = true
+	return n.Decode(&a.Schema)
+}
+
+type document struct {
+	Paths      map[string]map[string]yaml.Node `yaml:"paths"`
+	Components struct {
+		Schemas       map[string]*Schema      `yaml:"schemas"`
+		Parameters    map[string]*Parameter   `yaml:"parameters"`
+		RequestBodies map[string]*RequestBody `yaml:"requestBodies"`
+		Responses     map[string]*Response    `yaml:"responses"`
+	} `yaml:"components"`
+}
+
+type rawOperation struct {
+	OperationID string               `yaml:"operationId"`
+	Parameters  []*Parameter         `yaml:"parameters"`
+	RequestBody *RequestBody         `yaml:"requestBody"`
+	Responses   map[string]*Response `yaml:"responses"`
+}
+
+var methods = map[string]string{
+	"get": http.MethodGet, "put": http.MethodPut, "post": http.MethodPost,
+	"delete": http.MethodDelete, "options": http.MethodOptions, "head": http.MethodHead,
+	"patch": http.MethodPatch, "trace": http.MethodTrace,
+}
+
+// Parse parses an OpenAPI 3.0 YAML (or JSON) document and resolves its local
+// $refs.
+func Parse(b []byte) (*Spec, error) {
+	var doc document
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("parse openapi document: %w", err)
+	}
+	r := resolver{doc: &doc, done: map[*Schema]bool{}}
+
+	spec := &Spec{Schemas: map[string]*Schema{}}
+	for name, s := range doc.Components.Schemas {
+		var err error
+		if spec.Schemas[name], err = r.schema(s); err != nil {
+			return nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+	}
+	for path, item := range doc.Paths {
+		var shared []*Parameter
+		if n, ok := item["parameters"]; ok {
+			if err := n.Decode(&shared); err != nil {
+				return nil, fmt.Errorf("%s parameters: %w", path, err)
+			}
+		}
+		for key, n := range item {
+			method, ok := methods[key]
+			if !ok {
+				continue
+			}
+			var raw rawOperation
+			if err := n.Decode(&raw); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			op := &Operation{
+				ID:        raw.OperationID,
+				Method:    method,
+				Path:      path,
+				Responses: map[string]*Response{},
+				segments:  splitPath(path),
+			}
+			params, err := r.parameters(append(append([]*Parameter(nil), shared...), raw.Parameters...))
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			op.Parameters = params
+			if op.RequestBody, err = r.requestBody(raw.RequestBody); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			for status, resp := range raw.Responses {
+				if op.Responses[status], err = r.response(resp); err != nil {
+					return nil, fmt.Errorf("%s %s %s: %w", method, path, status, err)
+				}
+			}
+			spec.Operations = append(spec.Operations, op)
+		}
+	}
+	sort.Slice(spec.Operations, func(i, j int) bool {
+		a, b := spec.Operations[i], spec.Operations[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Method < b.Method
+	})
+	return spec, nil
+}
+
+// Operation returns the operation with the given operationId, or nil.
+func (s *Spec) Operation(id string) *Operation {
+	for _, op := range s.Operations {
+		if op.ID == id {
+			return op
+		}
+	}
+	return nil
+}
+
+// Find returns the operation serving method and path, and its path
+// parameters. The operation path may match a suffix of path, so a base URL
+// with a path prefix (https://host/v1) still resolves. Literal segments win
+// over templated ones (/trips/drafts over /trips/{tripId}).
+func (s *Spec) Find(method, path string) (*Operation, map[string]string) {
+	segs := splitPath(path)
+	var (
+		best       *Operation
+		bestParams map[string]string
+		bestScore  = -1
+	)
+	for _, op := range s.Operations {
+		if op.Method != method || len(op.segments) > len(segs) {
+			continue
+		}
+		tail := segs[len(segs)-len(op.segments):]
+		params := map[string]string{}
+		score := 0
+		for i, want := range op.segments {
+			if name, ok := templateName(want); ok {
+				params[name] = tail[i]
+				continue
+			}
+			if want != tail[i] {
+				score = -1
+				break
+			}
+			score += 2
+		}
+		if score < 0 {
+			continue
+		}
+		// Longer matches first, then more literal segments.
+		score += len(op.segments) * 100
+		if score > bestScore {
+			best, bestParams, bestScore = op, params, score
+		}
+	}
+	return best, bestParams
+}
+
+func splitPath(p string) []string {
+	var out []string
+	for _, s := range strings.Split(p, "/") {
+		if s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func templateName(seg string) (string, bool) {
+	if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+		return seg[1 : len(seg)-1], true
+	}
+	return "", false
+}
+
+// resolver replaces local $refs with the components they point at.
+type resolver struct {
+	doc  *document
+	done map[*Schema]bool
+}
+
+func refName(ref, kind string) (string, error) {
+	prefix := "#/components/" + kind + "/"
+	if !strings.HasPrefix(ref, prefix) {
+		return "", fmt.Errorf("unsupported $ref %q", ref)
+	}
+	return strings.TrimPrefix(ref, prefix), nil
+}
+
+func (r resolver) parameters(in []*Parameter) ([]*Parameter, error) {
+	out := make([]*Parameter, 0, len(in))
+	for _, p := range in {
+		if p.Ref != "" {
+			name, err := refName(p.Ref, "parameters")
+			if err != nil {
+				return nil, err
+			}
+			c, ok := r.doc.Components.Parameters[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown $ref %q", p.Ref)
+			}
+			p = c
+		}
+		s, err := r.schema(p.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		p.Schema = s
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func (r resolver) requestBody(b *RequestBody) (*RequestBody, error) {
+	if b == nil {
+		return nil, nil
+	}
+	if b.Ref != "" {
+		name, err := refName(b.Ref, "requestBodies")
+		if err != nil {
+			return nil, err
+		}
+		c, ok := r.doc.Components.RequestBodies[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown $ref %q", b.Ref)
+		}
+		b = c
+	}
+	return b, r.content(b.Content)
+}
+
+func (r resolver) response(resp *Response) (*Response, error) {
+	if resp == nil {
+		return &Response{}, nil
+	}
+	if resp.Ref != "" {
+		name, err := refName(resp.Ref, "responses")
+		if err != nil {
+			return nil, err
+		}
+		c, ok := r.doc.Components.Responses[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown $ref %q", resp.Ref)
+		}
+		resp = c
+	}
+	return resp, r.content(resp.Content)
+}
+
+func (r resolver) content(c map[string]MediaType) error {
+	for ct, mt := range c {
+		s, err := r.schema(mt.Schema)
+		if err != nil {
+			return fmt.Errorf("%s: %w", ct, err)
+		}
+		mt.Schema = s
+		c[ct] = mt
+	}
+	return nil
+}
+
+// schema returns s with every nested $ref replaced by its target. Shared
+// component schemas are resolved once, which also stops recursive schemas.
+func (r resolver) schema(s *Schema) (*Schema, error) {
+	if s == nil {
+		return nil, nil
+	}
+	if s.Ref != "" {
+		name, err := refName(s.Ref, "schemas")
+		if err != nil {
+			return nil, err
+		}
+		c, ok := r.doc.Components.Schemas[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown $ref %q", s.Ref)
+		}
+		return r.schema(c)
+	}
+	if r.done[s] {
+		return s, nil
+	}
+	r.done[s] = true
+	var err error
+	for k, p := range s.Properties {
+		if s.Properties[k], err = r.schema(p); err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+	}
+	if s.Items, err = r.schema(s.Items); err != nil {
+		return nil, err
+	}
+	if s.AdditionalProperties != nil {
+		if s.AdditionalProperties.Schema, err = r.schema(s.AdditionalProperties.Schema); err != nil {
+			return nil, err
+		}
+	}
+	for _, list := range [][]*Schema{s.AllOf, s.OneOf, s.AnyOf} {
+		for i, sub := range list {
+			if list[i], err = r.schema(sub); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return s, nil
+}
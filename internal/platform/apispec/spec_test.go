@@ -0,0 +1,242 @@
+package apispec
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/specpin"
+)
+
+func TestPinned_ParsesEveryOperation(t *testing.T) {
+	spec, err := Pinned()
+	if err != nil {
+		t.Fatalf("Pinned: %v", err)
+	}
+	if got := len(spec.Operations); got != 19 {
+		t.Fatalf("operations: got %d want 19", got)
+	}
+	for _, op := range spec.Operations {
+		if op.ID == "" {
+			t.Errorf("%s %s: missing operationId", op.Method, op.Path)
+		}
+		if len(op.Responses) == 0 {
+			t.Errorf("%s: no responses", op.ID)
+		}
+		for status, r := range op.Responses {
+			for ct, mt := range r.Content {
+				if mt.Schema == nil || mt.Schema.Ref != "" {
+					t.Errorf("%s %s %s: unresolved schema", op.ID, status, ct)
+				}
+			}
+		}
+	}
+}
+
+func TestPinned_MatchesSpecLock(t *testing.T) {
+	root := filepath.Join("..", "..", "..")
+	ref, err := specpin.ReadSpecTag(filepath.Join(root, "spec.lock"))
+	if err != nil {
+		t.Fatalf("read spec.lock: %v", err)
+	}
+	switch got := PinnedRef(); got {
+	case ref:
+	case "":
+		t.Skipf("openapi.yaml was not generated from spec %s; run make gen (scripts/verify_spec_lock.sh fails until then)", ref)
+	default:
+		t.Fatalf("embedded spec.lock %q does not match %q; run make gen", got, ref)
+	}
+
+	specDir := specpin.ResolveSpecDir(map[string]string{"EBO_SPEC_DIR": os.Getenv("EBO_SPEC_DIR")}, root)
+	if err := specpin.VerifyRefExists(nil, specDir, ref); err != nil {
+		t.Skipf("spec repo not available, embedded copy not compared: %v", err)
+	}
+	out := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := specpin.ExtractOpenAPIAtRef(nil, specDir, ref, out); err != nil {
+		t.Fatalf("extract: %v", err)
+	}
+	want, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(PinnedYAML(), want) {
+		t.Fatalf("embedded openapi.yaml differs from openapi/openapi.yaml at %s; run make gen", ref)
+	}
+}
+
+func TestSpec_Find(t *testing.T) {
+	spec, err := Pinned()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		method, path, wantID string
+		wantParams           map[string]string
+	}{
+		{"GET", "/trips", "listVisibleTripsForMember", nil},
+		{"GET", "/trips/drafts", "listMyDraftTrips", nil},
+		{"GET", "/trips/t1", "getTripDetails", map[string]string{"tripId": "t1"}},
+		{"GET", "/v1/trips/drafts", "listMyDraftTrips", nil},
+		{"DELETE", "/v1/trips/t1/organizers/m2", "removeTripOrganizer", map[string]string{"tripId": "t1", "memberId": "m2"}},
+		{"GET", "/members/me", "getMyMemberProfile", nil},
+		{"POST", "/trips/t1", "", nil},
+	}
+	for _, tc := range cases {
+		op, params := spec.Find(tc.method, tc.path)
+		got := ""
+		if op != nil {
+			got = op.ID
+		}
+		if got != tc.wantID {
+			t.Errorf("%s %s: got %q want %q", tc.method, tc.path, got, tc.wantID)
+			continue
+		}
+		for k, v := range tc.wantParams {
+			if params[k] != v {
+				t.Errorf("%s %s: param %s got %q want %q", tc.method, tc.path, k, params[k], v)
+			}
+		}
+	}
+}
+
+func TestSpec_CheckRequest(t *testing.T) {
+	spec, err := Pinned()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name, method, target, key, body string
+		want                            []string
+	}{
+		{
+			name: "conforming patch with nullable clears", method: "PATCH", target: "/trips/t1", key: "k1",
+			body: `{"description":null,"capacityRigs":null,"meetingLocation":{"label":"Gate","latitudeLongitude":{"latitude":null,"longitude":null}}}`,
+		},
+		{
+			name: "missing idempotency key", method: "PATCH", target: "/trips/t1",
+			body: `{"name":"x"}`,
+			want: []string{"header Idempotency-Key: required"},
+		},
+		{
+			name: "unknown LocationPatch field", method: "PATCH", target: "/trips/t1", key: "k1",
+			body: `{"meetingLocation":{"lat":1}}`,
+			want: []string{"body.meetingLocation.lat: unknown property"},
+		},
+		{
+			name: "name cannot be cleared", method: "PATCH", target: "/trips/t1", key: "k1",
+			body: `{"name":null,"startDate":"June 1"}`,
+			want: []string{"body.name: null is not allowed", `body.startDate: "June 1" is not a valid date`},
+		},
+		{
+			name: "empty string clears a nullable email", method: "PATCH", target: "/members/me", key: "k1",
+			body: `{"groupAliasEmail":""}`,
+		},
+		{
+			name: "email format", method: "PATCH", target: "/members/me", key: "k1",
+			body: `{"email":"not-an-email"}`,
+			want: []string{`body.email: "not-an-email" is not a valid email`},
+		},
+		{
+			name: "enum and required", method: "PUT", target: "/trips/t1/rsvp", key: "k1",
+			body: `{"response":"MAYBE"}`,
+			want: []string{`body.response: "MAYBE" is not one of YES, NO, UNSET`},
+		},
+		{
+			name: "search query too short", method: "GET", target: "/members/search?q=ab",
+			want: []string{"query q: length 2, want at least 3"},
+		},
+		{
+			name: "unknown query parameter", method: "GET", target: "/members?includeInactive=true&all=1",
+			want: []string{"query all: not a parameter of this operation"},
+		},
+		{
+			name: "missing body", method: "POST", target: "/trips", key: "k1",
+			want: []string{"body: required"},
+		},
+		{
+			name: "unexpected body", method: "POST", target: "/trips/t1/publish",
+			body: `{}`,
+			want: []string{"body: operation takes no request body"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.target, nil)
+			if tc.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			if tc.key != "" {
+				req.Header.Set("Idempotency-Key", tc.key)
+			}
+			got := problems(spec.CheckRequest(req, []byte(tc.body)))
+			if strings.Join(got, "\n") != strings.Join(tc.want, "\n") {
+				t.Fatalf("problems:\n got %q\nwant %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSpec_CheckRequest_UnknownOperation(t *testing.T) {
+	spec, err := Pinned()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := spec.CheckRequest(httptest.NewRequest("GET", "/nope", nil), nil)
+	if len(got) != 1 || got[0].String() != "GET /nope request: no operation in the spec matches" {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestSpec_CheckResponse(t *testing.T) {
+	spec, err := Pinned()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name, method, target string
+		status               int
+		body                 string
+		want                 []string
+	}{
+		{
+			name: "conforming", method: "GET", target: "/trips/t1", status: 200,
+			body: `{"trip":{"tripId":"t1","status":"DRAFT","name":null,"capacityRigs":null,"organizers":[],"artifacts":[],"rsvpActionsEnabled":false}}`,
+		},
+		{
+			name: "missing required and null array", method: "GET", target: "/trips/t1", status: 200,
+			body: `{"trip":{"tripId":"t1","status":"DRAFT","organizers":null,"rsvpActionsEnabled":false}}`,
+			want: []string{"body.trip.artifacts: required", "body.trip.organizers: null is not allowed"},
+		},
+		{
+			name: "error envelope", method: "GET", target: "/trips/t1", status: 404,
+			body: `{"error":{"code":"NOT_FOUND","message":"missing","requestId":null}}`,
+		},
+		{
+			name: "undocumented status", method: "GET", target: "/trips", status: 404,
+			body: `{"error":{"code":"NOT_FOUND","message":"missing"}}`,
+			want: []string{"status is not documented for this operation"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.target, nil)
+			resp := &http.Response{StatusCode: tc.status, Header: http.Header{"Content-Type": {"application/json"}}}
+			got := problems(spec.CheckResponse(req, resp, []byte(tc.body)))
+			if strings.Join(got, "\n") != strings.Join(tc.want, "\n") {
+				t.Fatalf("problems:\n got %q\nwant %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func problems(ms []Mismatch) []string {
+	var out []string
+	for _, m := range ms {
+		out = append(out, m.Problem)
+	}
+	return out
+}
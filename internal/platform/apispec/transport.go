@@ -0,0 +1,78 @@
+package apispec
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// Transport checks every request it sends, and every response it receives,
+// against Spec and passes each mismatch to Report. It never changes what is
+// sent or returned: a non-conforming exchange still goes through.
+type Transport struct {
+	// Base sends the requests; nil means http.DefaultTransport.
+	Base http.RoundTripper
+	// Spec is the contract; nil means Pinned().
+	Spec *Spec
+	// Report receives mismatches; it may be called concurrently.
+	Report func(Mismatch)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	spec := t.Spec
+	if spec == nil {
+		s, err := Pinned()
+		if err != nil {
+			// The embedded document is checked by tests; don't fail requests.
+			return base.RoundTrip(req)
+		}
+		spec = s
+	}
+
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		b, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(b))
+		req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(b)), nil }
+	}
+	t.report(spec.CheckRequest(req, body))
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	rb, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(rb))
+	if err != nil {
+		// Let the caller see the same read error.
+		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(rb), errReader{err}))
+		return resp, nil
+	}
+	t.report(spec.CheckResponse(req, resp, rb))
+	return resp, nil
+}
+
+func (t *Transport) report(ms []Mismatch) {
+	if t.Report == nil {
+		return
+	}
+	for _, m := range ms {
+		t.Report(m)
+	}
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
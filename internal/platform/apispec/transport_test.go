@@ -0,0 +1,54 @@
+package apispec
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestTransport_ReportsMismatchesAndPassesExchangeThrough(t *testing.T) {
+	var gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"trip":{"tripId":"t1","status":"DRAFT"}}`))
+	}))
+	defer srv.Close()
+
+	var (
+		mu       sync.Mutex
+		reported []string
+	)
+	hc := &http.Client{Transport: &Transport{Report: func(m Mismatch) {
+		mu.Lock()
+		defer mu.Unlock()
+		reported = append(reported, m.String())
+	}}}
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/trips", strings.NewReader(`{"name":"Run"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := hc.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+
+	if gotBody != `{"name":"Run"}` {
+		t.Fatalf("server body: %q", gotBody)
+	}
+	if resp.StatusCode != 200 || !strings.Contains(string(b), `"tripId":"t1"`) {
+		t.Fatalf("response: %d %s", resp.StatusCode, b)
+	}
+	want := []string{
+		"createTripDraft request: header Idempotency-Key: required",
+		"createTripDraft response 200: status is not documented for this operation",
+	}
+	if strings.Join(reported, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("reported:\n got %q\nwant %q", reported, want)
+	}
+}
@@ -0,0 +1,384 @@
+package apispec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/mail"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Mismatch is one way a request or response departs from the spec.
+type Mismatch struct {
+	// Operation is the operationId, or "METHOD /path" when none matched.
+	Operation string
+	// Where is "request" or "response <status>".
+	Where string
+	// Problem describes the mismatch, prefixed with the offending location
+	// (header Idempotency-Key, query q, body.meetingLocation.label, ...).
+	Problem string
+}
+
+func (m Mismatch) String() string {
+	return fmt.Sprintf("%s %s: %s", m.Operation, m.Where, m.Problem)
+}
+
+// CheckRequest validates req, whose body is body (req.Body is not read).
+func (s *Spec) CheckRequest(req *http.Request, body []byte) []Mismatch {
+	op, pathParams := s.Find(req.Method, req.URL.Path)
+	if op == nil {
+		return []Mismatch{{
+			Operation: req.Method + " " + req.URL.Path,
+			Where:     "request",
+			Problem:   "no operation in the spec matches",
+		}}
+	}
+	var problems []string
+	add := func(format string, args ...any) { problems = append(problems, fmt.Sprintf(format, args...)) }
+
+	query := req.URL.Query()
+	known := map[string]bool{}
+	for _, p := range op.Parameters {
+		var (
+			values  []string
+			present bool
+		)
+		switch p.In {
+		case "path":
+			v, ok := pathParams[p.Name]
+			values, present = []string{v}, ok
+		case "query":
+			known[p.Name] = true
+			values, present = query[p.Name]
+		case "header":
+			values = req.Header.Values(p.Name)
+			present = len(values) > 0
+		default:
+			continue
+		}
+		label := p.In + " " + p.Name
+		if !present {
+			if p.Required {
+				add("%s: required", label)
+			}
+			continue
+		}
+		for _, v := range values {
+			checkParam(p.Schema, v, label, &problems)
+		}
+	}
+	for name := range query {
+		if !known[name] {
+			add("query %s: not a parameter of this operation", name)
+		}
+	}
+
+	switch {
+	case op.RequestBody == nil:
+		if len(bytes.TrimSpace(body)) > 0 {
+			add("body: operation takes no request body")
+		}
+	case len(bytes.TrimSpace(body)) == 0:
+		if op.RequestBody.Required {
+			add("body: required")
+		}
+	default:
+		checkBody(op.RequestBody.Content, req.Header.Get("Content-Type"), body, &problems)
+	}
+	return mismatches(op.ID, "request", problems)
+}
+
+// CheckResponse validates resp (to req), whose body is body.
+func (s *Spec) CheckResponse(req *http.Request, resp *http.Response, body []byte) []Mismatch {
+	op, _ := s.Find(req.Method, req.URL.Path)
+	if op == nil {
+		// Already reported for the request.
+		return nil
+	}
+	where := "response " + strconv.Itoa(resp.StatusCode)
+	r := op.response(resp.StatusCode)
+	if r == nil {
+		return mismatches(op.ID, where, []string{"status is not documented for this operation"})
+	}
+	var problems []string
+	switch {
+	case len(r.Content) == 0:
+	case len(bytes.TrimSpace(body)) == 0:
+		problems = append(problems, "body: required")
+	default:
+		checkBody(r.Content, resp.Header.Get("Content-Type"), body, &problems)
+	}
+	return mismatches(op.ID, where, problems)
+}
+
+func (op *Operation) response(status int) *Response {
+	code := strconv.Itoa(status)
+	if r, ok := op.Responses[code]; ok {
+		return r
+	}
+	if r, ok := op.Responses[code[:1]+"XX"]; ok {
+		return r
+	}
+	return op.Responses["default"]
+}
+
+func mismatches(op, where string, problems []string) []Mismatch {
+	sort.Strings(problems)
+	out := make([]Mismatch, 0, len(problems))
+	for _, p := range problems {
+		out = append(out, Mismatch{Operation: op, Where: where, Problem: p})
+	}
+	return out
+}
+
+func checkParam(s *Schema, raw, label string, problems *[]string) {
+	if s == nil {
+		return
+	}
+	var v any = raw
+	switch s.Type {
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			*problems = append(*problems, fmt.Sprintf("%s: %q is not a boolean", label, raw))
+			return
+		}
+		v = b
+	case "integer", "number":
+		v = json.Number(raw)
+	}
+	checkValue(s, v, label, problems)
+}
+
+func checkBody(content map[string]MediaType, contentType string, body []byte, problems *[]string) {
+	mt, ok := content["application/json"]
+	if !ok {
+		// Only JSON bodies are modelled.
+		return
+	}
+	if media, _, err := mime.ParseMediaType(contentType); err != nil || media != "application/json" {
+		*problems = append(*problems, fmt.Sprintf("body: content type %q, want application/json", contentType))
+		return
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		*problems = append(*problems, fmt.Sprintf("body: invalid JSON: %v", err))
+		return
+	}
+	checkValue(mt.Schema, v, "body", problems)
+}
+
+// checkValue appends a problem for every way v (decoded with UseNumber)
+// departs from s.
+func checkValue(s *Schema, v any, at string, problems *[]string) {
+	if s == nil {
+		return
+	}
+	add := func(format string, args ...any) {
+		*problems = append(*problems, at+": "+fmt.Sprintf(format, args...))
+	}
+	if v == nil {
+		if !s.Nullable && !anyNullable(s) {
+			add("null is not allowed")
+		}
+		return
+	}
+	for _, sub := range s.AllOf {
+		checkValue(sub, v, at, problems)
+	}
+	if len(s.OneOf) > 0 {
+		if n := countMatches(s.OneOf, v, at); n != 1 {
+			add("matches %d of the oneOf schemas, want exactly 1", n)
+		}
+	}
+	if len(s.AnyOf) > 0 && countMatches(s.AnyOf, v, at) == 0 {
+		add("matches none of the anyOf schemas")
+	}
+	if len(s.Enum) > 0 && !inEnum(s.Enum, v) {
+		add("%s is not one of %s", describe(v), enumList(s.Enum))
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			add("want object, got %s", kindOf(v))
+			return
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				*problems = append(*problems, at+"."+name+": required")
+			}
+		}
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if ps, ok := s.Properties[name]; ok {
+				checkValue(ps, obj[name], at+"."+name, problems)
+				continue
+			}
+			switch ap := s.AdditionalProperties; {
+			case ap == nil:
+			case ap.Schema != nil:
+				checkValue(ap.Schema, obj[name], at+"."+name, problems)
+			case !ap.Allowed:
+				*problems = append(*problems, at+"."+name+": unknown property")
+			}
+		}
+	case "array":
+		arr, ok := v.([]any)
+		if !ok {
+			add("want array, got %s", kindOf(v))
+			return
+		}
+		if s.MinItems != nil && len(arr) < *s.MinItems {
+			add("%d items, want at least %d", len(arr), *s.MinItems)
+		}
+		for i, item := range arr {
+			checkValue(s.Items, item, fmt.Sprintf("%s[%d]", at, i), problems)
+		}
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			add("want string, got %s", kindOf(v))
+			return
+		}
+		n := utf8.RuneCountInString(str)
+		if s.MinLength != nil && n < *s.MinLength {
+			add("length %d, want at least %d", n, *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			add("length %d, want at most %d", n, *s.MaxLength)
+		}
+		// The API treats "" as a clear for nullable fields (e.g.
+		// groupAliasEmail), so it is not held to the format.
+		if str == "" && s.Nullable {
+			return
+		}
+		if err := checkFormat(s.Format, str); err != nil {
+			add("%q is not a valid %s", str, s.Format)
+		}
+	case "integer", "number":
+		num, ok := v.(json.Number)
+		if !ok {
+			add("want %s, got %s", s.Type, kindOf(v))
+			return
+		}
+		if s.Type == "integer" {
+			if _, err := num.Int64(); err != nil {
+				add("%s is not an integer", num)
+				return
+			}
+		}
+		f, err := num.Float64()
+		if err != nil {
+			add("%s is not a number", num)
+			return
+		}
+		if s.Minimum != nil && f < *s.Minimum {
+			add("%s is below the minimum %v", num, *s.Minimum)
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			add("%s is above the maximum %v", num, *s.Maximum)
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			add("want boolean, got %s", kindOf(v))
+		}
+	}
+}
+
+// anyNullable reports whether a composed schema admits null through one of
+// its branches (allOf: [{$ref}] plus nullable is the usual 3.0 idiom).
+func anyNullable(s *Schema) bool {
+	for _, list := range [][]*Schema{s.AllOf, s.OneOf, s.AnyOf} {
+		for _, sub := range list {
+			if sub != nil && sub.Nullable {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func countMatches(list []*Schema, v any, at string) int {
+	n := 0
+	for _, sub := range list {
+		var p []string
+		checkValue(sub, v, at, &p)
+		if len(p) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func checkFormat(format, s string) error {
+	var err error
+	switch format {
+	case "date":
+		_, err = time.Parse(time.DateOnly, s)
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, s)
+	case "email":
+		var a *mail.Address
+		if a, err = mail.ParseAddress(s); err == nil && a.Address != s {
+			err = fmt.Errorf("not a bare address")
+		}
+	}
+	return err
+}
+
+func inEnum(enum []any, v any) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func enumList(enum []any) string {
+	parts := make([]string, 0, len(enum))
+	for _, e := range enum {
+		parts = append(parts, fmt.Sprint(e))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func describe(v any) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(v)
+}
+
+func kindOf(v any) string {
+	switch v.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
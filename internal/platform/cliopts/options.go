@@ -15,8 +15,27 @@ type OutputFormat string
 const (
 	OutputTable OutputFormat = "table"
 	OutputJSON  OutputFormat = "json"
+	// OutputYAML is the JSON envelope rendered as YAML.
+	OutputYAML OutputFormat = "yaml"
+	// OutputCSV and OutputTSV flatten list-shaped data to a header row plus
+	// one row per item; only list commands support them.
+	OutputCSV OutputFormat = "csv"
+	OutputTSV OutputFormat = "tsv"
+	// OutputICS is an iCalendar file of the listed trips; only calendar
+	// commands support it.
+	OutputICS OutputFormat = "ics"
 )
 
+// Envelope reports whether f writes the {data, meta, error} envelope.
+func (f OutputFormat) Envelope() bool {
+	return f == OutputJSON || f == OutputYAML
+}
+
+// Delimited reports whether f is CSV or TSV.
+func (f OutputFormat) Delimited() bool {
+	return f == OutputCSV || f == OutputTSV
+}
+
 type GlobalOptions struct {
 	APIURL  string
 	Profile string
@@ -24,6 +43,27 @@ type GlobalOptions struct {
 	NoColor bool
 	Timeout time.Duration
 	Verbose bool
+	// Trace dumps redacted HTTP requests/responses to stderr.
+	Trace bool
+	// HAR is a file path to write an HTTP Archive of the invocation to.
+	HAR string
+	// Offline serves API reads from the local response cache only.
+	Offline bool
+	// Wide shows every table column and never truncates to the terminal width.
+	Wide bool
+	// NoPager writes human output straight to stdout, never through a pager.
+	NoPager bool
+	// Query is a JMESPath expression applied to the envelope's data.
+	Query string
+	// Raw writes only the (queried) data: strings unquoted, anything else as
+	// compact JSON.
+	Raw bool
+	// Format is a Go template (or @file) rendered per item of the data.
+	Format string
+	// RequestIDHeader names the response header carrying the API request ID
+	// ("" for the API adapter's default). Set by EBO_REQUEST_ID_HEADER only;
+	// profiles.<name>.requestIdHeader applies when it is unset.
+	RequestIDHeader string
 }
 
 func DefaultGlobalOptions() GlobalOptions {
@@ -52,10 +92,18 @@ func (m MapEnv) LookupEnv(key string) (string, bool) {
 func AddGlobalFlags(fs *pflag.FlagSet, defaults GlobalOptions) {
 	fs.String("api-url", defaults.APIURL, "Override API base URL (or set EBO_API_URL)")
 	fs.String("profile", defaults.Profile, "Select profile (or set EBO_PROFILE)")
-	fs.String("output", string(defaults.Output), "Output format: table|json (or set EBO_OUTPUT)")
-	fs.Bool("no-color", defaults.NoColor, "Disable ANSI color (or set EBO_NO_COLOR=1)")
+	fs.String("output", string(defaults.Output), "Output format: table|json|yaml|csv|tsv|ics (or set EBO_OUTPUT)")
+	fs.Bool("no-color", defaults.NoColor, "Disable ANSI color (or set EBO_NO_COLOR=1 or NO_COLOR)")
 	fs.Duration("timeout", defaults.Timeout, "Request timeout (e.g., 10s, 2m) (or set EBO_TIMEOUT)")
 	fs.Bool("verbose", defaults.Verbose, "Verbose logging to stderr (or set EBO_VERBOSE=1)")
+	fs.Bool("trace", defaults.Trace, "Dump redacted HTTP requests/responses to stderr (or set EBO_TRACE=1)")
+	fs.String("har", defaults.HAR, "Write a redacted HTTP Archive of this invocation to a file (or set EBO_HAR)")
+	fs.Bool("offline", defaults.Offline, "Serve read commands from the local cache without contacting the API (or set EBO_OFFLINE=1)")
+	fs.Bool("wide", defaults.Wide, "Show all table columns without truncating to the terminal width (or set EBO_WIDE=1)")
+	fs.Bool("no-pager", defaults.NoPager, "Do not page long human output (or set EBO_NO_PAGER=1)")
+	fs.String("query", defaults.Query, "JMESPath expression applied to the output's data, e.g. trip.tripId (implies --output json)")
+	fs.Bool("raw", defaults.Raw, "Print only the (queried) data; strings without quotes (implies --output json)")
+	fs.String("format", defaults.Format, "Go template for each result item, e.g. '{{.TripId}} {{.Name}}', or @file (implies --output json)")
 }
 
 type Resolved struct {
@@ -145,19 +193,55 @@ func ResolveGlobalOptions(fs *pflag.FlagSet, env EnvProvider, defaults GlobalOpt
 	if err := getBoolOne("no-color", "EBO_NO_COLOR", &out.Options.NoColor); err != nil {
 		return Resolved{}, err
 	}
+	if out.Sources["no-color"] == "default" && noColorEnv(env) {
+		out.Options.NoColor = true
+		out.Sources["no-color"] = "env"
+	}
 	if err := getDuration("timeout", "EBO_TIMEOUT", &out.Options.Timeout); err != nil {
 		return Resolved{}, err
 	}
 	if err := getBoolOne("verbose", "EBO_VERBOSE", &out.Options.Verbose); err != nil {
 		return Resolved{}, err
 	}
+	if err := getBoolOne("trace", "EBO_TRACE", &out.Options.Trace); err != nil {
+		return Resolved{}, err
+	}
+	if err := getString("har", "EBO_HAR", &out.Options.HAR); err != nil {
+		return Resolved{}, err
+	}
+	if err := getBoolOne("offline", "EBO_OFFLINE", &out.Options.Offline); err != nil {
+		return Resolved{}, err
+	}
+	if err := getBoolOne("wide", "EBO_WIDE", &out.Options.Wide); err != nil {
+		return Resolved{}, err
+	}
+	if err := getBoolOne("no-pager", "EBO_NO_PAGER", &out.Options.NoPager); err != nil {
+		return Resolved{}, err
+	}
+	// No flag: a per-deployment setting, from the environment or the profile.
+	if err := getString("request-id-header", "EBO_REQUEST_ID_HEADER", &out.Options.RequestIDHeader); err != nil {
+		return Resolved{}, err
+	}
+	// --query/--raw/--format are per-invocation: no environment equivalents.
+	if f := fs.Lookup("query"); f != nil && f.Changed {
+		out.Options.Query = f.Value.String()
+	}
+	if f := fs.Lookup("raw"); f != nil && f.Changed {
+		out.Options.Raw = f.Value.String() == "true"
+	}
+	if f := fs.Lookup("format"); f != nil && f.Changed {
+		out.Options.Format = f.Value.String()
+	}
+	if (out.Options.Query != "" || out.Options.Raw || out.Options.Format != "") && out.Sources["output"] == "default" {
+		outputStr = string(OutputJSON)
+	}
 
 	out.Options.Output = OutputFormat(strings.ToLower(strings.TrimSpace(outputStr)))
 	switch out.Options.Output {
-	case OutputTable, OutputJSON:
+	case OutputTable, OutputJSON, OutputYAML, OutputCSV, OutputTSV, OutputICS:
 		// ok
 	default:
-		return Resolved{}, fmt.Errorf("invalid --output %q (expected table|json)", outputStr)
+		return Resolved{}, fmt.Errorf("invalid --output %q (expected table|json|yaml|csv|tsv|ics)", outputStr)
 	}
 
 	if out.Options.Profile == "" {
@@ -171,6 +255,13 @@ func ResolveGlobalOptions(fs *pflag.FlagSet, env EnvProvider, defaults GlobalOpt
 	return out, nil
 }
 
+// noColorEnv reports whether the NO_COLOR convention (https://no-color.org)
+// asks for plain output: any non-empty value disables color.
+func noColorEnv(env EnvProvider) bool {
+	v, ok := env.LookupEnv("NO_COLOR")
+	return ok && v != ""
+}
+
 func parseTruthy(v string) (bool, error) {
 	v = strings.TrimSpace(v)
 	if v == "" {
@@ -1,6 +1,7 @@
 package cliopts
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -180,3 +181,104 @@ func TestOSEnv_LookupEnv(t *testing.T) {
 		t.Fatalf("expected yes, got %q", v)
 	}
 }
+
+func TestResolveGlobalOptions_TraceAndHARFromEnv(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	defaults := DefaultGlobalOptions()
+	AddGlobalFlags(fs, defaults)
+	if err := fs.Parse([]string{}); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	r, err := ResolveGlobalOptions(fs, MapEnv{"EBO_TRACE": "1", "EBO_HAR": "x.har"}, defaults)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if !r.Options.Trace || r.Options.HAR != "x.har" {
+		t.Fatalf("got %#v", r.Options)
+	}
+	if r.Sources["trace"] != "env" || r.Sources["har"] != "env" {
+		t.Fatalf("sources: got %#v", r.Sources)
+	}
+}
+
+func TestResolveGlobalOptions_NoColorConventionAndWide(t *testing.T) {
+	resolve := func(args []string, env MapEnv) Resolved {
+		t.Helper()
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		defaults := DefaultGlobalOptions()
+		AddGlobalFlags(fs, defaults)
+		if err := fs.Parse(args); err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		r, err := ResolveGlobalOptions(fs, env, defaults)
+		if err != nil {
+			t.Fatalf("resolve: %v", err)
+		}
+		return r
+	}
+
+	if r := resolve(nil, MapEnv{"NO_COLOR": "1", "EBO_WIDE": "1"}); !r.Options.NoColor || r.Sources["no-color"] != "env" || !r.Options.Wide {
+		t.Fatalf("NO_COLOR/EBO_WIDE: got %#v", r)
+	}
+	if r := resolve(nil, MapEnv{"NO_COLOR": ""}); r.Options.NoColor {
+		t.Fatalf("empty NO_COLOR must not disable color")
+	}
+	if r := resolve(nil, MapEnv{"NO_COLOR": "1", "EBO_NO_COLOR": "0"}); r.Options.NoColor {
+		t.Fatalf("EBO_NO_COLOR takes precedence over NO_COLOR")
+	}
+	if r := resolve([]string{"--wide"}, MapEnv{}); !r.Options.Wide || r.Sources["wide"] != "flag" {
+		t.Fatalf("--wide: got %#v", r)
+	}
+	if r := resolve([]string{"--no-pager"}, MapEnv{}); !r.Options.NoPager || r.Sources["no-pager"] != "flag" {
+		t.Fatalf("--no-pager: got %#v", r)
+	}
+	if r := resolve(nil, MapEnv{"EBO_NO_PAGER": "1"}); !r.Options.NoPager || r.Sources["no-pager"] != "env" {
+		t.Fatalf("EBO_NO_PAGER: got %#v", r)
+	}
+}
+
+func TestResolveGlobalOptions_OutputFormats(t *testing.T) {
+	for _, v := range []string{"yaml", "CSV", "tsv", "ics"} {
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		defaults := DefaultGlobalOptions()
+		AddGlobalFlags(fs, defaults)
+		_ = fs.Parse(nil)
+		r, err := ResolveGlobalOptions(fs, MapEnv{"EBO_OUTPUT": v}, defaults)
+		if err != nil {
+			t.Fatalf("%s: %v", v, err)
+		}
+		if string(r.Options.Output) != strings.ToLower(v) {
+			t.Fatalf("%s: got %q", v, r.Options.Output)
+		}
+	}
+	if !OutputYAML.Envelope() || OutputCSV.Envelope() || !OutputTSV.Delimited() || OutputJSON.Delimited() {
+		t.Fatalf("format predicates")
+	}
+}
+
+func TestResolveGlobalOptions_QueryImpliesJSON(t *testing.T) {
+	resolve := func(args ...string) Resolved {
+		t.Helper()
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		defaults := DefaultGlobalOptions()
+		AddGlobalFlags(fs, defaults)
+		if err := fs.Parse(args); err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		r, err := ResolveGlobalOptions(fs, MapEnv{}, defaults)
+		if err != nil {
+			t.Fatalf("resolve: %v", err)
+		}
+		return r
+	}
+	if r := resolve("--query", "trip.tripId", "--raw"); r.Options.Output != OutputJSON || r.Options.Query != "trip.tripId" || !r.Options.Raw {
+		t.Fatalf("got %#v", r.Options)
+	}
+	if r := resolve("--raw", "--output", "yaml"); r.Options.Output != OutputYAML {
+		t.Fatalf("explicit --output must win: %#v", r.Options)
+	}
+	if r := resolve("--format", "{{.TripId}}"); r.Options.Output != OutputJSON || r.Options.Format != "{{.TripId}}" {
+		t.Fatalf("--format: %#v", r.Options)
+	}
+}
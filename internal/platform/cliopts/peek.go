@@ -11,13 +11,24 @@ import (
 // entrypoint can decide whether to emit JSON output even when Cobra flag parsing
 // fails (e.g., unknown flag).
 func PeekGlobalOptions(args []string, env EnvProvider, defaults GlobalOptions) GlobalOptions {
+	return PeekResolved(args, env, defaults).Options
+}
+
+// PeekResolved is PeekGlobalOptions plus per-setting Sources ("flag", "env",
+// "default"), so the entrypoint can apply config precedence (e.g. the
+// config file's currentProfile) before Cobra runs.
+func PeekResolved(args []string, env EnvProvider, defaults GlobalOptions) Resolved {
 	opts := defaults
+	sources := map[string]string{}
 	outputSet := false
 	profileSet := false
 	apiURLSet := false
 	noColorSet := false
 	timeoutSet := false
 	verboseSet := false
+	traceSet := false
+	harSet := false
+	offlineSet := false
 
 	// flags first
 	for i := 0; i < len(args); i++ {
@@ -61,6 +72,47 @@ func PeekGlobalOptions(args []string, env EnvProvider, defaults GlobalOptions) G
 		case a == "--verbose":
 			opts.Verbose = true
 			verboseSet = true
+		case a == "--trace":
+			opts.Trace = true
+			traceSet = true
+		case a == "--har" && i+1 < len(args):
+			opts.HAR = args[i+1]
+			harSet = true
+			i++
+		case strings.HasPrefix(a, "--har="):
+			opts.HAR = strings.TrimPrefix(a, "--har=")
+			harSet = true
+		case a == "--offline":
+			opts.Offline = true
+			offlineSet = true
+		case a == "--query" && i+1 < len(args):
+			opts.Query = args[i+1]
+			i++
+		case strings.HasPrefix(a, "--query="):
+			opts.Query = strings.TrimPrefix(a, "--query=")
+		case a == "--raw":
+			opts.Raw = true
+		case a == "--format" && i+1 < len(args):
+			opts.Format = args[i+1]
+			i++
+		case strings.HasPrefix(a, "--format="):
+			opts.Format = strings.TrimPrefix(a, "--format=")
+		}
+	}
+
+	for name, set := range map[string]bool{
+		"api-url":  apiURLSet,
+		"profile":  profileSet,
+		"output":   outputSet,
+		"no-color": noColorSet,
+		"timeout":  timeoutSet,
+		"verbose":  verboseSet,
+		"trace":    traceSet,
+		"har":      harSet,
+		"offline":  offlineSet,
+	} {
+		if set {
+			sources[name] = "flag"
 		}
 	}
 
@@ -69,29 +121,37 @@ func PeekGlobalOptions(args []string, env EnvProvider, defaults GlobalOptions) G
 		if !apiURLSet {
 			if v, ok := env.LookupEnv("EBO_API_URL"); ok {
 				opts.APIURL = v
+				sources["api-url"] = "env"
 			}
 		}
 		if !profileSet {
 			if v, ok := env.LookupEnv("EBO_PROFILE"); ok {
 				opts.Profile = v
+				sources["profile"] = "env"
 			}
 		}
 		if !outputSet {
 			if v, ok := env.LookupEnv("EBO_OUTPUT"); ok {
 				opts.Output = OutputFormat(strings.ToLower(v))
+				sources["output"] = "env"
 			}
 		}
 		if !noColorSet {
 			if v, ok := env.LookupEnv("EBO_NO_COLOR"); ok {
 				if b, err := parseTruthy(v); err == nil {
 					opts.NoColor = b
+					sources["no-color"] = "env"
 				}
+			} else if noColorEnv(env) {
+				opts.NoColor = true
+				sources["no-color"] = "env"
 			}
 		}
 		if !timeoutSet {
 			if v, ok := env.LookupEnv("EBO_TIMEOUT"); ok {
 				if d, err := time.ParseDuration(v); err == nil {
 					opts.Timeout = d
+					sources["timeout"] = "env"
 				}
 			}
 		}
@@ -99,10 +159,45 @@ func PeekGlobalOptions(args []string, env EnvProvider, defaults GlobalOptions) G
 			if v, ok := env.LookupEnv("EBO_VERBOSE"); ok {
 				if b, err := parseTruthy(v); err == nil {
 					opts.Verbose = b
+					sources["verbose"] = "env"
+				}
+			}
+		}
+		if !traceSet {
+			if v, ok := env.LookupEnv("EBO_TRACE"); ok {
+				if b, err := parseTruthy(v); err == nil {
+					opts.Trace = b
+					sources["trace"] = "env"
 				}
 			}
 		}
+		if !harSet {
+			if v, ok := env.LookupEnv("EBO_HAR"); ok {
+				opts.HAR = v
+				sources["har"] = "env"
+			}
+		}
+		if !offlineSet {
+			if v, ok := env.LookupEnv("EBO_OFFLINE"); ok {
+				if b, err := parseTruthy(v); err == nil {
+					opts.Offline = b
+					sources["offline"] = "env"
+				}
+			}
+		}
+		if v, ok := env.LookupEnv("EBO_REQUEST_ID_HEADER"); ok {
+			opts.RequestIDHeader = v
+			sources["request-id-header"] = "env"
+		}
 	}
 
-	return opts
+	for _, name := range []string{"api-url", "profile", "output", "no-color", "timeout", "verbose", "trace", "har", "offline", "request-id-header"} {
+		if _, ok := sources[name]; !ok {
+			sources[name] = "default"
+		}
+	}
+	if (opts.Query != "" || opts.Raw || opts.Format != "") && sources["output"] == "default" {
+		opts.Output = OutputJSON
+	}
+	return Resolved{Options: opts, Sources: sources}
 }
@@ -1,6 +1,9 @@
 package cliopts
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestPeekGlobalOptions_FlagOverridesEnv(t *testing.T) {
 	defaults := DefaultGlobalOptions()
@@ -75,3 +78,84 @@ func TestPeekGlobalOptions_VerboseEnvFalse(t *testing.T) {
 		t.Fatalf("verbose: got %v", opts.Verbose)
 	}
 }
+
+func TestPeekGlobalOptions_TraceAndHAR(t *testing.T) {
+	defaults := DefaultGlobalOptions()
+	opts := PeekGlobalOptions([]string{"--trace", "--har", "out.har"}, MapEnv{}, defaults)
+	if !opts.Trace || opts.HAR != "out.har" {
+		t.Fatalf("got trace=%v har=%q", opts.Trace, opts.HAR)
+	}
+
+	opts = PeekGlobalOptions([]string{"--har=flag.har"}, MapEnv{"EBO_HAR": "env.har", "EBO_TRACE": "1"}, defaults)
+	if !opts.Trace || opts.HAR != "flag.har" {
+		t.Fatalf("got trace=%v har=%q", opts.Trace, opts.HAR)
+	}
+}
+
+func TestPeekResolved_Sources(t *testing.T) {
+	defaults := DefaultGlobalOptions()
+	r := PeekResolved([]string{"--profile", "p"}, MapEnv{"EBO_API_URL": "http://env"}, defaults)
+	if r.Sources["profile"] != "flag" || r.Sources["api-url"] != "env" || r.Sources["output"] != "default" {
+		t.Fatalf("sources: got %#v", r.Sources)
+	}
+	if r.Options.Profile != "p" || r.Options.APIURL != "http://env" {
+		t.Fatalf("options: got %#v", r.Options)
+	}
+}
+
+func TestPeekResolved_RequestIDHeader(t *testing.T) {
+	defaults := DefaultGlobalOptions()
+	if r := PeekResolved(nil, MapEnv{}, defaults); r.Sources["request-id-header"] != "default" {
+		t.Fatalf("got %#v", r.Sources)
+	}
+	r := PeekResolved(nil, MapEnv{"EBO_REQUEST_ID_HEADER": "X-Trace"}, defaults)
+	if r.Options.RequestIDHeader != "X-Trace" || r.Sources["request-id-header"] != "env" {
+		t.Fatalf("got %#v", r)
+	}
+}
+
+func TestPeekGlobalOptions_Offline(t *testing.T) {
+	defaults := DefaultGlobalOptions()
+	if !PeekGlobalOptions([]string{"--offline"}, MapEnv{}, defaults).Offline {
+		t.Fatalf("expected offline from flag")
+	}
+	r := PeekResolved(nil, MapEnv{"EBO_OFFLINE": "1"}, defaults)
+	if !r.Options.Offline || r.Sources["offline"] != "env" {
+		t.Fatalf("got %#v", r)
+	}
+}
+
+func TestPeekGlobalOptions_NoColorConvention(t *testing.T) {
+	defaults := DefaultGlobalOptions()
+	if !PeekGlobalOptions(nil, MapEnv{"NO_COLOR": "1"}, defaults).NoColor {
+		t.Fatalf("expected NO_COLOR to disable color")
+	}
+}
+
+func TestPeekGlobalOptions_AcceptsAllOutputFormats(t *testing.T) {
+	defaults := DefaultGlobalOptions()
+	for _, f := range []OutputFormat{OutputTable, OutputJSON, OutputYAML, OutputCSV, OutputTSV} {
+		if got := PeekGlobalOptions([]string{"--output=" + strings.ToUpper(string(f))}, MapEnv{}, defaults).Output; got != f {
+			t.Errorf("--output %s: got %q", f, got)
+		}
+		if got := PeekGlobalOptions(nil, MapEnv{"EBO_OUTPUT": string(f)}, defaults).Output; got != f {
+			t.Errorf("EBO_OUTPUT=%s: got %q", f, got)
+		}
+	}
+}
+
+func TestPeekGlobalOptions_QueryAndRaw(t *testing.T) {
+	opts := PeekGlobalOptions([]string{"trip", "get", "t1", "--query", "--output", "--raw"}, MapEnv{}, DefaultGlobalOptions())
+	if opts.Query != "--output" || !opts.Raw || opts.Output != OutputJSON {
+		t.Fatalf("got %#v", opts)
+	}
+}
+
+func TestPeekGlobalOptions_Format(t *testing.T) {
+	for _, args := range [][]string{{"trip", "list", "--format", "{{.TripId}}"}, {"trip", "list", "--format={{.TripId}}"}} {
+		opts := PeekGlobalOptions(args, MapEnv{}, DefaultGlobalOptions())
+		if opts.Format != "{{.TripId}}" || opts.Output != OutputJSON {
+			t.Fatalf("%v: got %#v", args, opts)
+		}
+	}
+}
@@ -27,6 +27,7 @@ func ViewOf(doc Document) (View, error) {
 			if au := mapGet(pv, "apiUrl"); au != nil && au.Kind == yaml.ScalarNode {
 				p.APIURL = au.Value
 			}
+			p.RequestIDHeader = scalarValue(pv, "requestIdHeader")
 			v.Profiles[k.Value] = p
 		}
 	}
@@ -34,6 +35,16 @@ func ViewOf(doc Document) (View, error) {
 	return v, nil
 }
 
+// PagerOf returns the top-level pager setting ("" if unset): a pager command
+// line, or "off".
+func PagerOf(doc Document) (string, error) {
+	root, err := rootMapping(doc)
+	if err != nil {
+		return "", err
+	}
+	return scalarValue(root, "pager"), nil
+}
+
 func WithCurrentProfile(doc Document, profile string) (Document, error) {
 	root, err := rootMapping(doc)
 	if err != nil {
@@ -22,5 +22,6 @@ type View struct {
 }
 
 type ProfileView struct {
-	APIURL string
+	APIURL          string
+	RequestIDHeader string
 }
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TLSConfig holds profiles.<name>.tls.
+type TLSConfig struct {
+	CAFile             string
+	ClientCert         string
+	ClientKey          string
+	ServerName         string
+	InsecureSkipVerify bool
+}
+
+// NetworkConfig holds per-profile transport settings (profiles.<name>.tls and
+// profiles.<name>.proxy).
+type NetworkConfig struct {
+	TLS   TLSConfig
+	Proxy string
+}
+
+// NetworkOf returns the transport settings for profile.
+//
+// Unlike OIDCOf, every key is optional: a missing profile or missing keys yield
+// the zero value (system roots, proxy from environment).
+func NetworkOf(doc Document, profile string) (NetworkConfig, error) {
+	root, err := rootMapping(doc)
+	if err != nil {
+		return NetworkConfig{}, err
+	}
+	profiles := mapGet(root, "profiles")
+	if profiles == nil || profiles.Kind != yaml.MappingNode {
+		return NetworkConfig{}, nil
+	}
+	pnode := mapGet(profiles, profile)
+	if pnode == nil || pnode.Kind != yaml.MappingNode {
+		return NetworkConfig{}, nil
+	}
+
+	out := NetworkConfig{Proxy: scalarValue(pnode, "proxy")}
+	tls := mapGet(pnode, "tls")
+	if tls == nil {
+		return out, nil
+	}
+	if tls.Kind != yaml.MappingNode {
+		return NetworkConfig{}, fmt.Errorf("profiles.%s.tls: expected a mapping", profile)
+	}
+	out.TLS = TLSConfig{
+		CAFile:     scalarValue(tls, "caFile"),
+		ClientCert: scalarValue(tls, "clientCert"),
+		ClientKey:  scalarValue(tls, "clientKey"),
+		ServerName: scalarValue(tls, "serverName"),
+	}
+	if v := scalarValue(tls, "insecureSkipVerify"); v != "" {
+		// `ebo config set` stores strings, so accept "true" as well as a YAML bool.
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return NetworkConfig{}, fmt.Errorf("profiles.%s.tls.insecureSkipVerify: invalid boolean %q", profile, v)
+		}
+		out.TLS.InsecureSkipVerify = b
+	}
+	return out, nil
+}
+
+func scalarValue(m *yaml.Node, key string) string {
+	n := mapGet(m, key)
+	if n == nil || n.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return strings.TrimSpace(n.Value)
+}
@@ -0,0 +1,56 @@
+package config
+
+import "testing"
+
+func TestNetworkOf_MissingProfileIsZero(t *testing.T) {
+	got, err := NetworkOf(NewEmptyDocument(), "default")
+	if err != nil {
+		t.Fatalf("network: %v", err)
+	}
+	if got != (NetworkConfig{}) {
+		t.Fatalf("expected zero value, got %#v", got)
+	}
+}
+
+func TestNetworkOf_ReadsTLSAndProxy(t *testing.T) {
+	doc := NewEmptyDocument()
+	for k, v := range map[string]string{
+		"profiles.staging.proxy":                  "http://proxy:3128",
+		"profiles.staging.tls.caFile":             "/etc/ca.pem",
+		"profiles.staging.tls.clientCert":         "/etc/client.pem",
+		"profiles.staging.tls.clientKey":          "/etc/client.key",
+		"profiles.staging.tls.serverName":         "api.internal",
+		"profiles.staging.tls.insecureSkipVerify": "true",
+	} {
+		var err error
+		doc, err = SetString(doc, k, v)
+		if err != nil {
+			t.Fatalf("set %s: %v", k, err)
+		}
+	}
+
+	got, err := NetworkOf(doc, "staging")
+	if err != nil {
+		t.Fatalf("network: %v", err)
+	}
+	want := NetworkConfig{
+		Proxy: "http://proxy:3128",
+		TLS: TLSConfig{
+			CAFile:             "/etc/ca.pem",
+			ClientCert:         "/etc/client.pem",
+			ClientKey:          "/etc/client.key",
+			ServerName:         "api.internal",
+			InsecureSkipVerify: true,
+		},
+	}
+	if got != want {
+		t.Fatalf("got %#v want %#v", got, want)
+	}
+}
+
+func TestNetworkOf_InvalidInsecureSkipVerify(t *testing.T) {
+	doc, _ := SetString(NewEmptyDocument(), "profiles.default.tls.insecureSkipVerify", "maybe")
+	if _, err := NetworkOf(doc, "default"); err == nil {
+		t.Fatalf("expected error")
+	}
+}
@@ -5,6 +5,9 @@ import "github.com/Overland-East-Bay/trip-planner-cli/internal/platform/cliopts"
 type Effective struct {
 	Profile string
 	APIURL  string
+	// RequestIDHeader is "" when neither EBO_REQUEST_ID_HEADER nor the
+	// profile sets one.
+	RequestIDHeader string
 }
 
 // ResolveEffective combines CLI options (including their source) with config-file
@@ -13,7 +16,8 @@ type Effective struct {
 // Precedence (highest -> lowest):
 //  1. CLI flags
 //  2. env vars
-//  3. config file (currentProfile + profiles.<name>.apiUrl)
+//  3. config file (currentProfile + profiles.<name>.apiUrl and
+//     .requestIdHeader)
 //  4. defaults
 func ResolveEffective(cli cliopts.Resolved, cfg View) Effective {
 	profile := cli.Options.Profile
@@ -28,5 +32,12 @@ func ResolveEffective(cli cliopts.Resolved, cfg View) Effective {
 		}
 	}
 
-	return Effective{Profile: profile, APIURL: apiURL}
+	requestIDHeader := cli.Options.RequestIDHeader
+	if cli.Sources["request-id-header"] == "default" {
+		if p, ok := cfg.Profiles[profile]; ok && p.RequestIDHeader != "" {
+			requestIDHeader = p.RequestIDHeader
+		}
+	}
+
+	return Effective{Profile: profile, APIURL: apiURL, RequestIDHeader: requestIDHeader}
 }
@@ -92,3 +92,23 @@ func TestResolveEffective_IgnoresOtherClioptsFields(t *testing.T) {
 	}
 	_ = ResolveEffective(r, View{})
 }
+
+func TestResolveEffective_RequestIDHeaderFromEnvThenProfile(t *testing.T) {
+	cfg := View{Profiles: map[string]ProfileView{"default": {APIURL: "https://api", RequestIDHeader: "X-Correlation-Id"}}}
+	if got := ResolveEffective(resolvedFromArgs(t, nil), cfg).RequestIDHeader; got != "X-Correlation-Id" {
+		t.Fatalf("from profile: got %q", got)
+	}
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	cliopts.AddGlobalFlags(fs, cliopts.DefaultGlobalOptions())
+	cli, err := cliopts.ResolveGlobalOptions(fs, cliopts.MapEnv{"EBO_REQUEST_ID_HEADER": "X-Trace"}, cliopts.DefaultGlobalOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ResolveEffective(cli, cfg).RequestIDHeader; got != "X-Trace" {
+		t.Fatalf("env should override the profile: got %q", got)
+	}
+	if got := ResolveEffective(resolvedFromArgs(t, nil), View{}).RequestIDHeader; got != "" {
+		t.Fatalf("unset: got %q", got)
+	}
+}
@@ -1,9 +1,14 @@
 package editmode
 
 import (
+	"encoding/json"
+	"net/url"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/apispec"
 )
 
 // ResolveEditor returns the editor command name and args per CLI spec:
@@ -63,3 +68,54 @@ func EditTemp(yamlTemplate string) ([]byte, error) {
 
 	return EditFile(name)
 }
+
+// EditRequest is EditTemp for a request body: the template is saved as .yaml
+// next to the JSON Schema of the named pinned OpenAPI type (e.g.
+// "UpdateTripRequest"), and starts with a yaml-language-server modeline
+// pointing at it, so editors with YAML support validate and autocomplete.
+func EditRequest(schemaName, yamlTemplate string) ([]byte, error) {
+	spec, err := apispec.Pinned()
+	if err != nil {
+		return nil, err
+	}
+	schema, err := spec.JSONSchema(schemaName)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "ebo-edit-*")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	schemaPath := filepath.Join(dir, schemaName+".schema.json")
+	if err := os.WriteFile(schemaPath, append(b, '\n'), 0o600); err != nil {
+		return nil, err
+	}
+	name := filepath.Join(dir, schemaName+".yaml")
+	if yamlTemplate == "" {
+		yamlTemplate = "{}\n"
+	}
+	if !strings.HasSuffix(yamlTemplate, "\n") {
+		yamlTemplate += "\n"
+	}
+	if err := os.WriteFile(name, []byte(Modeline(schemaPath)+yamlTemplate), 0o600); err != nil {
+		return nil, err
+	}
+	return EditFile(name)
+}
+
+// Modeline is the yaml-language-server comment line that binds a YAML file to
+// the JSON Schema at schemaPath.
+func Modeline(schemaPath string) string {
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(schemaPath)}
+	if !strings.HasPrefix(u.Path, "/") {
+		u.Path = "/" + u.Path // Windows: C:/... becomes file:///C:/...
+	}
+	return "# yaml-language-server: $schema=" + u.String() + "\n"
+}
@@ -3,6 +3,7 @@ package editmode
 import (
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -131,3 +132,47 @@ func TestEditFile_RunErrorIsReturned(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestEditRequest_WritesSchemaAndModeline(t *testing.T) {
+	old := execCommand
+	t.Cleanup(func() { execCommand = old })
+	t.Setenv("EBO_EDITOR", "fake-editor")
+
+	var seenPath, seen, schema string
+	execCommand = func(name string, args ...string) *exec.Cmd {
+		seenPath = args[0]
+		b, err := os.ReadFile(seenPath)
+		if err != nil {
+			t.Fatalf("read template: %v", err)
+		}
+		seen = string(b)
+		s, err := os.ReadFile(filepath.Join(filepath.Dir(seenPath), "UpdateTripRequest.schema.json"))
+		if err != nil {
+			t.Fatalf("read schema: %v", err)
+		}
+		schema = string(s)
+		return exec.Command("true")
+	}
+
+	b, err := EditRequest("UpdateTripRequest", "name: x")
+	if err != nil {
+		t.Fatalf("EditRequest: %v", err)
+	}
+	schemaURL := "file://" + filepath.ToSlash(filepath.Join(filepath.Dir(seenPath), "UpdateTripRequest.schema.json"))
+	if want := "# yaml-language-server: $schema=" + schemaURL + "\nname: x\n"; seen != want || string(b) != want {
+		t.Fatalf("template:\n%q\nwant:\n%q", seen, want)
+	}
+	if filepath.Ext(seenPath) != ".yaml" {
+		t.Fatalf("template path %q should end in .yaml", seenPath)
+	}
+	if !strings.Contains(schema, `"title": "UpdateTripRequest"`) {
+		t.Fatalf("schema: %s", schema)
+	}
+	if _, err := os.Stat(filepath.Dir(seenPath)); err == nil {
+		t.Fatalf("expected temp dir removed")
+	}
+
+	if _, err := EditRequest("Nope", ""); err == nil {
+		t.Fatal("expected an error for an unknown schema")
+	}
+}
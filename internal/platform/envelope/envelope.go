@@ -1,8 +1,11 @@
 package envelope
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
+
+	"gopkg.in/yaml.v3"
 )
 
 type Meta struct {
@@ -10,11 +13,18 @@ type Meta struct {
 	Profile        string `json:"profile"`
 	IdempotencyKey string `json:"idempotencyKey,omitempty"`
 	RequestID      string `json:"requestId,omitempty"`
+	// Cached is true when data was served from the local response cache
+	// (--offline); FetchedAt (RFC3339) is when it was fetched from the API.
+	Cached    bool   `json:"cached,omitempty"`
+	FetchedAt string `json:"fetchedAt,omitempty"`
 }
 
 type ErrorBody struct {
 	Code    string `json:"code,omitempty"`
 	Message string `json:"message"`
+	// Details carries the API's error.details as-is (e.g. per-field
+	// validation problems).
+	Details map[string]any `json:"details,omitempty"`
 }
 
 type Envelope struct {
@@ -28,3 +38,67 @@ func WriteJSON(w io.Writer, env Envelope) error {
 	enc.SetEscapeHTML(false)
 	return enc.Encode(env)
 }
+
+// WriteYAML writes env as YAML with the same keys, key order and values as
+// WriteJSON, so scripts can switch formats without remapping fields.
+func WriteYAML(w io.Writer, env Envelope) error {
+	var b bytes.Buffer
+	if err := WriteJSON(&b, env); err != nil {
+		return err
+	}
+	// JSON is YAML: decoding it into a node keeps key order and types.
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b.Bytes(), &doc); err != nil {
+		return err
+	}
+	blockStyle(&doc)
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// blockStyle drops the flow/quoted styles the JSON input implies; the encoder
+// still quotes strings that would otherwise read as another type.
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
+
+// JSONSchema describes an envelope whose data matches data (itself a JSON
+// Schema): the shape scripts get from --output json.
+func JSONSchema(data map[string]any) map[string]any {
+	str := map[string]any{"type": "string"}
+	return map[string]any{
+		"type":     "object",
+		"required": []string{"meta"},
+		"properties": map[string]any{
+			"data": data,
+			"meta": map[string]any{
+				"type":     "object",
+				"required": []string{"apiUrl", "profile"},
+				"properties": map[string]any{
+					"apiUrl":         str,
+					"profile":        str,
+					"idempotencyKey": str,
+					"requestId":      str,
+					"cached":         map[string]any{"type": "boolean"},
+					"fetchedAt":      map[string]any{"type": "string", "format": "date-time"},
+				},
+			},
+			"error": map[string]any{
+				"type":     "object",
+				"required": []string{"message"},
+				"properties": map[string]any{
+					"code":    str,
+					"message": str,
+					"details": map[string]any{"type": "object"},
+				},
+			},
+		},
+	}
+}
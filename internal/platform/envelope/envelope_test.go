@@ -3,6 +3,9 @@ package envelope
 import (
 	"bytes"
 	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -52,3 +55,60 @@ func TestWriteJSON_ErrorEnvelope(t *testing.T) {
 		t.Fatalf("message: got %q", got.Error.Message)
 	}
 }
+
+func TestWriteYAML_MirrorsJSONEnvelope(t *testing.T) {
+	buf := &bytes.Buffer{}
+	env := Envelope{
+		Data: map[string]any{
+			"trips": []map[string]any{{"tripId": "t1", "name": nil, "capacityRigs": 12, "zip": "94110", "notes": "line one\nline two"}},
+		},
+		Meta: Meta{APIURL: "http://x", Profile: "default", IdempotencyKey: "k1"},
+	}
+	if err := WriteYAML(buf, env); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	want := `data:
+  trips:
+    - capacityRigs: 12
+      name: null
+      notes: |-
+        line one
+        line two
+      tripId: t1
+      zip: "94110"
+meta:
+  apiUrl: http://x
+  profile: default
+  idempotencyKey: k1
+`
+	if buf.String() != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestJSONSchema_CoversEveryField(t *testing.T) {
+	props := JSONSchema(map[string]any{})["properties"].(map[string]any)
+	for key, typ := range map[string]reflect.Type{
+		"":      reflect.TypeOf(Envelope{}),
+		"meta":  reflect.TypeOf(Meta{}),
+		"error": reflect.TypeOf(ErrorBody{}),
+	} {
+		p := props
+		if key != "" {
+			p = props[key].(map[string]any)["properties"].(map[string]any)
+		}
+		var fields, keys []string
+		for i := 0; i < typ.NumField(); i++ {
+			name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+			fields = append(fields, name)
+		}
+		for k := range p {
+			keys = append(keys, k)
+		}
+		slices.Sort(fields)
+		slices.Sort(keys)
+		if !slices.Equal(fields, keys) {
+			t.Errorf("%s: schema properties %v, want %v", typ.Name(), keys, fields)
+		}
+	}
+}
@@ -21,13 +21,16 @@ const (
 type Kind string
 
 const (
-	KindUnexpected  Kind = "unexpected"
-	KindUsage       Kind = "usage"
-	KindAuth        Kind = "auth"
-	KindNotFound    Kind = "not_found"
-	KindConflict    Kind = "conflict"
-	KindValidation  Kind = "validation"
-	KindServer      Kind = "server"
+	KindUnexpected Kind = "unexpected"
+	KindUsage      Kind = "usage"
+	KindAuth       Kind = "auth"
+	KindNotFound   Kind = "not_found"
+	KindConflict   Kind = "conflict"
+	KindValidation Kind = "validation"
+	KindServer     Kind = "server"
+	// KindNetwork is a transport failure (DNS, refused, TLS, timeout, proxy).
+	// It shares exit code 7 with server errors; Error.Code tells them apart.
+	KindNetwork     Kind = "network"
 	KindInterrupted Kind = "interrupted"
 )
 
@@ -35,6 +38,30 @@ type Error struct {
 	Kind Kind
 	Msg  string
 	Err  error
+
+	// Code is a stable machine-readable code for error.code in JSON envelopes
+	// (e.g. "connection_refused"). Empty means the Kind is used.
+	Code string
+
+	// Hint is actionable guidance for humans (rendered after the message as
+	// "Try:"). It is not part of Error().
+	Hint string
+
+	// Context is what the command had resolved when it failed; nil when the
+	// error was returned before resolution.
+	Context *Context
+
+	// Data is a partial result that belongs in the JSON error envelope's
+	// data (e.g. the checks `ebo doctor` ran before reporting failure).
+	Data any
+}
+
+// Context describes the invocation an error came from, so error envelopes can
+// report the same meta as success envelopes.
+type Context struct {
+	Profile        string
+	APIURL         string
+	IdempotencyKey string
 }
 
 func (e *Error) Error() string {
@@ -56,6 +83,116 @@ func New(kind Kind, msg string, err error) *Error {
 	return &Error{Kind: kind, Msg: msg, Err: err}
 }
 
+// WithHint returns err annotated with a "Try:" hint, keeping its exit code.
+// A nil err or empty hint returns err unchanged.
+func WithHint(err error, hint string) error {
+	if err == nil || hint == "" {
+		return err
+	}
+	kind := KindUnexpected
+	var e *Error
+	if errors.As(err, &e) {
+		kind = e.Kind
+	}
+	return &Error{Kind: kind, Err: err, Hint: hint}
+}
+
+// HintOf returns the outermost hint attached to err, if any.
+func HintOf(err error) string {
+	for err != nil {
+		var e *Error
+		if !errors.As(err, &e) {
+			return ""
+		}
+		if e.Hint != "" {
+			return e.Hint
+		}
+		err = e.Err
+	}
+	return ""
+}
+
+// WithContext returns err annotated with c, keeping its exit code and hint.
+// A nil err returns nil.
+func WithContext(err error, c Context) error {
+	if err == nil {
+		return nil
+	}
+	kind := KindUnexpected
+	var e *Error
+	if errors.As(err, &e) {
+		kind = e.Kind
+	}
+	return &Error{Kind: kind, Err: err, Context: &c}
+}
+
+// ContextOf returns the outermost context attached to err.
+func ContextOf(err error) (Context, bool) {
+	for err != nil {
+		var e *Error
+		if !errors.As(err, &e) {
+			return Context{}, false
+		}
+		if e.Context != nil {
+			return *e.Context, true
+		}
+		err = e.Err
+	}
+	return Context{}, false
+}
+
+// WithData returns err annotated with data for the error envelope, keeping
+// its exit code. A nil err returns nil.
+func WithData(err error, data any) error {
+	if err == nil {
+		return nil
+	}
+	kind := KindUnexpected
+	var e *Error
+	if errors.As(err, &e) {
+		kind = e.Kind
+	}
+	return &Error{Kind: kind, Err: err, Data: data}
+}
+
+// DataOf returns the outermost data attached to err, or nil.
+func DataOf(err error) any {
+	for err != nil {
+		var e *Error
+		if !errors.As(err, &e) {
+			return nil
+		}
+		if e.Data != nil {
+			return e.Data
+		}
+		err = e.Err
+	}
+	return nil
+}
+
+// ErrorCode returns the outermost Error.Code in err, or its Kind, or
+// KindUnexpected for foreign errors.
+func ErrorCode(err error) string {
+	kind := ""
+	for err != nil {
+		var e *Error
+		if !errors.As(err, &e) {
+			break
+		}
+		if e.Code != "" {
+			return e.Code
+		}
+		if kind == "" {
+			kind = string(e.Kind)
+		}
+		err = e.Err
+	}
+	if kind == "" {
+		return string(KindUnexpected)
+	}
+	return kind
+}
+
 func Code(err error) int {
 	if err == nil {
 		return Success
@@ -73,7 +210,7 @@ func Code(err error) int {
 			return Conflict
 		case KindValidation:
 			return Validation
-		case KindServer:
+		case KindServer, KindNetwork:
 			return Server
 		case KindInterrupted:
 			return Interrupted
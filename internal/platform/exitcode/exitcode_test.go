@@ -18,6 +18,7 @@ func TestCode(t *testing.T) {
 		{"conflict", New(KindConflict, "dup", nil), Conflict},
 		{"validation", New(KindValidation, "invalid", nil), Validation},
 		{"server", New(KindServer, "down", nil), Server},
+		{"network", New(KindNetwork, "refused", nil), Server},
 		{"interrupted", New(KindInterrupted, "sigint", nil), Interrupted},
 		{"unexpected", New(KindUnexpected, "boom", nil), Unexpected},
 		{"plain error", errors.New("x"), Unexpected},
@@ -53,3 +54,71 @@ func TestErrorFormattingAndUnwrap(t *testing.T) {
 		t.Fatalf("error: got %q", e3.Error())
 	}
 }
+
+func TestWithHint(t *testing.T) {
+	base := New(KindValidation, "invalid", nil)
+	err := WithHint(base, "ebo trip update t1")
+	if Code(err) != Validation {
+		t.Fatalf("expected kind preserved, got %d", Code(err))
+	}
+	if err.Error() != "invalid" {
+		t.Fatalf("hint must not change the message, got %q", err.Error())
+	}
+	if HintOf(err) != "ebo trip update t1" {
+		t.Fatalf("hint: got %q", HintOf(err))
+	}
+	if HintOf(base) != "" || HintOf(errors.New("x")) != "" {
+		t.Fatalf("expected no hint")
+	}
+	if WithHint(nil, "x") != nil || WithHint(base, "") != error(base) {
+		t.Fatalf("expected passthrough")
+	}
+	if Code(WithHint(errors.New("x"), "y")) != Unexpected {
+		t.Fatalf("plain errors stay unexpected")
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	base := WithHint(New(KindConflict, "dup", nil), "retry")
+	err := WithContext(base, Context{Profile: "staging", IdempotencyKey: "k"})
+	if Code(err) != Conflict || HintOf(err) != "retry" || err.Error() != "dup" {
+		t.Fatalf("wrapping must keep code/hint/message: %d %q %q", Code(err), HintOf(err), err.Error())
+	}
+	c, ok := ContextOf(err)
+	if !ok || c.Profile != "staging" || c.IdempotencyKey != "k" {
+		t.Fatalf("context: %#v %v", c, ok)
+	}
+	if _, ok := ContextOf(base); ok {
+		t.Fatalf("expected no context")
+	}
+	if WithContext(nil, Context{}) != nil {
+		t.Fatalf("expected nil")
+	}
+}
+
+func TestWithData(t *testing.T) {
+	base := WithHint(New(KindAuth, "no token", nil), "ebo auth login")
+	err := WithData(base, []string{"checks"})
+	if Code(err) != Auth || HintOf(err) != "ebo auth login" || err.Error() != "no token" {
+		t.Fatalf("wrapping must keep code/hint/message: %d %q %q", Code(err), HintOf(err), err.Error())
+	}
+	if d, ok := DataOf(err).([]string); !ok || len(d) != 1 {
+		t.Fatalf("data: %#v", DataOf(err))
+	}
+	if DataOf(base) != nil || WithData(nil, 1) != nil {
+		t.Fatalf("expected no data")
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	if got := ErrorCode(New(KindNetwork, "refused", nil)); got != "network" {
+		t.Fatalf("kind fallback: %q", got)
+	}
+	coded := &Error{Kind: KindNetwork, Code: "connection_refused"}
+	if got := ErrorCode(WithHint(coded, "start it")); got != "connection_refused" {
+		t.Fatalf("nested code: %q", got)
+	}
+	if got := ErrorCode(errors.New("x")); got != "unexpected" {
+		t.Fatalf("foreign: %q", got)
+	}
+}
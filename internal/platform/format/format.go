@@ -0,0 +1,252 @@
+// Package format renders command output through a --format Go template,
+// one line per list item (or one for a single result).
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// dateLayout is the wire format of calendar dates (plannerapi.DateLayout).
+const dateLayout = "2006-01-02"
+
+// Template is a parsed --format template.
+type Template struct {
+	t *template.Template
+}
+
+// Parse parses spec, or the file it names when it starts with "@"
+// (e.g. @trips.tmpl).
+func Parse(spec string) (*Template, error) {
+	text := spec
+	if path, ok := strings.CutPrefix(spec, "@"); ok {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		text = string(b)
+	}
+	t, err := template.New("format").Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	for _, tt := range t.Templates() {
+		printEmpty(tt.Root)
+	}
+	return &Template{t: t}, nil
+}
+
+// printEmptyFunc ends every printing action, so a missing or null field
+// prints as "" instead of text/template's "<no value>".
+const printEmptyFunc = "printEmpty"
+
+// printEmpty appends printEmptyFunc to the pipeline of every action under n
+// that prints (those not declaring variables).
+func printEmpty(n parse.Node) {
+	switch n := n.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			printEmpty(c)
+		}
+	case *parse.ActionNode:
+		if len(n.Pipe.Decl) == 0 {
+			n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+				NodeType: parse.NodeCommand,
+				Pos:      n.Pos,
+				Args:     []parse.Node{parse.NewIdentifier(printEmptyFunc).SetPos(n.Pos)},
+			})
+		}
+	case *parse.IfNode:
+		printEmpty(n.List)
+		printEmpty(n.ElseList)
+	case *parse.RangeNode:
+		printEmpty(n.List)
+		printEmpty(n.ElseList)
+	case *parse.WithNode:
+		printEmpty(n.List)
+		printEmpty(n.ElseList)
+	}
+}
+
+// Execute renders data as it would be encoded to JSON, with field names
+// capitalized (tripId is .TripId). A list, or an object whose only field is a
+// list (e.g. {"trips": [...]}), renders once per item; an object whose only
+// field is an object (e.g. {"trip": {...}}) renders that object. Each
+// rendering ends with a newline. Nothing is written if any item fails.
+func (t *Template) Execute(w io.Writer, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+	for _, item := range items(mapKeys(doc, exported)) {
+		var line bytes.Buffer
+		if err := t.t.Execute(&line, item); err != nil {
+			return err
+		}
+		s := line.String()
+		if !strings.HasSuffix(s, "\n") {
+			s += "\n"
+		}
+		out.WriteString(s)
+	}
+	_, err = w.Write(out.Bytes())
+	return err
+}
+
+func items(doc any) []any {
+	if m, ok := doc.(map[string]any); ok && len(m) == 1 {
+		for _, v := range m {
+			switch v.(type) {
+			case []any, map[string]any:
+				doc = v
+			}
+		}
+	}
+	if list, ok := doc.([]any); ok {
+		return list
+	}
+	return []any{doc}
+}
+
+// mapKeys returns v with every object key renamed by f.
+func mapKeys(v any, f func(string) string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[f(k)] = mapKeys(e, f)
+		}
+		return m
+	case []any:
+		l := make([]any, len(v))
+		for i, e := range v {
+			l[i] = mapKeys(e, f)
+		}
+		return l
+	default:
+		return v
+	}
+}
+
+func exported(k string) string   { return withFirstRune(k, unicode.ToUpper) }
+func unexported(k string) string { return withFirstRune(k, unicode.ToLower) }
+
+func withFirstRune(s string, f func(rune) rune) string {
+	r, n := utf8.DecodeRuneInString(s)
+	if n == 0 {
+		return s
+	}
+	return string(f(r)) + s[n:]
+}
+
+var funcs = template.FuncMap{
+	"date":     dateFunc,
+	"default":  defaultFunc,
+	"join":     joinFunc,
+	"json":     jsonFunc,
+	"upper":    func(v any) string { return strings.ToUpper(str(v)) },
+	"truncate": truncateFunc,
+
+	printEmptyFunc: func(v any) any {
+		if v == nil {
+			return ""
+		}
+		return v
+	},
+}
+
+// dateFunc formats a YYYY-MM-DD or RFC 3339 value with a Go layout:
+// {{.StartDate | date "Mon Jan 2"}}. Null is "".
+func dateFunc(layout string, v any) (string, error) {
+	s := str(v)
+	if s == "" {
+		return "", nil
+	}
+	for _, in := range []string{dateLayout, time.RFC3339Nano} {
+		if t, err := time.Parse(in, s); err == nil {
+			return t.Format(layout), nil
+		}
+	}
+	return "", fmt.Errorf("date: %q is not a date", s)
+}
+
+// defaultFunc returns def when v is null or empty: {{.Name | default "-"}}.
+func defaultFunc(def, v any) any {
+	if str(v) == "" {
+		return def
+	}
+	return v
+}
+
+// joinFunc joins a list's items with sep: {{.Tags | join ", "}}.
+func joinFunc(sep string, v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case []any:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = str(e)
+		}
+		return strings.Join(parts, sep), nil
+	default:
+		return "", fmt.Errorf("join: %s is not a list", jsonOf(v))
+	}
+}
+
+// jsonFunc encodes v as compact JSON with its original field names.
+func jsonFunc(v any) (string, error) {
+	b, err := json.Marshal(mapKeys(v, unexported))
+	return string(b), err
+}
+
+// truncateFunc shortens v to n characters, ending in "…" when cut:
+// {{.Name | truncate 20}}.
+func truncateFunc(n int, v any) string {
+	s := str(v)
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
+
+// str is v as printed: null is "", objects and lists are JSON.
+func str(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case map[string]any, []any:
+		return jsonOf(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func jsonOf(v any) string {
+	s, _ := jsonFunc(v)
+	return s
+}
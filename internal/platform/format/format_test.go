@@ -0,0 +1,108 @@
+package format
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type trip struct {
+	TripID    string   `json:"tripId"`
+	Name      *string  `json:"name"`
+	StartDate *string  `json:"startDate"`
+	Tags      []string `json:"tags,omitempty"`
+}
+
+func execute(t *testing.T, spec string, data any) string {
+	t.Helper()
+	tmpl, err := Parse(spec)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	return b.String()
+}
+
+func TestExecute_OncePerListItem(t *testing.T) {
+	name, start := "Rubicon", "2026-05-02"
+	data := map[string]any{"trips": []trip{{TripID: "t1", Name: &name, StartDate: &start}, {TripID: "t2"}}}
+
+	got := execute(t, `{{.TripId}} {{.Name}} {{.StartDate | date "Jan 2"}}`, data)
+	if want := "t1 Rubicon May 2\nt2  \n"; got != want {
+		t.Fatalf("got %q want %q", got, want)
+	}
+}
+
+func TestExecute_SingleResultObject(t *testing.T) {
+	data := map[string]any{"trip": trip{TripID: "t1"}}
+	if got := execute(t, `{{.TripId}}`, data); got != "t1\n" {
+		t.Fatalf("got %q", got)
+	}
+	// Several fields: the template sees the whole object.
+	data = map[string]any{"trip": trip{TripID: "t1"}, "announcementCopy": "Hi"}
+	if got := execute(t, "{{.Trip.TripId}}: {{.AnnouncementCopy}}\n", data); got != "t1: Hi\n" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestExecute_Helpers(t *testing.T) {
+	name := "Rubicon Trail Spring Run"
+	data := trip{TripID: "t1", Name: &name, Tags: []string{"rocks", "camping"}}
+
+	cases := map[string]string{
+		`{{.Name | truncate 10}}`:                   "Rubicon T…",
+		`{{.Name | upper}}`:                         "RUBICON TRAIL SPRING RUN",
+		`{{.StartDate | default "TBD"}}`:            "TBD",
+		`{{.Tags | join ", "}}`:                     "rocks, camping",
+		`{{json .}}`:                                `{"name":"Rubicon Trail Spring Run","startDate":null,"tags":["rocks","camping"],"tripId":"t1"}`,
+		`{{.Missing}}|{{.StartDate}}`:               "|",
+		`{{"2026-05-02T17:00:00Z" | date "Jan 2"}}`: "May 2",
+	}
+	for spec, want := range cases {
+		if got := execute(t, spec, data); got != want+"\n" {
+			t.Errorf("%s: got %q want %q", spec, got, want)
+		}
+	}
+}
+
+func TestExecute_KeepsNoValueText(t *testing.T) {
+	name := "<no value>"
+	data := []trip{{TripID: "t1", Name: &name}}
+	spec := `{{.Name}}|{{.StartDate}}|{{.Missing.Field}}|{{range .Tags}}{{.}}{{else}}{{.Name}}{{end}}`
+	if got, want := execute(t, spec, data), "<no value>|||<no value>\n"; got != want {
+		t.Fatalf("got %q want %q", got, want)
+	}
+}
+
+func TestParse_FromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trips.tmpl")
+	if err := os.WriteFile(path, []byte("{{.TripId}}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got := execute(t, "@"+path, []trip{{TripID: "t1"}, {TripID: "t2"}}); got != "t1\nt2\n" {
+		t.Fatalf("got %q", got)
+	}
+	if _, err := Parse("@" + filepath.Join(t.TempDir(), "missing.tmpl")); err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+}
+
+func TestExecute_ErrorsWriteNothing(t *testing.T) {
+	tmpl, err := Parse(`{{.TripId | date "Jan 2"}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	err = tmpl.Execute(&b, []trip{{TripID: "t1"}})
+	if err == nil || !strings.Contains(err.Error(), `"t1" is not a date`) || b.Len() != 0 {
+		t.Fatalf("err=%v out=%q", err, b.String())
+	}
+	if _, err := Parse("{{.TripId"); err == nil {
+		t.Fatal("expected a parse error")
+	}
+}
@@ -0,0 +1,26 @@
+package httpx
+
+import "errors"
+
+// Response headers the response cache (adapters/out/httpcache) sets on the
+// responses it produces, so code above the transport (the plannerapi adapter)
+// can report cache state without depending on the cache.
+const (
+	// HeaderCacheSource is CacheSourceOffline when served from cache without
+	// contacting the server, or CacheSourceRevalidated when the server
+	// answered 304 Not Modified.
+	HeaderCacheSource = "X-Ebo-Cache"
+	// HeaderCacheFetchedAt is the RFC3339 time the cached body was last
+	// fetched or revalidated.
+	HeaderCacheFetchedAt = "X-Ebo-Fetched-At"
+)
+
+// ErrOffline is returned by the response cache for requests it cannot serve
+// in offline mode (--offline).
+var ErrOffline = errors.New("offline")
+
+// Values of HeaderCacheSource.
+const (
+	CacheSourceOffline     = "offline"
+	CacheSourceRevalidated = "revalidated"
+)
@@ -0,0 +1,360 @@
+package httpx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// A cassette is a directory of recorded HTTP interactions, one JSON file per
+// request/response pair, named in the order they happened
+// (0001-GET-v1-trips.json, ...). Secrets are redacted before anything is
+// written, so cassettes can be attached to bug reports and checked into tests.
+//
+// A session can span several invocations: recording into a directory that
+// already holds interactions continues their numbering, and strict replay
+// given a cursor file (kept outside the cassette, so replay never writes to
+// it) picks up where the previous invocation stopped.
+
+// ErrNoInteraction is returned by a Replayer when no recorded interaction
+// matches a request.
+var ErrNoInteraction = errors.New("no matching cassette interaction")
+
+// MatchMode controls how a Replayer pairs requests with recorded interactions.
+type MatchMode string
+
+const (
+	// MatchStrict replays interactions in recorded order; each request must
+	// have the same method, URL, and body as the next interaction.
+	MatchStrict MatchMode = "strict"
+	// MatchLenient matches on method and path only, in any order. Once every
+	// match has been used, the last one is repeated (e.g. extra OIDC polls).
+	MatchLenient MatchMode = "lenient"
+)
+
+// ParseMatchMode parses EBO_REPLAY_MATCH; empty means strict.
+func ParseMatchMode(s string) (MatchMode, error) {
+	switch MatchMode(strings.ToLower(strings.TrimSpace(s))) {
+	case "", MatchStrict:
+		return MatchStrict, nil
+	case MatchLenient:
+		return MatchLenient, nil
+	default:
+		return "", fmt.Errorf("invalid replay match mode %q (expected strict or lenient)", s)
+	}
+}
+
+type interaction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+type cassetteRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type cassetteResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// CassetteRecorder writes every exchange made through its RoundTripper to Dir.
+type CassetteRecorder struct {
+	Dir      string
+	Redactor Redactor
+
+	mu      sync.Mutex
+	seq     int
+	scanned bool
+}
+
+// NewCassetteRecorder returns a recorder writing to dir (created on first use).
+// Numbering continues after any interactions already in dir.
+func NewCassetteRecorder(dir string, r Redactor) *CassetteRecorder {
+	return &CassetteRecorder{Dir: dir, Redactor: r}
+}
+
+// RoundTripper wraps base so its traffic is recorded.
+func (c *CassetteRecorder) RoundTripper(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &recordRoundTripper{base: base, rec: c}
+}
+
+type recordRoundTripper struct {
+	base http.RoundTripper
+	rec  *CassetteRecorder
+}
+
+func (t *recordRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := snapshotRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		// Transport errors have no response to replay.
+		return resp, err
+	}
+	respBody, err := snapshotResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r := t.rec.Redactor
+	in := interaction{
+		Request: redactedRequest(r, req, reqBody),
+		Response: cassetteResponse{
+			Status: resp.StatusCode,
+			Header: r.Header(resp.Header),
+			Body:   string(r.Body(resp.Header.Get("Content-Type"), respBody)),
+		},
+	}
+	if err := t.rec.write(req, in); err != nil {
+		return nil, fmt.Errorf("record cassette: %w", err)
+	}
+	return resp, nil
+}
+
+func (c *CassetteRecorder) write(req *http.Request, in interaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := os.MkdirAll(c.Dir, 0o700); err != nil {
+		return err
+	}
+	if !c.scanned {
+		names, err := cassetteFiles(c.Dir)
+		if err != nil {
+			return err
+		}
+		if len(names) > 0 {
+			c.seq = fileSeq(names[len(names)-1])
+		}
+		c.scanned = true
+	}
+	c.seq++
+	name := fmt.Sprintf("%04d-%s-%s.json", c.seq, req.Method, pathSlug(req.URL.Path))
+	b, err := json.MarshalIndent(in, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(c.Dir, name), append(b, '\n'), 0o600)
+}
+
+// cassetteFiles lists the interaction files in dir in recorded order.
+func cassetteFiles(dir string) ([]string, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		if a, b := fileSeq(names[i]), fileSeq(names[j]); a != b {
+			return a < b
+		}
+		return names[i] < names[j]
+	})
+	return names, nil
+}
+
+// fileSeq is the sequence number an interaction file name starts with, or 0.
+func fileSeq(name string) int {
+	prefix, _, _ := strings.Cut(filepath.Base(name), "-")
+	n, err := strconv.Atoi(prefix)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+func redactedRequest(r Redactor, req *http.Request, body []byte) cassetteRequest {
+	return cassetteRequest{
+		Method: req.Method,
+		URL:    r.URL(req.URL),
+		Header: r.Header(req.Header),
+		Body:   string(r.Body(req.Header.Get("Content-Type"), body)),
+	}
+}
+
+func pathSlug(p string) string {
+	var b strings.Builder
+	for _, r := range strings.Trim(p, "/") {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('-')
+		}
+	}
+	if b.Len() == 0 {
+		return "root"
+	}
+	return b.String()
+}
+
+// Replayer is an http.RoundTripper that serves responses from a cassette
+// directory without touching the network.
+//
+// Requests are redacted with Redactor before matching, so they compare equal
+// to what CassetteRecorder wrote.
+//
+// Replay never writes to the cassette. In strict mode the position is kept in
+// memory; with a cursor file it is also saved there after every match, so a
+// session recorded over several invocations replays over several too. Once
+// every interaction has been replayed, the next Replayer starts over; delete
+// the cursor file to restart an interrupted session.
+type Replayer struct {
+	Mode     MatchMode
+	Redactor Redactor
+
+	cursor       string
+	mu           sync.Mutex
+	interactions []interaction
+	used         []bool
+	next         int
+}
+
+// NewReplayer loads every interaction under dir. In strict mode with a
+// cursor file (empty for none), replay resumes at the position saved there;
+// the file must be outside dir.
+func NewReplayer(dir string, mode MatchMode, r Redactor, cursor string) (*Replayer, error) {
+	if cursor != "" && mode == MatchStrict && within(dir, cursor) {
+		return nil, fmt.Errorf("replay cursor %s must be outside cassette %s", cursor, dir)
+	}
+	names, err := cassetteFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("cassette %s: no interactions", dir)
+	}
+	out := &Replayer{Mode: mode, Redactor: r}
+	if mode == MatchStrict {
+		out.cursor = cursor
+	}
+	for _, name := range names {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		var in interaction
+		if err := json.Unmarshal(b, &in); err != nil {
+			return nil, fmt.Errorf("cassette %s: %w", name, err)
+		}
+		out.interactions = append(out.interactions, in)
+	}
+	out.used = make([]bool, len(out.interactions))
+	if out.cursor != "" {
+		if b, err := os.ReadFile(out.cursor); err == nil {
+			if n, err := strconv.Atoi(strings.TrimSpace(string(b))); err == nil && n > 0 && n < len(out.interactions) {
+				out.next = n
+			}
+		}
+	}
+	return out, nil
+}
+
+func (p *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := snapshotRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	got := redactedRequest(p.Redactor, req, body)
+
+	p.mu.Lock()
+	idx := p.match(got)
+	var expected string
+	switch {
+	case idx >= 0:
+		p.used[idx] = true
+	case p.Mode == MatchStrict && p.next < len(p.interactions):
+		want := p.interactions[p.next].Request
+		expected = fmt.Sprintf(" (expected %s %s, interaction %d of %d)", want.Method, want.URL, p.next+1, len(p.interactions))
+		if p.cursor != "" {
+			expected = fmt.Sprintf(" (expected %s %s, interaction %d of %d; delete %s to start over)", want.Method, want.URL, p.next+1, len(p.interactions), p.cursor)
+		}
+	}
+	p.mu.Unlock()
+	if idx < 0 {
+		return nil, fmt.Errorf("%w: %s %s%s", ErrNoInteraction, got.Method, got.URL, expected)
+	}
+
+	rec := p.interactions[idx].Response
+	h := rec.Header.Clone()
+	if h == nil {
+		h = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.Status, http.StatusText(rec.Status)),
+		StatusCode:    rec.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        h,
+		Body:          io.NopCloser(bytes.NewReader([]byte(rec.Body))),
+		ContentLength: int64(len(rec.Body)),
+		Request:       req,
+	}, nil
+}
+
+// match returns the index of the interaction for got, or -1. Callers hold p.mu.
+func (p *Replayer) match(got cassetteRequest) int {
+	if p.Mode == MatchLenient {
+		last := -1
+		for i, in := range p.interactions {
+			if in.Request.Method != got.Method || urlPath(in.Request.URL) != urlPath(got.URL) {
+				continue
+			}
+			if !p.used[i] {
+				return i
+			}
+			last = i
+		}
+		return last
+	}
+
+	if p.next >= len(p.interactions) {
+		return -1
+	}
+	want := p.interactions[p.next].Request
+	if want.Method != got.Method || want.URL != got.URL || want.Body != got.Body {
+		return -1
+	}
+	p.next++
+	if p.cursor != "" {
+		// Best effort: an unwritable cursor still replays within one invocation.
+		_ = os.WriteFile(p.cursor, []byte(strconv.Itoa(p.next)+"\n"), 0o600)
+	}
+	return p.next - 1
+}
+
+// within reports whether path is dir or inside it.
+func within(dir, path string) bool {
+	d, err1 := filepath.Abs(dir)
+	f, err2 := filepath.Abs(path)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	rel, err := filepath.Rel(d, f)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func urlPath(u string) string {
+	if i := strings.IndexAny(u, "?#"); i >= 0 {
+		return u[:i]
+	}
+	return u
+}
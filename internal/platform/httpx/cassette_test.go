@@ -0,0 +1,282 @@
+package httpx
+
+import (
+	"errors"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func do(t *testing.T, c *http.Client, method, url, form string) (int, string, error) {
+	t.Helper()
+	var body io.Reader
+	if form != "" {
+		body = strings.NewReader(form)
+	}
+	req, _ := http.NewRequest(method, url, body)
+	req.Header.Set("Authorization", "Bearer secret")
+	if form != "" {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	b, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(b), nil
+}
+
+// recordSession records a device-flow-like session: one poll that is pending,
+// one that succeeds, then an API read.
+func recordSession(t *testing.T) string {
+	t.Helper()
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/token":
+			polls++
+			if polls == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"authorization_pending"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token":"tok-secret","token_type":"Bearer"}`))
+		default:
+			_, _ = w.Write([]byte(`{"trips":[{"tripId":"t1"}]}`))
+		}
+	}))
+	defer srv.Close()
+
+	dir := filepath.Join(t.TempDir(), "cassette")
+	c := &http.Client{Transport: NewCassetteRecorder(dir, Redactor{}).RoundTripper(nil)}
+	for i := 0; i < 2; i++ {
+		if _, _, err := do(t, c, http.MethodPost, srv.URL+"/token", "device_code=dc-secret&client_id=cli"); err != nil {
+			t.Fatalf("poll: %v", err)
+		}
+	}
+	if _, _, err := do(t, c, http.MethodGet, srv.URL+"/v1/trips", ""); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	return dir
+}
+
+func TestCassetteRecorder_WritesRedactedFilesInOrder(t *testing.T) {
+	dir := recordSession(t)
+	names, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(names) != 3 {
+		t.Fatalf("expected 3 interactions, got %v", names)
+	}
+	if filepath.Base(names[2]) != "0003-GET-v1-trips.json" {
+		t.Fatalf("name: %s", filepath.Base(names[2]))
+	}
+	for _, name := range names {
+		b, _ := os.ReadFile(name)
+		for _, leak := range []string{"tok-secret", "dc-secret", "Bearer secret"} {
+			if strings.Contains(string(b), leak) {
+				t.Fatalf("leaked %q in %s", leak, name)
+			}
+		}
+		if fi, _ := os.Stat(name); fi.Mode().Perm() != 0o600 {
+			t.Fatalf("mode %v", fi.Mode().Perm())
+		}
+	}
+}
+
+func TestReplayer_StrictReplaysInOrder(t *testing.T) {
+	dir := recordSession(t)
+	rp, err := NewReplayer(dir, MatchStrict, Redactor{}, "")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	c := &http.Client{Transport: rp}
+
+	// The recording server is gone; the URL only has to match.
+	base := urlOf(t, dir)
+	status, _, err := do(t, c, http.MethodPost, base+"/token", "device_code=dc-other&client_id=cli")
+	if err != nil || status != http.StatusBadRequest {
+		t.Fatalf("first poll: %d %v", status, err)
+	}
+	status, body, err := do(t, c, http.MethodPost, base+"/token", "device_code=dc-other&client_id=cli")
+	if err != nil || status != http.StatusOK || !strings.Contains(body, `"access_token":"REDACTED"`) {
+		t.Fatalf("second poll: %d %q %v", status, body, err)
+	}
+	if _, _, err := do(t, c, http.MethodGet, base+"/v1/members/me", ""); !errors.Is(err, ErrNoInteraction) {
+		t.Fatalf("expected out-of-order request to fail, got %v", err)
+	}
+}
+
+func TestReplayer_LenientMatchesAnyOrderAndRepeatsLast(t *testing.T) {
+	dir := recordSession(t)
+	rp, err := NewReplayer(dir, MatchLenient, Redactor{}, "")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	c := &http.Client{Transport: rp}
+	base := urlOf(t, dir)
+
+	if _, body, err := do(t, c, http.MethodGet, base+"/v1/trips?x=1", ""); err != nil || !strings.Contains(body, "t1") {
+		t.Fatalf("get: %q %v", body, err)
+	}
+	statuses := []int{}
+	for i := 0; i < 3; i++ {
+		status, _, err := do(t, c, http.MethodPost, base+"/token", "device_code=x")
+		if err != nil {
+			t.Fatalf("poll %d: %v", i, err)
+		}
+		statuses = append(statuses, status)
+	}
+	if statuses[0] != 400 || statuses[1] != 200 || statuses[2] != 200 {
+		t.Fatalf("statuses: %v", statuses)
+	}
+	if _, _, err := do(t, c, http.MethodDelete, base+"/v1/trips", ""); !errors.Is(err, ErrNoInteraction) {
+		t.Fatalf("expected unknown request to fail, got %v", err)
+	}
+}
+
+func TestNewReplayer_EmptyDirFails(t *testing.T) {
+	if _, err := NewReplayer(t.TempDir(), MatchStrict, Redactor{}, ""); err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestParseMatchMode(t *testing.T) {
+	if m, err := ParseMatchMode(""); err != nil || m != MatchStrict {
+		t.Fatalf("default: %q %v", m, err)
+	}
+	if m, err := ParseMatchMode("Lenient"); err != nil || m != MatchLenient {
+		t.Fatalf("lenient: %q %v", m, err)
+	}
+	if _, err := ParseMatchMode("fuzzy"); err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+// urlOf returns the scheme://host the cassette in dir was recorded against.
+func urlOf(t *testing.T, dir string) string {
+	t.Helper()
+	names, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	b, _ := os.ReadFile(names[0])
+	s := string(b)
+	i := strings.Index(s, "http://")
+	j := strings.Index(s[i:], "/token")
+	return s[i : i+j]
+}
+
+func TestCassette_SessionAcrossInvocationsReplaysStrictly(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"path":"` + r.URL.Path + `"}`))
+	}))
+	defer srv.Close()
+
+	// Each invocation has its own recorder, as separate ebo processes do.
+	dir := filepath.Join(t.TempDir(), "cassette")
+	invocations := [][]string{{"/trips"}, {"/members/me", "/trips"}}
+	for _, paths := range invocations {
+		c := &http.Client{Transport: NewCassetteRecorder(dir, Redactor{}).RoundTripper(nil)}
+		for _, p := range paths {
+			if _, _, err := do(t, c, http.MethodGet, srv.URL+p, ""); err != nil {
+				t.Fatalf("record %s: %v", p, err)
+			}
+		}
+	}
+	names, _ := cassetteFiles(dir)
+	var got []string
+	for _, n := range names {
+		got = append(got, filepath.Base(n))
+	}
+	if want := "0001-GET-trips.json 0002-GET-members-me.json 0003-GET-trips.json"; strings.Join(got, " ") != want {
+		t.Fatalf("files: %v, want %s", got, want)
+	}
+
+	// Replaying the session twice: with a cursor file, each Replayer
+	// continues where the previous one stopped, and starts over once all
+	// were replayed.
+	cursor := filepath.Join(t.TempDir(), "cursor")
+	for run := 0; run < 2; run++ {
+		for _, paths := range invocations {
+			rp, err := NewReplayer(dir, MatchStrict, Redactor{}, cursor)
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			c := &http.Client{Transport: rp}
+			for _, p := range paths {
+				if _, body, err := do(t, c, http.MethodGet, srv.URL+p, ""); err != nil || !strings.Contains(body, p) {
+					t.Fatalf("run %d replay %s: %q %v", run, p, body, err)
+				}
+			}
+		}
+	}
+
+	rp, _ := NewReplayer(dir, MatchStrict, Redactor{}, cursor)
+	if _, _, err := do(t, &http.Client{Transport: rp}, http.MethodGet, srv.URL+"/members/me", ""); !errors.Is(err, ErrNoInteraction) || !strings.Contains(err.Error(), "expected GET") {
+		t.Fatalf("expected strict miss naming the next interaction, got %v", err)
+	}
+}
+
+// snapshot returns every file in dir with its contents.
+func snapshot(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := map[string]string{}
+	for _, e := range entries {
+		b, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		out[e.Name()] = string(b)
+	}
+	return out
+}
+
+func TestReplayer_LeavesCassetteUntouched(t *testing.T) {
+	dir := recordSession(t)
+	before := snapshot(t, dir)
+	base := urlOf(t, dir)
+	for _, cursor := range []string{"", filepath.Join(t.TempDir(), "cursor")} {
+		for range 2 {
+			rp, err := NewReplayer(dir, MatchStrict, Redactor{}, cursor)
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			// Without a cursor file every Replayer starts at the first
+			// interaction; a miss leaves nothing behind either.
+			if cursor == "" {
+				if status, _, err := do(t, &http.Client{Transport: rp}, http.MethodPost, base+"/token", "device_code=dc-other&client_id=cli"); err != nil || status != http.StatusBadRequest {
+					t.Fatalf("first poll: %d %v", status, err)
+				}
+			}
+			_, _, _ = do(t, &http.Client{Transport: rp}, http.MethodGet, base+"/v1/members/me", "")
+		}
+	}
+	if after := snapshot(t, dir); !maps.Equal(before, after) {
+		t.Fatalf("replay changed the cassette: %d files before, %d after", len(before), len(after))
+	}
+
+	if _, err := NewReplayer(dir, MatchStrict, Redactor{}, filepath.Join(dir, "cursor")); err == nil {
+		t.Fatalf("expected a cursor inside the cassette to be rejected")
+	}
+}
+
+func TestCassetteFiles_SortsBySequenceNumber(t *testing.T) {
+	dir := t.TempDir()
+	for _, n := range []string{"10000-GET-b.json", "9999-GET-a.json", "0002-GET-c.json"} {
+		if err := os.WriteFile(filepath.Join(dir, n), []byte("{}"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	names, _ := cassetteFiles(dir)
+	if len(names) != 3 || filepath.Base(names[0]) != "0002-GET-c.json" || filepath.Base(names[2]) != "10000-GET-b.json" {
+		t.Fatalf("order: %v", names)
+	}
+}
@@ -14,6 +14,13 @@ type Options struct {
 	Timeout time.Duration
 	Verbose bool
 	LogSink io.Writer
+
+	// Trace dumps redacted request/response headers and bodies to LogSink.
+	Trace bool
+	// HAR, when set, records every exchange for export as an HTTP Archive.
+	HAR *HARRecorder
+	// Redactor controls what Trace and HAR scrub.
+	Redactor Redactor
 }
 
 // NewClient returns an http.Client configured for CLI runtime behavior.
@@ -21,6 +28,7 @@ type Options struct {
 // - Timeout is enforced via request context deadlines (not http.Client.Timeout).
 // - Verbose logging writes method/url/status/timing to LogSink (stderr by convention).
 // - Authorization headers are redacted in logs.
+// - Trace and HAR capture full exchanges with secrets redacted by Redactor.
 func NewClient(base *http.Client, opts Options) *http.Client {
 	if base == nil {
 		base = http.DefaultClient
@@ -31,11 +39,18 @@ func NewClient(base *http.Client, opts Options) *http.Client {
 		transport = http.DefaultTransport
 	}
 
+	if opts.LogSink == nil {
+		opts.LogSink = io.Discard
+	}
+
 	rt := transport
+	if opts.HAR != nil {
+		rt = opts.HAR.RoundTripper(rt)
+	}
+	if opts.Trace {
+		rt = &traceRoundTripper{base: rt, out: opts.LogSink, redactor: opts.Redactor}
+	}
 	if opts.Verbose {
-		if opts.LogSink == nil {
-			opts.LogSink = io.Discard
-		}
 		rt = &loggingRoundTripper{base: rt, out: opts.LogSink}
 	}
 	if opts.Timeout > 0 {
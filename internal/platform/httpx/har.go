@@ -0,0 +1,242 @@
+package httpx
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// HAR 1.2 subset (http://www.softwareishard.com/blog/har-12-spec/).
+type harLog struct {
+	Log harLogBody `json:"log"`
+}
+
+type harLogBody struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// HARRecorder collects every request made through its RoundTripper into an
+// HTTP Archive, with secrets redacted by Redactor.
+//
+// One recorder is shared by all clients in an invocation (API and OIDC), and
+// written once at exit via WriteFile.
+type HARRecorder struct {
+	Redactor Redactor
+
+	mu      sync.Mutex
+	entries []harEntry
+}
+
+// NewHARRecorder returns an empty recorder.
+func NewHARRecorder(r Redactor) *HARRecorder {
+	return &HARRecorder{Redactor: r}
+}
+
+// RoundTripper wraps base so its traffic is recorded.
+func (h *HARRecorder) RoundTripper(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &harRoundTripper{base: base, rec: h}
+}
+
+func (h *HARRecorder) add(e harEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, e)
+}
+
+// Len returns the number of recorded entries.
+func (h *HARRecorder) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.entries)
+}
+
+// Write encodes the archive as JSON to w.
+func (h *HARRecorder) Write(w io.Writer) error {
+	h.mu.Lock()
+	entries := append([]harEntry{}, h.entries...)
+	h.mu.Unlock()
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(harLog{Log: harLogBody{
+		Version: "1.2",
+		Creator: harCreator{Name: "ebo", Version: "dev"},
+		Entries: entries,
+	}})
+}
+
+// WriteFile writes the archive to path (0600: it may still contain personal data).
+func (h *HARRecorder) WriteFile(path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := h.Write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+type harRoundTripper struct {
+	base http.RoundTripper
+	rec  *HARRecorder
+}
+
+func (t *harRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := t.rec.Redactor
+	reqBody, err := snapshotRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	e := harEntry{
+		StartedDateTime: start.UTC().Format(time.RFC3339Nano),
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         r.URL(req.URL),
+			HTTPVersion: protoOrDefault(req.Proto),
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(r.Header(req.Header)),
+			QueryString: harQuery(r, req),
+			HeadersSize: -1,
+			BodySize:    len(reqBody),
+		},
+	}
+	if len(reqBody) > 0 {
+		ct := req.Header.Get("Content-Type")
+		e.Request.PostData = &harPostData{MimeType: ct, Text: string(r.Body(ct, reqBody))}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	wait := time.Now().Sub(start)
+	if err != nil {
+		e.Time = ms(wait)
+		e.Timings = harTimings{Wait: ms(wait)}
+		e.Response = harResponse{Cookies: []harNameValue{}, Headers: []harNameValue{}, HeadersSize: -1, BodySize: -1}
+		e.Comment = "error: " + err.Error()
+		t.rec.add(e)
+		return resp, err
+	}
+
+	respBody, err := snapshotResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	total := time.Now().Sub(start)
+	ct := resp.Header.Get("Content-Type")
+	e.Time = ms(total)
+	e.Timings = harTimings{Wait: ms(wait), Receive: ms(total - wait)}
+	e.Response = harResponse{
+		Status:      resp.StatusCode,
+		StatusText:  http.StatusText(resp.StatusCode),
+		HTTPVersion: protoOrDefault(resp.Proto),
+		Cookies:     []harNameValue{},
+		Headers:     harHeaders(r.Header(resp.Header)),
+		Content:     harContent{Size: len(respBody), MimeType: ct, Text: string(r.Body(ct, respBody))},
+		RedirectURL: resp.Header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    len(respBody),
+	}
+	t.rec.add(e)
+	return resp, nil
+}
+
+func harHeaders(h http.Header) []harNameValue {
+	return harPairs(h)
+}
+
+func harQuery(r Redactor, req *http.Request) []harNameValue {
+	return harPairs(r.values(req.URL.Query()))
+}
+
+// harPairs flattens a multi-valued map into name/value pairs in stable order.
+func harPairs(m map[string][]string) []harNameValue {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := []harNameValue{}
+	for _, k := range keys {
+		for _, v := range m[k] {
+			out = append(out, harNameValue{Name: k, Value: v})
+		}
+	}
+	return out
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
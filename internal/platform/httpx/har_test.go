@@ -0,0 +1,97 @@
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHARRecorder_RecordsExchangesAndWritesFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"tok-secret","token_type":"Bearer"}`))
+	}))
+	defer srv.Close()
+
+	rec := NewHARRecorder(Redactor{})
+	c := NewClient(nil, Options{HAR: rec})
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/token?x=1", strings.NewReader("device_code=dc-secret&client_id=cli"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if rec.Len() != 1 {
+		t.Fatalf("expected 1 entry, got %d", rec.Len())
+	}
+
+	path := filepath.Join(t.TempDir(), "out.har")
+	if err := rec.WriteFile(path); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	for _, leak := range []string{"tok-secret", "dc-secret", "Bearer secret"} {
+		if strings.Contains(string(b), leak) {
+			t.Fatalf("leaked %q in HAR", leak)
+		}
+	}
+
+	var har struct {
+		Log struct {
+			Version string `json:"version"`
+			Entries []struct {
+				Request struct {
+					Method   string `json:"method"`
+					URL      string `json:"url"`
+					PostData struct {
+						Text string `json:"text"`
+					} `json:"postData"`
+				} `json:"request"`
+				Response struct {
+					Status  int `json:"status"`
+					Content struct {
+						Text string `json:"text"`
+					} `json:"content"`
+				} `json:"response"`
+			} `json:"entries"`
+		} `json:"log"`
+	}
+	if err := json.Unmarshal(b, &har); err != nil {
+		t.Fatalf("invalid HAR json: %v", err)
+	}
+	if har.Log.Version != "1.2" || len(har.Log.Entries) != 1 {
+		t.Fatalf("unexpected HAR: %s", b)
+	}
+	e := har.Log.Entries[0]
+	if e.Request.Method != http.MethodPost || !strings.Contains(e.Request.URL, "/token?x=1") {
+		t.Fatalf("request: %#v", e.Request)
+	}
+	if !strings.Contains(e.Request.PostData.Text, "client_id=cli") {
+		t.Fatalf("postData: %q", e.Request.PostData.Text)
+	}
+	if e.Response.Status != 200 || !strings.Contains(e.Response.Content.Text, `"token_type":"Bearer"`) {
+		t.Fatalf("response: %#v", e.Response)
+	}
+}
+
+func TestHARRecorder_RecordsTransportErrors(t *testing.T) {
+	rec := NewHARRecorder(Redactor{})
+	c := NewClient(nil, Options{HAR: rec})
+	_, err := c.Get("http://127.0.0.1:1/unreachable")
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if rec.Len() != 1 {
+		t.Fatalf("expected failed request recorded, got %d", rec.Len())
+	}
+}
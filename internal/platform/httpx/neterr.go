@@ -0,0 +1,101 @@
+package httpx
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/url"
+	"syscall"
+)
+
+// NetErrorClass is a stable, machine-readable classification of a transport
+// failure (used as error.code in JSON envelopes).
+type NetErrorClass string
+
+const (
+	NetDNS       NetErrorClass = "dns_failure"
+	NetRefused   NetErrorClass = "connection_refused"
+	NetTLSVerify NetErrorClass = "tls_verify_failed"
+	NetTimeout   NetErrorClass = "timeout"
+	NetProxy     NetErrorClass = "proxy_failure"
+	NetOther     NetErrorClass = "network_error"
+)
+
+// ClassifyNetError returns the class of a transport error returned by an
+// http.Client. Errors it does not recognize are NetOther.
+func ClassifyNetError(err error) NetErrorClass {
+	// Proxy first: a refused or unresolvable proxy is a proxy problem, not an
+	// API one.
+	var op *net.OpError
+	if errors.As(err, &op) && op.Op == "proxyconnect" {
+		return NetProxy
+	}
+
+	var dns *net.DNSError
+	if errors.As(err, &dns) {
+		if dns.IsTimeout {
+			return NetTimeout
+		}
+		return NetDNS
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return NetRefused
+	}
+
+	var (
+		unknownAuthority x509.UnknownAuthorityError
+		hostname         x509.HostnameError
+		invalid          x509.CertificateInvalidError
+		verify           *tls.CertificateVerificationError
+		record           tls.RecordHeaderError
+	)
+	if errors.As(err, &unknownAuthority) || errors.As(err, &hostname) || errors.As(err, &invalid) ||
+		errors.As(err, &verify) || errors.As(err, &record) {
+		return NetTLSVerify
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return NetTimeout
+	}
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return NetTimeout
+	}
+	return NetOther
+}
+
+// NetErrorSummary describes a failure of class against baseURL for humans
+// ("connection refused to <baseURL>") and returns a hint, which may be empty.
+func NetErrorSummary(class NetErrorClass, baseURL string) (summary, hint string) {
+	host := baseURL
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		host = u.Hostname()
+	}
+	switch class {
+	case NetDNS:
+		return "cannot resolve host " + host, "check the API URL (ebo config get profiles.<name>.apiUrl) and your DNS/VPN"
+	case NetRefused:
+		if isLocalHost(host) {
+			return "connection refused to " + baseURL, "is the local API running (docker compose up)?"
+		}
+		return "connection refused to " + baseURL, "check the API URL and that the service is up"
+	case NetTLSVerify:
+		return "TLS verification failed for " + baseURL, "set profiles.<name>.tls.caFile (or tls.serverName) for private CAs, and check the URL scheme (http vs https)"
+	case NetTimeout:
+		return "request to " + baseURL + " timed out", "retry, or raise --timeout"
+	case NetProxy:
+		return "proxy failure reaching " + baseURL, "check profiles.<name>.proxy and HTTPS_PROXY/NO_PROXY"
+	default:
+		return "request to " + baseURL + " failed", ""
+	}
+}
+
+func isLocalHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,96 @@
+package httpx
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// closedURL returns a loopback URL with nothing listening on it.
+func closedURL(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := l.Addr().String()
+	_ = l.Close()
+	return "http://" + addr
+}
+
+func classify(t *testing.T, c *http.Client, u string) NetErrorClass {
+	t.Helper()
+	resp, err := c.Get(u)
+	if err == nil {
+		_ = resp.Body.Close()
+		t.Fatalf("expected error for %s", u)
+	}
+	return ClassifyNetError(err)
+}
+
+func TestClassifyNetError(t *testing.T) {
+	t.Run("refused", func(t *testing.T) {
+		if got := classify(t, &http.Client{Transport: NewTransport()}, closedURL(t)); got != NetRefused {
+			t.Fatalf("got %s", got)
+		}
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer srv.Close()
+		if got := classify(t, &http.Client{Transport: NewTransport()}, srv.URL); got != NetTLSVerify {
+			t.Fatalf("got %s", got)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		}))
+		defer srv.Close()
+		c := NewClient(&http.Client{Transport: NewTransport()}, Options{Timeout: 20 * time.Millisecond})
+		if got := classify(t, c, srv.URL); got != NetTimeout {
+			t.Fatalf("got %s", got)
+		}
+	})
+
+	t.Run("proxy", func(t *testing.T) {
+		proxy, _ := url.Parse(closedURL(t))
+		tr := NewTransport()
+		tr.Proxy = http.ProxyURL(proxy)
+		if got := classify(t, &http.Client{Transport: tr}, "http://api.example.invalid/"); got != NetProxy {
+			t.Fatalf("got %s", got)
+		}
+	})
+
+	t.Run("dns", func(t *testing.T) {
+		err := &url.Error{Op: "Get", URL: "http://api.example.invalid", Err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "api.example.invalid", IsNotFound: true}}}
+		if got := ClassifyNetError(err); got != NetDNS {
+			t.Fatalf("got %s", got)
+		}
+	})
+
+	t.Run("other", func(t *testing.T) {
+		if got := ClassifyNetError(errors.New("boom")); got != NetOther {
+			t.Fatalf("got %s", got)
+		}
+	})
+}
+
+func TestNetErrorSummary_LocalRefusedSuggestsDockerCompose(t *testing.T) {
+	summary, hint := NetErrorSummary(NetRefused, "http://localhost:8081")
+	if summary != "connection refused to http://localhost:8081" || !strings.Contains(hint, "docker compose up") {
+		t.Fatalf("got %q / %q", summary, hint)
+	}
+	if _, hint := NetErrorSummary(NetRefused, "https://api.example.com"); strings.Contains(hint, "docker") {
+		t.Fatalf("remote hosts should not suggest docker: %q", hint)
+	}
+	if summary, _ := NetErrorSummary(NetDNS, "https://api.example.com/v1"); summary != "cannot resolve host api.example.com" {
+		t.Fatalf("got %q", summary)
+	}
+}
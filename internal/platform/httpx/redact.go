@@ -0,0 +1,156 @@
+package httpx
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Redacted replaces secret values in traces and HAR exports.
+const Redacted = "REDACTED"
+
+// secretHeaders are always redacted, regardless of Redactor.Fields.
+var secretHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+	"set-cookie":          true,
+}
+
+// secretFields are query/form/JSON field names that always hold secrets.
+// Names are compared after normalizeField (case- and separator-insensitive).
+var secretFields = map[string]bool{
+	"accesstoken":  true,
+	"refreshtoken": true,
+	"idtoken":      true,
+	"devicecode":   true,
+	"clientsecret": true,
+	"password":     true,
+	"token":        true,
+	"secret":       true,
+	"apikey":       true,
+}
+
+// Redactor scrubs secrets from HTTP traffic before it is written anywhere.
+//
+// Authorization-style headers and well-known token fields are always redacted;
+// Fields adds names on top (e.g. from EBO_REDACT_FIELDS).
+type Redactor struct {
+	Fields []string
+}
+
+func normalizeField(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.ReplaceAll(name, "_", "")
+	name = strings.ReplaceAll(name, "-", "")
+	return name
+}
+
+func (r Redactor) isSecret(name string) bool {
+	n := normalizeField(name)
+	if secretFields[n] || secretHeaders[strings.ToLower(name)] {
+		return true
+	}
+	for _, f := range r.Fields {
+		if normalizeField(f) == n && n != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Header returns a copy of h with secret header values redacted.
+func (r Redactor) Header(h http.Header) http.Header {
+	out := make(http.Header, len(h))
+	for k, vs := range h {
+		if r.isSecret(k) {
+			out[k] = []string{Redacted}
+			continue
+		}
+		out[k] = append([]string(nil), vs...)
+	}
+	return out
+}
+
+// URL returns u as a string with secret query parameter values redacted.
+// Non-secret parameters are kept so traces stay useful for reproduction.
+func (r Redactor) URL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	cu := *u
+	cu.User = nil
+	if cu.RawQuery != "" {
+		cu.RawQuery = r.values(cu.Query()).Encode()
+	}
+	return cu.String()
+}
+
+func (r Redactor) values(v url.Values) url.Values {
+	out := url.Values{}
+	for k, vs := range v {
+		if r.isSecret(k) {
+			out[k] = []string{Redacted}
+			continue
+		}
+		out[k] = append([]string(nil), vs...)
+	}
+	return out
+}
+
+// Body returns body with secret fields redacted.
+//
+// JSON and form-encoded bodies are redacted field by field; other content
+// types are returned unchanged.
+func (r Redactor) Body(contentType string, body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	mt, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mt == "application/x-www-form-urlencoded":
+		v, err := url.ParseQuery(string(body))
+		if err != nil {
+			return []byte(Redacted)
+		}
+		return []byte(r.values(v).Encode())
+	case mt == "application/json" || strings.HasSuffix(mt, "+json") || (mt == "" && json.Valid(body)):
+		var doc any
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		if err := dec.Decode(&doc); err != nil {
+			return body
+		}
+		out, err := json.Marshal(r.jsonValue(doc))
+		if err != nil {
+			return body
+		}
+		return out
+	default:
+		return body
+	}
+}
+
+func (r Redactor) jsonValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, vv := range t {
+			if r.isSecret(k) {
+				t[k] = Redacted
+				continue
+			}
+			t[k] = r.jsonValue(vv)
+		}
+		return t
+	case []any:
+		for i := range t {
+			t[i] = r.jsonValue(t[i])
+		}
+		return t
+	default:
+		return v
+	}
+}
@@ -0,0 +1,68 @@
+package httpx
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestRedactor_Header_RedactsAuthAndCookies(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer secret")
+	h.Set("Cookie", "sid=secret")
+	h.Set("Accept", "application/json")
+
+	got := Redactor{}.Header(h)
+	if got.Get("Authorization") != Redacted || got.Get("Cookie") != Redacted {
+		t.Fatalf("expected redacted, got %#v", got)
+	}
+	if got.Get("Accept") != "application/json" {
+		t.Fatalf("expected non-secret header kept, got %#v", got)
+	}
+	if h.Get("Authorization") != "Bearer secret" {
+		t.Fatalf("input header mutated")
+	}
+}
+
+func TestRedactor_URL_RedactsOnlySecretParams(t *testing.T) {
+	u, _ := url.Parse("https://api/x?access_token=secret&q=bob")
+	got := Redactor{}.URL(u)
+	if strings.Contains(got, "secret") {
+		t.Fatalf("expected token redacted, got %q", got)
+	}
+	if !strings.Contains(got, "q=bob") {
+		t.Fatalf("expected non-secret param kept, got %q", got)
+	}
+}
+
+func TestRedactor_Body_JSONNestedAndConfiguredFields(t *testing.T) {
+	body := []byte(`{"access_token":"a","nested":{"refreshToken":"r","pin":"1234"},"list":[{"id_token":"i"}],"name":"Alice"}`)
+	got := string(Redactor{Fields: []string{"pin"}}.Body("application/json; charset=utf-8", body))
+	for _, leak := range []string{`"a"`, `"r"`, `"1234"`, `"i"`} {
+		if strings.Contains(got, leak) {
+			t.Fatalf("leaked %s in %s", leak, got)
+		}
+	}
+	if !strings.Contains(got, `"Alice"`) {
+		t.Fatalf("expected non-secret field kept, got %s", got)
+	}
+}
+
+func TestRedactor_Body_Form(t *testing.T) {
+	body := []byte("grant_type=urn%3Adevice&device_code=dc&client_id=cli")
+	got := string(Redactor{}.Body("application/x-www-form-urlencoded", body))
+	if strings.Contains(got, "=dc") {
+		t.Fatalf("expected device_code redacted, got %q", got)
+	}
+	if !strings.Contains(got, "client_id=cli") {
+		t.Fatalf("expected client_id kept, got %q", got)
+	}
+}
+
+func TestRedactor_Body_OtherContentTypeUnchanged(t *testing.T) {
+	body := []byte("plain text")
+	if got := string(Redactor{}.Body("text/plain", body)); got != "plain text" {
+		t.Fatalf("got %q", got)
+	}
+}
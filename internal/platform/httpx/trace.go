@@ -0,0 +1,128 @@
+package httpx
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxTraceBody caps how much of each body is dumped by --trace.
+const maxTraceBody = 64 << 10
+
+// traceRoundTripper dumps redacted request/response headers and bodies
+// (curl -v style) to out.
+type traceRoundTripper struct {
+	base     http.RoundTripper
+	out      io.Writer
+	redactor Redactor
+}
+
+func (t *traceRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := snapshotRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "> %s %s %s\n", req.Method, t.redactor.URL(req.URL), protoOrDefault(req.Proto))
+	fmt.Fprintf(b, "> Host: %s\n", req.URL.Host)
+	writeTraceHeaders(b, "> ", t.redactor.Header(req.Header))
+	writeTraceBody(b, "> ", t.redactor.Body(req.Header.Get("Content-Type"), reqBody))
+	_, _ = io.WriteString(t.out, b.String())
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	dur := time.Since(start)
+	if err != nil {
+		_, _ = fmt.Fprintf(t.out, "* error after %s: %v\n\n", dur, err)
+		return resp, err
+	}
+
+	respBody, err := snapshotResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	b.Reset()
+	fmt.Fprintf(b, "< %s %s (%s)\n", protoOrDefault(resp.Proto), resp.Status, dur)
+	writeTraceHeaders(b, "< ", t.redactor.Header(resp.Header))
+	writeTraceBody(b, "< ", t.redactor.Body(resp.Header.Get("Content-Type"), respBody))
+	b.WriteString("\n")
+	_, _ = io.WriteString(t.out, b.String())
+	return resp, nil
+}
+
+func writeTraceHeaders(b *strings.Builder, prefix string, h http.Header) {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range h[k] {
+			fmt.Fprintf(b, "%s%s: %s\n", prefix, k, v)
+		}
+	}
+	b.WriteString(prefix + "\n")
+}
+
+func writeTraceBody(b *strings.Builder, prefix string, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	truncated := false
+	if len(body) > maxTraceBody {
+		body = body[:maxTraceBody]
+		truncated = true
+	}
+	for _, line := range strings.Split(strings.TrimRight(string(body), "\n"), "\n") {
+		b.WriteString(prefix + line + "\n")
+	}
+	if truncated {
+		fmt.Fprintf(b, "%s... (truncated at %d bytes)\n", prefix, maxTraceBody)
+	}
+}
+
+func protoOrDefault(p string) string {
+	if p == "" {
+		return "HTTP/1.1"
+	}
+	return p
+}
+
+// snapshotRequestBody reads req.Body and replaces it so the request can still
+// be sent.
+func snapshotRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
+
+// snapshotResponseBody reads resp.Body and replaces it so callers can still
+// decode the response.
+func snapshotResponseBody(resp *http.Response) ([]byte, error) {
+	if resp.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
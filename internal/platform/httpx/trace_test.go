@@ -0,0 +1,51 @@
+package httpx
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTraceRoundTripper_DumpsRedactedExchange(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		if string(b) != `{"name":"x","password":"hunter2"}` {
+			t.Errorf("server saw body %q", b)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-1")
+		_, _ = w.Write([]byte(`{"access_token":"tok-secret","ok":true}`))
+	}))
+	defer srv.Close()
+
+	buf := &bytes.Buffer{}
+	c := NewClient(nil, Options{Trace: true, LogSink: buf})
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/things", strings.NewReader(`{"name":"x","password":"hunter2"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if !strings.Contains(string(body), "tok-secret") {
+		t.Fatalf("caller must still see the real body, got %q", body)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"> POST ", "/things", "> Authorization: REDACTED", `"name":"x"`, "< HTTP/1.1 200 OK", "< X-Request-Id: req-1", `"ok":true`} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in trace:\n%s", want, out)
+		}
+	}
+	for _, leak := range []string{"Bearer secret", "hunter2", "tok-secret"} {
+		if strings.Contains(out, leak) {
+			t.Fatalf("leaked %q in trace:\n%s", leak, out)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package httpx
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	sharedTransportOnce sync.Once
+	sharedTransport     *http.Transport
+)
+
+// NewTransport returns an *http.Transport tuned for CLI fan-out (many requests
+// to the same API host within one invocation).
+//
+//   - Keep-alives and HTTP/2 are enabled so TLS handshakes are paid once per host.
+//   - The per-host idle pool is larger than net/http's default of 2, so
+//     concurrent requests can reuse connections instead of re-dialing.
+func NewTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   16,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// SharedTransport returns a process-wide transport built by NewTransport.
+//
+// Sharing one transport lets every client in the process draw from the same
+// connection pool.
+func SharedTransport() *http.Transport {
+	sharedTransportOnce.Do(func() {
+		sharedTransport = NewTransport()
+	})
+	return sharedTransport
+}
+
+// TransportOptions customizes TLS and proxy behavior (per profile).
+type TransportOptions struct {
+	// CAFile is a PEM bundle trusted in addition to the system roots.
+	CAFile string
+	// ClientCert/ClientKey are a PEM client certificate pair for mTLS.
+	ClientCert string
+	ClientKey  string
+	// ServerName overrides the name used for SNI and certificate verification.
+	ServerName         string
+	InsecureSkipVerify bool
+	// Proxy is a proxy URL; empty means use the environment (HTTPS_PROXY etc.).
+	Proxy string
+}
+
+// IsZero reports whether opts would leave NewTransport's defaults unchanged.
+func (o TransportOptions) IsZero() bool {
+	return o == TransportOptions{}
+}
+
+// NewTransportWith returns a NewTransport customized by opts.
+func NewTransportWith(opts TransportOptions) (*http.Transport, error) {
+	tr := NewTransport()
+
+	if opts.Proxy != "" {
+		u, err := url.Parse(opts.Proxy)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy url %q", opts.Proxy)
+		}
+		tr.Proxy = http.ProxyURL(u)
+	}
+
+	if opts.CAFile == "" && opts.ClientCert == "" && opts.ClientKey == "" && opts.ServerName == "" && !opts.InsecureSkipVerify {
+		return tr, nil
+	}
+
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         opts.ServerName,
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+	}
+	if opts.CAFile != "" {
+		pem, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read caFile: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("caFile %s: no PEM certificates found", opts.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+	if opts.ClientCert != "" || opts.ClientKey != "" {
+		if opts.ClientCert == "" || opts.ClientKey == "" {
+			return nil, errors.New("clientCert and clientKey must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(opts.ClientCert, opts.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	tr.TLSClientConfig = cfg
+	return tr, nil
+}
+
+// ErrorTransport fails every request with Err.
+//
+// It lets the CLI defer transport configuration errors (e.g. a missing CA file)
+// to the first network call, so offline commands such as `ebo config set`
+// still work to fix the configuration.
+type ErrorTransport struct {
+	Err error
+}
+
+func (t ErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	return nil, t.Err
+}
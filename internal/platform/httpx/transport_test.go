@@ -0,0 +1,131 @@
+package httpx
+
+import (
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewTransport_TunedForPooling(t *testing.T) {
+	tr := NewTransport()
+	if !tr.ForceAttemptHTTP2 {
+		t.Fatalf("expected HTTP/2 enabled")
+	}
+	if tr.DisableKeepAlives {
+		t.Fatalf("expected keep-alives enabled")
+	}
+	if tr.MaxIdleConnsPerHost <= 2 {
+		t.Fatalf("expected per-host idle pool > default, got %d", tr.MaxIdleConnsPerHost)
+	}
+	if tr.IdleConnTimeout <= 0 {
+		t.Fatalf("expected idle timeout")
+	}
+}
+
+func TestSharedTransport_ReturnsSameInstance(t *testing.T) {
+	if SharedTransport() != SharedTransport() {
+		t.Fatalf("expected shared transport to be reused")
+	}
+}
+
+func TestNewTransportWith_ZeroOptionsMatchesDefaults(t *testing.T) {
+	tr, err := NewTransportWith(TransportOptions{})
+	if err != nil {
+		t.Fatalf("transport: %v", err)
+	}
+	if tr.TLSClientConfig != nil {
+		t.Fatalf("expected default TLS config")
+	}
+	if !(TransportOptions{}).IsZero() || (TransportOptions{Proxy: "http://p:1"}).IsZero() {
+		t.Fatalf("IsZero mismatch")
+	}
+}
+
+func TestNewTransportWith_CAFileTrustsServer(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+	}))
+	defer srv.Close()
+
+	// Without the CA the handshake fails.
+	if _, err := (&http.Client{Transport: NewTransport()}).Get(srv.URL); err == nil {
+		t.Fatalf("expected unknown authority error")
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(caFile, pemBytes, 0o600); err != nil {
+		t.Fatalf("write ca: %v", err)
+	}
+	tr, err := NewTransportWith(TransportOptions{CAFile: caFile})
+	if err != nil {
+		t.Fatalf("transport: %v", err)
+	}
+	resp, err := (&http.Client{Transport: tr}).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != 204 {
+		t.Fatalf("status: %d", resp.StatusCode)
+	}
+}
+
+func TestNewTransportWith_InsecureSkipVerify(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+	}))
+	defer srv.Close()
+
+	tr, err := NewTransportWith(TransportOptions{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatalf("transport: %v", err)
+	}
+	resp, err := (&http.Client{Transport: tr}).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestNewTransportWith_Proxy(t *testing.T) {
+	tr, err := NewTransportWith(TransportOptions{Proxy: "http://proxy.internal:3128"})
+	if err != nil {
+		t.Fatalf("transport: %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://api.example.com/x", nil)
+	u, err := tr.Proxy(req)
+	if err != nil || u == nil || u.Host != "proxy.internal:3128" {
+		t.Fatalf("proxy: got %v, %v", u, err)
+	}
+
+	if _, err := NewTransportWith(TransportOptions{Proxy: "not a url"}); err == nil {
+		t.Fatalf("expected invalid proxy error")
+	}
+}
+
+func TestNewTransportWith_ConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	cases := map[string]TransportOptions{
+		"missing ca file":  {CAFile: filepath.Join(dir, "nope.pem")},
+		"cert without key": {ClientCert: filepath.Join(dir, "c.pem")},
+		"missing cert":     {ClientCert: filepath.Join(dir, "c.pem"), ClientKey: filepath.Join(dir, "k.pem")},
+	}
+	for name, opts := range cases {
+		if _, err := NewTransportWith(opts); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+func TestErrorTransport_FailsEveryRequest(t *testing.T) {
+	want := errors.New("bad tls config")
+	_, err := (&http.Client{Transport: ErrorTransport{Err: want}}).Get("http://example.invalid")
+	if !errors.Is(err, want) {
+		t.Fatalf("expected wrapped config error, got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+// Package ical writes RFC 5545 iCalendar files of all-day events.
+package ical
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ProdID identifies the producer in every calendar written.
+const ProdID = "-//Overland East Bay//ebo//EN"
+
+// Event statuses (RFC 5545 STATUS for VEVENT).
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusTentative = "TENTATIVE"
+	StatusCancelled = "CANCELLED"
+)
+
+// Event is one all-day event. Empty text fields are omitted.
+type Event struct {
+	// UID must stay the same across exports so calendars update the event on
+	// re-import instead of adding a copy.
+	UID string
+	// Start and End are the first and last day of the event, inclusive; only
+	// the date is used. A zero End means a one-day event.
+	Start, End  time.Time
+	Summary     string
+	Description string
+	Location    string
+	Geo         *Geo
+	Status      string
+}
+
+// Geo is a latitude/longitude pair in decimal degrees.
+type Geo struct {
+	Latitude, Longitude float64
+}
+
+// Write writes a VCALENDAR with one VEVENT per event. stamp is every event's
+// DTSTAMP (the time the file was created).
+func Write(w io.Writer, events []Event, stamp time.Time) error {
+	var b strings.Builder
+	line := func(s string) {
+		b.WriteString(fold(s))
+	}
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:" + ProdID)
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	for _, e := range events {
+		end := e.End
+		if end.IsZero() || end.Before(e.Start) {
+			end = e.Start
+		}
+		line("BEGIN:VEVENT")
+		line("UID:" + text(e.UID))
+		line("DTSTAMP:" + stamp.UTC().Format("20060102T150405Z"))
+		line("DTSTART;VALUE=DATE:" + e.Start.Format("20060102"))
+		// DTEND of an all-day event is exclusive: the day after the last.
+		line("DTEND;VALUE=DATE:" + end.AddDate(0, 0, 1).Format("20060102"))
+		if e.Summary != "" {
+			line("SUMMARY:" + text(e.Summary))
+		}
+		if e.Description != "" {
+			line("DESCRIPTION:" + text(e.Description))
+		}
+		if e.Location != "" {
+			line("LOCATION:" + text(e.Location))
+		}
+		if e.Geo != nil {
+			line(fmt.Sprintf("GEO:%s;%s", coord(e.Geo.Latitude), coord(e.Geo.Longitude)))
+		}
+		if e.Status != "" {
+			line("STATUS:" + e.Status)
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func coord(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// text escapes a TEXT value: backslash, semicolon, comma and newlines.
+func text(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// fold ends a content line with CRLF, folding it into lines of at most 75
+// octets (continuations start with a space) without splitting a UTF-8
+// character.
+func fold(s string) string {
+	var b strings.Builder
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut] + "\r\n ")
+		s = s[cut:]
+		limit = 74 // the leading space counts
+	}
+	b.WriteString(s + "\r\n")
+	return b.String()
+}
@@ -0,0 +1,91 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestWrite_AllDayEvents(t *testing.T) {
+	events := []Event{
+		{
+			UID:         "ebo-trip-t1",
+			Start:       day("2026-05-02"),
+			End:         day("2026-05-04"),
+			Summary:     "Rubicon, spring run",
+			Description: "Bring recovery gear; CB.\nLine two",
+			Location:    `Loon Lake \ trailhead`,
+			Geo:         &Geo{Latitude: 38.98, Longitude: -120.32},
+			Status:      StatusConfirmed,
+		},
+		{UID: "ebo-trip-t2", Start: day("2026-06-01"), Status: StatusCancelled},
+	}
+	var b bytes.Buffer
+	if err := Write(&b, events, time.Date(2026, 4, 1, 9, 30, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Overland East Bay//ebo//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"BEGIN:VEVENT",
+		"UID:ebo-trip-t1",
+		"DTSTAMP:20260401T093000Z",
+		"DTSTART;VALUE=DATE:20260502",
+		"DTEND;VALUE=DATE:20260505",
+		`SUMMARY:Rubicon\, spring run`,
+		`DESCRIPTION:Bring recovery gear\; CB.\nLine two`,
+		`LOCATION:Loon Lake \\ trailhead`,
+		"GEO:38.98;-120.32",
+		"STATUS:CONFIRMED",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:ebo-trip-t2",
+		"DTSTAMP:20260401T093000Z",
+		"DTSTART;VALUE=DATE:20260601",
+		"DTEND;VALUE=DATE:20260602",
+		"STATUS:CANCELLED",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	if got := b.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFold_LongLinesAndMultibyte(t *testing.T) {
+	s := "DESCRIPTION:" + strings.Repeat("é", 70)
+	folded := fold(s)
+	lines := strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected folding: %q", folded)
+	}
+	var joined strings.Builder
+	for i, l := range lines {
+		if len(l) > 75 {
+			t.Errorf("line %d is %d octets", i, len(l))
+		}
+		if i > 0 {
+			if !strings.HasPrefix(l, " ") {
+				t.Errorf("continuation %d: %q", i, l)
+			}
+			l = l[1:]
+		}
+		joined.WriteString(l)
+	}
+	if joined.String() != s {
+		t.Fatalf("unfolded %q", joined.String())
+	}
+}
@@ -36,8 +36,13 @@ func (RealSleeper) Sleep(ctx context.Context, d time.Duration) error {
 type Discovery struct {
 	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
 	TokenEndpoint               string `json:"token_endpoint"`
+	// GrantTypesSupported is optional in discovery; empty means unknown.
+	GrantTypesSupported []string `json:"grant_types_supported"`
 }
 
+// DeviceCodeGrantType is the grant_type of the device authorization grant.
+const DeviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
 type DeviceCodeResponse struct {
 	DeviceCode              string `json:"device_code"`
 	UserCode                string `json:"user_code"`
@@ -163,7 +168,7 @@ func (c Client) PollToken(ctx context.Context, tokenEndpoint, clientID, deviceCo
 		}
 
 		form := url.Values{}
-		form.Set("grant_type", "urn:ietf:params:oauth:grant-type:device_code")
+		form.Set("grant_type", DeviceCodeGrantType)
 		form.Set("device_code", deviceCode)
 		form.Set("client_id", clientID)
 		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, tokenEndpoint, strings.NewReader(form.Encode()))
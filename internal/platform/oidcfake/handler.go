@@ -0,0 +1,383 @@
+package oidcfake
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Handler serves the issuer's endpoints. The issuer URL is derived from each
+// request's Host, so it works unchanged behind httptest.Server or any port.
+//
+//	GET  /.well-known/openid-configuration
+//	GET  /jwks
+//	POST /device            device authorization (RFC 8628)
+//	GET  /device            verification page
+//	POST /device/approve    user_code, subject, deny=1
+//	GET  /authorize         authorization code + PKCE (S256, loopback redirect)
+//	POST /token             device_code, authorization_code, refresh_token
+//	POST /revoke            refresh or access token (RFC 7009)
+func (i *Issuer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", i.handleDiscovery)
+	mux.HandleFunc("GET /jwks", i.handleJWKS)
+	mux.HandleFunc("POST /device", i.handleDeviceAuthorization)
+	mux.HandleFunc("GET /device", i.handleVerificationPage)
+	mux.HandleFunc("POST /device/approve", i.handleApprove)
+	mux.HandleFunc("GET /authorize", i.handleAuthorize)
+	mux.HandleFunc("POST /token", i.handleToken)
+	mux.HandleFunc("POST /revoke", i.handleRevoke)
+	return mux
+}
+
+func issuerURL(r *http.Request) string {
+	return "http://" + r.Host
+}
+
+func (i *Issuer) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	base := issuerURL(r)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                base,
+		"authorization_endpoint":                base + "/authorize",
+		"device_authorization_endpoint":         base + "/device",
+		"token_endpoint":                        base + "/token",
+		"revocation_endpoint":                   base + "/revoke",
+		"jwks_uri":                              base + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"ES256"},
+		"token_endpoint_auth_methods_supported": []string{"none"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"grant_types_supported": []string{
+			"urn:ietf:params:oauth:grant-type:device_code",
+			"authorization_code",
+			"refresh_token",
+		},
+	})
+}
+
+func (i *Issuer) handleJWKS(w http.ResponseWriter, _ *http.Request) {
+	pub := i.key.PublicKey
+	coord := func(n *big.Int) string {
+		b := make([]byte, 32)
+		n.FillBytes(b)
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "EC",
+			"crv": "P-256",
+			"x":   coord(pub.X),
+			"y":   coord(pub.Y),
+			"kid": i.kid,
+			"use": "sig",
+			"alg": "ES256",
+		}},
+	})
+}
+
+func (i *Issuer) handleDeviceAuthorization(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", "malformed form body")
+		return
+	}
+	clientID := r.PostForm.Get("client_id")
+	if !i.clientAllowed(clientID) {
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_client", "unknown client_id")
+		return
+	}
+	hint := r.PostForm.Get("login_hint")
+	if hint != "" && !i.knownSubject(hint) {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", "unknown login_hint")
+		return
+	}
+
+	g := &deviceGrant{
+		clientID: clientID,
+		scopes:   strings.Fields(r.PostForm.Get("scope")),
+		expires:  i.now().Add(deviceCodeTTL),
+	}
+	if i.opts.AutoApprove {
+		g.state = deviceApproved
+		g.subject = hint
+		if g.subject == "" {
+			g.subject = i.DefaultSubject()
+		}
+	}
+	deviceCode := "dc-" + randomString(16)
+	userCode := newUserCode()
+
+	i.mu.Lock()
+	for i.userCodes[userCode] != "" {
+		userCode = newUserCode()
+	}
+	i.devices[deviceCode] = g
+	i.userCodes[userCode] = deviceCode
+	i.mu.Unlock()
+
+	verification := issuerURL(r) + "/device"
+	writeJSON(w, http.StatusOK, map[string]any{
+		"device_code":               deviceCode,
+		"user_code":                 userCode,
+		"verification_uri":          verification,
+		"verification_uri_complete": verification + "?user_code=" + url.QueryEscape(userCode),
+		"expires_in":                int(deviceCodeTTL.Seconds()),
+		"interval":                  i.opts.Interval,
+	})
+}
+
+var verificationPage = template.Must(template.New("device").Parse(`<!doctype html>
+<html><head><title>Fake OIDC issuer</title></head>
+<body>
+<h1>Sign in (fake issuer)</h1>
+<form method="post" action="/device/approve">
+<p><label>Code <input name="user_code" value="{{.UserCode}}"></label></p>
+<p><label>Subject <select name="subject">{{range .Subjects}}<option>{{.}}</option>{{end}}</select></label></p>
+<p><button type="submit">Approve</button> <button type="submit" name="deny" value="1">Deny</button></p>
+</form>
+</body></html>
+`))
+
+func (i *Issuer) handleVerificationPage(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = verificationPage.Execute(w, map[string]any{
+		"UserCode": r.URL.Query().Get("user_code"),
+		"Subjects": i.opts.Subjects,
+	})
+}
+
+func (i *Issuer) handleApprove(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", "malformed form body")
+		return
+	}
+	userCode := r.PostForm.Get("user_code")
+	subject := r.PostForm.Get("subject")
+	var err error
+	if deny, _ := strconv.ParseBool(r.PostForm.Get("deny")); deny {
+		err = i.Deny(userCode)
+	} else {
+		err = i.Approve(userCode, subject)
+		if subject == "" {
+			subject = i.DefaultSubject()
+		}
+	}
+	switch {
+	case errors.Is(err, ErrUnknownUserCode):
+		writeOAuthError(w, http.StatusNotFound, "invalid_request", err.Error())
+	case err != nil:
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", err.Error())
+	default:
+		writeJSON(w, http.StatusOK, map[string]any{"user_code": normalizeUserCode(userCode), "subject": subject})
+	}
+}
+
+func (i *Issuer) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	clientID := q.Get("client_id")
+	redirectURI := q.Get("redirect_uri")
+	// Errors about the client or redirect URI must not be redirected.
+	if !i.clientAllowed(clientID) {
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+		return
+	}
+	redirect, err := url.Parse(redirectURI)
+	if err != nil || redirect.Scheme != "http" || !isLoopback(redirect.Hostname()) {
+		http.Error(w, "redirect_uri must be an http loopback URL", http.StatusBadRequest)
+		return
+	}
+
+	fail := func(code, desc string) {
+		v := redirect.Query()
+		v.Set("error", code)
+		v.Set("error_description", desc)
+		if s := q.Get("state"); s != "" {
+			v.Set("state", s)
+		}
+		redirect.RawQuery = v.Encode()
+		http.Redirect(w, r, redirect.String(), http.StatusFound)
+	}
+	if q.Get("response_type") != "code" {
+		fail("unsupported_response_type", "only response_type=code is supported")
+		return
+	}
+	if q.Get("code_challenge") == "" || q.Get("code_challenge_method") != "S256" {
+		fail("invalid_request", "PKCE with code_challenge_method=S256 is required")
+		return
+	}
+	subject := q.Get("login_hint")
+	if subject == "" {
+		subject = i.DefaultSubject()
+	}
+	if !i.knownSubject(subject) {
+		fail("access_denied", "unknown login_hint")
+		return
+	}
+
+	code := "ac-" + randomString(16)
+	i.mu.Lock()
+	i.codes[code] = &authCode{
+		clientID:    clientID,
+		redirectURI: redirectURI,
+		challenge:   q.Get("code_challenge"),
+		scopes:      strings.Fields(q.Get("scope")),
+		subject:     subject,
+		nonce:       q.Get("nonce"),
+		expires:     i.now().Add(authCodeTTL),
+	}
+	i.mu.Unlock()
+
+	v := redirect.Query()
+	v.Set("code", code)
+	if s := q.Get("state"); s != "" {
+		v.Set("state", s)
+	}
+	redirect.RawQuery = v.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (i *Issuer) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", "malformed form body")
+		return
+	}
+	f := r.PostForm
+	clientID := f.Get("client_id")
+	if !i.clientAllowed(clientID) {
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_client", "unknown client_id")
+		return
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	var (
+		res     tokenResponse
+		errCode string
+		errDesc string
+	)
+	switch f.Get("grant_type") {
+	case "urn:ietf:params:oauth:grant-type:device_code":
+		res, errCode, errDesc = i.exchangeDeviceCode(issuerURL(r), clientID, f.Get("device_code"))
+	case "authorization_code":
+		res, errCode, errDesc = i.exchangeAuthCode(issuerURL(r), clientID, f)
+	case "refresh_token":
+		res, errCode, errDesc = i.exchangeRefreshToken(issuerURL(r), clientID, f.Get("refresh_token"))
+	default:
+		errCode, errDesc = "unsupported_grant_type", "unsupported grant_type"
+	}
+	if errCode != "" {
+		writeOAuthError(w, http.StatusBadRequest, errCode, errDesc)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, res)
+}
+
+// The exchange helpers run with i.mu held.
+
+func (i *Issuer) exchangeDeviceCode(issuer, clientID, deviceCode string) (tokenResponse, string, string) {
+	g, ok := i.devices[deviceCode]
+	if !ok || g.clientID != clientID {
+		return tokenResponse{}, "invalid_grant", "unknown device_code"
+	}
+	if i.now().After(g.expires) {
+		return tokenResponse{}, "expired_token", "device code expired"
+	}
+	switch g.state {
+	case devicePending:
+		return tokenResponse{}, "authorization_pending", "waiting for approval"
+	case deviceDenied:
+		return tokenResponse{}, "access_denied", "the user denied the request"
+	case deviceUsed:
+		return tokenResponse{}, "invalid_grant", "device code already used"
+	}
+	g.state = deviceUsed
+	return i.issue(issuer, clientID, g.subject, g.scopes, ""), "", ""
+}
+
+func (i *Issuer) exchangeAuthCode(issuer, clientID string, f url.Values) (tokenResponse, string, string) {
+	c, ok := i.codes[f.Get("code")]
+	if !ok {
+		return tokenResponse{}, "invalid_grant", "unknown or used authorization code"
+	}
+	// Codes are single-use, even when the exchange fails.
+	delete(i.codes, f.Get("code"))
+	if i.now().After(c.expires) {
+		return tokenResponse{}, "invalid_grant", "authorization code expired"
+	}
+	if c.clientID != clientID || c.redirectURI != f.Get("redirect_uri") {
+		return tokenResponse{}, "invalid_grant", "client_id or redirect_uri mismatch"
+	}
+	sum := sha256.Sum256([]byte(f.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != c.challenge {
+		return tokenResponse{}, "invalid_grant", "code_verifier does not match code_challenge"
+	}
+	return i.issue(issuer, clientID, c.subject, c.scopes, c.nonce), "", ""
+}
+
+func (i *Issuer) exchangeRefreshToken(issuer, clientID, token string) (tokenResponse, string, string) {
+	g, ok := i.refresh[token]
+	if !ok || g.clientID != clientID {
+		return tokenResponse{}, "invalid_grant", "unknown or revoked refresh token"
+	}
+	// Refresh tokens rotate.
+	delete(i.refresh, token)
+	return i.issue(issuer, clientID, g.subject, g.scopes, ""), "", ""
+}
+
+func (i *Issuer) handleRevoke(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", "malformed form body")
+		return
+	}
+	token := r.PostForm.Get("token")
+	i.mu.Lock()
+	delete(i.refresh, token)
+	i.mu.Unlock()
+	// Unknown tokens are not an error (RFC 7009 §2.2); Verify checks the
+	// signature so only our own tokens' IDs are recorded.
+	if c, err := i.Verify(token); err == nil {
+		i.mu.Lock()
+		i.revoked[c.ID] = true
+		i.mu.Unlock()
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (i *Issuer) clientAllowed(clientID string) bool {
+	if clientID == "" {
+		return false
+	}
+	return i.opts.ClientID == "" || clientID == i.opts.ClientID
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func bigInt(b []byte) *big.Int {
+	return new(big.Int).SetBytes(b)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeOAuthError(w http.ResponseWriter, status int, code, desc string) {
+	writeJSON(w, status, map[string]string{"error": code, "error_description": desc})
+}
@@ -0,0 +1,337 @@
+// Package oidcfake is a loopback OpenID Connect issuer for local development
+// and tests.
+//
+// It serves discovery, the device authorization grant (RFC 8628), the
+// authorization code grant with PKCE (RFC 7636), refresh tokens, token
+// revocation (RFC 7009) and a JWKS. Tokens are ES256-signed JWTs for a fixed
+// set of subjects. Device codes are approved automatically, or explicitly via
+// Approve / POST /device/approve (what `ebo dev oidc-server --approve` does).
+//
+// Nothing is persisted and nobody is authenticated: any listed subject can be
+// signed in by anyone who can reach the server. Bind it to loopback only.
+package oidcfake
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Options configures an Issuer.
+type Options struct {
+	// Subjects may sign in; the first is the default. Empty means "alice".
+	Subjects []string
+	// ClientID, when set, is the only client accepted. Empty accepts any.
+	ClientID string
+	// AutoApprove approves device codes when they are issued, as the
+	// request's login_hint or the default subject.
+	AutoApprove bool
+	// TokenTTL is the access/ID token lifetime. Zero means one hour.
+	TokenTTL time.Duration
+	// Interval is the device-flow polling interval, in seconds. Zero means 5.
+	Interval int
+	// Now is the clock; nil means time.Now.
+	Now func() time.Time
+}
+
+// Issuer holds the signing key and all grants in memory; it is safe for
+// concurrent use.
+type Issuer struct {
+	opts Options
+	key  *ecdsa.PrivateKey
+	kid  string
+
+	mu        sync.Mutex
+	devices   map[string]*deviceGrant // by device code
+	userCodes map[string]string       // user code -> device code
+	codes     map[string]*authCode
+	refresh   map[string]refreshGrant
+	revoked   map[string]bool // access/ID token jti
+	seq       int
+}
+
+type deviceState int
+
+const (
+	devicePending deviceState = iota
+	deviceApproved
+	deviceDenied
+	deviceUsed
+)
+
+type deviceGrant struct {
+	clientID string
+	scopes   []string
+	state    deviceState
+	subject  string
+	expires  time.Time
+}
+
+type authCode struct {
+	clientID    string
+	redirectURI string
+	challenge   string
+	scopes      []string
+	subject     string
+	nonce       string
+	expires     time.Time
+}
+
+type refreshGrant struct {
+	clientID string
+	subject  string
+	scopes   []string
+}
+
+// Errors returned by Approve and Deny.
+var (
+	ErrUnknownUserCode = errors.New("unknown or expired user code")
+	ErrUnknownSubject  = errors.New("unknown subject")
+)
+
+const (
+	deviceCodeTTL = 10 * time.Minute
+	authCodeTTL   = time.Minute
+)
+
+// New returns an Issuer with a fresh signing key.
+func New(opts Options) (*Issuer, error) {
+	if len(opts.Subjects) == 0 {
+		opts.Subjects = []string{"alice"}
+	}
+	if opts.TokenTTL <= 0 {
+		opts.TokenTTL = time.Hour
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 5
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate signing key: %w", err)
+	}
+	return &Issuer{
+		opts:      opts,
+		key:       key,
+		kid:       randomString(8),
+		devices:   map[string]*deviceGrant{},
+		userCodes: map[string]string{},
+		codes:     map[string]*authCode{},
+		refresh:   map[string]refreshGrant{},
+		revoked:   map[string]bool{},
+	}, nil
+}
+
+func (i *Issuer) now() time.Time {
+	if i.opts.Now != nil {
+		return i.opts.Now()
+	}
+	return time.Now()
+}
+
+// DefaultSubject is the subject used when a sign-in does not name one.
+func (i *Issuer) DefaultSubject() string {
+	return i.opts.Subjects[0]
+}
+
+func (i *Issuer) knownSubject(s string) bool {
+	for _, v := range i.opts.Subjects {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Approve signs in the device code with userCode as subject ("" means the
+// default subject).
+func (i *Issuer) Approve(userCode, subject string) error {
+	if subject == "" {
+		subject = i.DefaultSubject()
+	}
+	if !i.knownSubject(subject) {
+		return fmt.Errorf("%w %q", ErrUnknownSubject, subject)
+	}
+	return i.decide(userCode, func(g *deviceGrant) {
+		g.state = deviceApproved
+		g.subject = subject
+	})
+}
+
+// Deny rejects the device code with userCode; the client gets access_denied.
+func (i *Issuer) Deny(userCode string) error {
+	return i.decide(userCode, func(g *deviceGrant) { g.state = deviceDenied })
+}
+
+func (i *Issuer) decide(userCode string, apply func(*deviceGrant)) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	dc, ok := i.userCodes[normalizeUserCode(userCode)]
+	if !ok {
+		return ErrUnknownUserCode
+	}
+	g := i.devices[dc]
+	if g.state != devicePending || i.now().After(g.expires) {
+		return ErrUnknownUserCode
+	}
+	apply(g)
+	return nil
+}
+
+// Claims are the verified claims of a token issued by this Issuer.
+type Claims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"`
+	Audience  string `json:"aud"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+	ID        string `json:"jti"`
+	Scope     string `json:"scope,omitempty"`
+	Nonce     string `json:"nonce,omitempty"`
+}
+
+// Verify checks a token's signature, expiry and revocation, so an httptest
+// API can authenticate bearer tokens the way a real resource server would.
+func (i *Issuer) Verify(token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, errors.New("malformed token")
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(sig) != 64 {
+		return Claims{}, errors.New("malformed signature")
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if !ecdsa.Verify(&i.key.PublicKey, digest[:], bigInt(sig[:32]), bigInt(sig[32:])) {
+		return Claims{}, errors.New("invalid signature")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Claims{}, errors.New("malformed payload")
+	}
+	var c Claims
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return Claims{}, errors.New("malformed payload")
+	}
+	if i.now().Unix() >= c.ExpiresAt {
+		return Claims{}, errors.New("token expired")
+	}
+	i.mu.Lock()
+	revoked := i.revoked[c.ID]
+	i.mu.Unlock()
+	if revoked {
+		return Claims{}, errors.New("token revoked")
+	}
+	return c, nil
+}
+
+// sign returns a compact ES256 JWS of claims.
+func (i *Issuer) sign(claims Claims) string {
+	enc := base64.RawURLEncoding
+	header, _ := json.Marshal(map[string]string{"alg": "ES256", "typ": "JWT", "kid": i.kid})
+	payload, _ := json.Marshal(claims)
+	signingInput := enc.EncodeToString(header) + "." + enc.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, i.key, digest[:])
+	if err != nil {
+		// crypto/rand does not fail on supported platforms.
+		panic(err)
+	}
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return signingInput + "." + enc.EncodeToString(sig)
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// issue mints tokens for subject. Callers hold i.mu.
+func (i *Issuer) issue(issuer, clientID, subject string, scopes []string, nonce string) tokenResponse {
+	now := i.now()
+	scope := strings.Join(scopes, " ")
+	base := Claims{
+		Issuer:    issuer,
+		Subject:   subject,
+		Audience:  clientID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(i.opts.TokenTTL).Unix(),
+	}
+
+	access := base
+	access.ID = i.nextID("at")
+	access.Scope = scope
+	res := tokenResponse{
+		AccessToken: i.sign(access),
+		TokenType:   "Bearer",
+		ExpiresIn:   int(i.opts.TokenTTL / time.Second),
+		Scope:       scope,
+	}
+	if hasScope(scopes, "openid") {
+		id := base
+		id.ID = i.nextID("id")
+		id.Nonce = nonce
+		res.IDToken = i.sign(id)
+	}
+	res.RefreshToken = "rt-" + randomString(16)
+	i.refresh[res.RefreshToken] = refreshGrant{clientID: clientID, subject: subject, scopes: scopes}
+	return res
+}
+
+func (i *Issuer) nextID(prefix string) string {
+	i.seq++
+	return fmt.Sprintf("%s-%d-%s", prefix, i.seq, randomString(4))
+}
+
+func hasScope(scopes []string, s string) bool {
+	for _, v := range scopes {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func randomString(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// newUserCode returns a short code in the usual XXXX-XXXX shape, from an
+// alphabet without look-alike characters.
+func newUserCode() string {
+	const alphabet = "BCDFGHJKLMNPQRSTVWXZ"
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	out := make([]byte, 0, 9)
+	for n, v := range b {
+		if n == 4 {
+			out = append(out, '-')
+		}
+		out = append(out, alphabet[int(v)%len(alphabet)])
+	}
+	return string(out)
+}
+
+func normalizeUserCode(s string) string {
+	s = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(s), "-", ""))
+	if len(s) == 8 {
+		return s[:4] + "-" + s[4:]
+	}
+	return s
+}
@@ -0,0 +1,297 @@
+package oidcfake
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Overland-East-Bay/trip-planner-cli/internal/platform/oidcdevice"
+)
+
+// approvingSleeper plays the user: the first time the client waits, it
+// approves the pending user code.
+type approvingSleeper struct {
+	approve func() error
+	calls   int
+}
+
+func (s *approvingSleeper) Sleep(ctx context.Context, d time.Duration) error {
+	s.calls++
+	if s.calls == 1 {
+		return s.approve()
+	}
+	return nil
+}
+
+func newServer(t *testing.T, opts Options) (*Issuer, *httptest.Server) {
+	t.Helper()
+	iss, err := New(opts)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	srv := httptest.NewServer(iss.Handler())
+	t.Cleanup(srv.Close)
+	return iss, srv
+}
+
+func postForm(t *testing.T, endpoint string, form url.Values) (int, map[string]any) {
+	t.Helper()
+	resp, err := http.PostForm(endpoint, form)
+	if err != nil {
+		t.Fatalf("post %s: %v", endpoint, err)
+	}
+	defer resp.Body.Close()
+	var body map[string]any
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	return resp.StatusCode, body
+}
+
+func TestDeviceFlow_PendingUntilApproved(t *testing.T) {
+	iss, srv := newServer(t, Options{Subjects: []string{"alice", "bob"}, ClientID: "cli"})
+	ctx := context.Background()
+
+	d, err := oidcdevice.Discover(ctx, srv.Client(), srv.URL)
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+	sleeper := &approvingSleeper{}
+	c := oidcdevice.Client{HTTP: srv.Client(), Sleeper: sleeper}
+	dc, err := c.RequestDeviceCode(ctx, d.DeviceAuthorizationEndpoint, "cli", []string{"openid", "profile"})
+	if err != nil {
+		t.Fatalf("device code: %v", err)
+	}
+	sleeper.approve = func() error { return iss.Approve(strings.ToLower(dc.UserCode), "bob") }
+
+	tr, err := c.PollToken(ctx, d.TokenEndpoint, "cli", dc.DeviceCode, time.Second)
+	if err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if sleeper.calls != 1 || tr.TokenType != "Bearer" || tr.ExpiresIn != 3600 {
+		t.Fatalf("calls=%d token=%+v", sleeper.calls, tr)
+	}
+	claims, err := iss.Verify(tr.AccessToken)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if claims.Subject != "bob" || claims.Issuer != srv.URL || claims.Audience != "cli" || claims.Scope != "openid profile" {
+		t.Fatalf("claims: %+v", claims)
+	}
+
+	// Device codes are single-use.
+	if status, body := postForm(t, d.TokenEndpoint, url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:device_code"}, "client_id": {"cli"}, "device_code": {dc.DeviceCode},
+	}); status != 400 || body["error"] != "invalid_grant" {
+		t.Fatalf("reuse: %d %v", status, body)
+	}
+	if err := iss.Approve(dc.UserCode, ""); !errors.Is(err, ErrUnknownUserCode) {
+		t.Fatalf("approve used code: %v", err)
+	}
+}
+
+func TestDeviceFlow_AutoApproveAndDeny(t *testing.T) {
+	_, srv := newServer(t, Options{AutoApprove: true, Subjects: []string{"alice", "bob"}})
+	status, dc := postForm(t, srv.URL+"/device", url.Values{"client_id": {"any"}, "login_hint": {"bob"}})
+	if status != 200 {
+		t.Fatalf("device: %d %v", status, dc)
+	}
+	status, tok := postForm(t, srv.URL+"/token", url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:device_code"}, "client_id": {"any"}, "device_code": {dc["device_code"].(string)},
+	})
+	if status != 200 || tok["access_token"] == nil || tok["id_token"] != nil {
+		t.Fatalf("token: %d %v", status, tok)
+	}
+
+	iss, srv := newServer(t, Options{})
+	_, dc = postForm(t, srv.URL+"/device", url.Values{"client_id": {"cli"}})
+	if status, body := postForm(t, srv.URL+"/device/approve", url.Values{"user_code": {dc["user_code"].(string)}, "deny": {"1"}}); status != 200 {
+		t.Fatalf("deny: %d %v", status, body)
+	}
+	_, err := (oidcdevice.Client{HTTP: srv.Client()}).PollToken(context.Background(), srv.URL+"/token", "cli", dc["device_code"].(string), time.Second)
+	if err == nil || err.Error() != "access_denied" {
+		t.Fatalf("poll denied: %v", err)
+	}
+	if err := iss.Approve("NOPE-NOPE", ""); !errors.Is(err, ErrUnknownUserCode) {
+		t.Fatalf("unknown code: %v", err)
+	}
+}
+
+func TestDeviceFlow_RejectsUnknownClientAndSubject(t *testing.T) {
+	iss, srv := newServer(t, Options{ClientID: "cli"})
+	if status, body := postForm(t, srv.URL+"/device", url.Values{"client_id": {"other"}}); status != 401 || body["error"] != "invalid_client" {
+		t.Fatalf("client: %d %v", status, body)
+	}
+	_, dc := postForm(t, srv.URL+"/device", url.Values{"client_id": {"cli"}})
+	if err := iss.Approve(dc["user_code"].(string), "mallory"); !errors.Is(err, ErrUnknownSubject) {
+		t.Fatalf("subject: %v", err)
+	}
+}
+
+func TestDeviceFlow_ExpiredCode(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, srv := newServer(t, Options{AutoApprove: true, Now: func() time.Time { return now }})
+	_, dc := postForm(t, srv.URL+"/device", url.Values{"client_id": {"cli"}})
+	now = now.Add(deviceCodeTTL + time.Second)
+	status, body := postForm(t, srv.URL+"/token", url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:device_code"}, "client_id": {"cli"}, "device_code": {dc["device_code"].(string)},
+	})
+	if status != 400 || body["error"] != "expired_token" {
+		t.Fatalf("got %d %v", status, body)
+	}
+}
+
+func TestAuthorizationCode_PKCE(t *testing.T) {
+	iss, srv := newServer(t, Options{Subjects: []string{"alice", "carol"}})
+	noFollow := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+
+	verifier := "a-long-enough-code-verifier-for-the-test-0123456789"
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+	authorize := func(redirect string) *http.Response {
+		t.Helper()
+		q := url.Values{
+			"response_type": {"code"}, "client_id": {"cli"}, "redirect_uri": {redirect},
+			"code_challenge": {challenge}, "code_challenge_method": {"S256"},
+			"state": {"st"}, "scope": {"openid"}, "login_hint": {"carol"}, "nonce": {"n1"},
+		}
+		resp, err := noFollow.Get(srv.URL + "/authorize?" + q.Encode())
+		if err != nil {
+			t.Fatalf("authorize: %v", err)
+		}
+		_ = resp.Body.Close()
+		return resp
+	}
+
+	if resp := authorize("https://evil.example.com/cb"); resp.StatusCode != 400 {
+		t.Fatalf("non-loopback redirect: %d", resp.StatusCode)
+	}
+	resp := authorize("http://127.0.0.1:9999/cb")
+	loc, _ := url.Parse(resp.Header.Get("Location"))
+	code := loc.Query().Get("code")
+	if resp.StatusCode != 302 || code == "" || loc.Query().Get("state") != "st" {
+		t.Fatalf("redirect: %d %s", resp.StatusCode, loc)
+	}
+
+	exchange := func(code, verifier string) (int, map[string]any) {
+		return postForm(t, srv.URL+"/token", url.Values{
+			"grant_type": {"authorization_code"}, "client_id": {"cli"}, "code": {code},
+			"redirect_uri": {"http://127.0.0.1:9999/cb"}, "code_verifier": {verifier},
+		})
+	}
+	if status, body := exchange(code, "wrong-verifier"); status != 400 || body["error"] != "invalid_grant" {
+		t.Fatalf("bad verifier: %d %v", status, body)
+	}
+	// The failed exchange burned the code.
+	if status, _ := exchange(code, verifier); status != 400 {
+		t.Fatalf("reused code: %d", status)
+	}
+
+	resp = authorize("http://localhost:9999/cb")
+	loc, _ = url.Parse(resp.Header.Get("Location"))
+	status, body := postForm(t, srv.URL+"/token", url.Values{
+		"grant_type": {"authorization_code"}, "client_id": {"cli"}, "code": {loc.Query().Get("code")},
+		"redirect_uri": {"http://localhost:9999/cb"}, "code_verifier": {verifier},
+	})
+	if status != 200 {
+		t.Fatalf("exchange: %d %v", status, body)
+	}
+	id, err := iss.Verify(body["id_token"].(string))
+	if err != nil || id.Subject != "carol" || id.Nonce != "n1" {
+		t.Fatalf("id token: %+v %v", id, err)
+	}
+}
+
+func TestRefreshAndRevoke(t *testing.T) {
+	iss, srv := newServer(t, Options{})
+	_, dc := postForm(t, srv.URL+"/device", url.Values{"client_id": {"cli"}})
+	if err := iss.Approve(dc["user_code"].(string), ""); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	_, tok := postForm(t, srv.URL+"/token", url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:device_code"}, "client_id": {"cli"}, "device_code": {dc["device_code"].(string)},
+	})
+
+	refresh := func(rt string) (int, map[string]any) {
+		return postForm(t, srv.URL+"/token", url.Values{"grant_type": {"refresh_token"}, "client_id": {"cli"}, "refresh_token": {rt}})
+	}
+	status, rotated := refresh(tok["refresh_token"].(string))
+	if status != 200 || rotated["refresh_token"] == tok["refresh_token"] {
+		t.Fatalf("refresh: %d %v", status, rotated)
+	}
+	if status, _ := refresh(tok["refresh_token"].(string)); status != 400 {
+		t.Fatalf("old refresh token still valid")
+	}
+
+	access := rotated["access_token"].(string)
+	if c, err := iss.Verify(access); err != nil || c.Subject != "alice" {
+		t.Fatalf("verify: %+v %v", c, err)
+	}
+	for _, token := range []string{access, rotated["refresh_token"].(string), "garbage"} {
+		if status, _ := postForm(t, srv.URL+"/revoke", url.Values{"token": {token}}); status != 200 {
+			t.Fatalf("revoke %q: %d", token, status)
+		}
+	}
+	if _, err := iss.Verify(access); err == nil {
+		t.Fatalf("revoked token still verifies")
+	}
+	if status, _ := refresh(rotated["refresh_token"].(string)); status != 400 {
+		t.Fatalf("revoked refresh token still valid")
+	}
+}
+
+func TestDiscoveryAndJWKS(t *testing.T) {
+	_, srv := newServer(t, Options{})
+	resp, err := http.Get(srv.URL + "/.well-known/openid-configuration")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	var doc map[string]any
+	_ = json.NewDecoder(resp.Body).Decode(&doc)
+	_ = resp.Body.Close()
+	for _, k := range []string{"authorization_endpoint", "device_authorization_endpoint", "token_endpoint", "revocation_endpoint", "jwks_uri"} {
+		if s, _ := doc[k].(string); !strings.HasPrefix(s, srv.URL+"/") {
+			t.Fatalf("%s: %v", k, doc[k])
+		}
+	}
+	if doc["issuer"] != srv.URL {
+		t.Fatalf("issuer: %v", doc["issuer"])
+	}
+
+	resp, err = http.Get(doc["jwks_uri"].(string))
+	if err != nil {
+		t.Fatalf("jwks: %v", err)
+	}
+	var jwks struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&jwks)
+	_ = resp.Body.Close()
+	if len(jwks.Keys) != 1 || jwks.Keys[0]["alg"] != "ES256" || len(jwks.Keys[0]["x"]) != 43 {
+		t.Fatalf("jwks: %+v", jwks)
+	}
+}
+
+func TestVerify_RejectsTamperedAndExpired(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	iss, err := New(Options{Now: func() time.Time { return now }, TokenTTL: time.Minute})
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	tok := iss.sign(Claims{Subject: "alice", ExpiresAt: now.Add(time.Minute).Unix()})
+	parts := strings.Split(tok, ".")
+	forged, _ := json.Marshal(Claims{Subject: "root", ExpiresAt: now.Add(time.Minute).Unix()})
+	if _, err := iss.Verify(parts[0] + "." + base64.RawURLEncoding.EncodeToString(forged) + "." + parts[2]); err == nil {
+		t.Fatalf("forged payload verified")
+	}
+	now = now.Add(2 * time.Minute)
+	if _, err := iss.Verify(tok); err == nil {
+		t.Fatalf("expired token verified")
+	}
+}
@@ -0,0 +1,125 @@
+// Package pager sends long human output through $EBO_PAGER/$PAGER when
+// stdout is a terminal.
+package pager
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Default is the pager used when none is configured: quit if the output fits
+// on one screen, pass colors through, and leave it on screen on exit.
+const Default = "less -FRX"
+
+// Command resolves the pager command line: EBO_PAGER, then configured (the
+// config file's pager), then PAGER, then Default. An empty or "off" value at
+// the first level that is set disables paging (ok is false); "cat" works too.
+func Command(lookupEnv func(string) (string, bool), configured string) (command string, ok bool) {
+	command = Default
+	if v, set := lookupEnv("EBO_PAGER"); set {
+		command = v
+	} else if configured != "" {
+		command = configured
+	} else if v, set := lookupEnv("PAGER"); set {
+		command = v
+	}
+	command = strings.TrimSpace(command)
+	switch strings.ToLower(command) {
+	case "", "off", "false", "0":
+		return "", false
+	}
+	return command, true
+}
+
+// Writer is stdout for the invocation. Writes go straight to Out until Page
+// is called; after that, the first Write starts the pager and everything is
+// piped to it. Close waits for the pager to exit.
+type Writer struct {
+	Out io.Writer
+
+	// Stderr is the pager's stderr; nil means os.Stderr.
+	Stderr io.Writer
+
+	command string
+	cmd     *exec.Cmd
+	in      io.WriteCloser
+	broken  bool
+}
+
+// IsTerminal reports whether Out is a terminal; only then is paging useful.
+func (w *Writer) IsTerminal() bool {
+	f, ok := w.Out.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// Fd is Out's file descriptor (if Out is a file), so terminal detection
+// (colors, width) sees through the Writer.
+func (w *Writer) Fd() uintptr {
+	if f, ok := w.Out.(*os.File); ok {
+		return f.Fd()
+	}
+	return ^uintptr(0)
+}
+
+// Page routes later writes through command. It has no effect unless Out is a
+// terminal.
+func (w *Writer) Page(command string) {
+	if w.IsTerminal() {
+		w.command = command
+	}
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.command != "" && w.cmd == nil {
+		w.start()
+	}
+	if w.in == nil {
+		return w.Out.Write(p)
+	}
+	if w.broken {
+		return len(p), nil
+	}
+	if _, err := w.in.Write(p); err != nil {
+		// The pager was quit before reading everything: drop the rest.
+		w.broken = true
+	}
+	return len(p), nil
+}
+
+// start runs the pager; if it cannot be started, output goes to Out.
+func (w *Writer) start() {
+	args := strings.Fields(w.command)
+	w.cmd = exec.Command(args[0], args[1:]...)
+	w.cmd.Stdout = w.Out
+	w.cmd.Stderr = w.Stderr
+	if w.cmd.Stderr == nil {
+		w.cmd.Stderr = os.Stderr
+	}
+	in, err := w.cmd.StdinPipe()
+	if err != nil {
+		return
+	}
+	if err := w.cmd.Start(); err != nil {
+		return
+	}
+	// Ctrl+C belongs to the pager (e.g. to stop a search in less).
+	signal.Ignore(os.Interrupt)
+	w.in = in
+}
+
+// Close ends the pager's input and waits for the user to quit it.
+func (w *Writer) Close() error {
+	if w.in == nil {
+		return nil
+	}
+	_ = w.in.Close()
+	err := w.cmd.Wait()
+	signal.Reset(os.Interrupt)
+	w.in = nil
+	return err
+}
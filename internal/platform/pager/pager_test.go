@@ -0,0 +1,79 @@
+package pager
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCommand_Precedence(t *testing.T) {
+	cases := []struct {
+		name       string
+		env        map[string]string
+		configured string
+		want       string
+		ok         bool
+	}{
+		{"default", nil, "", Default, true},
+		{"PAGER", map[string]string{"PAGER": "more"}, "", "more", true},
+		{"config beats PAGER", map[string]string{"PAGER": "more"}, "less -R", "less -R", true},
+		{"EBO_PAGER beats config", map[string]string{"EBO_PAGER": "most"}, "less -R", "most", true},
+		{"empty EBO_PAGER disables", map[string]string{"EBO_PAGER": "", "PAGER": "more"}, "", "", false},
+		{"config off", nil, "off", "", false},
+	}
+	for _, tc := range cases {
+		lookup := func(k string) (string, bool) {
+			v, ok := tc.env[k]
+			return v, ok
+		}
+		got, ok := Command(lookup, tc.configured)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("%s: got %q,%v want %q,%v", tc.name, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestWriter_PageIsIgnoredWhenOutIsNotATerminal(t *testing.T) {
+	var out bytes.Buffer
+	w := &Writer{Out: &out}
+	w.Page("tr a-z A-Z")
+	_, _ = w.Write([]byte("hello\n"))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "hello\n" || w.cmd != nil {
+		t.Fatalf("out=%q paged=%v", out.String(), w.cmd != nil)
+	}
+}
+
+func TestWriter_PipesThroughThePager(t *testing.T) {
+	var out bytes.Buffer
+	w := &Writer{Out: &out, command: "tr a-z A-Z"}
+	_, _ = w.Write([]byte("hello "))
+	_, _ = w.Write([]byte("world\n"))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "HELLO WORLD\n" {
+		t.Fatalf("out=%q", out.String())
+	}
+}
+
+func TestWriter_PagerQuitEarlyDropsTheRest(t *testing.T) {
+	var out bytes.Buffer
+	w := &Writer{Out: &out, command: "true"}
+	for i := 0; i < 1000; i++ {
+		if _, err := w.Write(bytes.Repeat([]byte("x"), 1024)); err != nil {
+			t.Fatalf("write %d: %v", i, err)
+		}
+	}
+	_ = w.Close()
+}
+
+func TestWriter_FallsBackWhenThePagerIsMissing(t *testing.T) {
+	var out bytes.Buffer
+	w := &Writer{Out: &out, command: "ebo-no-such-pager"}
+	_, _ = w.Write([]byte("hello\n"))
+	if err := w.Close(); err != nil || out.String() != "hello\n" {
+		t.Fatalf("out=%q err=%v", out.String(), err)
+	}
+}
@@ -0,0 +1,71 @@
+// Package query applies --query (JMESPath) expressions to command output
+// and writes --raw results.
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/jmespath/go-jmespath"
+)
+
+// Query is a compiled JMESPath expression. The zero expression ("") selects
+// the input unchanged.
+type Query struct {
+	expr string
+	jp   *jmespath.JMESPath
+}
+
+// Compile parses expr. Errors describe the syntax problem and where it is,
+// so callers can fail before doing any work.
+func Compile(expr string) (*Query, error) {
+	q := &Query{expr: expr}
+	if expr == "" {
+		return q, nil
+	}
+	jp, err := jmespath.Compile(expr)
+	if err != nil {
+		if se, ok := err.(jmespath.SyntaxError); ok {
+			return nil, fmt.Errorf("%s\n%s", se.Error(), se.HighlightLocation())
+		}
+		return nil, err
+	}
+	q.jp = jp
+	return q, nil
+}
+
+// Apply evaluates q against data as it would be encoded to JSON, so field
+// names are the JSON ones (e.g. trip.tripId).
+func (q *Query) Apply(data any) (any, error) {
+	if q.jp == nil {
+		return data, nil
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	return q.jp.Search(doc)
+}
+
+// WriteRaw writes v on one line: strings without quotes, everything else
+// (numbers, booleans, null, objects, arrays) as compact JSON.
+func WriteRaw(w io.Writer, v any) error {
+	if s, ok := v.(string); ok {
+		_, err := io.WriteString(w, s+"\n")
+		return err
+	}
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
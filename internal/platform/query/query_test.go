@@ -0,0 +1,77 @@
+package query
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type trip struct {
+	TripID   string  `json:"tripId"`
+	Capacity *int    `json:"capacityRigs"`
+	Name     *string `json:"name"`
+}
+
+func TestApply_UsesJSONFieldNames(t *testing.T) {
+	capacity := 5
+	data := map[string]any{"trips": []trip{{TripID: "t1", Capacity: &capacity}, {TripID: "t2"}}}
+
+	cases := map[string]string{
+		"trips[0].tripId":                     `t1`,
+		"trips[].tripId":                      `["t1","t2"]`,
+		"trips[?capacityRigs != null].tripId": `["t1"]`,
+		"length(trips)":                       `2`,
+		"trips[1].name":                       `null`,
+		"":                                    `{"trips":[{"tripId":"t1","capacityRigs":5,"name":null},{"tripId":"t2","capacityRigs":null,"name":null}]}`,
+	}
+	for expr, want := range cases {
+		q, err := Compile(expr)
+		if err != nil {
+			t.Fatalf("%q: compile: %v", expr, err)
+		}
+		got, err := q.Apply(data)
+		if err != nil {
+			t.Fatalf("%q: apply: %v", expr, err)
+		}
+		var b bytes.Buffer
+		if err := WriteRaw(&b, got); err != nil {
+			t.Fatal(err)
+		}
+		if strings.TrimSpace(b.String()) != want {
+			t.Errorf("%q: got %s want %s", expr, b.String(), want)
+		}
+	}
+}
+
+func TestCompile_SyntaxErrorPointsAtTheProblem(t *testing.T) {
+	_, err := Compile("trip.[tripId")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(err.Error(), "trip.[tripId") || !strings.Contains(err.Error(), "^") {
+		t.Fatalf("err: %v", err)
+	}
+}
+
+func TestWriteRaw(t *testing.T) {
+	cases := []struct {
+		v    any
+		want string
+	}{
+		{"t-123", "t-123\n"},
+		{"a <b>", "a <b>\n"},
+		{float64(5), "5\n"},
+		{true, "true\n"},
+		{nil, "null\n"},
+		{[]any{"a", "b"}, `["a","b"]` + "\n"},
+	}
+	for _, tc := range cases {
+		var b bytes.Buffer
+		if err := WriteRaw(&b, tc.v); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tc.want {
+			t.Errorf("%#v: got %q want %q", tc.v, b.String(), tc.want)
+		}
+	}
+}
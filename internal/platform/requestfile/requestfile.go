@@ -33,16 +33,22 @@ func LoadStrict(path string, out any) error {
 	case ".yaml", ".yml":
 		return decodeYAMLAsJSONStrict(b, out)
 	default:
-		jsonErr := decodeJSONStrict(b, out)
-		if jsonErr == nil {
-			return nil
-		}
-		yamlErr := decodeYAMLAsJSONStrict(b, out)
-		if yamlErr == nil {
-			return nil
-		}
-		return fmt.Errorf("parse %s: JSON: %v; YAML: %v", path, jsonErr, yamlErr)
+		return DecodeStrict(path, b, out)
+	}
+}
+
+// DecodeStrict decodes an in-memory buffer (e.g. an edited template) into out,
+// trying JSON first, then YAML. name identifies the buffer in errors.
+func DecodeStrict(name string, b []byte, out any) error {
+	jsonErr := decodeJSONStrict(b, out)
+	if jsonErr == nil {
+		return nil
+	}
+	yamlErr := decodeYAMLAsJSONStrict(b, out)
+	if yamlErr == nil {
+		return nil
 	}
+	return fmt.Errorf("parse %s: JSON: %v; YAML: %v", name, jsonErr, yamlErr)
 }
 
 func decodeJSONStrict(b []byte, out any) error {
@@ -170,3 +170,16 @@ func TestLoadStrict_JSON_TrailingContentRejected(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestDecodeStrict_YAMLBufferAndNameInError(t *testing.T) {
+	var req gen.CreateTripDraftRequest
+	if err := DecodeStrict("edited buffer", []byte("# comment\nname: Trip\n"), &req); err != nil {
+		t.Fatalf("DecodeStrict: %v", err)
+	}
+	if req.Name != "Trip" {
+		t.Fatalf("name: %q", req.Name)
+	}
+	if err := DecodeStrict("edited buffer", []byte("{"), &req); err == nil || !strings.Contains(err.Error(), "edited buffer") {
+		t.Fatalf("expected named error, got: %v", err)
+	}
+}
@@ -0,0 +1,312 @@
+// Package table renders list output: aligned, optionally colored columns
+// fitted to the terminal for humans, or CSV/TSV for spreadsheets.
+package table
+
+import (
+	"cmp"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// gap separates columns.
+const gap = "  "
+
+// minFlexWidth is the narrowest a Flex column is truncated to (or its header,
+// if longer).
+const minFlexWidth = 10
+
+// Style is how a cell is colored when color is enabled.
+type Style int
+
+const (
+	Plain Style = iota
+	Green
+	Yellow
+	Red
+	Dim
+)
+
+var ansi = map[Style]string{
+	Green:  "\x1b[32m",
+	Yellow: "\x1b[33m",
+	Red:    "\x1b[31m",
+	Dim:    "\x1b[2m",
+}
+
+// Kind is how a column's values compare when sorting.
+type Kind int
+
+const (
+	Text Kind = iota
+	Int
+	// Date values are YYYY-MM-DD, which sort as text.
+	Date
+)
+
+// Column describes one table column.
+type Column struct {
+	Header string
+	// Key is the CSV/TSV header (the JSON field name); defaults to Header.
+	Key string
+	// Empty is shown for an empty cell in human output (e.g. "-"). CSV/TSV
+	// cells stay empty.
+	Empty string
+	// Flex columns (free text such as names) are truncated, widest first,
+	// when the table is wider than the terminal.
+	Flex bool
+	// Wide columns are only shown with --wide (always in CSV/TSV).
+	Wide bool
+	// Style picks a color for a cell value; nil means Plain.
+	Style func(value string) Style
+	// Kind is used by SortBy.
+	Kind Kind
+}
+
+// Options controls rendering; see OptionsFor.
+type Options struct {
+	// Width is the terminal width; 0 disables truncation.
+	Width int
+	Color bool
+	// Wide shows Wide columns and disables truncation.
+	Wide bool
+}
+
+// Table is a header plus rows, rendered by Render.
+type Table struct {
+	columns  []Column
+	rows     [][]string
+	noHeader bool
+}
+
+func New(columns ...Column) *Table {
+	return &Table{columns: columns}
+}
+
+// Row appends a row; missing trailing values render empty.
+func (t *Table) Row(values ...string) {
+	t.rows = append(t.rows, values)
+}
+
+// Keys returns the column keys (see Column.Key), in order.
+func (t *Table) Keys() []string {
+	keys := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		keys[i] = c.key()
+	}
+	return keys
+}
+
+// Select keeps only the columns with the given keys, in that order. Selected
+// Wide columns are shown without --wide.
+func (t *Table) Select(keys ...string) error {
+	idx := make([]int, len(keys))
+	for j, k := range keys {
+		idx[j] = slices.Index(t.Keys(), k)
+		if idx[j] < 0 {
+			return fmt.Errorf("unknown column %q", k)
+		}
+	}
+	columns := make([]Column, len(keys))
+	for j, i := range idx {
+		columns[j] = t.columns[i]
+		columns[j].Wide = false
+	}
+	for r, row := range t.rows {
+		values := make([]string, len(keys))
+		for j, i := range idx {
+			values[j] = cell(row, i)
+		}
+		t.rows[r] = values
+	}
+	t.columns = columns
+	return nil
+}
+
+// SortBy orders rows by the column with key, comparing values by the
+// column's Kind. Empty values sort last either way; ties keep their order.
+func (t *Table) SortBy(key string, desc bool) error {
+	i := slices.Index(t.Keys(), key)
+	if i < 0 {
+		return fmt.Errorf("unknown column %q", key)
+	}
+	kind := t.columns[i].Kind
+	slices.SortStableFunc(t.rows, func(a, b []string) int {
+		x, y := cell(a, i), cell(b, i)
+		switch {
+		case x == "" && y == "":
+			return 0
+		case x == "":
+			return 1
+		case y == "":
+			return -1
+		case desc:
+			return -compare(kind, x, y)
+		default:
+			return compare(kind, x, y)
+		}
+	})
+	return nil
+}
+
+// HideHeader omits the header row from Render and WriteDelimited.
+func (t *Table) HideHeader() {
+	t.noHeader = true
+}
+
+func compare(kind Kind, x, y string) int {
+	if kind == Int {
+		a, errA := strconv.Atoi(x)
+		b, errB := strconv.Atoi(y)
+		if errA == nil && errB == nil {
+			return cmp.Compare(a, b)
+		}
+	}
+	return cmp.Compare(x, y)
+}
+
+func (c Column) key() string {
+	if c.Key != "" {
+		return c.Key
+	}
+	return c.Header
+}
+
+func cell(row []string, i int) string {
+	if i < len(row) {
+		return row[i]
+	}
+	return ""
+}
+
+// ByValue styles the values in styles and leaves others Plain.
+func ByValue(styles map[string]Style) func(string) Style {
+	return func(v string) Style { return styles[v] }
+}
+
+// TripStatus colors trip statuses: PUBLISHED green, CANCELED red, DRAFT dim.
+var TripStatus = ByValue(map[string]Style{
+	"PUBLISHED": Green,
+	"CANCELED":  Red,
+	"DRAFT":     Dim,
+})
+
+func (t *Table) Render(w io.Writer, opts Options) error {
+	var cols []int
+	for i, c := range t.columns {
+		if !c.Wide || opts.Wide {
+			cols = append(cols, i)
+		}
+	}
+	widths := make([]int, len(cols))
+	for j, i := range cols {
+		widths[j] = runeWidth(t.columns[i].Empty)
+		if !t.noHeader {
+			widths[j] = max(widths[j], runeWidth(t.columns[i].Header))
+		}
+		for _, r := range t.rows {
+			widths[j] = max(widths[j], runeWidth(cell(r, i)))
+		}
+	}
+	if !opts.Wide && opts.Width > 0 {
+		t.shrink(cols, widths, opts.Width)
+	}
+
+	var b strings.Builder
+	line := func(values func(i int) string, styled bool) {
+		var l strings.Builder
+		for j, i := range cols {
+			v := truncate(values(i), widths[j])
+			pad := widths[j] - runeWidth(v)
+			if st := t.columns[i].Style; styled && opts.Color && st != nil {
+				if code, ok := ansi[st(v)]; ok {
+					v = code + v + "\x1b[0m"
+				}
+			}
+			l.WriteString(v)
+			if j < len(cols)-1 {
+				l.WriteString(strings.Repeat(" ", pad))
+				l.WriteString(gap)
+			}
+		}
+		// An empty last column would otherwise leave trailing padding.
+		b.WriteString(strings.TrimRight(l.String(), " "))
+		b.WriteString("\n")
+	}
+	if !t.noHeader {
+		line(func(i int) string { return t.columns[i].Header }, false)
+	}
+	for _, r := range t.rows {
+		line(func(i int) string {
+			if v := cell(r, i); v != "" {
+				return v
+			}
+			return t.columns[i].Empty
+		}, true)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteDelimited writes every column (Wide ones included) as CSV, or TSV
+// when comma is '\t', with a header row of column keys.
+func (t *Table) WriteDelimited(w io.Writer, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	if !t.noHeader {
+		if err := cw.Write(t.Keys()); err != nil {
+			return err
+		}
+	}
+	for _, r := range t.rows {
+		rec := make([]string, len(t.columns))
+		copy(rec, r)
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// shrink narrows Flex columns, widest first, until the table fits width or
+// every Flex column is at its minimum.
+func (t *Table) shrink(cols, widths []int, width int) {
+	total := len(gap) * (len(cols) - 1)
+	for _, w := range widths {
+		total += w
+	}
+	for total > width {
+		widest := -1
+		for j, i := range cols {
+			c := t.columns[i]
+			if !c.Flex || widths[j] <= max(minFlexWidth, runeWidth(c.Header)) {
+				continue
+			}
+			if widest < 0 || widths[j] > widths[widest] {
+				widest = j
+			}
+		}
+		if widest < 0 {
+			return
+		}
+		widths[widest]--
+		total--
+	}
+}
+
+func truncate(s string, width int) string {
+	if runeWidth(s) <= width {
+		return s
+	}
+	r := []rune(s)
+	return string(r[:width-1]) + "…"
+}
+
+func runeWidth(s string) int {
+	return utf8.RuneCountInString(s)
+}
@@ -0,0 +1,183 @@
+package table
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func sample() *Table {
+	t := New(
+		Column{Header: "ID"},
+		Column{Header: "STATUS", Style: TripStatus},
+		Column{Header: "NOTE", Wide: true},
+		Column{Header: "NAME", Flex: true},
+	)
+	t.Row("t1", "PUBLISHED", "n", "Rubicon Trail Spring Run")
+	t.Row("t22", "DRAFT", "", "Short")
+	return t
+}
+
+func render(t *testing.T, tb *Table, opts Options) string {
+	t.Helper()
+	var b bytes.Buffer
+	if err := tb.Render(&b, opts); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	return b.String()
+}
+
+func TestRender_AlignsAndHidesWideColumns(t *testing.T) {
+	want := "" +
+		"ID   STATUS     NAME\n" +
+		"t1   PUBLISHED  Rubicon Trail Spring Run\n" +
+		"t22  DRAFT      Short\n"
+	if got := render(t, sample(), Options{}); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRender_TruncatesFlexColumnsToWidth(t *testing.T) {
+	got := render(t, sample(), Options{Width: 30})
+	for _, l := range strings.Split(strings.TrimSuffix(got, "\n"), "\n") {
+		if n := runeWidth(l); n > 30 {
+			t.Errorf("line is %d wide: %q", n, l)
+		}
+	}
+	if !strings.Contains(got, "t1   PUBLISHED  Rubicon Trail…\n") {
+		t.Fatalf("got:\n%s", got)
+	}
+}
+
+func TestRender_FlexColumnsKeepAMinimumWidth(t *testing.T) {
+	got := render(t, sample(), Options{Width: 5})
+	if !strings.Contains(got, "Rubicon T…") {
+		t.Fatalf("got:\n%s", got)
+	}
+}
+
+func TestRender_WideShowsEverythingUntruncated(t *testing.T) {
+	got := render(t, sample(), Options{Width: 20, Wide: true})
+	if !strings.HasPrefix(got, "ID   STATUS     NOTE  NAME\n") || !strings.Contains(got, "Rubicon Trail Spring Run") {
+		t.Fatalf("got:\n%s", got)
+	}
+}
+
+func TestRender_ColorsCellsButNotHeaders(t *testing.T) {
+	got := render(t, sample(), Options{Color: true})
+	want := "" +
+		"ID   STATUS     NAME\n" +
+		"t1   \x1b[32mPUBLISHED\x1b[0m  Rubicon Trail Spring Run\n" +
+		"t22  \x1b[2mDRAFT\x1b[0m      Short\n"
+	if got != want {
+		t.Fatalf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestOptionsFor_NonTerminalIsPlain(t *testing.T) {
+	if got := OptionsFor(&bytes.Buffer{}, false, true); got != (Options{Wide: true}) {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestRender_EmptyPlaceholder(t *testing.T) {
+	tb := New(Column{Header: "ID"}, Column{Header: "CAP", Empty: "-"})
+	tb.Row("t1", "")
+	if got, want := render(t, tb, Options{}), "ID  CAP\nt1  -\n"; got != want {
+		t.Fatalf("got %q want %q", got, want)
+	}
+}
+
+func TestWriteDelimited_AllColumnsWithKeys(t *testing.T) {
+	tb := New(
+		Column{Header: "ID", Key: "tripId"},
+		Column{Header: "NOTE", Wide: true, Empty: "-"},
+		Column{Header: "NAME", Key: "name", Flex: true},
+	)
+	tb.Row("t1", "", `Rubicon, "Spring"`)
+	tb.Row("t2")
+
+	var csvOut, tsvOut bytes.Buffer
+	if err := tb.WriteDelimited(&csvOut, ','); err != nil {
+		t.Fatal(err)
+	}
+	if want := "tripId,NOTE,name\nt1,,\"Rubicon, \"\"Spring\"\"\"\nt2,,\n"; csvOut.String() != want {
+		t.Fatalf("csv:\n%q\nwant:\n%q", csvOut.String(), want)
+	}
+	if err := tb.WriteDelimited(&tsvOut, '\t'); err != nil {
+		t.Fatal(err)
+	}
+	if want := "tripId\tNOTE\tname\nt1\t\t\"Rubicon, \"\"Spring\"\"\"\nt2\t\t\n"; tsvOut.String() != want {
+		t.Fatalf("tsv:\n%q\nwant:\n%q", tsvOut.String(), want)
+	}
+}
+
+func TestSelect_ReordersAndShowsWideColumns(t *testing.T) {
+	tb := sample()
+	if err := tb.Select("NAME", "NOTE", "ID"); err != nil {
+		t.Fatal(err)
+	}
+	want := "" +
+		"NAME                      NOTE  ID\n" +
+		"Rubicon Trail Spring Run  n     t1\n" +
+		"Short                           t22\n"
+	if got := render(t, tb, Options{}); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+	if err := sample().Select("ID", "nope"); err == nil || !strings.Contains(err.Error(), `"nope"`) {
+		t.Fatalf("err: %v", err)
+	}
+}
+
+func TestSortBy_ComparesByKindWithEmptyLast(t *testing.T) {
+	newTable := func() *Table {
+		tb := New(Column{Header: "ID"}, Column{Header: "RIGS", Kind: Int}, Column{Header: "START", Kind: Date})
+		tb.Row("a", "10", "2026-06-01")
+		tb.Row("b", "", "2025-12-31")
+		tb.Row("c", "9", "")
+		tb.Row("d", "10", "2026-01-15")
+		return tb
+	}
+	ids := func(tb *Table) string {
+		var b strings.Builder
+		for _, r := range tb.rows {
+			b.WriteString(r[0])
+		}
+		return b.String()
+	}
+	cases := []struct {
+		key  string
+		desc bool
+		want string
+	}{
+		{"RIGS", false, "cadb"},
+		{"RIGS", true, "adcb"},
+		{"START", false, "bdac"},
+		{"START", true, "adbc"},
+	}
+	for _, tc := range cases {
+		tb := newTable()
+		if err := tb.SortBy(tc.key, tc.desc); err != nil {
+			t.Fatal(err)
+		}
+		if got := ids(tb); got != tc.want {
+			t.Errorf("%s desc=%v: got %s want %s", tc.key, tc.desc, got, tc.want)
+		}
+	}
+	if err := newTable().SortBy("nope", false); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestHideHeader(t *testing.T) {
+	tb := New(Column{Header: "ID", Key: "tripId"}, Column{Header: "NAME"})
+	tb.Row("t1", "A")
+	tb.HideHeader()
+	if got := render(t, tb, Options{}); got != "t1  A\n" {
+		t.Fatalf("render: %q", got)
+	}
+	var b bytes.Buffer
+	if err := tb.WriteDelimited(&b, ','); err != nil || b.String() != "t1,A\n" {
+		t.Fatalf("csv: %q %v", b.String(), err)
+	}
+}
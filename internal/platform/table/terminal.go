@@ -0,0 +1,24 @@
+package table
+
+import (
+	"io"
+
+	"golang.org/x/term"
+)
+
+// OptionsFor returns the options for rendering to w. Color and truncation
+// only apply when w is a terminal (an *os.File, or a writer such as
+// pager.Writer that reports the file it ends up on), so pipes and files get
+// the full, uncolored table.
+func OptionsFor(w io.Writer, noColor, wide bool) Options {
+	opts := Options{Wide: wide}
+	f, ok := w.(interface{ Fd() uintptr })
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return opts
+	}
+	opts.Color = !noColor
+	if width, _, err := term.GetSize(int(f.Fd())); err == nil {
+		opts.Width = width
+	}
+	return opts
+}
@@ -0,0 +1,38 @@
+package plannerapi
+
+import (
+	"context"
+	"time"
+)
+
+// ResponseMeta carries transport-level details of a Planner API response that
+// are not part of the response body (e.g. the server-assigned request ID).
+//
+// Callers attach a ResponseMeta to the context with WithResponseMeta; the
+// adapter fills it in for every response received under that context. When a
+// command makes several calls, the last response wins.
+type ResponseMeta struct {
+	RequestID string
+	// Cached is true when the response was served from the local cache without
+	// contacting the API (--offline); FetchedAt is when it was last fetched.
+	Cached    bool
+	FetchedAt time.Time
+}
+
+type responseMetaKey struct{}
+
+// WithResponseMeta returns a derived context that records response metadata
+// into the returned *ResponseMeta.
+func WithResponseMeta(ctx context.Context) (context.Context, *ResponseMeta) {
+	m := &ResponseMeta{}
+	return context.WithValue(ctx, responseMetaKey{}, m), m
+}
+
+// ResponseMetaFrom returns the recorder attached by WithResponseMeta, or nil.
+func ResponseMetaFrom(ctx context.Context) *ResponseMeta {
+	if ctx == nil {
+		return nil
+	}
+	m, _ := ctx.Value(responseMetaKey{}).(*ResponseMeta)
+	return m
+}
@@ -1,38 +1,33 @@
 package plannerapi
 
-import (
-	"context"
-
-	gen "github.com/Overland-East-Bay/trip-planner-cli/internal/gen/plannerapi"
-)
+import "context"
 
 // Client is the outbound port used by the application layer.
 //
-// It wraps the generated OpenAPI client behind a stable interface.
-//
-// Note: this port currently exposes generated types. As the CLI matures,
-// we can introduce domain-level DTOs.
+// It speaks the domain types in types.go; the adapter maps them to and from
+// the generated OpenAPI client. A nil result with a nil error means the API
+// answered without a body.
 type Client interface {
 	// Trips
-	ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*gen.ListVisibleTripsForMemberClientResponse, error)
-	ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*gen.ListMyDraftTripsClientResponse, error)
-	GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripDetailsClientResponse, error)
-	CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.CreateTripDraftJSONRequestBody) (*gen.CreateTripDraftClientResponse, error)
-	UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.UpdateTripJSONRequestBody) (*gen.UpdateTripClientResponse, error)
-	SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetTripDraftVisibilityJSONRequestBody) (*gen.SetTripDraftVisibilityClientResponse, error)
-	PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.PublishTripClientResponse, error)
-	AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.AddTripOrganizerJSONRequestBody) (*gen.AddTripOrganizerClientResponse, error)
-	RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, memberID gen.MemberId, idempotencyKey string) (*gen.RemoveTripOrganizerClientResponse, error)
-	CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey *string) (*gen.CancelTripClientResponse, error)
-	SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId, idempotencyKey string, req gen.SetMyRSVPJSONRequestBody) (*gen.SetMyRSVPClientResponse, error)
-	GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetMyRSVPForTripClientResponse, error)
-	GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID gen.TripId) (*gen.GetTripRSVPSummaryClientResponse, error)
+	ListVisibleTripsForMember(ctx context.Context, baseURL string, bearerToken string) (*TripList, error)
+	ListMyDraftTrips(ctx context.Context, baseURL string, bearerToken string) (*TripList, error)
+	GetTripDetails(ctx context.Context, baseURL string, bearerToken string, tripID string) (*TripResult, error)
+	CreateTripDraft(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req CreateTripDraftRequest) (*TripCreatedResult, error)
+	UpdateTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req UpdateTripRequest) (*TripResult, error)
+	SetTripDraftVisibility(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req SetDraftVisibilityRequest) (*TripResult, error)
+	PublishTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*PublishResult, error)
+	AddTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req AddOrganizerRequest) (*TripResult, error)
+	RemoveTripOrganizer(ctx context.Context, baseURL string, bearerToken string, tripID string, memberID string, idempotencyKey string) (*TripResult, error)
+	CancelTrip(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey *string) (*TripResult, error)
+	SetMyRSVP(ctx context.Context, baseURL string, bearerToken string, tripID string, idempotencyKey string, req SetMyRSVPRequest) (*RSVPResult, error)
+	GetMyRSVPForTrip(ctx context.Context, baseURL string, bearerToken string, tripID string) (*RSVPResult, error)
+	GetTripRSVPSummary(ctx context.Context, baseURL string, bearerToken string, tripID string) (*RSVPSummaryResult, error)
 
 	// Members
-	ListMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.ListMembersParams) (*gen.ListMembersClientResponse, error)
-	SearchMembers(ctx context.Context, baseURL string, bearerToken string, params *gen.SearchMembersParams) (*gen.SearchMembersClientResponse, error)
-	GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*gen.GetMyMemberProfileClientResponse, error)
-	DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.DeleteMyMemberAccountJSONRequestBody) (*gen.DeleteMyMemberAccountClientResponse, error)
-	CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req gen.CreateMyMemberJSONRequestBody) (*gen.CreateMyMemberClientResponse, error)
-	UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req gen.UpdateMyMemberProfileJSONRequestBody) (*gen.UpdateMyMemberProfileClientResponse, error)
+	ListMembers(ctx context.Context, baseURL string, bearerToken string, includeInactive bool) (*MemberList, error)
+	SearchMembers(ctx context.Context, baseURL string, bearerToken string, query string) (*MemberList, error)
+	GetMyMemberProfile(ctx context.Context, baseURL string, bearerToken string) (*MemberResult, error)
+	DeleteMyMemberAccount(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req DeleteMemberRequest) (*MemberDeleted, error)
+	CreateMyMember(ctx context.Context, baseURL string, bearerToken string, req CreateMemberRequest) (*MemberResult, error)
+	UpdateMyMemberProfile(ctx context.Context, baseURL string, bearerToken string, idempotencyKey string, req UpdateMemberRequest) (*MemberResult, error)
 }
@@ -0,0 +1,292 @@
+package plannerapi
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Domain types exchanged over the Client port.
+//
+// They mirror the Planner API wire format (JSON field names, nullability), so
+// `--output json` can emit them as-is, but they are owned by the CLI: a spec
+// regeneration only touches the mapping in adapters/out/plannerapi.
+
+type TripStatus string
+
+const (
+	TripStatusDraft     TripStatus = "DRAFT"
+	TripStatusPublished TripStatus = "PUBLISHED"
+	TripStatusCanceled  TripStatus = "CANCELED"
+)
+
+type DraftVisibility string
+
+const (
+	DraftVisibilityPrivate DraftVisibility = "PRIVATE"
+	DraftVisibilityPublic  DraftVisibility = "PUBLIC"
+)
+
+type RSVPResponse string
+
+const (
+	RSVPYes   RSVPResponse = "YES"
+	RSVPNo    RSVPResponse = "NO"
+	RSVPUnset RSVPResponse = "UNSET"
+)
+
+type ArtifactType string
+
+const (
+	ArtifactGPX      ArtifactType = "GPX"
+	ArtifactSchedule ArtifactType = "SCHEDULE"
+	ArtifactDocument ArtifactType = "DOCUMENT"
+	ArtifactOther    ArtifactType = "OTHER"
+)
+
+// DateLayout is the wire format of Date.
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date (no time of day), encoded as YYYY-MM-DD.
+type Date struct {
+	time.Time
+}
+
+func (d Date) String() string {
+	return d.Format(DateLayout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return err
+	}
+	d.Time = t
+	return nil
+}
+
+type LatLng struct {
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+type Location struct {
+	Address           *string `json:"address"`
+	Label             string  `json:"label"`
+	LatitudeLongitude *LatLng `json:"latitudeLongitude"`
+}
+
+// LocationPatch is a partial Location; nil fields are left unchanged.
+type LocationPatch struct {
+	Address           *string      `json:"address"`
+	Label             *string      `json:"label"`
+	LatitudeLongitude *LatLngPatch `json:"latitudeLongitude"`
+}
+
+type LatLngPatch struct {
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+}
+
+type Artifact struct {
+	ArtifactID string       `json:"artifactId"`
+	Title      string       `json:"title"`
+	Type       ArtifactType `json:"type"`
+	URL        string       `json:"url"`
+}
+
+type VehicleProfile struct {
+	FuelRange        *string `json:"fuelRange"`
+	HamRadioCallSign *string `json:"hamRadioCallSign"`
+	LiftLockers      *string `json:"liftLockers"`
+	Make             *string `json:"make"`
+	Model            *string `json:"model"`
+	Notes            *string `json:"notes"`
+	RecoveryGear     *string `json:"recoveryGear"`
+	TireSize         *string `json:"tireSize"`
+}
+
+// Member is the caller's own profile.
+type Member struct {
+	DisplayName     string          `json:"displayName"`
+	Email           string          `json:"email"`
+	GroupAliasEmail *string         `json:"groupAliasEmail"`
+	MemberID        string          `json:"memberId"`
+	VehicleProfile  *VehicleProfile `json:"vehicleProfile,omitempty"`
+}
+
+// MemberSummary is a member as listed on a trip (organizers, attendees).
+type MemberSummary struct {
+	DisplayName     string  `json:"displayName"`
+	Email           string  `json:"email"`
+	GroupAliasEmail *string `json:"groupAliasEmail"`
+	MemberID        string  `json:"memberId"`
+}
+
+// MemberDirectoryEntry is a member as listed in the directory (no emails).
+type MemberDirectoryEntry struct {
+	DisplayName string `json:"displayName"`
+	MemberID    string `json:"memberId"`
+}
+
+// RSVP is the caller's RSVP for one trip.
+type RSVP struct {
+	MemberID  string       `json:"memberId"`
+	Response  RSVPResponse `json:"response"`
+	TripID    string       `json:"tripId"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+}
+
+type RSVPSummary struct {
+	AttendingMembers    []MemberSummary `json:"attendingMembers"`
+	AttendingRigs       int             `json:"attendingRigs"`
+	CapacityRigs        *int            `json:"capacityRigs"`
+	NotAttendingMembers []MemberSummary `json:"notAttendingMembers"`
+}
+
+// TripSummary is a trip as listed by `trip list` / `trip drafts`.
+type TripSummary struct {
+	// AttendingRigs is set only for published trips.
+	AttendingRigs   *int             `json:"attendingRigs"`
+	CapacityRigs    *int             `json:"capacityRigs"`
+	DraftVisibility *DraftVisibility `json:"draftVisibility,omitempty"`
+	EndDate         *Date            `json:"endDate"`
+	Name            *string          `json:"name"`
+	StartDate       *Date            `json:"startDate"`
+	Status          TripStatus       `json:"status"`
+	TripID          string           `json:"tripId"`
+}
+
+// Trip is the full trip as returned by `trip get` and every trip mutation.
+type Trip struct {
+	Artifacts                   []Artifact       `json:"artifacts"`
+	CapacityRigs                *int             `json:"capacityRigs"`
+	CommsRequirementsText       *string          `json:"commsRequirementsText"`
+	Description                 *string          `json:"description"`
+	DifficultyText              *string          `json:"difficultyText"`
+	DraftVisibility             *DraftVisibility `json:"draftVisibility,omitempty"`
+	EndDate                     *Date            `json:"endDate"`
+	MeetingLocation             *Location        `json:"meetingLocation,omitempty"`
+	MyRSVP                      *RSVP            `json:"myRsvp,omitempty"`
+	Name                        *string          `json:"name"`
+	Organizers                  []MemberSummary  `json:"organizers"`
+	RecommendedRequirementsText *string          `json:"recommendedRequirementsText"`
+	// RSVPActionsEnabled is false for drafts and canceled trips.
+	RSVPActionsEnabled bool         `json:"rsvpActionsEnabled"`
+	RSVPSummary        *RSVPSummary `json:"rsvpSummary,omitempty"`
+	StartDate          *Date        `json:"startDate"`
+	Status             TripStatus   `json:"status"`
+	TripID             string       `json:"tripId"`
+}
+
+// TripCreated is the minimal trip returned when a draft is created.
+type TripCreated struct {
+	DraftVisibility DraftVisibility `json:"draftVisibility"`
+	Status          TripStatus      `json:"status"`
+	TripID          string          `json:"tripId"`
+}
+
+// Responses. Each mirrors the API response body, which is what `--output json`
+// emits as data.
+
+type TripList struct {
+	Trips []TripSummary `json:"trips"`
+}
+
+type TripResult struct {
+	Trip Trip `json:"trip"`
+}
+
+type TripCreatedResult struct {
+	Trip TripCreated `json:"trip"`
+}
+
+type PublishResult struct {
+	// AnnouncementCopy is prepared text for posting to the Google Group.
+	AnnouncementCopy string `json:"announcementCopy"`
+	Trip             Trip   `json:"trip"`
+}
+
+type RSVPResult struct {
+	MyRSVP RSVP `json:"myRsvp"`
+}
+
+type RSVPSummaryResult struct {
+	RSVPSummary RSVPSummary `json:"rsvpSummary"`
+}
+
+type MemberList struct {
+	Members []MemberDirectoryEntry `json:"members"`
+}
+
+type MemberResult struct {
+	Member Member `json:"member"`
+}
+
+type MemberDeleted struct {
+	Deleted   bool       `json:"deleted"`
+	DeletedAt *time.Time `json:"deletedAt"`
+}
+
+// Requests. Field names match the API request bodies, so request files
+// (--from-file/--edit) decode straight into them.
+
+type CreateTripDraftRequest struct {
+	Name string `json:"name"`
+}
+
+// UpdateTripRequest is a partial update; nil fields are left unchanged.
+type UpdateTripRequest struct {
+	// ArtifactIDs replaces the trip's artifact list (in order).
+	ArtifactIDs                 *[]string      `json:"artifactIds"`
+	CapacityRigs                *int           `json:"capacityRigs"`
+	CommsRequirementsText       *string        `json:"commsRequirementsText"`
+	Description                 *string        `json:"description"`
+	DifficultyText              *string        `json:"difficultyText"`
+	EndDate                     *Date          `json:"endDate"`
+	MeetingLocation             *LocationPatch `json:"meetingLocation,omitempty"`
+	Name                        *string        `json:"name,omitempty"`
+	RecommendedRequirementsText *string        `json:"recommendedRequirementsText"`
+	StartDate                   *Date          `json:"startDate"`
+}
+
+type SetDraftVisibilityRequest struct {
+	DraftVisibility DraftVisibility `json:"draftVisibility"`
+}
+
+type AddOrganizerRequest struct {
+	MemberID string `json:"memberId"`
+}
+
+type SetMyRSVPRequest struct {
+	Response RSVPResponse `json:"response"`
+}
+
+type CreateMemberRequest struct {
+	DisplayName     string          `json:"displayName"`
+	Email           string          `json:"email"`
+	GroupAliasEmail *string         `json:"groupAliasEmail"`
+	VehicleProfile  *VehicleProfile `json:"vehicleProfile,omitempty"`
+}
+
+// UpdateMemberRequest is a partial update; nil fields are left unchanged.
+type UpdateMemberRequest struct {
+	DisplayName *string `json:"displayName"`
+	// Email cannot be cleared.
+	Email           *string         `json:"email,omitempty"`
+	GroupAliasEmail *string         `json:"groupAliasEmail"`
+	VehicleProfile  *VehicleProfile `json:"vehicleProfile,omitempty"`
+}
+
+type DeleteMemberRequest struct {
+	// Confirm must be true for the server to delete anything.
+	Confirm bool    `json:"confirm"`
+	Reason  *string `json:"reason"`
+}
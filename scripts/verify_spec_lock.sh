@@ -23,6 +23,29 @@ if ! printf "%s" "${spec_version}" | grep -Eq '^(v?[0-9]+\.[0-9]+\.[0-9]+|[0-9a-
   exit 1
 fi
 
+# The embedded copy (internal/platform/apispec) must be the document at the
+# pinned ref, written by `make gen`; it is never edited by hand.
+EMBEDDED_DIR="${EMBEDDED_DIR:-internal/platform/apispec}"
+embedded_ref="$(tr -d '[:space:]' < "${EMBEDDED_DIR}/spec.lock" 2>/dev/null || true)"
+if [[ "${embedded_ref}" != "${spec_version}" ]]; then
+  err "${EMBEDDED_DIR}/openapi.yaml was not generated from spec ${spec_version} (embedded spec.lock: '${embedded_ref}'). Run make gen."
+  exit 1
+fi
+
+spec_dir="${EBO_SPEC_DIR:-../trip-planner-spec}"
+if git -C "${spec_dir}" rev-parse --verify --quiet "${spec_version}^{commit}" >/dev/null 2>&1; then
+  if ! git -C "${spec_dir}" show "${spec_version}:openapi/openapi.yaml" | cmp -s - "${EMBEDDED_DIR}/openapi.yaml"; then
+    err "${EMBEDDED_DIR}/openapi.yaml differs from openapi/openapi.yaml at ${spec_version} in ${spec_dir}. Run make gen."
+    exit 1
+  fi
+  printf "OK: %s/openapi.yaml matches spec %s\n" "${EMBEDDED_DIR}" "${spec_version}"
+elif [[ "${EBO_REQUIRE_SPEC:-}" == "1" ]]; then
+  err "spec repo with ref ${spec_version} not found at ${spec_dir} (set EBO_SPEC_DIR)."
+  exit 1
+else
+  printf "NOTE: spec repo not found at %s; embedded openapi.yaml not compared (EBO_REQUIRE_SPEC=1 makes this an error)\n" "${spec_dir}"
+fi
+
 printf "OK: %s pins spec version %s\n" "${SPEC_LOCK_FILE}" "${spec_version}"
 
 